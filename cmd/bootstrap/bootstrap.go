@@ -0,0 +1,44 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package bootstrap
+
+import (
+	"github.com/mercedes-benz/gitflow-cli/core"
+
+	"github.com/spf13/cobra"
+)
+
+// Released baseline version of the new project.
+var initialVersion string
+
+// BootstrapCmd represents the bootstrap subcommand of RootCmd.
+var BootstrapCmd = &cobra.Command{
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	Use:          "bootstrap <template>",
+	Short:        "Create a new project repository from a plugin template",
+
+	Long: `Create a new project repository from a plugin template.
+
+Bootstrap creates the repository under the project path, or fills an existing
+repository without commits, for the template of a plugin (e.g. 'standard', 'mvn',
+'npm', 'composer', 'python', or 'road'). It writes the version file of the plugin
+and the project-local configuration file '.gitflow-cli.yaml' on master, commits
+and tags them with the baseline version (default 0.1.0), and creates develop with
+the next minor development version (e.g. '0.2.0-dev').
+
+Branches and tags are pushed if the repository has a remote. Repositories with
+existing commits are prepared with 'init' instead.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return core.Bootstrap(args[0], initialVersion, core.ProjectPath)
+	},
+}
+
+// Initialize Cobra flags for the bootstrap subcommand.
+func init() {
+	BootstrapCmd.Flags().StringVar(&initialVersion, "initial-version", "0.1.0", "released baseline version of the new project (e.g. 1.0.0)")
+}
@@ -0,0 +1,74 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package bugfix
+
+import (
+	"github.com/mercedes-benz/gitflow-cli/core"
+
+	"github.com/spf13/cobra"
+)
+
+// BugfixCmd represents the bugfix subcommand of RootCmd.
+var BugfixCmd = &cobra.Command{
+	Args:  cobra.NoArgs,
+	Use:   "bugfix",
+	Short: "Fix a bug on the development branch",
+
+	Long: `Fix a bug on the development branch.
+
+Bugfix branches are used to fix bugs that were found during development and
+are not yet part of a production release. They are based on develop and named
+'bugfix/' followed by a brief name of the fix.
+
+Once the fix is complete, the bugfix branch is merged back into develop and
+deleted. Unlike hotfix branches, bugfix branches do not change the version and
+are not merged into master, so several bugfix branches can exist at a time.`,
+}
+
+// StartCmd represents the start subcommand of BugfixCmd.
+var startCmd = &cobra.Command{
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	Use:          "start <name>",
+	Short:        "Create a new bugfix branch",
+
+	Long: `Create a new bugfix branch.
+
+The bugfix branch 'bugfix/<name>' is created from develop and pushed to the
+remote repository.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return core.StartBugfix(args[0], core.ProjectPath)
+	},
+}
+
+// FinishCmd represents the finish subcommand of BugfixCmd.
+var finishCmd = &cobra.Command{
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	Use:          "finish [name]",
+	Short:        "Finish a bugfix branch",
+
+	Long: `Finish a bugfix branch.
+
+The bugfix branch 'bugfix/<name>' is merged back into develop and deleted
+locally and remotely. The name can be omitted if the repository has only one
+bugfix branch.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		return core.FinishBugfix(name, core.ProjectPath)
+	},
+}
+
+// Initialize Cobra flags for the bugfix subcommand.
+func init() {
+	// add subcommands to the bugfix command
+	BugfixCmd.AddCommand(startCmd, finishCmd)
+}
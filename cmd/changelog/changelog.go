@@ -0,0 +1,53 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package changelog
+
+import (
+	"github.com/mercedes-benz/gitflow-cli/core"
+
+	"github.com/spf13/cobra"
+)
+
+// File the changelog is written to.
+var changelogFile string
+
+// Format of the changelog.
+var format string
+
+// ChangelogCmd represents the changelog subcommand of RootCmd.
+var ChangelogCmd = &cobra.Command{
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	Use:          "changelog",
+	Short:        "Generate the changelog of all releases",
+
+	Long: `Generate the changelog of all releases.
+
+The changelog is derived from the version tags: each released version lists the
+notable changes of the commits since the previous version, grouped by the type
+of their conventional commit header ('feat', 'fix', 'perf' and breaking changes).
+Commits without conventional header are listed as other changes, while 'chore',
+'docs', 'ci', 'build', 'style' and 'test' commits and the commits of the
+workflow commands are left out.
+
+The formats are 'keep-a-changelog' (https://keepachangelog.com) and
+'conventional', the format of conventional-changelog. The file, relative to the
+project, and the format default to the settings under 'changelog'. The file is
+written from scratch, use '--file -' to print the changelog instead.
+
+With 'changelog.enabled', release finish adds the section of the release to the
+changelog on the release branch instead.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return core.Changelog(core.ProjectPath, changelogFile, format)
+	},
+}
+
+// Initialize Cobra flags for the changelog subcommand.
+func init() {
+	ChangelogCmd.Flags().StringVarP(&changelogFile, "file", "f", "", "file the changelog is written to (default from 'changelog.file', CHANGELOG.md)")
+	ChangelogCmd.Flags().StringVar(&format, "format", "", "format of the changelog (keep-a-changelog, conventional)")
+}
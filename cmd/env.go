@@ -0,0 +1,84 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package cmd
+
+import (
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Prefix of the environment variables which override settings, e.g. GITFLOW_BRANCHES__PRODUCTION for the setting
+// 'branches.production': two underscores separate the levels of the key, and an underscore stands for a hyphen.
+const (
+	envPrefix         = "GITFLOW"
+	envLevelSeparator = "__"
+)
+
+// Environment variables with the prefix which are no settings, e.g. GITFLOW_LOCALE, which is the fallback of the
+// 'locale' setting, and the variables of the pre-tag hook and of the steps inserted into the workflows.
+var envExcluded = []string{"GITFLOW_LOCALE", "GITFLOW_COMMIT", "GITFLOW_VERSION", "GITFLOW_BRANCH"}
+
+// bindEnvironment overrides the settings of the configuration files with the GITFLOW_* environment variables, so
+// that pipelines can be configured without config file. The settings are applied in groups, e.g. all settings of
+// 'workflow', which only hold the keys of the config files, so each variable is set as a setting of its own.
+func bindEnvironment() {
+	for _, variable := range os.Environ() {
+		name, value, _ := strings.Cut(variable, "=")
+		if key, ok := envKey(name); ok {
+			viper.Set(key, envValue(value))
+		}
+	}
+}
+
+// recordEnvironmentSources records the GITFLOW_* variables as the sources of the settings running commands they set,
+// which take precedence over the config files.
+func recordEnvironmentSources(sources map[string]string) {
+	for _, variable := range os.Environ() {
+		name, value, _ := strings.Cut(variable, "=")
+		if key, ok := envKey(name); ok {
+			setting := viper.New()
+			setting.Set(key, envValue(value))
+			recordCommandSources(sources, setting.AllSettings(), name)
+		}
+	}
+}
+
+// envKey returns the setting of an environment variable, e.g. 'workflow.docker-fallback' for
+// GITFLOW_WORKFLOW__DOCKER_FALLBACK.
+func envKey(name string) (string, bool) {
+	name, found := strings.CutPrefix(name, envPrefix+"_")
+	if !found || len(name) == 0 || slices.Contains(envExcluded, envPrefix+"_"+name) {
+		return "", false
+	}
+
+	levels := strings.Split(strings.ToLower(name), envLevelSeparator)
+	for i, level := range levels {
+		if len(level) == 0 {
+			return "", false
+		}
+		levels[i] = strings.ReplaceAll(level, "_", "-")
+	}
+	return strings.Join(levels, "."), true
+}
+
+// envValue returns the value of an environment variable as it would be read from a config file, e.g. a boolean for
+// 'true' or a list for '[a, b]', so that the settings need no conversion. Values which YAML reads as floating-point
+// numbers, e.g. '1.10', and invalid YAML are kept as strings.
+func envValue(value string) any {
+	var parsed any
+	if err := yaml.Unmarshal([]byte(value), &parsed); err != nil || parsed == nil {
+		return value
+	}
+
+	if _, ok := parsed.(float64); ok {
+		return value
+	}
+	return parsed
+}
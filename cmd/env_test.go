@@ -0,0 +1,67 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvKey(t *testing.T) {
+	testCases := []struct {
+		name, variable, key string
+		ok                  bool
+	}{
+		{"TopLevel", "GITFLOW_OUTPUT", "output", true},
+		{"Group", "GITFLOW_BRANCHES__PRODUCTION", "branches.production", true},
+		{"Hyphens", "GITFLOW_STALE_BRANCHES__MAX_AGE_DAYS", "stale-branches.max-age-days", true},
+		{"Plugin", "GITFLOW_HELM__APP_VERSION", "helm.app-version", true},
+		{"LocaleFallback", "GITFLOW_LOCALE", "", false},
+		{"OtherPrefix", "GITLAB_TOKEN", "", false},
+		{"PrefixOnly", "GITFLOW_", "", false},
+		{"EmptyLevel", "GITFLOW_WORKFLOW____PUSH", "", false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			key, ok := envKey(testCase.variable)
+			assert.Equal(t, testCase.ok, ok)
+			assert.Equal(t, testCase.key, key)
+		})
+	}
+}
+
+func TestEnvValue(t *testing.T) {
+	assert.Equal(t, true, envValue("true"))
+	assert.Equal(t, 30, envValue("30"))
+	assert.Equal(t, "off", envValue("off"))
+	assert.Equal(t, "1.10", envValue("1.10"))
+	assert.Equal(t, []any{"a", "b"}, envValue("[a, b]"))
+	assert.Equal(t, "", envValue(""))
+	assert.Equal(t, "[a", envValue("[a"))
+}
+
+func TestBindEnvironment_OverridesConfig(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set("workflow", map[string]any{"push": true, "rollback": false})
+	t.Setenv("GITFLOW_WORKFLOW__PUSH", "false")
+	t.Setenv("GITFLOW_STALE_BRANCHES__MAX_AGE_DAYS", "10")
+
+	bindEnvironment()
+
+	// the settings of a group hold the variables with their types, next to the settings of the config
+	workflow, ok := viper.AllSettings()["workflow"].(map[string]any)
+	assert.True(t, ok)
+	assert.Equal(t, false, workflow["push"])
+	assert.Equal(t, false, workflow["rollback"])
+
+	stale, ok := viper.AllSettings()["stale-branches"].(map[string]any)
+	assert.True(t, ok)
+	assert.Equal(t, 10, stale["max-age-days"])
+}
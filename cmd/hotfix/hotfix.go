@@ -48,11 +48,18 @@ var startCmd = &cobra.Command{
 Hotfix branches are created when there's a need to quickly fix an issue in the
 production version of the software.
 
+With --support, the hotfix is started from the support branch of an older
+maintenance line instead of master.
+
+With --issue, the title of the issue is fetched from the issue tracker configured
+under 'tracker' and appended to the branch name as description, and the commit
+messages reference the issue.
+
 By default, plugin commands run natively on the host. Use --docker-mode to run
 them inside a Docker container instead.`,
 
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return core.Start(core.Hotfix, core.ProjectPath)
+		return core.Start(core.Hotfix, "", core.None, core.ProjectPath)
 	},
 }
 
@@ -68,6 +75,18 @@ var finishCmd = &cobra.Command{
 Once the fix is complete, the hotfix branch is merged back into both master and
 develop (or the current release branch).
 
+With --support, the hotfix branch is merged into the support branch of the
+maintenance line only.
+
+With --via-pr, the hotfix branch is pushed and merged by GitHub or Bitbucket
+pull requests or GitLab merge requests instead, e.g. for protected branches which
+reject direct pushes. The merge commit of the request into master, or into the
+support branch, is tagged once all requests are merged. Without --wait, the
+finish stops until the requests are merged and is resumed by 'hotfix continue'.
+With --wait, it waits for the merge up to the 'merge-timeout' of the hosting
+service. On Bitbucket, a finish without --via-pr fails before it starts if one
+of the merged branches accepts changes by pull requests only.
+
 By default, plugin commands run natively on the host. Use --docker-mode to run
 them inside a Docker container instead.`,
 
@@ -76,8 +95,38 @@ them inside a Docker container instead.`,
 	},
 }
 
+// ContinueCmd represents the continue subcommand of HotfixCmd.
+var continueCmd = &cobra.Command{
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	Use:          "continue",
+	Short:        "Continue an interrupted hotfix finish",
+
+	Long: `Continue an interrupted hotfix finish.
+
+The finish command records its progress in '.git/gitflow-cli/state.json', or in
+the git directory of a linked worktree. If it is interrupted, e.g. by a merge conflict or a rejected push, the cause can be
+resolved (e.g. by committing the merge) and the finish is continued at the step
+where it stopped. The state file is removed once the finish has completed.
+
+A hotfix finished with --via-pr is continued once its pull or merge requests are
+merged. With --wait, it waits for the merge up to 'github.merge-timeout' or
+'gitlab.merge-timeout'.`,
+
+	RunE: func(c *cobra.Command, args []string) error {
+		return core.Continue(core.Hotfix, core.ProjectPath)
+	},
+}
+
 // Initialize Cobra flags for the hotfix subcommand.
 func init() {
+	startCmd.Flags().StringVar(&core.IssueKey, "issue", "", "issue the hotfix is started for, e.g. PROJ-123 or #42")
+	startCmd.Flags().StringVar(&core.SupportLine, "support", "", "maintenance line of the support branch to start the hotfix from, e.g. 1.2")
+	finishCmd.Flags().StringVar(&core.SupportLine, "support", "", "maintenance line of the support branch to finish the hotfix into, e.g. 1.2")
+	finishCmd.Flags().BoolVar(&core.ViaPullRequest, "via-pr", false, "merge the hotfix branch by GitHub or Bitbucket pull requests or GitLab merge requests instead of pushing the merges")
+	finishCmd.Flags().BoolVar(&core.WaitForMerge, "wait", false, "wait for the merge of the pull or merge requests with --via-pr")
+	continueCmd.Flags().BoolVar(&core.WaitForMerge, "wait", false, "wait for the merge of the pull or merge requests of a hotfix finished with --via-pr")
+
 	// add subcommands to the hotfix command
-	HotfixCmd.AddCommand(startCmd, finishCmd)
+	HotfixCmd.AddCommand(startCmd, finishCmd, continueCmd)
 }
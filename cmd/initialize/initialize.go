@@ -0,0 +1,42 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package initialize
+
+import (
+	"github.com/mercedes-benz/gitflow-cli/core"
+
+	"github.com/spf13/cobra"
+)
+
+// Create the version file of the project if it does not have one.
+var versionFile bool
+
+// InitCmd represents the init subcommand of RootCmd.
+var InitCmd = &cobra.Command{
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	Use:          "init",
+	Short:        "Prepare a repository for the Gitflow workflow",
+
+	Long: `Prepare a repository for the Gitflow workflow.
+
+Init creates the develop branch from master if the repository does not have it,
+writes the project-local configuration file '.gitflow-cli.yaml' with the branch
+names in use, and pushes everything to the remote repository.
+
+With --version-file, the version file of the detected plugin is created with the
+initial development version (e.g. 'version.txt' with '1.0.0-dev') if the project
+does not have a version yet. Existing files are never overwritten.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return core.Init(versionFile, core.ProjectPath)
+	},
+}
+
+// Initialize Cobra flags for the init subcommand.
+func init() {
+	InitCmd.Flags().BoolVar(&versionFile, "version-file", false, "create the version file if the project does not have one")
+}
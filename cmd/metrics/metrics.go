@@ -0,0 +1,99 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+
+	"github.com/spf13/cobra"
+)
+
+// Output format of the metrics.
+var metricsFormat string
+
+// File the metrics are written to instead of stdout.
+var metricsFile string
+
+// Number of days of the period of the metrics.
+var days int
+
+// Push the metrics to the configured Prometheus pushgateway.
+var push bool
+
+// MetricsCmd represents the metrics subcommand of RootCmd.
+var MetricsCmd = &cobra.Command{
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	Use:          "metrics",
+	Short:        "Export release frequency and lead time metrics",
+
+	Long: `Export release frequency and lead time metrics.
+
+The DORA-style metrics are derived from the version tags of the last 90 days,
+use --days 0 for all released versions. Every release and hotfix counts as a
+deployment. The lead time of a version is the duration from the first commit of
+its release or hotfix branch to its tag, the metrics hold the medians of
+releases and hotfixes.
+
+The metrics are printed as JSON or, per released version, as CSV. With --push,
+they are pushed to the Prometheus pushgateway configured under
+'metrics.pushgateway' in the configuration file.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		metrics, err := core.CollectMetrics(core.ProjectPath, days)
+		if err != nil {
+			return err
+		}
+
+		var content string
+		switch metricsFormat {
+		case "json":
+			data, err := json.MarshalIndent(metrics, "", "  ")
+			if err != nil {
+				return err
+			}
+			content = string(data) + "\n"
+		case "csv":
+			content = metrics.CSV()
+		default:
+			return fmt.Errorf("unsupported metrics format: %v", metricsFormat)
+		}
+
+		if push {
+			if err := core.PushMetrics(metrics); err != nil {
+				return err
+			}
+		}
+
+		if len(metricsFile) > 0 {
+			if err := os.WriteFile(metricsFile, []byte(content), 0644); err != nil {
+				return err
+			}
+			core.Success(core.Message(core.MsgMetricsWritten, len(metrics.Versions), metricsFile))
+		}
+
+		// in JSON output mode, the metrics are part of the result document
+		if core.JSONOutput() {
+			core.RecordMetrics(metrics)
+		} else if len(metricsFile) == 0 && !push {
+			fmt.Print(content)
+		}
+
+		return nil
+	},
+}
+
+// Initialize Cobra flags for the metrics subcommand.
+func init() {
+	MetricsCmd.Flags().StringVar(&metricsFormat, "format", "json", "output format of the metrics (json, csv)")
+	MetricsCmd.Flags().StringVarP(&metricsFile, "file", "f", "", "file the metrics are written to instead of stdout")
+	MetricsCmd.Flags().IntVar(&days, "days", 90, "number of days of the period of the metrics (0: all released versions)")
+	MetricsCmd.Flags().BoolVar(&push, "push", false, "push the metrics to the Prometheus pushgateway configured under 'metrics.pushgateway'")
+}
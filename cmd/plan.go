@@ -0,0 +1,45 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/spf13/cobra"
+)
+
+// preparePlan records the execution plan of a command run with --plan-out, and verifies the approved plan of a
+// command run with --plan-in by a dry run before the command is executed.
+func preparePlan(c *cobra.Command, args []string) error {
+	command := commandLine(c, args)
+
+	if planOut, _ := c.Flags().GetString("plan-out"); len(planOut) > 0 {
+		core.RecordPlan(command)
+	}
+
+	planIn, _ := c.Flags().GetString("plan-in")
+	if len(planIn) == 0 {
+		return nil
+	}
+
+	if c.RunE == nil {
+		return core.Error(core.MsgDryRunUnsupported, command)
+	}
+
+	approved, err := core.ReadPlan(planIn)
+	if err != nil {
+		return err
+	}
+
+	return core.VerifyPlan(approved, planIn, command, func() error { return c.RunE(c, args) })
+}
+
+// commandLine returns the command and its arguments without the name of the tool, e.g. 'release start 1.2.0'.
+func commandLine(c *cobra.Command, args []string) string {
+	name := strings.TrimPrefix(c.CommandPath(), c.Root().Name()+" ")
+	return strings.Join(append([]string{name}, args...), " ")
+}
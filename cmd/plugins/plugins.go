@@ -0,0 +1,67 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package plugins
+
+import (
+	"github.com/mercedes-benz/gitflow-cli/core"
+
+	"github.com/spf13/cobra"
+)
+
+// PluginCmd represents the plugin subcommand of RootCmd.
+var PluginCmd = &cobra.Command{
+	Args:  cobra.NoArgs,
+	Use:   "plugin",
+	Short: "Develop plugins of the tool",
+
+	Long: `Develop plugins of the tool.
+
+Plugins read and write the version of a kind of project, e.g. the version of
+pom.xml for Maven projects. They are built into the tool and register
+themselves with the workflow commands.`,
+}
+
+// Version file, qualifier and container image of the scaffolded plugin.
+var versionFile, qualifier, dockerImage string
+
+// ScaffoldCmd represents the scaffold subcommand of PluginCmd.
+var scaffoldCmd = &cobra.Command{
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	Use:          "scaffold <name>",
+	Short:        "Generate the skeleton of a new plugin",
+
+	Long: `Generate the skeleton of a new plugin.
+
+The plugin is generated in the source tree of the tool given by --path, e.g. a
+clone of the repository, in 'plugin/<name>/':
+
+  <name>.go                  configuration, ReadVersion and WriteVersion
+                             stubs and the registration of a hook
+  <name>_test.go             unit tests and the generic e2e tests of the
+                             workflow commands
+  testdata/e2e/<file>.tpl    version file of the e2e tests
+
+and imported in 'plugin/plugin.go', so that it registers itself. The stubs
+handle a 'version: x.y.z' or 'version = x.y.z' line of the version file, so that
+the tests pass right away. The name is the name of the plugin and of its Go
+package, e.g. 'bazel'.`,
+
+	RunE: func(c *cobra.Command, args []string) error {
+		return core.ScaffoldPlugin(args[0], versionFile, qualifier, dockerImage, core.ProjectPath)
+	},
+}
+
+// Initialize Cobra flags for the plugin subcommand.
+func init() {
+	scaffoldCmd.Flags().StringVar(&versionFile, "version-file", "", "version file of the projects of the plugin, e.g. project.yaml")
+	scaffoldCmd.Flags().StringVar(&qualifier, "qualifier", "dev", "qualifier of the development versions")
+	scaffoldCmd.Flags().StringVar(&dockerImage, "docker-image", "alpine:3", "container image of the docker execution mode")
+	_ = scaffoldCmd.MarkFlagRequired("version-file")
+
+	// add subcommands to the plugin command
+	PluginCmd.AddCommand(scaffoldCmd)
+}
@@ -0,0 +1,45 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package promote
+
+import (
+	"github.com/mercedes-benz/gitflow-cli/core"
+
+	"github.com/spf13/cobra"
+)
+
+// Target environment of the promotion.
+var environment string
+
+// PromoteCmd represents the promote subcommand of RootCmd.
+var PromoteCmd = &cobra.Command{
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	Use:          "promote <version>",
+	Short:        "Promote a released version to a target environment",
+
+	Long: `Promote a released version to a target environment.
+
+Environments are configured in promotion order under 'environments' in the
+configuration file (e.g. staging, prod). Promoting a version tags the release
+commit with an environment ref such as 'prod/1.2.0', which GitOps tooling can
+watch to roll out the version. With 'workflow.promotion: branch', e.g. of the
+GitLab Flow preset, the environment branch named like the environment is
+fast-forwarded to the release commit and pushed instead.
+
+A version can only be promoted once it has been released, and only after it
+has been promoted to the preceding environment.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return core.Promote(args[0], environment, core.ProjectPath)
+	},
+}
+
+// Initialize Cobra flags for the promote subcommand.
+func init() {
+	PromoteCmd.Flags().StringVar(&environment, "to", "", "target environment (must be listed under 'environments' in the config)")
+	_ = PromoteCmd.MarkFlagRequired("to")
+}
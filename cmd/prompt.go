@@ -23,8 +23,7 @@ func initPrompts() {
 
 func initBranchSync() {
 	core.BranchSync = func(req core.BranchSyncRequest) (core.BranchSyncResult, error) {
-		autoConfirm, _ := rootCmd.Flags().GetBool("yes")
-		return handleBranchSync(req, autoConfirm)
+		return handleBranchSync(req, autoConfirm())
 	}
 }
 
@@ -33,38 +32,36 @@ func handleBranchSync(req core.BranchSyncRequest, autoConfirm bool) (core.Branch
 
 	if autoConfirm {
 		if canCreate {
-			fmt.Fprintf(os.Stderr, "INFO: creating %s branch '%s' from '%s'\n",
-				req.BranchType, req.Configured, req.CreateFrom)
+			fmt.Fprintln(os.Stderr, core.Message(core.MsgBranchCreatingAuto, req.BranchType, req.Configured, req.CreateFrom))
 			return core.BranchSyncResult{ResolvedName: req.Configured, Created: true}, nil
 		}
 		if len(req.Candidates) > 0 {
 			chosen := req.Candidates[0]
-			fmt.Fprintf(os.Stderr, "INFO: %s branch '%s' not found, using '%s'\n",
-				req.BranchType, req.Configured, chosen)
+			fmt.Fprintln(os.Stderr, core.Message(core.MsgBranchUsingAuto, req.BranchType, req.Configured, chosen))
 			persistBranchToConfig(req.BranchType, chosen)
 			return core.BranchSyncResult{ResolvedName: chosen, Persist: true}, nil
 		}
 		return core.BranchSyncResult{}, nil
 	}
 
-	fmt.Fprintf(os.Stderr, "%s branch '%s' not found.\n", req.BranchType, req.Configured)
+	fmt.Fprintln(os.Stderr, core.Message(core.MsgBranchNotFound, req.BranchType, req.Configured))
 
 	var input string
 	if canCreate {
-		fmt.Fprintf(os.Stderr, "Enter branch name or press Enter to create '%s': ", req.Configured)
+		core.Prompt(os.Stderr, core.Message(core.MsgBranchEnterOrCreate, req.Configured))
 		input = readLine()
 		if input == "" {
-			fmt.Fprintf(os.Stderr, "Creating '%s' from '%s'...\n", req.Configured, req.CreateFrom)
+			fmt.Fprintln(os.Stderr, core.Message(core.MsgBranchCreating, req.Configured, req.CreateFrom))
 			return core.BranchSyncResult{ResolvedName: req.Configured, Created: true}, nil
 		}
 	} else if len(req.Candidates) > 0 {
-		fmt.Fprintf(os.Stderr, "Enter branch name [%s]: ", req.Candidates[0])
+		core.Prompt(os.Stderr, core.Message(core.MsgBranchEnterDefault, req.Candidates[0]))
 		input = readLine()
 		if input == "" {
 			input = req.Candidates[0]
 		}
 	} else {
-		fmt.Fprintf(os.Stderr, "Enter existing branch name: ")
+		core.Prompt(os.Stderr, core.Message(core.MsgBranchEnterExisting))
 		input = readLine()
 		if input == "" {
 			return core.BranchSyncResult{}, nil
@@ -81,11 +78,11 @@ func handleBranchSync(req core.BranchSyncRequest, autoConfirm bool) (core.Branch
 	}
 
 	if !canCreate {
-		fmt.Fprintf(os.Stderr, "Branch '%s' does not exist on remote.\n", input)
+		fmt.Fprintln(os.Stderr, core.Message(core.MsgBranchNotOnRemote, input))
 		return core.BranchSyncResult{}, nil
 	}
 
-	fmt.Fprintf(os.Stderr, "Creating '%s' from '%s'...\n", input, req.CreateFrom)
+	fmt.Fprintln(os.Stderr, core.Message(core.MsgBranchCreating, input, req.CreateFrom))
 	if input != req.Configured {
 		persistBranchToConfig(req.BranchType, input)
 	}
@@ -110,12 +107,19 @@ func persistBranchToConfig(branchType core.Branch, name string) {
 	key := "branches." + branchType.ConfigKey()
 	viper.Set(key, name)
 	if err := viper.WriteConfig(); err != nil {
-		fmt.Fprintf(os.Stderr, "WARN: could not save config: %v\n", err)
+		fmt.Fprintln(os.Stderr, core.Message(core.MsgConfigSaveFailed, err))
 	} else {
-		fmt.Fprintf(os.Stderr, "Configured '%s: %s'\n", key, name)
+		fmt.Fprintln(os.Stderr, core.Message(core.MsgConfigSaved, key, name))
 	}
 }
 
+// autoConfirm reports whether interactive prompts are confirmed automatically, which pipelines of a CI system
+// require as well, whether it is selected with --ci or detected.
+func autoConfirm() bool {
+	yes, _ := rootCmd.Flags().GetBool("yes")
+	return yes || core.CIMode() != core.CINone || core.CIDetected()
+}
+
 func readLine() string {
 	reader := bufio.NewReader(os.Stdin)
 	line, _ := reader.ReadString('\n')
@@ -124,20 +128,13 @@ func readLine() string {
 
 func initToolFallback() {
 	plugin.ToolFallbackFunc = func(tool string, image string) (bool, error) {
-		autoConfirm, _ := rootCmd.Flags().GetBool("yes")
-
-		if autoConfirm {
-			fmt.Fprintf(os.Stderr, "INFO: %s not found, using Docker (%s)\n", tool, image)
+		if autoConfirm() {
+			fmt.Fprintln(os.Stderr, core.Message(core.MsgDockerFallbackAuto, tool, image))
 			return true, nil
 		}
 
-		fmt.Fprintf(os.Stderr, "%s not found. Use Docker (%s) instead? [Y/n] ", tool, image)
-
-		answer := readLine()
-		if answer != "" && answer != "y" && answer != "yes" {
-			return false, nil
-		}
+		core.Prompt(os.Stderr, core.Message(core.MsgDockerFallbackPrompt, tool, image))
 
-		return true, nil
+		return core.IsAffirmative(readLine()), nil
 	}
 }
@@ -0,0 +1,42 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package recovery
+
+import (
+	"github.com/mercedes-benz/gitflow-cli/core"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags of the recover command.
+var execute bool
+
+// RecoverCmd represents the recover subcommand of RootCmd.
+var RecoverCmd = &cobra.Command{
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	Use:          "recover",
+	Short:        "Complete a half-completed release or hotfix finish",
+
+	Long: `Complete a half-completed release or hotfix finish.
+
+A finish can stop halfway, e.g. after the release branch has been merged
+into the production branch but not into the development branch, or after
+the tag has been pushed but the branch has not been deleted. Recover lists
+the remaining steps of such a finish. They are taken from the state file of
+an interrupted finish if there is one, and derived from the branches and
+tags of the repository otherwise. With --execute, the remaining steps are
+executed as well.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return core.Recover(core.ProjectPath, execute)
+	},
+}
+
+// Initialize Cobra flags for the recover command.
+func init() {
+	RecoverCmd.Flags().BoolVar(&execute, "execute", false, "execute the remaining steps of the finish")
+}
@@ -6,6 +6,11 @@ SPDX-License-Identifier: MIT
 package release
 
 import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/mercedes-benz/gitflow-cli/core"
 
 	"github.com/spf13/cobra"
@@ -37,11 +42,20 @@ By doing this, the master branch always reflects the latest released and
 production-ready state of the software.`,
 }
 
+// Explicit version of the release to start.
+var startVersion string
+
+// Bumps of the release version from the latest release.
+var bumps = []string{core.BumpAuto, "major", "minor", "patch"}
+
+// Bump the major or minor version on develop before starting the release.
+var major, minor bool
+
 // StartCmd represents the start subcommand of ReleaseCmd.
 var startCmd = &cobra.Command{
-	Args:         cobra.NoArgs,
+	Args:         cobra.MaximumNArgs(1),
 	SilenceUsage: true,
-	Use:          "start",
+	Use:          "start [version]",
 	Short:        "Create a new production release branch",
 
 	Long: `Create a new production release branch.
@@ -50,11 +64,58 @@ When the develop branch has acquired enough features for a release, a new
 branch is created. This branch is used to prepare for a new production
 release.
 
+Without version, the release version is the version of the develop branch
+without qualifier. With version as argument or --version, e.g. '2.5.0', the
+release is started with this version instead, which must not be released yet.
+
+With --major or --minor, the version of the develop branch is bumped to the
+next major or minor version first (e.g. '1.1.0-dev' to '2.0.0-dev' with
+--major), and the release is started with the bumped version.
+
+With --bump, the release version is derived from the latest release tag instead
+of the develop version: 'major', 'minor' and 'patch' bump the latest release
+(e.g. '1.0.0' to '1.0.1' with --bump=patch), and 'auto' chooses the bump from the
+conventional commits on develop since the latest release: 'major' for breaking
+changes ('feat!:' or a 'BREAKING CHANGE:' footer), 'minor' for 'feat:' and
+'patch' for 'fix:'. The version of the develop branch is set accordingly first.
+
+With --issue, the title of the issue is fetched from the issue tracker configured
+under 'tracker' and appended to the branch name as description, and the commit
+messages reference the issue.
+
 By default, plugin commands run natively on the host. Use --docker-mode to run
 them inside a Docker container instead.`,
 
 	RunE: func(c *cobra.Command, args []string) error {
-		return core.Start(core.Release, core.ProjectPath)
+		version := startVersion
+		if len(args) > 0 {
+			if len(version) > 0 && version != args[0] {
+				return fmt.Errorf("conflicting release versions: %v and %v", args[0], version)
+			}
+			version = args[0]
+		}
+
+		increment := core.None
+		if major {
+			increment = core.Major
+		} else if minor {
+			increment = core.Minor
+		}
+
+		if len(version) > 0 && increment != core.None {
+			return fmt.Errorf("release version %v cannot be combined with --%v", version, increment)
+		}
+
+		if len(core.ReleaseBump) > 0 {
+			if !slices.Contains(bumps, core.ReleaseBump) {
+				return fmt.Errorf("unsupported version bump: %v (%v)", core.ReleaseBump, strings.Join(bumps, ", "))
+			}
+			if len(version) > 0 {
+				return fmt.Errorf("release version %v cannot be combined with --bump", version)
+			}
+		}
+
+		return core.Start(core.Release, version, increment, core.ProjectPath)
 	},
 }
 
@@ -70,6 +131,29 @@ var finishCmd = &cobra.Command{
 Once the team is satisfied with the state of the release branch, it is merged
 into master and tagged with a version number.
 
+With --fast-forward-develop, develop is updated by merging the production branch
+afterwards instead of the release branch, which fast-forwards develop if it has
+no commits of its own, so that develop follows the history of production.
+
+With --via-pr, the release branch is pushed and merged by GitHub or Bitbucket
+pull requests or GitLab merge requests into master and develop instead, e.g. for
+protected branches which reject direct pushes. The merge commit of the request
+into master is tagged once all requests are merged, and the next development
+version is delivered as request. Without --wait, the finish stops until the
+requests are merged and is resumed by 'release continue'. With --wait, it waits
+for the merge up to the 'merge-timeout' of the hosting service. The token of the
+GitHub API is read from GITHUB_TOKEN or 'github.token', the token of the GitLab
+API from GITLAB_TOKEN, 'gitlab.token' or the job token of GitLab CI, and the
+token of the Bitbucket API from BITBUCKET_TOKEN or 'bitbucket.token'. On
+Bitbucket, a finish without --via-pr fails before it starts if master or develop
+accepts changes by pull requests only.
+
+With --publish, the GitHub or GitLab release of the pushed tag is created once
+the release is finished, with the changes since the previous release in the
+format of the changelog as notes. With --draft, a GitHub release is created as
+draft without assets, which is published by hand. Both are also enabled by
+'publish' and 'draft' of the 'github' or 'gitlab' configuration.
+
 By default, plugin commands run natively on the host. Use --docker-mode to run
 them inside a Docker container instead.`,
 
@@ -78,8 +162,132 @@ them inside a Docker container instead.`,
 	},
 }
 
+// ContinueCmd represents the continue subcommand of ReleaseCmd.
+var continueCmd = &cobra.Command{
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	Use:          "continue",
+	Short:        "Continue an interrupted release finish",
+
+	Long: `Continue an interrupted release finish.
+
+The finish command records its progress in '.git/gitflow-cli/state.json', or in
+the git directory of a linked worktree. If it is interrupted, e.g. by a merge conflict or a rejected push, the cause can be
+resolved (e.g. by committing the merge) and the finish is continued at the step
+where it stopped. The state file is removed once the finish has completed.
+
+A release finished with --via-pr is continued once its pull or merge requests
+are merged. With --wait, it waits for the merge up to 'github.merge-timeout' or
+'gitlab.merge-timeout'.`,
+
+	RunE: func(c *cobra.Command, args []string) error {
+		return core.Continue(core.Release, core.ProjectPath)
+	},
+}
+
+// Write the candidate version to the version file of the release branch.
+var writeCandidate bool
+
+// CandidateCmd represents the rc subcommand of ReleaseCmd.
+var candidateCmd = &cobra.Command{
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	Use:          "rc",
+	Short:        "Tag the next release candidate of the current release branch",
+
+	Long: `Tag the next release candidate of the current release branch.
+
+Release candidates are pre-release versions of the open release, numbered in
+the order they are tagged, e.g. '1.2.0-rc.1' and then '1.2.0-rc.2', so that QA
+builds can be produced before the release is finished. The name of the
+qualifier is the release qualifier configured under '<plugin>.qualifiers', or
+'rc' if none is configured.
+
+By default, the head of the release branch is tagged and pushed without changing
+it. With --write, the candidate version is written to the version file of the
+release branch and committed first, so that the build reports the candidate
+version. Release finish removes the candidate qualifier again, so that the
+release tag holds the released version.`,
+
+	RunE: func(c *cobra.Command, args []string) error {
+		return core.ReleaseCandidate(writeCandidate, core.ProjectPath)
+	},
+}
+
+// Output format of the release notes.
+var notesFormat string
+
+// NotesCmd represents the notes subcommand of ReleaseCmd.
+var notesCmd = &cobra.Command{
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	Use:          "notes [version]",
+	Short:        "Print the release notes of a release",
+
+	Long: `Print the release notes of a release.
+
+Without version, the notes cover the commits of the current release branch that
+are not yet part of the production branch. With version, the notes cover the
+commits between the previous release tag and the tag of the version.
+
+Issue references such as PROJ-123 or #456 are extracted from the commit
+messages and the changes are grouped by issue. Patterns and link templates are
+configured under 'notes.issues' in the configuration file.`,
+
+	RunE: func(c *cobra.Command, args []string) error {
+		version := ""
+		if len(args) > 0 {
+			version = args[0]
+		}
+
+		notes, err := core.Notes(version, core.ProjectPath)
+		if err != nil {
+			return err
+		}
+
+		// in JSON output mode, the notes are part of the result document
+		if core.JSONOutput() {
+			core.RecordNotes(notes)
+			return nil
+		}
+
+		switch notesFormat {
+		case "markdown":
+			fmt.Print(notes.Markdown())
+		case "json":
+			content, err := json.MarshalIndent(notes, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(content))
+		default:
+			return fmt.Errorf("unsupported release notes format: %v", notesFormat)
+		}
+
+		return nil
+	},
+}
+
 // Initialize Cobra flags for the release subcommand.
 func init() {
+	startCmd.Flags().StringVar(&startVersion, "version", "", "version of the release instead of the develop version, e.g. 2.5.0")
+	startCmd.Flags().BoolVar(&major, "major", false, "bump the major version on develop before starting the release")
+	startCmd.Flags().BoolVar(&minor, "minor", false, "bump the minor version on develop before starting the release")
+	startCmd.Flags().StringVar(&core.ReleaseBump, "bump", "", "derive the release version from the latest release (auto, major, minor, patch)")
+	startCmd.MarkFlagsMutuallyExclusive("major", "minor", "bump")
+	startCmd.Flags().StringVar(&core.IssueKey, "issue", "", "issue the release is started for, e.g. PROJ-123 or #42")
+
+	finishCmd.Flags().BoolVar(&core.FastForwardDevelop, "fast-forward-develop", false, "update develop by merging the production branch instead of the release branch")
+	finishCmd.Flags().BoolVar(&core.ViaPullRequest, "via-pr", false, "merge the release branch by GitHub or Bitbucket pull requests or GitLab merge requests instead of pushing the merges")
+	finishCmd.Flags().BoolVar(&core.WaitForMerge, "wait", false, "wait for the merge of the pull or merge requests with --via-pr")
+	finishCmd.Flags().BoolVar(&core.Publish, "publish", false, "create the GitHub or GitLab release of the tag with the changes of the release as notes")
+	finishCmd.Flags().BoolVar(&core.PublishDraft, "draft", false, "create the GitHub release as draft, which is published by hand")
+	continueCmd.Flags().BoolVar(&core.WaitForMerge, "wait", false, "wait for the merge of the pull or merge requests of a release finished with --via-pr")
+
+	candidateCmd.Flags().BoolVar(&writeCandidate, "write", false, "write the candidate version to the version file of the release branch")
+
+	notesCmd.Flags().StringVar(&notesFormat, "format", "markdown", "output format of the release notes (markdown, json)")
+
 	// add subcommands to the release command
-	ReleaseCmd.AddCommand(startCmd, finishCmd)
+	ReleaseCmd.AddCommand(startCmd, finishCmd, continueCmd, candidateCmd, notesCmd)
 }
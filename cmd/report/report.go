@@ -0,0 +1,46 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package report
+
+import (
+	"github.com/mercedes-benz/gitflow-cli/core"
+
+	"github.com/spf13/cobra"
+)
+
+// File the report is written to.
+var reportFile string
+
+// Number of the latest released versions in the report.
+var limit int
+
+// ReportCmd represents the report subcommand of RootCmd.
+var ReportCmd = &cobra.Command{
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	Use:          "report",
+	Short:        "Write an HTML summary of the latest releases and hotfixes",
+
+	Long: `Write an HTML summary of the latest releases and hotfixes.
+
+The report is a single static HTML file to share the release history with
+stakeholders. It is derived from the version tags: each released version
+lists its type, the date of its tag, the duration from the first commit of
+its release or hotfix branch to the tag, and the commits since the previous
+version. Versions with a patch number other than zero are hotfixes.
+
+The report covers the latest 10 versions, use --limit 0 to report all.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return core.Report(core.ProjectPath, reportFile, limit)
+	},
+}
+
+// Initialize Cobra flags for the report subcommand.
+func init() {
+	ReportCmd.Flags().StringVarP(&reportFile, "file", "f", "gitflow-report.html", "file the HTML report is written to")
+	ReportCmd.Flags().IntVar(&limit, "limit", 10, "number of the latest released versions in the report (0: all)")
+}
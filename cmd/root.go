@@ -9,27 +9,124 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/mercedes-benz/gitflow-cli/cmd/bootstrap"
+	"github.com/mercedes-benz/gitflow-cli/cmd/bugfix"
+	"github.com/mercedes-benz/gitflow-cli/cmd/changelog"
+	"github.com/mercedes-benz/gitflow-cli/cmd/check"
 	"github.com/mercedes-benz/gitflow-cli/cmd/hotfix"
+	"github.com/mercedes-benz/gitflow-cli/cmd/initialize"
+	"github.com/mercedes-benz/gitflow-cli/cmd/metrics"
+	"github.com/mercedes-benz/gitflow-cli/cmd/plugins"
+	"github.com/mercedes-benz/gitflow-cli/cmd/promote"
+	"github.com/mercedes-benz/gitflow-cli/cmd/recovery"
 	"github.com/mercedes-benz/gitflow-cli/cmd/release"
+	"github.com/mercedes-benz/gitflow-cli/cmd/report"
+	"github.com/mercedes-benz/gitflow-cli/cmd/schema"
+	"github.com/mercedes-benz/gitflow-cli/cmd/support"
+	"github.com/mercedes-benz/gitflow-cli/cmd/verify"
 	"github.com/mercedes-benz/gitflow-cli/core"
 	"github.com/mercedes-benz/gitflow-cli/core/plugin"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
 // Configuration file of the workflow automation command line tool.
 var cfgFile string
 
+// Flags which override a branch name of the configuration for a single run, with the key of the branch name.
+var branchFlags = []struct{ flag, key, usage string }{
+	{"production-branch", "production", "name of the production branch for this run"},
+	{"development-branch", "development", "name of the development branch for this run"},
+	{"release-prefix", "release", "prefix of release branches for this run"},
+	{"hotfix-prefix", "hotfix", "prefix of hotfix branches for this run"},
+	{"bugfix-prefix", "bugfix", "prefix of bugfix branches for this run"},
+	{"support-prefix", "support", "prefix of support branches for this run"},
+}
+
 // RootCmd represents the base command when called without any subcommands.
 var rootCmd = &cobra.Command{
 	Args: cobra.NoArgs,
 	Use:  "gitflow-cli",
+
+	// errors are printed by Execute with their secrets redacted
+	SilenceErrors: true,
+
+	// apply the safe defaults of a detected CI system, tune the environment of the git commands for the CI system
+	// selected with --ci, and warn about stalled release and hotfix branches
+	PersistentPreRunE: func(c *cobra.Command, args []string) error {
+		if system := core.ApplyCIDefaults(); system != core.CINone {
+			fmt.Fprintln(os.Stderr, core.Message(core.MsgCIDetected, system))
+		}
+		if err := core.PrepareCI(core.ProjectPath); err != nil {
+			return err
+		}
+		core.WarnStaleBranches(core.ProjectPath)
+		return preparePlan(c, args)
+	},
+
+	PersistentPostRunE: func(c *cobra.Command, args []string) error {
+		if planOut, _ := c.Flags().GetString("plan-out"); len(planOut) > 0 {
+			return core.WritePlan(planOut, commandLine(c, args))
+		}
+		return nil
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() error {
-	return rootCmd.Execute()
+	// flag values and results outlive an execution when the command tree is reused in-process (e.g. in e2e tests)
+	defer resetFlags(rootCmd)
+	defer core.ResetResult()
+	defer core.RemoveScratchDirs()
+	defer core.ResetPlan()
+
+	started := time.Now()
+	command, err := rootCmd.ExecuteC()
+	err = core.RedactError(err)
+	if err != nil {
+		rootCmd.PrintErrln(rootCmd.ErrPrefix(), err.Error())
+	}
+
+	// the opt-in telemetry records the commands, but not the help and version output of the root command
+	name := strings.TrimPrefix(command.CommandPath(), rootCmd.Name()+" ")
+	if command != rootCmd {
+		core.RecordTelemetry(name, rootCmd.Version, time.Since(started), err)
+	}
+
+	// in JSON output mode, the result document is the only output of the command on stdout, except for the schema
+	if core.JSONOutput() && command != schema.SchemaCmd {
+		if writeErr := core.WriteResult(os.Stdout, name, err); writeErr != nil {
+			return writeErr
+		}
+	}
+
+	return err
+}
+
+// Reset all flags of a command and its subcommands to their default values.
+func resetFlags(command *cobra.Command) {
+	reset := func(flag *pflag.Flag) {
+		if !flag.Changed {
+			return
+		}
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			_ = slice.Replace(nil)
+		} else {
+			_ = flag.Value.Set(flag.DefValue)
+		}
+		flag.Changed = false
+	}
+
+	command.Flags().VisitAll(reset)
+	command.PersistentFlags().VisitAll(reset)
+
+	for _, child := range command.Commands() {
+		resetFlags(child)
+	}
 }
 
 // Initialize Cobra flags and configuration settings.
@@ -44,7 +141,7 @@ func init() {
 	initPrompts()
 
 	// add subcommands to the root command
-	rootCmd.AddCommand(release.ReleaseCmd, hotfix.HotfixCmd)
+	rootCmd.AddCommand(initialize.InitCmd, bootstrap.BootstrapCmd, release.ReleaseCmd, hotfix.HotfixCmd, bugfix.BugfixCmd, support.SupportCmd, promote.PromoteCmd, verify.VerifyCmd, check.CheckCmd, recovery.RecoverCmd, report.ReportCmd, changelog.ChangelogCmd, metrics.MetricsCmd, plugins.PluginCmd, schema.SchemaCmd)
 
 	// persistent flags, which, if defined here, will be global for the application
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file (default is $HOME/.gitflow-cli.yaml)")
@@ -52,12 +149,31 @@ func init() {
 	rootCmd.PersistentFlags().Bool("docker-mode", false, "run plugin commands inside a Docker container")
 	rootCmd.PersistentFlags().Bool("native-mode", false, "run plugin commands natively on the host (default)")
 	rootCmd.PersistentFlags().Bool("no-push", false, "do not push changes to remote repository")
+	rootCmd.PersistentFlags().Bool("dry-run", false, "print the git commands and version changes without changing the repository")
 	rootCmd.PersistentFlags().BoolP("yes", "y", false, "automatically confirm all interactive prompts")
+	rootCmd.PersistentFlags().Bool("plain", false, "plain line-oriented output without colors and glyphs (default unless the output mode is rich)")
+	rootCmd.PersistentFlags().String("output", "", "output mode: auto, plain, rich, or json (result document on stdout, see 'schema')")
+	rootCmd.PersistentFlags().String("ci", "", "tune the invocation for a CI system (gitlab), which also confirms all prompts")
+	rootCmd.PersistentFlags().Bool("no-ci-defaults", false, "do not apply the safe defaults of a detected CI system (no prompts, plain output, no rollback)")
+	for _, branchFlag := range branchFlags {
+		rootCmd.PersistentFlags().String(branchFlag.flag, "", branchFlag.usage)
+	}
+	rootCmd.PersistentFlags().String("plan-out", "", "write the execution plan of a dry run to a file, e.g. plan.json, for approval (implies --dry-run)")
+	rootCmd.PersistentFlags().String("plan-in", "", "execute the command only if its execution plan is the approved plan of the file")
+	rootCmd.MarkFlagsMutuallyExclusive("plan-out", "plan-in")
+	rootCmd.MarkFlagsMutuallyExclusive("dry-run", "plan-in")
 	rootCmd.MarkFlagsMutuallyExclusive("docker-mode", "native-mode")
+	rootCmd.MarkFlagsMutuallyExclusive("plain", "output")
 }
 
 // Read in Viper config file and environment variables if set.
 func initConfiguration() {
+	// discard settings of a previous in-process execution
+	viper.Reset()
+
+	// environment variables override the config files, and the flags override both
+	bindEnvironment()
+
 	if docker, _ := rootCmd.Flags().GetBool("docker-mode"); docker {
 		plugin.ExecutorModeOverride = plugin.ModeDocker
 	} else if native, _ := rootCmd.Flags().GetBool("native-mode"); native {
@@ -68,33 +184,189 @@ func initConfiguration() {
 		viper.Set("workflow.push", false)
 	}
 
+	if dryRun, _ := rootCmd.Flags().GetBool("dry-run"); dryRun {
+		viper.Set("workflow.dry-run", true)
+	}
+
+	if plain, _ := rootCmd.Flags().GetBool("plain"); plain {
+		viper.Set("output", core.OutputPlain)
+	}
+
+	if output, _ := rootCmd.Flags().GetString("output"); len(output) > 0 {
+		viper.Set("output", output)
+	}
+
+	if ci, _ := rootCmd.Flags().GetString("ci"); len(ci) > 0 {
+		viper.Set("ci", ci)
+	}
+
+	if noCIDefaults, _ := rootCmd.Flags().GetBool("no-ci-defaults"); noCIDefaults {
+		viper.Set("ci-defaults", false)
+	}
+
+	for _, branchFlag := range branchFlags {
+		if name, _ := rootCmd.Flags().GetString(branchFlag.flag); len(name) > 0 {
+			viper.Set("branches."+branchFlag.key, name)
+		}
+	}
+
+	// config files or variables of the settings which run commands, which the validation of the commands reports
+	sources := map[string]string{}
+
 	if cfgFile != "" {
 		// use config file from the flag
 		viper.SetConfigFile(cfgFile)
-	} else {
-		// find home directory
-		home, err := os.UserHomeDir()
-		cobra.CheckErr(err)
-
-		// search config in home directory with name ".gitflow-cli" (without extension)
-		viper.AddConfigPath(home)
-		viper.SetConfigType("yaml")
-		viper.SetConfigName(".gitflow-cli")
+		if err := viper.ReadInConfig(); err == nil {
+			fmt.Fprintln(os.Stderr, core.Message(core.MsgConfigUsing, viper.ConfigFileUsed()))
+		}
+		readUserConfig(cfgFile, sources)
+		recordEnvironmentSources(sources)
+		core.SetCommandSources(sources)
+		return
 	}
 
-	// read in environment variables that match
-	viper.AutomaticEnv()
+	// find home directory
+	home, err := os.UserHomeDir()
+	cobra.CheckErr(err)
+
+	// search the user config in the home directory with name ".gitflow-cli" (without extension)
+	viper.AddConfigPath(home)
+	viper.SetConfigType("yaml")
+	viper.SetConfigName(".gitflow-cli")
 
-	// if a config file is found, read it in
+	userConfig := ""
 	if err := viper.ReadInConfig(); err == nil {
-		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
-	} else if cfgFile == "" {
+		userConfig = viper.ConfigFileUsed()
+		fmt.Fprintln(os.Stderr, core.Message(core.MsgConfigUsing, userConfig))
+	}
+	readUserConfig(userConfig, sources)
+
+	// the project-local config is versioned with the repository and its settings take precedence
+	if projectConfig := findProjectConfig(core.ProjectPath, userConfig); len(projectConfig) > 0 {
+		if err := mergeProjectConfig(projectConfig, sources); err == nil {
+			fmt.Fprintln(os.Stderr, core.Message(core.MsgConfigUsing, projectConfig))
+		} else {
+			fmt.Fprintln(os.Stderr, core.Message(core.MsgConfigReadFailed, projectConfig, err))
+		}
+	} else if len(userConfig) == 0 {
 		if err := initDefaultConfig(); err != nil {
-			fmt.Fprintln(os.Stderr, "Warning: could not create default config:", err)
+			fmt.Fprintln(os.Stderr, core.Message(core.MsgConfigCreateFailed, err))
 		} else {
 			_ = viper.ReadInConfig()
 		}
 	}
+
+	recordEnvironmentSources(sources)
+	core.SetCommandSources(sources)
+}
+
+// Configuration group of the opt-in telemetry.
+const telemetryGroup = "telemetry"
+
+// readUserConfig passes the telemetry settings of the user's own config file to the core, because the telemetry
+// is opted in by the user only, not by the project config or the environment which the merged settings include.
+// The config file is recorded as the source of its settings which run commands.
+func readUserConfig(configFile string, sources map[string]string) {
+	settings := map[string]any{}
+	if len(configFile) > 0 {
+		user := viper.New()
+		user.SetConfigFile(configFile)
+		if err := user.ReadInConfig(); err == nil {
+			settings = user.GetStringMap(telemetryGroup)
+			recordCommandSources(sources, user.AllSettings(), configFile)
+		}
+	}
+	core.SetUserTelemetrySettings(settings)
+}
+
+// recordCommandSources records a config file or a variable as the source of the settings running commands it holds.
+func recordCommandSources(sources map[string]string, settings map[string]any, source string) {
+	for _, key := range core.CommandSettingsOf(settings) {
+		sources[key] = source
+	}
+}
+
+// Setting of the user config with the directories of the trusted projects, whose project configs may run commands.
+const trustedProjectsKey = "trusted-projects"
+
+// mergeProjectConfig merges the settings of a project config over the settings read so far. The settings which run
+// commands, e.g. the pre-tag hook, are ignored with a warning, unless the project is in or below a directory of the
+// trusted projects, because a cloned repository must not run commands on the machines of its contributors. The
+// project config is recorded as the source of the settings running commands it keeps.
+func mergeProjectConfig(projectConfig string, sources map[string]string) error {
+	project := viper.New()
+	project.SetConfigFile(projectConfig)
+	if err := project.ReadInConfig(); err != nil {
+		return err
+	}
+
+	settings := project.AllSettings()
+	if !trustedProject(filepath.Dir(projectConfig), viper.GetStringSlice(trustedProjectsKey)) {
+		for _, key := range core.RemoveCommandSettings(settings) {
+			fmt.Fprintln(os.Stderr, core.Message(core.MsgConfigIgnored, key, projectConfig, trustedProjectsKey))
+		}
+	}
+
+	recordCommandSources(sources, settings, projectConfig)
+
+	viper.SetConfigFile(projectConfig)
+	return viper.MergeConfigMap(settings)
+}
+
+// trustedProject reports whether the directory of a project is one of the trusted directories or below one of them,
+// which may start with '~/' for the home directory.
+func trustedProject(projectDir string, trusted []string) bool {
+	dir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return false
+	}
+
+	for _, entry := range trusted {
+		if len(entry) == 0 {
+			continue
+		}
+		if rest, found := strings.CutPrefix(entry, "~/"); found {
+			if home, err := os.UserHomeDir(); err == nil {
+				entry = filepath.Join(home, rest)
+			}
+		}
+		trustedDir, err := filepath.Abs(entry)
+		if err != nil {
+			continue
+		}
+		if relative, err := filepath.Rel(trustedDir, dir); err == nil && relative != ".." &&
+			!strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// findProjectConfig returns the project-local config file of the project path or of its nearest parent directory
+// which has one, or an empty string. The user config is skipped, e.g. for projects below the home directory.
+func findProjectConfig(projectPath, userConfig string) string {
+	dir, err := filepath.Abs(projectPath)
+	if err != nil {
+		return ""
+	}
+
+	var user os.FileInfo
+	if len(userConfig) > 0 {
+		user, _ = os.Stat(userConfig)
+	}
+
+	for {
+		configPath := filepath.Join(dir, core.ProjectConfigFileName)
+		if info, err := os.Stat(configPath); err == nil && !info.IsDir() && (user == nil || !os.SameFile(info, user)) {
+			return configPath
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
 }
 
 const defaultConfig = `branches:
@@ -102,6 +374,8 @@ const defaultConfig = `branches:
   development: develop
   release: release
   hotfix: hotfix
+  bugfix: bugfix
+  support: support
 
 workflow:
   push: true
@@ -127,6 +401,6 @@ func initDefaultConfig() error {
 		return err
 	}
 
-	fmt.Fprintln(os.Stderr, "Created default config file:", configPath)
+	fmt.Fprintln(os.Stderr, core.Message(core.MsgConfigCreated, configPath))
 	return nil
 }
@@ -6,12 +6,15 @@ SPDX-License-Identifier: MIT
 package cmd_test
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/mercedes-benz/gitflow-cli/cmd"
 	"github.com/mercedes-benz/gitflow-cli/e2e"
 	_ "github.com/mercedes-benz/gitflow-cli/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func init() {
@@ -63,6 +66,58 @@ func TestReleaseStartWithConfigFile(t *testing.T) {
 	env.AssertCommitMessageEquals("Remove qualifier from project version.", customReleaseBranch)
 }
 
+// TestReleaseStartWithEnvironment tests the release start workflow configured by GITFLOW_* environment variables
+func TestReleaseStartWithEnvironment(t *testing.T) {
+	env, _ := setupCustomBranchTest(t)
+	t.Setenv("GITFLOW_BRANCHES__PRODUCTION", productionBranch)
+	t.Setenv("GITFLOW_BRANCHES__DEVELOPMENT", developmentBranch)
+	t.Setenv("GITFLOW_BRANCHES__RELEASE", releaseBranch)
+	t.Setenv("GITFLOW_BRANCHES__HOTFIX", hotfixBranch)
+	t.Setenv("GITFLOW_WORKFLOW__PUSH", "false")
+
+	env.CommitTemplateContent(versionTemplate, versionFileName, "1.0.0", productionBranch)
+	env.CommitTemplateContent(versionTemplate, versionFileName, "1.1.0-dev", developmentBranch)
+
+	env.ExecuteGitflow("release", "start")
+
+	customReleaseBranch := releaseBranch + "/1.1.0"
+	env.AssertBranchExists(customReleaseBranch)
+	env.AssertBranchNotOnRemote(customReleaseBranch)
+	env.AssertTemplateVersionEquals(versionTemplate, versionFileName, "1.1.0", customReleaseBranch)
+}
+
+// TestReleaseStartWithBranchFlags tests the release start workflow with branch names passed as flags
+func TestReleaseStartWithBranchFlags(t *testing.T) {
+	env, _ := setupCustomBranchTest(t)
+
+	env.CommitTemplateContent(versionTemplate, versionFileName, "1.0.0", productionBranch)
+	env.CommitTemplateContent(versionTemplate, versionFileName, "1.1.0-dev", developmentBranch)
+
+	env.ExecuteGitflow("release", "start",
+		"--production-branch", productionBranch,
+		"--development-branch", developmentBranch,
+		"--release-prefix", releaseBranch)
+
+	customReleaseBranch := releaseBranch + "/1.1.0"
+	env.AssertBranchExists(customReleaseBranch)
+	env.AssertTemplateVersionEquals(versionTemplate, versionFileName, "1.1.0", customReleaseBranch)
+}
+
+// TestReleaseStartWithBranchFlagOverLegacyConfig tests that a branch flag keeps the other branch names of a
+// configuration with the legacy 'core' group
+func TestReleaseStartWithBranchFlagOverLegacyConfig(t *testing.T) {
+	env, _ := setupCustomBranchTest(t)
+	configPath := env.WriteConfig("core:\n  production: " + productionBranch + "\n  development: " + developmentBranch + "\n  release: release\n")
+
+	env.CommitTemplateContent(versionTemplate, versionFileName, "1.0.0", productionBranch)
+	env.CommitTemplateContent(versionTemplate, versionFileName, "1.1.0-dev", developmentBranch)
+
+	env.ExecuteGitflow("release", "start", "--config", configPath, "--release-prefix", releaseBranch)
+
+	env.AssertBranchExists(releaseBranch + "/1.1.0")
+	env.AssertBranchDoesNotExist("release/1.1.0")
+}
+
 // TestReleaseFinishWithConfigFile tests the release finish workflow with a custom configuration file
 func TestReleaseFinishWithConfigFile(t *testing.T) {
 	env, configPath := setupCustomBranchTest(t)
@@ -128,3 +183,91 @@ func TestHotfixFinishWithConfigFile(t *testing.T) {
 	env.AssertBranchDoesNotExist(customHotfixBranch)
 	env.AssertCurrentBranchEquals(developmentBranch)
 }
+
+// TestLocaleFromConfigFile tests that user-facing messages follow the locale of the configuration file
+func TestLocaleFromConfigFile(t *testing.T) {
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent(versionTemplate, versionFileName, "1.0.0", "main")
+	env.CommitTemplateContent(versionTemplate, versionFileName, "1.1.0-dev", "develop")
+
+	configPath := env.WriteConfig("locale: de\n")
+	errMsg := env.ExecuteGitflowExpectError("release", "finish", "--config", configPath)
+
+	assert.Contains(t, errMsg, "Repository hat keinen 'release'-Branch, der abgeschlossen werden kann")
+}
+
+// TestLocaleFromEnvironment tests that user-facing messages follow the GITFLOW_LOCALE environment variable
+func TestLocaleFromEnvironment(t *testing.T) {
+	env := e2e.SetupTestEnv(t)
+	t.Setenv("GITFLOW_LOCALE", "de_DE.UTF-8")
+
+	env.CommitTemplateContent(versionTemplate, versionFileName, "1.0.0", "main")
+	env.CommitTemplateContent(versionTemplate, versionFileName, "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+
+	output := env.ExecuteGitflow("release", "finish")
+
+	assert.Contains(t, output, "standard-Plugin: Abschluss auf Branch release abgeschlossen")
+}
+
+// TestRichOutputFromConfigFile tests that status messages are decorated with colors and glyphs in rich output mode
+func TestRichOutputFromConfigFile(t *testing.T) {
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent(versionTemplate, versionFileName, "1.0.0", "main")
+	env.CommitTemplateContent(versionTemplate, versionFileName, "1.1.0-dev", "develop")
+
+	t.Setenv("NO_COLOR", "")
+	require.NoError(t, os.Unsetenv("NO_COLOR"))
+	t.Setenv("TERM", "xterm")
+
+	configPath := env.WriteConfig("output: rich\n")
+	output := env.ExecuteGitflow("release", "start", "--config", configPath)
+
+	assert.Contains(t, output, "\x1b[32m✔\x1b[0m standard Plugin Start on branch release completed")
+}
+
+// TestPlainOutputByDefault tests that status messages are plain text without colors and glyphs by default
+func TestPlainOutputByDefault(t *testing.T) {
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent(versionTemplate, versionFileName, "1.0.0", "main")
+	env.CommitTemplateContent(versionTemplate, versionFileName, "1.1.0-dev", "develop")
+	t.Setenv("TERM", "xterm")
+
+	output := env.ExecuteGitflow("release", "start")
+
+	assert.Contains(t, output, "\nstandard Plugin Start on branch release completed")
+	assert.NotContains(t, output, "\x1b[")
+}
+
+// TestRichOutputNoColor tests that NO_COLOR keeps the output plain even in rich output mode
+func TestRichOutputNoColor(t *testing.T) {
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent(versionTemplate, versionFileName, "1.0.0", "main")
+	env.CommitTemplateContent(versionTemplate, versionFileName, "1.1.0-dev", "develop")
+	t.Setenv("NO_COLOR", "1")
+
+	configPath := env.WriteConfig("output: rich\n")
+	output := env.ExecuteGitflow("release", "start", "--config", configPath)
+
+	assert.NotContains(t, output, "\x1b[")
+	assert.NotContains(t, output, "✔")
+}
+
+// TestPlainOutputFlag tests that the plain flag overrides the rich output mode of the configuration file
+func TestPlainOutputFlag(t *testing.T) {
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent(versionTemplate, versionFileName, "1.0.0", "main")
+	env.CommitTemplateContent(versionTemplate, versionFileName, "1.1.0-dev", "develop")
+
+	configPath := env.WriteConfig("output: rich\n")
+	output := env.ExecuteGitflow("release", "start", "--plain", "--config", configPath)
+
+	assert.Contains(t, output, "\nstandard Plugin Start on branch release completed")
+	assert.NotContains(t, output, "\x1b[")
+	assert.NotContains(t, output, "✔")
+}
@@ -0,0 +1,50 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package schema
+
+import (
+	"fmt"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+
+	"github.com/spf13/cobra"
+)
+
+// SchemaCmd represents the schema subcommand of RootCmd.
+var SchemaCmd = &cobra.Command{
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	Use:          "schema [name]",
+	Short:        "Print the JSON Schema of the machine-readable output",
+
+	Long: `Print the JSON Schema of the machine-readable output.
+
+With --output json, every command writes a result document to stdout, while
+status messages are written to stderr. The result holds the command, its
+status, the branch, version and tag of workflow commands, the violations found
+by check, the release notes, the metrics, and the error of a failed command.
+The exit code is non-zero on failure.
+
+Without name, the schema of the result document is printed. The schemas of the
+release notes and the metrics, which are also written by 'release notes
+--format json' and 'metrics', are printed with 'release-notes' and 'metrics'. The schemas are versioned: within a version,
+fields are only added, never renamed or removed.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := "result"
+		if len(args) > 0 {
+			name = args[0]
+		}
+
+		schema, err := core.Schema(name)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(schema)
+		return nil
+	},
+}
@@ -0,0 +1,52 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package support
+
+import (
+	"github.com/mercedes-benz/gitflow-cli/core"
+
+	"github.com/spf13/cobra"
+)
+
+// SupportCmd represents the support subcommand of RootCmd.
+var SupportCmd = &cobra.Command{
+	Args:  cobra.NoArgs,
+	Use:   "support",
+	Short: "Maintain an older major or minor release line",
+
+	Long: `Maintain an older major or minor release line.
+
+Support branches are long-lived branches for maintenance releases of older
+major or minor versions. They are based on a release tag and named 'support/'
+followed by the major and minor version of the release, e.g. 'support/1.2'.
+
+Hotfixes for the maintained line are started from and finished into the support
+branch with 'hotfix start --support 1.2' and 'hotfix finish --support 1.2'. They
+are tagged on the support branch and neither touch master nor develop.`,
+}
+
+// StartCmd represents the start subcommand of SupportCmd.
+var startCmd = &cobra.Command{
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	Use:          "start <version>",
+	Short:        "Create a new support branch from a release",
+
+	Long: `Create a new support branch from a release.
+
+The support branch 'support/<major>.<minor>' is created at the tag of the
+released version and pushed to the remote repository.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return core.StartSupport(args[0], core.ProjectPath)
+	},
+}
+
+// Initialize Cobra flags for the support subcommand.
+func init() {
+	// add subcommands to the support command
+	SupportCmd.AddCommand(startCmd)
+}
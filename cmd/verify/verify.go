@@ -0,0 +1,50 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package verify
+
+import (
+	"github.com/mercedes-benz/gitflow-cli/core"
+
+	"github.com/spf13/cobra"
+)
+
+// VerifyCmd represents the verify subcommand of RootCmd.
+var VerifyCmd = &cobra.Command{
+	Args:  cobra.NoArgs,
+	Use:   "verify",
+	Short: "Check the repository for drift from the workflow",
+
+	Long: `Check the repository for drift from the workflow.
+
+Repositories which are managed partly by hand can end up with tags or
+versions the workflow would never have created. The verify commands report
+such drift without changing the repository.`,
+}
+
+// TagsCmd represents the tags subcommand of VerifyCmd.
+var tagsCmd = &cobra.Command{
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	Use:          "tags",
+	Short:        "Check the version tags against the production branch",
+
+	Long: `Check the version tags against the production branch.
+
+Every tag matching the version scheme, e.g. '1.2.0', must be reachable from
+the production branch, and the version file of the production branch must
+hold the version of the latest tag. Each drift is reported and the command
+fails if any is found.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return core.VerifyTags(core.ProjectPath)
+	},
+}
+
+// Initialize Cobra flags for the verify subcommand.
+func init() {
+	// add subcommands to the verify command
+	VerifyCmd.AddCommand(tagsCmd)
+}
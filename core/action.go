@@ -0,0 +1,40 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Environment variable of GitHub Actions naming the file which collects the outputs of the current step.
+const githubOutputEnv = "GITHUB_OUTPUT"
+
+// setOutputs publishes the branch, version and tag of a workflow command as step outputs, if it runs in GitHub
+// Actions, and records them for the result document. Start commands create no tag and publish an empty tag.
+func setOutputs(branchName string, version Version, tagName string) error {
+	recordResult(branchName, version, tagName)
+
+	path := os.Getenv(githubOutputEnv)
+	if len(path) == 0 {
+		return nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	var outputs strings.Builder
+	fmt.Fprintf(&outputs, "branch=%v\n", branchName)
+	fmt.Fprintf(&outputs, "version=%v\n", version)
+	fmt.Fprintf(&outputs, "tag=%v\n", tagName)
+
+	_, err = file.WriteString(outputs.String())
+	return err
+}
@@ -0,0 +1,206 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Configuration group and settings of the on-disk cache of hosting API responses.
+const (
+	apiCacheGroup          = "api-cache"
+	apiCacheEnabledSetting = "enabled"
+	apiCachePathSetting    = "path"
+)
+
+// API cache settings of the current run, the default path is in the user cache directory.
+var apiCacheEnabled = true
+var apiCachePath string
+
+// apiClient sends the requests to hosting APIs and issue trackers (GitHub, GitLab, JIRA). Responses with an ETag or
+// Last-Modified header are cached on disk and revalidated with conditional requests, which GitHub does not count
+// against the rate limit when the response is unchanged. Rate limited and failed requests are retried.
+var apiClient = &http.Client{Transport: &cachingTransport{next: &retryingTransport{next: http.DefaultTransport}}}
+
+// Cached response of a hosting API request.
+type cachedResponse struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	ContentType  string `json:"contentType,omitempty"`
+	Body         []byte `json:"body"`
+}
+
+// cachingTransport revalidates cached responses of GET requests and caches successful responses.
+type cachingTransport struct {
+	next http.RoundTripper
+}
+
+func applyAPICacheSettings(settings map[string]any) {
+	if v, ok := settings[apiCacheEnabledSetting].(bool); ok {
+		apiCacheEnabled = v
+	}
+	if v, ok := settings[apiCachePathSetting].(string); ok && len(v) > 0 {
+		apiCachePath = v
+	}
+}
+
+func resetAPICacheSettings() {
+	apiCacheEnabled = true
+	apiCachePath = ""
+}
+
+// apiCacheDir returns the directory of the cached responses, or an empty string if caching is disabled or there is
+// no user cache directory.
+func apiCacheDir() string {
+	if !apiCacheEnabled {
+		return ""
+	}
+	if len(apiCachePath) > 0 {
+		return apiCachePath
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gitflow-cli", "api")
+}
+
+// RoundTrip sends a request with the validators of its cached response and returns the cached response if the
+// server reports it as not modified. Failures of the cache only disable it for the request.
+func (t *cachingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	dir := apiCacheDir()
+	if request.Method != http.MethodGet || len(dir) == 0 {
+		return t.next.RoundTrip(request)
+	}
+
+	path := filepath.Join(dir, cacheKey(request)+".json")
+	cached := loadCachedResponse(path)
+
+	if cached != nil {
+		request = request.Clone(request.Context())
+		if len(cached.ETag) > 0 {
+			request.Header.Set("If-None-Match", cached.ETag)
+		}
+		if len(cached.LastModified) > 0 {
+			request.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	response, err := t.next.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode == http.StatusNotModified && cached != nil {
+		_ = response.Body.Close()
+		return cached.response(request), nil
+	}
+
+	etag, lastModified := response.Header.Get("ETag"), response.Header.Get("Last-Modified")
+	if response.StatusCode != http.StatusOK || (len(etag) == 0 && len(lastModified) == 0) ||
+		strings.Contains(response.Header.Get("Cache-Control"), "no-store") {
+		return response, nil
+	}
+
+	body, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	saveCachedResponse(path, &cachedResponse{
+		URL:          request.URL.String(),
+		ETag:         etag,
+		LastModified: lastModified,
+		ContentType:  response.Header.Get("Content-Type"),
+		Body:         body,
+	})
+
+	return response, nil
+}
+
+// cacheKey identifies a request by its URL, media type and credentials, so that responses are not shared between
+// tokens with different access. Only the hash of the credentials is part of the file name.
+func cacheKey(request *http.Request) string {
+	hash := sha256.New()
+	for _, part := range []string{request.URL.String(), request.Header.Get("Accept"), request.Header.Get("Authorization"), request.Header.Get(gitlabJobTokenHeader)} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// response returns the cached response as response of a request.
+func (c *cachedResponse) response(request *http.Request) *http.Response {
+	header := make(http.Header)
+	if len(c.ContentType) > 0 {
+		header.Set("Content-Type", c.ContentType)
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       request,
+	}
+}
+
+func loadCachedResponse(path string) *cachedResponse {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var cached cachedResponse
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil
+	}
+	return &cached
+}
+
+// saveCachedResponse writes a response readable only by the user, because it may hold data of private repositories.
+// The file is replaced atomically, so that concurrent runs never read a partial response.
+func saveCachedResponse(path string, cached *cachedResponse) {
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".response-*")
+	if err != nil {
+		return
+	}
+
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err != nil || closeErr != nil {
+		_ = os.Remove(file.Name())
+		return
+	}
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		_ = os.Remove(file.Name())
+	}
+}
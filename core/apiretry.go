@@ -0,0 +1,155 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Configuration group and settings of the retries of hosting API requests.
+const (
+	apiRetryGroup           = "api-retry"
+	apiRetryAttemptsSetting = "max-attempts"
+	apiRetryTimeoutSetting  = "timeout"
+)
+
+// Default limits of the retries of a request, and the bounds of the exponential backoff between them.
+const (
+	defaultAPIRetryAttempts = 5
+	defaultAPIRetryTimeout  = 2 * time.Minute
+	apiRetryBaseDelay       = time.Second
+	apiRetryMaxDelay        = 30 * time.Second
+)
+
+// API retry settings of the current run.
+var apiRetryAttempts = defaultAPIRetryAttempts
+var apiRetryTimeout = defaultAPIRetryTimeout
+
+func applyAPIRetrySettings(settings map[string]any) {
+	if v, ok := settings[apiRetryAttemptsSetting].(int); ok && v > 0 {
+		apiRetryAttempts = v
+	}
+	if v, ok := settings[apiRetryTimeoutSetting].(string); ok {
+		if timeout, err := time.ParseDuration(v); err == nil && timeout > 0 {
+			apiRetryTimeout = timeout
+		}
+	}
+}
+
+func resetAPIRetrySettings() {
+	apiRetryAttempts = defaultAPIRetryAttempts
+	apiRetryTimeout = defaultAPIRetryTimeout
+}
+
+// retryingTransport retries idempotent requests which are rate limited or fail temporarily. It waits as long as the
+// server asks for with Retry-After or the reset of the GitHub rate limit, and backs off exponentially otherwise.
+type retryingTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip sends a request until it succeeds, fails permanently, or the attempts or the time are exhausted.
+func (t *retryingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		return t.next.RoundTrip(request)
+	}
+
+	deadline := time.Now().Add(apiRetryTimeout)
+	for attempt := 1; ; attempt++ {
+		response, err := t.next.RoundTrip(request)
+
+		wait, reason, retry := retryDelay(response, err, attempt)
+		if !retry || attempt >= apiRetryAttempts {
+			return response, err
+		}
+
+		if response != nil {
+			_ = response.Body.Close()
+		}
+
+		// a request which cannot succeed in time fails with a clear error instead of an error of the last attempt
+		if time.Now().Add(wait).After(deadline) {
+			return nil, Error(MsgAPITimeout, request.URL.Redacted(), apiRetryTimeout, apiRetryGroup, apiRetryTimeoutSetting)
+		}
+
+		fmt.Fprintln(os.Stderr, Message(MsgAPIRetry, request.URL.Host, reason, wait.Round(time.Second)))
+
+		select {
+		case <-time.After(wait):
+		case <-request.Context().Done():
+			return nil, request.Context().Err()
+		}
+	}
+}
+
+// retryDelay returns how long to wait before the next attempt of a request and why, or false if the response or
+// error is final. Permission errors are final, unless GitHub reports an exceeded primary or secondary rate limit.
+func retryDelay(response *http.Response, err error, attempt int) (time.Duration, string, bool) {
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return 0, "", false
+		}
+		return backoff(attempt), err.Error(), true
+	}
+
+	switch response.StatusCode {
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		if wait, ok := retryAfter(response); ok {
+			return wait, response.Status, true
+		}
+		if response.Header.Get("X-RateLimit-Remaining") == "0" {
+			if reset, err := strconv.ParseInt(response.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+				return max(time.Until(time.Unix(reset, 0)), apiRetryBaseDelay), response.Status, true
+			}
+		}
+		if response.StatusCode == http.StatusTooManyRequests {
+			return backoff(attempt), response.Status, true
+		}
+		return 0, "", false
+
+	case http.StatusServiceUnavailable:
+		if wait, ok := retryAfter(response); ok {
+			return wait, response.Status, true
+		}
+		return backoff(attempt), response.Status, true
+
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return backoff(attempt), response.Status, true
+	}
+
+	return 0, "", false
+}
+
+// retryAfter returns the wait of the Retry-After header, given in seconds or as date.
+func retryAfter(response *http.Response) (time.Duration, bool) {
+	value := response.Header.Get("Retry-After")
+	if len(value) == 0 {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// backoff returns the exponential delay of an attempt with jitter, so that concurrent pipelines spread their retries.
+func backoff(attempt int) time.Duration {
+	delay := apiRetryMaxDelay
+	if attempt <= 5 {
+		delay = min(apiRetryBaseDelay<<(attempt-1), apiRetryMaxDelay)
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
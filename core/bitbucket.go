@@ -0,0 +1,338 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Configuration group and settings of the Bitbucket integration, in addition to the settings of all hosting services.
+const (
+	bitbucketGroup             = "bitbucket"
+	bitbucketUsernameSetting   = "username"
+	bitbucketRepositorySetting = "repository"
+)
+
+// Environment variables of the credentials of the Bitbucket API, and of the repository, which Bitbucket Pipelines set,
+// e.g. 'workspace/repo'.
+const (
+	bitbucketTokenEnv      = "BITBUCKET_TOKEN"
+	bitbucketUsernameEnv   = "BITBUCKET_USERNAME"
+	bitbucketRepositoryEnv = "BITBUCKET_REPO_FULL_NAME"
+)
+
+// API of Bitbucket Cloud, the APIs of Bitbucket Server and Data Center are below the base URL of the server.
+const defaultBitbucketCloudAPI = "https://api.bitbucket.org/2.0"
+
+// Types of the branch restrictions of Bitbucket Server which reject direct pushes.
+var bitbucketServerPushRestrictions = []string{"read-only", "pull-request-only"}
+
+// Bitbucket settings of the current run.
+var bitbucketSettings = defaultHostingSettings()
+var bitbucketUsername string
+var bitbucketRepository string
+
+func applyBitbucketSettings(settings map[string]any) {
+	bitbucketSettings.apply(settings)
+	if v, ok := settings[bitbucketUsernameSetting].(string); ok {
+		bitbucketUsername = v
+	}
+	if v, ok := settings[bitbucketRepositorySetting].(string); ok {
+		bitbucketRepository = v
+	}
+}
+
+func resetBitbucketSettings() {
+	bitbucketSettings = defaultHostingSettings()
+	bitbucketUsername = ""
+	bitbucketRepository = ""
+}
+
+// bitbucketCredentials returns the authorization header of the Bitbucket API: an access token from BITBUCKET_TOKEN or
+// 'bitbucket.token' as bearer token, or together with the user from BITBUCKET_USERNAME or 'bitbucket.username' as
+// basic credentials, e.g. for app passwords of Bitbucket Cloud.
+func bitbucketCredentials() string {
+	token := os.Getenv(bitbucketTokenEnv)
+	if len(token) == 0 {
+		token = bitbucketSettings.token
+	}
+	if len(token) == 0 {
+		return ""
+	}
+
+	username := os.Getenv(bitbucketUsernameEnv)
+	if len(username) == 0 {
+		username = bitbucketUsername
+	}
+	if len(username) > 0 {
+		request := http.Request{Header: http.Header{}}
+		request.SetBasicAuth(username, token)
+		return request.Header.Get("Authorization")
+	}
+	return "Bearer " + token
+}
+
+// bitbucketProvider is the API of a repository on Bitbucket Cloud or on a Bitbucket Server or Data Center, whose
+// repositories are addressed by the workspace or project key and the slug of the repository.
+type bitbucketProvider struct {
+	api     string
+	cloud   bool
+	project string
+	slug    string
+}
+
+// newBitbucketProvider returns the API of a repository, derived from the hosted repository of the remote, if there is
+// one: remotes on bitbucket.org are repositories of Bitbucket Cloud, remotes on other hosts are repositories of a
+// Bitbucket Server, whose https remotes are below '/scm/'. 'bitbucket.api-url' and 'bitbucket.repository' override the
+// derived API and repository, e.g. for remotes of a mirror. APIs ending with '/2.0' are APIs of Bitbucket Cloud.
+func newBitbucketProvider(hosted hostedRepository) *bitbucketProvider {
+	api, repository := defaultBitbucketCloudAPI, os.Getenv(bitbucketRepositoryEnv)
+	if len(hosted.host) > 0 && hosted.host != "bitbucket.org" {
+		context, serverPath, found := strings.Cut("/"+hosted.path, "/scm/")
+		if !found {
+			context, serverPath = "", hosted.path
+		}
+		api, repository = hosted.scheme+"://"+hosted.host+context, serverPath
+	} else if len(hosted.host) > 0 {
+		repository = hosted.path
+	}
+
+	if len(bitbucketSettings.apiURL) > 0 {
+		api = bitbucketSettings.apiURL
+	}
+	if len(bitbucketRepository) > 0 {
+		repository = bitbucketRepository
+	}
+
+	api = strings.TrimSuffix(api, "/")
+	project, slug, _ := strings.Cut(repository, "/")
+	return &bitbucketProvider{api: api, cloud: strings.HasSuffix(api, "/2.0"), project: project, slug: slug}
+}
+
+func (p *bitbucketProvider) name() string {
+	return "Bitbucket"
+}
+
+func (p *bitbucketProvider) group() string {
+	return bitbucketGroup
+}
+
+func (p *bitbucketProvider) settings() hostingSettings {
+	return bitbucketSettings
+}
+
+func (p *bitbucketProvider) term() string {
+	return Message(MsgPullRequestTerm)
+}
+
+func (p *bitbucketProvider) reference(number int) string {
+	return fmt.Sprintf("#%v", number)
+}
+
+func (p *bitbucketProvider) tokenSources() (bool, string) {
+	return len(bitbucketCredentials()) > 0, fmt.Sprintf("%v or '%v.%v'", bitbucketTokenEnv, bitbucketGroup, hostingTokenSetting)
+}
+
+// releases reports false, because Bitbucket has no releases of tags.
+func (p *bitbucketProvider) releases() bool {
+	return false
+}
+
+func (p *bitbucketProvider) draftReleases() bool {
+	return false
+}
+
+func (p *bitbucketProvider) openPullRequest(branchName, base, title, description string) (int, error) {
+	var pullRequest struct {
+		ID int `json:"id"`
+	}
+
+	if p.cloud {
+		err := p.call(http.MethodPost, p.repositoryAPI()+"/pullrequests", map[string]any{
+			"title":       title,
+			"description": description,
+			"source":      map[string]any{"branch": map[string]string{"name": branchName}},
+			"destination": map[string]any{"branch": map[string]string{"name": base}},
+		}, http.StatusCreated, &pullRequest)
+		return pullRequest.ID, err
+	}
+
+	repository := map[string]any{"slug": p.slug, "project": map[string]string{"key": p.project}}
+	err := p.call(http.MethodPost, p.repositoryAPI()+"/pull-requests", map[string]any{
+		"title":       title,
+		"description": description,
+		"fromRef":     map[string]any{"id": "refs/heads/" + branchName, "repository": repository},
+		"toRef":       map[string]any{"id": "refs/heads/" + base, "repository": repository},
+	}, http.StatusCreated, &pullRequest)
+	return pullRequest.ID, err
+}
+
+// pullRequest reads the state of a pull request. Bitbucket Server reports the merge commit since version 7, older
+// servers report none, so that the merged base branch on the remote is tagged.
+func (p *bitbucketProvider) pullRequest(number int) (pullRequestState, error) {
+	var pullRequest struct {
+		State       string `json:"state"`
+		MergeCommit struct {
+			Hash string `json:"hash"`
+		} `json:"merge_commit"`
+		Properties struct {
+			MergeCommit struct {
+				ID string `json:"id"`
+			} `json:"mergeCommit"`
+		} `json:"properties"`
+	}
+
+	resource := fmt.Sprintf("%v/pull-requests/%v", p.repositoryAPI(), number)
+	if p.cloud {
+		resource = fmt.Sprintf("%v/pullrequests/%v", p.repositoryAPI(), number)
+	}
+	if err := p.call(http.MethodGet, resource, nil, http.StatusOK, &pullRequest); err != nil {
+		return pullRequestState{}, err
+	}
+
+	mergeCommit := pullRequest.MergeCommit.Hash
+	if !p.cloud {
+		mergeCommit = pullRequest.Properties.MergeCommit.ID
+	}
+
+	return pullRequestState{
+		merged:      pullRequest.State == "MERGED",
+		closed:      pullRequest.State == "DECLINED" || pullRequest.State == "SUPERSEDED",
+		mergeCommit: mergeCommit,
+	}, nil
+}
+
+func (p *bitbucketProvider) createRelease(string, string, string, bool) (string, error) {
+	return "", Error(MsgPublishUnsupported, p.name())
+}
+
+// restrictedBranch returns the first branch whose branch restrictions reject direct pushes of all users: push
+// restrictions of Bitbucket Cloud and read-only or pull-request-only restrictions of Bitbucket Server, which match
+// the branch by its name, a pattern or its type in the branching model. Restrictions with exempted users, groups or
+// access keys are skipped, because the exemptions of the token cannot be read.
+func (p *bitbucketProvider) restrictedBranch(branchNames []string) (string, error) {
+	type restriction struct {
+		pattern   string
+		modelType string
+	}
+	restrictions := make([]restriction, 0)
+
+	if p.cloud {
+		var page struct {
+			Values []struct {
+				BranchMatchKind string `json:"branch_match_kind"`
+				BranchType      string `json:"branch_type"`
+				Pattern         string `json:"pattern"`
+				Users           []any  `json:"users"`
+				Groups          []any  `json:"groups"`
+			} `json:"values"`
+		}
+		if err := p.call(http.MethodGet, p.repositoryAPI()+"/branch-restrictions?kind=push&pagelen=100", nil, http.StatusOK, &page); err != nil {
+			return "", err
+		}
+		for _, value := range page.Values {
+			if len(value.Users) == 0 && len(value.Groups) == 0 {
+				if value.BranchMatchKind == "branching_model" {
+					restrictions = append(restrictions, restriction{modelType: value.BranchType})
+				} else {
+					restrictions = append(restrictions, restriction{pattern: value.Pattern})
+				}
+			}
+		}
+	} else {
+		var page struct {
+			Values []struct {
+				Type    string `json:"type"`
+				Matcher struct {
+					ID   string `json:"id"`
+					Type struct {
+						ID string `json:"id"`
+					} `json:"type"`
+				} `json:"matcher"`
+				Users      []any `json:"users"`
+				Groups     []any `json:"groups"`
+				AccessKeys []any `json:"accessKeys"`
+			} `json:"values"`
+		}
+		resource := fmt.Sprintf("%v/rest/branch-permissions/2.0/projects/%v/repos/%v/restrictions?limit=100", p.api, p.project, p.slug)
+		if err := p.call(http.MethodGet, resource, nil, http.StatusOK, &page); err != nil {
+			return "", err
+		}
+		for _, value := range page.Values {
+			if !slices.Contains(bitbucketServerPushRestrictions, value.Type) ||
+				len(value.Users) > 0 || len(value.Groups) > 0 || len(value.AccessKeys) > 0 {
+				continue
+			}
+			switch value.Matcher.Type.ID {
+			case "BRANCH", "PATTERN":
+				restrictions = append(restrictions, restriction{pattern: strings.TrimPrefix(value.Matcher.ID, "refs/heads/")})
+			case "MODEL_BRANCH":
+				restrictions = append(restrictions, restriction{modelType: value.Matcher.ID})
+			}
+		}
+	}
+
+	// the production and development branches of the branching model are the branches of the workflow
+	for _, branchName := range branchNames {
+		for _, restriction := range restrictions {
+			if matched, _ := path.Match(restriction.pattern, branchName); matched ||
+				restriction.modelType == "production" && branchName == Production.String() ||
+				restriction.modelType == "development" && branchName == Development.String() {
+				return branchName, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// repositoryAPI returns the URL of the repository in the API of Bitbucket Cloud or Bitbucket Server.
+func (p *bitbucketProvider) repositoryAPI() string {
+	if p.cloud {
+		return fmt.Sprintf("%v/repositories/%v/%v", p.api, p.project, p.slug)
+	}
+	return fmt.Sprintf("%v/rest/api/1.0/projects/%v/repos/%v", p.api, p.project, p.slug)
+}
+
+// call sends a request to the API and decodes the response, which must have the expected status.
+func (p *bitbucketProvider) call(method, address string, payload map[string]any, status int, result any) error {
+	var body []byte
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return err
+		}
+	}
+
+	request, err := http.NewRequest(method, address, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Authorization", bitbucketCredentials())
+	if payload != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := apiClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode != status {
+		return fmt.Errorf("status %v", response.Status)
+	}
+
+	return json.NewDecoder(response.Body).Decode(result)
+}
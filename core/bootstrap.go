@@ -0,0 +1,209 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// Characters which are replaced in the project name derived from the project path.
+var projectNameExpression = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// Bootstrap creates a new repository, or fills an existing empty one, from the version file template of a plugin:
+// the production branch gets the baseline version tagged as released, the development branch the next minor
+// development version, and both get the project-local configuration.
+func Bootstrap(templateName, baseline, projectPath string) error {
+	pluginRegistryLock.Lock()
+	defer pluginRegistryLock.Unlock()
+
+	// apply suitable settings from the global configuration to the core package
+	if err := applySettings(); err != nil {
+		return err
+	}
+
+	// set path to execute workflow commands
+	ProjectPath = projectPath
+
+	// a dry run cannot read the versions of a repository which does not exist yet
+	if dryRun {
+		return Error(MsgDryRunUnsupported, "bootstrap")
+	}
+
+	// the baseline must be a plain release version
+	release, err := ParseVersion(baseline)
+	if err != nil {
+		return err
+	}
+
+	// the template of a new project is the version file of a registered plugin
+	plugin, err := templatePlugin(templateName)
+	if err != nil {
+		return err
+	}
+
+	// create the project path of a new project
+	if err := os.MkdirAll(projectPath, 0755); err != nil {
+		return err
+	}
+
+	repository := NewRepository(projectPath, Remote)
+
+	// create the repository with the production branch as its first branch
+	if err := repository.Initialize(Production.String()); err != nil {
+		return err
+	}
+
+	// existing projects are prepared with the init command instead
+	if found, err := repository.HasCommits(); err != nil {
+		return err
+	} else if found {
+		return Error(MsgRepositoryNotEmpty, projectPath)
+	}
+
+	// check if the initial commits get the same identity as manual commits in the project path
+	if err := checkIdentity(repository); err != nil {
+		return err
+	}
+
+	// format bootstrap command messages
+	called := Message(MsgBootstrapCalled, plugin, repository.Local())
+	completed := Message(MsgBootstrapCompleted, plugin, repository.Local())
+	failed := Message(MsgBootstrapFailed, plugin, repository.Local())
+
+	Progress(called)
+
+	if err := bootstrapRepository(plugin, repository, release.RemoveQualifier()); err != nil {
+		Failure(failed)
+		return err
+	}
+
+	Success(completed)
+	return nil
+}
+
+func bootstrapRepository(plugin Plugin, repository Repository, release Version) error {
+	// plugins with multiple version files bootstrap the first one
+	if fileNames := plugin.VersionFileNames(); len(fileNames) > 0 {
+		plugin.SetVersionFileName(fileNames[0])
+	}
+
+	name := projectName(repository.Local())
+
+	// write the version file with the baseline version and the project-local configuration
+	if err := writeVersionFileTemplate(plugin, repository, name, release); err != nil {
+		return err
+	}
+
+	content := fmt.Sprintf(projectConfigTemplate, Production, Development, Release, Hotfix, Bugfix, Support)
+	if err := repository.WriteFile(ProjectConfigFileName, content); err != nil {
+		return err
+	}
+
+	for _, file := range []string{plugin.VersionFileName(), ProjectConfigFileName} {
+		if err := repository.AddFile(file); err != nil {
+			return err
+		}
+	}
+
+	// commit the baseline on the production branch and tag it as released
+	if err := repository.CommitChanges(fmt.Sprintf("Bootstrap project with version %v.", release)); err != nil {
+		return err
+	}
+
+	if err := repository.TagCommit(versionTag(release.String())); err != nil {
+		return err
+	}
+
+	// create the development branch from the production branch, unless it is the mainline itself
+	if !mainline() {
+		if err := repository.CreateBranch(Development.String()); err != nil {
+			return err
+		}
+	}
+
+	// calculate the next minor version
+	next, err := Scheme().Next(release, Minor)
+	if err != nil {
+		return err
+	}
+
+	// set project version to the next develop version ${major}.(${minor}+1).0-${qualifier}
+	if err := writeVersionFileTemplate(plugin, repository, name, next.AddQualifier(Qualifier(plugin, Development))); err != nil {
+		return err
+	}
+
+	// perform a git commit with a commit message
+	if err := repository.CommitChanges(nextVersionSubject); err != nil {
+		return err
+	}
+
+	// a new repository has no remote to push to yet
+	if _, err := repository.RemoteURL(); err != nil {
+		fmt.Fprintln(os.Stderr, Message(MsgBootstrapNoRemote, Remote))
+		return nil
+	}
+
+	// push all branches and tags to remotes
+	if err := pushIfEnabled(repository.PushAllChanges); err != nil {
+		return err
+	}
+
+	if err := pushIfEnabled(repository.PushAllTags); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// templatePlugin returns the registered plugin with the template name which can bootstrap new projects.
+func templatePlugin(templateName string) (Plugin, error) {
+	available := make([]string, 0, len(pluginRegistry))
+	for _, plugin := range pluginRegistry {
+		if len(plugin.VersionFileTemplate()) == 0 {
+			continue
+		}
+		if plugin.String() == templateName {
+			return plugin, nil
+		}
+		available = append(available, plugin.String())
+	}
+
+	return nil, Error(MsgTemplateUnknown, templateName, strings.Join(available, ", "))
+}
+
+// writeVersionFileTemplate renders the version file template of the plugin with the project name and version.
+func writeVersionFileTemplate(plugin Plugin, repository Repository, name string, version Version) error {
+	parsed, err := template.New(plugin.VersionFileName()).Parse(plugin.VersionFileTemplate())
+	if err != nil {
+		return err
+	}
+
+	var content strings.Builder
+	if err := parsed.Execute(&content, struct{ Name, Version string }{name, version.String()}); err != nil {
+		return err
+	}
+
+	return repository.WriteFile(plugin.VersionFileName(), content.String())
+}
+
+// projectName derives a package-safe project name from the directory of the project path, e.g. 'my-service'.
+func projectName(projectPath string) string {
+	if absolute, err := filepath.Abs(projectPath); err == nil {
+		projectPath = absolute
+	}
+
+	name := strings.Trim(projectNameExpression.ReplaceAllString(strings.ToLower(filepath.Base(projectPath)), "-"), "-.")
+	if len(name) == 0 {
+		return "project"
+	}
+
+	return name
+}
@@ -0,0 +1,216 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"os"
+	"strings"
+)
+
+// StartBugfix creates a bugfix branch named '<prefix>/<name>' from the development branch.
+func StartBugfix(name, projectPath string) error {
+	// apply suitable settings from the global configuration to the core package
+	if err := applySettings(); err != nil {
+		return err
+	}
+
+	// set path to execute workflow commands
+	ProjectPath = projectPath
+
+	// check if project path exists
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return Error(MsgProjectPathMissing, projectPath)
+	}
+
+	// the name becomes part of the branch name and must not be empty or contain whitespace
+	if len(name) == 0 || strings.ContainsAny(name, " \t\r\n") {
+		return Error(MsgBugfixNameInvalid, name)
+	}
+
+	repository := openRepository(projectPath)
+
+	// check if the repository prerequisites are met
+	if err := repository.IsClean(); err != nil {
+		return err
+	}
+
+	// check if the current checkout is a safe starting point
+	if err := checkStartingPoint(repository, Bugfix, Development.String()); err != nil {
+		return err
+	}
+
+	// ensure production branch exists, and the development branch as base of the bugfix branch
+	if err := syncBranches(repository, Production, Development); err != nil {
+		return err
+	}
+
+	branchName := Bugfix.String() + "/" + name
+
+	// format bugfix start command messages
+	called := Message(MsgBugfixStartCalled, branchName, repository.Local())
+	completed := Message(MsgBugfixStartCompleted, branchName, repository.Local())
+	failed := Message(MsgBugfixStartFailed, branchName, repository.Local())
+
+	Progress(called)
+
+	if err := bugfixStart(repository, branchName); err != nil {
+		Failure(failed)
+		return err
+	}
+
+	Success(completed)
+	return nil
+}
+
+// FinishBugfix merges a bugfix branch back into the development branch. Without a name,
+// the repository must have exactly one bugfix branch.
+func FinishBugfix(name, projectPath string) error {
+	// apply suitable settings from the global configuration to the core package
+	if err := applySettings(); err != nil {
+		return err
+	}
+
+	// set path to execute workflow commands
+	ProjectPath = projectPath
+
+	// check if project path exists
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return Error(MsgProjectPathMissing, projectPath)
+	}
+
+	repository := openRepository(projectPath)
+
+	// check if the repository prerequisites are met
+	if err := repository.IsClean(); err != nil {
+		return err
+	}
+
+	// check if the merge commit gets the same identity as manual commits in the project path
+	if err := checkIdentity(repository); err != nil {
+		return err
+	}
+
+	// ensure production branch exists, and the development branch as target of the bugfix branch
+	if err := syncBranches(repository, Production, Development); err != nil {
+		return err
+	}
+
+	// select the bugfix branch to finish
+	bugfixBranch, err := selectBugfixBranch(repository, name)
+	if err != nil {
+		return err
+	}
+
+	// format bugfix finish command messages
+	called := Message(MsgBugfixFinishCalled, bugfixBranch.Name, repository.Local())
+	completed := Message(MsgBugfixFinishCompleted, bugfixBranch.Name, repository.Local())
+	failed := Message(MsgBugfixFinishFailed, bugfixBranch.Name, repository.Local())
+
+	Progress(called)
+
+	if err := bugfixFinish(repository, bugfixBranch); err != nil {
+		Failure(failed)
+		return err
+	}
+
+	Success(completed)
+	return nil
+}
+
+// Select the named bugfix branch or, without a name, the only bugfix branch of the repository.
+func selectBugfixBranch(repository Repository, name string) (BranchInfo, error) {
+	found, branches, err := repository.HasBranch(Bugfix)
+	if err != nil {
+		return BranchInfo{}, err
+	}
+
+	if len(name) > 0 {
+		branchName := Bugfix.String() + "/" + name
+		for _, branch := range branches {
+			if branch.Name == branchName {
+				return branch, nil
+			}
+		}
+		return BranchInfo{}, Error(MsgBugfixNotFound, branchName)
+	}
+
+	if !found {
+		return BranchInfo{}, Error(MsgNoBranchToFinish, Bugfix)
+	}
+
+	if len(branches) > 1 {
+		names := make([]string, 0, len(branches))
+		for _, branch := range branches {
+			names = append(names, branch.Name)
+		}
+		return BranchInfo{}, Error(MsgBugfixAmbiguous, strings.Join(names, ", "))
+	}
+
+	return branches[0], nil
+}
+
+func bugfixStart(repository Repository, branchName string) error {
+	// check if the repository already has a bugfix branch with this name
+	if found, err := repository.HasRemoteBranch(branchName); err != nil {
+		return err
+	} else if found {
+		return Error(MsgBugfixAlreadyExists, branchName)
+	}
+
+	// checkout develop branch
+	if err := checkoutBranch(repository, Development.String()); err != nil {
+		return err
+	}
+
+	// create bugfix branch
+	if err := repository.CreateBranch(branchName); err != nil {
+		return repository.Rollback(err)
+	}
+
+	// push the bugfix branch to remotes
+	if err := pushIfEnabled(func() error { return repository.PushChanges(branchName) }); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func bugfixFinish(repository Repository, bugfixBranch BranchInfo) error {
+	// checkout bugfix branch
+	if err := checkoutBranch(repository, bugfixBranch.Name); err != nil {
+		return err
+	}
+
+	// checkout develop branch
+	if err := checkoutBranch(repository, Development.String()); err != nil {
+		return err
+	}
+
+	// merge bugfix branch into current develop branch (with merge commit --no-ff git flag)
+	if err := repository.MergeBranch(bugfixBranch.Name, NoFastForward); err != nil {
+		return repository.Rollback(err)
+	}
+
+	// delete the bugfix branch locally
+	if err := repository.DeleteBranch(bugfixBranch.Name); err != nil {
+		return repository.Rollback(err)
+	}
+
+	// fast-forward lagging local branches, which would otherwise be rejected when pushing all branches
+	fastForwardBranches(repository)
+
+	// push all branches to remotes
+	if err := pushIfEnabled(repository.PushAllChanges); err != nil {
+		return err
+	}
+
+	// delete the bugfix branch remotely
+	if err := pushBranchDeletion(repository, bugfixBranch.Name); err != nil {
+		return err
+	}
+
+	return nil
+}
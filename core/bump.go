@@ -0,0 +1,129 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Workflow setting of the delivery of the next development version after a release finish: pushed to the
+// development branch, or as pull request for protected development branches.
+const developmentBumpSetting = "development-bump"
+
+// Deliveries of the next development version.
+const (
+	bumpPush        = "push"
+	bumpPullRequest = "pull-request"
+)
+
+// Prefix of the branch of the pull request with the next development version, e.g. 'chore/bump-1.3.0-dev'.
+const bumpBranchPrefix = "chore/bump-"
+
+// Delivery of the next development version of the current run.
+var developmentBump = bumpPush
+
+func applyDevelopmentBumpSetting(settings map[string]any) {
+	if v, ok := settings[developmentBumpSetting].(string); ok && (v == bumpPush || v == bumpPullRequest) {
+		developmentBump = v
+	}
+}
+
+func resetDevelopmentBumpSetting() {
+	developmentBump = bumpPush
+}
+
+// bumpBranchName returns the branch of the pull request with the next development version.
+func bumpBranchName(next string) string {
+	return bumpBranchPrefix + next
+}
+
+// branchNextVersion moves the merge of the release and the commit of the next version from the development branch
+// to the branch of the pull request, and resets the development branch to its remote branch, so that pushing all
+// branches does not push to the protected development branch.
+func branchNextVersion(repository Repository, next string) error {
+	if err := repository.CreateBranch(bumpBranchName(next)); err != nil {
+		return err
+	}
+
+	if err := repository.ResetBranch(Development.String(), Remote+"/"+Development.String()); err != nil {
+		return err
+	}
+
+	return repository.CheckoutBranch(Development.String())
+}
+
+// openBumpPullRequest opens the pull request of the next development version into the development branch via the
+// API of the hosting service of the remote. Without token, the pushed branch is reported, so that the pull request
+// can be opened by hand.
+func openBumpPullRequest(repository Repository, next string) error {
+	provider := hostingProviderOf(repository)
+	branchName := bumpBranchName(next)
+
+	if skipDryRun(repository, fmt.Sprintf("%v of '%v' into '%v'", provider.term(), branchName, Development)) {
+		return nil
+	}
+
+	if available, _ := provider.tokenSources(); !available {
+		fmt.Fprintln(os.Stderr, Message(MsgBumpOpenManually, provider.term(), branchName, Development))
+		return nil
+	}
+
+	body := fmt.Sprintf("Next development version after the release, merge to update '%v'.", Development)
+	number, err := provider.openPullRequest(branchName, Development.String(), nextVersionSubject, body)
+	if err != nil {
+		return Error(MsgBumpPullRequestFailed, provider.term(), branchName, Development, err)
+	}
+
+	fmt.Fprintln(os.Stderr, Message(MsgBumpPullRequest, provider.term(), provider.reference(number), branchName, Development))
+	return nil
+}
+
+// createGitHubPullRequest opens a pull request of a branch into a base branch and returns its number.
+func createGitHubPullRequest(api, name, token, branchName, base, title, description string) (int, error) {
+	body, err := json.Marshal(map[string]string{
+		"title": title,
+		"head":  branchName,
+		"base":  base,
+		"body":  description,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	url := fmt.Sprintf("%v/repos/%v/pulls", strings.TrimSuffix(api, "/"), name)
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	request.Header.Set("Accept", "application/vnd.github+json")
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+token)
+
+	response, err := apiClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode != http.StatusCreated {
+		return 0, fmt.Errorf("status %v", response.Status)
+	}
+
+	var pullRequest struct {
+		Number int `json:"number"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&pullRequest); err != nil {
+		return 0, err
+	}
+
+	return pullRequest.Number, nil
+}
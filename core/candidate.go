@@ -0,0 +1,203 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Qualifier of release candidates, unless a release qualifier is configured under '<plugin>.qualifiers', whose name
+// is used instead, e.g. 'beta' for 'beta.1'.
+const candidateQualifier = "rc"
+
+// ReleaseCandidate tags the next release candidate on the open release branch, e.g. '1.2.0-rc.1' and then
+// '1.2.0-rc.2', so that QA builds can be produced before the release is finished. With write, the version file of
+// the release branch is set to the candidate version first, which is committed and tagged; otherwise the head of
+// the release branch is tagged without changing it.
+func ReleaseCandidate(write bool, projectPath string) error {
+	pluginRegistryLock.Lock()
+	defer pluginRegistryLock.Unlock()
+
+	// apply suitable settings from the global configuration to the core package
+	if err := applySettings(); err != nil {
+		return err
+	}
+
+	// set path to execute plugin detection and workflow commands
+	ProjectPath = projectPath
+
+	// check if project path exists
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return Error(MsgProjectPathMissing, projectPath)
+	}
+
+	// get access to the local version control system and the plugin of the project
+	repository := openRepository(projectPath)
+	plugin := openPlugin(projectPlugin(), repository)
+
+	// format release candidate command messages
+	called := Message(MsgCandidateCalled, Release, repository.Local())
+	completed := Message(MsgCandidateCompleted, Release, repository.Local())
+	failed := Message(MsgCandidateFailed, Release, repository.Local())
+
+	Progress(called)
+
+	if err := releaseCandidate(plugin, repository, write); err != nil {
+		Failure(failed)
+		return err
+	}
+
+	Success(completed)
+	return nil
+}
+
+func releaseCandidate(plugin Plugin, repository Repository, write bool) error {
+	var releaseBranch BranchInfo
+
+	// only writing the version needs the tools of the plugin and a clean working tree
+	if write {
+		if err := ValidateToolsAvailability(plugin.RequiredTools()...); err != nil {
+			return err
+		}
+		if err := repository.IsClean(); err != nil {
+			return err
+		}
+	}
+
+	// check if the repository has a suitable release branch on the remote
+	if err := fetchRemotes(repository); err != nil {
+		return err
+	}
+	if found, branches, err := repository.HasBranch(Release); err != nil {
+		return err
+	} else if !found {
+		return Error(MsgCandidateNoBranch, Release)
+	} else if len(branches) > 1 {
+		return Error(MsgMultipleBranches, Release)
+	} else {
+		releaseBranch = branches[0]
+	}
+
+	release := releaseBranch.Version.RemoveQualifier()
+	candidate, err := nextCandidate(plugin, repository, release)
+	if err != nil {
+		return err
+	}
+	tagName := versionTag(candidate.String())
+
+	steps := []workflowStep{
+		{
+			name:        "tag-candidate",
+			description: "tag the head of the release branch with the candidate version",
+			execute: func() error {
+				return repository.TagRef(tagName, releaseBranch.Ref())
+			},
+			undo: repository.Rollback,
+		},
+	}
+
+	if write {
+		steps = []workflowStep{
+			{
+				name:        "checkout-release",
+				description: "checkout the release branch",
+				execute: func() error {
+					return checkoutBranch(repository, releaseBranch.Name)
+				},
+			},
+			{
+				name:        "update-version",
+				description: "set the version of the release branch to the candidate version",
+				execute: func() error {
+					return plugin.WriteVersion(repository, candidate)
+				},
+				undo: repository.Rollback,
+			},
+			{
+				name:        "commit-version",
+				description: "commit the candidate version",
+				execute: func() error {
+					return repository.CommitChanges(fmt.Sprintf("Set release candidate version %v.", candidate))
+				},
+				undo: repository.Rollback,
+			},
+			{
+				name:        "tag-candidate",
+				description: "tag the commit with the candidate version",
+				execute: func() error {
+					return repository.TagCommit(tagName)
+				},
+				undo: repository.Rollback,
+			},
+			{
+				name:        "push-branch",
+				description: "push the release branch to the remote",
+				execute: func() error {
+					return pushIfEnabled(func() error { return repository.PushChanges(releaseBranch.Name) })
+				},
+			},
+		}
+	}
+
+	steps = append(steps, workflowStep{
+		name:        "push-tag",
+		description: "push the candidate tag to the remote",
+		execute: func() error {
+			return pushIfEnabled(func() error { return repository.PushTag(tagName) })
+		},
+	})
+
+	if err := executeSteps(plugin, repository, "release-candidate", steps, nil); err != nil {
+		return err
+	}
+
+	return setOutputs(releaseBranch.Name, candidate, tagName)
+}
+
+// nextCandidate returns the next release candidate of a release, which numbers the candidates tagged so far.
+func nextCandidate(plugin Plugin, repository Repository, release Version) (Version, error) {
+	numbers, err := candidateNumbers(plugin, repository, release)
+	if err != nil {
+		return NoVersion, err
+	}
+
+	last := 0
+	for _, number := range numbers {
+		last = max(last, number)
+	}
+	return release.AddQualifier(fmt.Sprintf("%v.%v", candidateName(plugin), last+1)), nil
+}
+
+// candidateNumbers returns the numbers of the release candidates of a release tagged locally or on the remote.
+func candidateNumbers(plugin Plugin, repository Repository, release Version) ([]int, error) {
+	tagNames, err := knownTags(repository)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := versionTag(release.AddQualifier(candidateName(plugin)).String()) + "."
+	numbers := make([]int, 0)
+	for _, tagName := range tagNames {
+		if suffix, found := strings.CutPrefix(tagName, prefix); found {
+			if number, err := strconv.Atoi(suffix); err == nil && number > 0 {
+				numbers = append(numbers, number)
+			}
+		}
+	}
+	return numbers, nil
+}
+
+// candidateName returns the qualifier name of release candidates, which is the name of the configured release
+// qualifier, e.g. 'rc' for 'rc.1', or 'rc' if none is configured.
+func candidateName(plugin Plugin) string {
+	if name := qualifierName(Qualifier(plugin, Release)); len(name) > 0 {
+		return name
+	}
+	return candidateQualifier
+}
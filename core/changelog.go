@@ -0,0 +1,346 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Changelog settings keys.
+const (
+	changelogGroup          = "changelog"
+	changelogEnabledSetting = "enabled"
+	changelogFormatSetting  = "format"
+	changelogFileSetting    = "file"
+	changelogHeadingSetting = "heading"
+)
+
+// Formats of the changelog: Keep a Changelog (https://keepachangelog.com) and the conventional-changelog format of
+// the Angular preset, which lists breaking changes, features, fixes and performance improvements only.
+const (
+	keepAChangelogFormat = "keep-a-changelog"
+	conventionalFormat   = "conventional"
+)
+
+// Headings of the sections of releases in the formats, unless configured under 'changelog.heading'.
+var changelogHeadings = map[string]string{
+	keepAChangelogFormat: "[{version}] - {date}",
+	conventionalFormat:   "{version} ({date})",
+}
+
+// Placeholder in the heading of a section which is replaced by the release date.
+const datePlaceholder = "{date}"
+
+// Default file of the changelog.
+const defaultChangelogFile = "CHANGELOG.md"
+
+// Heading of the section of unreleased changes in Keep a Changelog, which stays on top of the released versions.
+const unreleasedHeading = "## [Unreleased]"
+
+// Types of conventional commits which are no notable changes, e.g. 'chore: update modules'.
+var unnotableTypes = []string{"build", "chore", "ci", "docs", "style", "test"}
+
+// Parts of the subjects of the commits created by the workflow commands, which are no notable changes either.
+var workflowSubjects = []string{
+	"project version.",
+	hotfixStartMessage,
+	"Remove qualifier of the released version.",
+	"Add SBOM for release ",
+	"Update changelog for release ",
+}
+
+// Update of the changelog on release finish, its format and file.
+var changelogEnabled = false
+var changelogFormat = keepAChangelogFormat
+var changelogFile = defaultChangelogFile
+var changelogHeading = ""
+
+func applyChangelogSettings(settings map[string]any) {
+	if v, ok := settings[changelogEnabledSetting].(bool); ok {
+		changelogEnabled = v
+	}
+	if v, ok := settings[changelogFormatSetting].(string); ok && len(v) > 0 {
+		changelogFormat = v
+	}
+	if v, ok := settings[changelogFileSetting].(string); ok && len(v) > 0 {
+		changelogFile = v
+	}
+	if v, ok := settings[changelogHeadingSetting].(string); ok {
+		changelogHeading = strings.TrimSpace(v)
+	}
+}
+
+func resetChangelogSettings() {
+	changelogEnabled = false
+	changelogFormat = keepAChangelogFormat
+	changelogFile = defaultChangelogFile
+	changelogHeading = ""
+}
+
+// validateChangelogSettings ensures that the heading template holds the version, by which a continued or repeated
+// finish recognizes the section of the release.
+func validateChangelogSettings() error {
+	if len(changelogHeading) > 0 && !strings.Contains(changelogHeading, versionPlaceholder) {
+		return Error(MsgTemplatePlaceholder, changelogGroup+"."+changelogHeadingSetting, changelogHeading, versionPlaceholder)
+	}
+	return nil
+}
+
+// changelogEntry is a notable change of a release, e.g. a feature with the scope 'api'.
+type changelogEntry struct {
+	Scope       string
+	Description string
+}
+
+// String renders the entry as item of a Markdown list, with the scope in bold.
+func (e changelogEntry) String() string {
+	if len(e.Scope) > 0 {
+		return fmt.Sprintf("**%v:** %v", e.Scope, e.Description)
+	}
+	return e.Description
+}
+
+// changelogRelease holds the notable changes of a released version grouped by their kind. Commits without
+// conventional header are other changes, so that projects without conventional commits get a changelog as well.
+type changelogRelease struct {
+	Version     string
+	Date        time.Time
+	Breaking    []changelogEntry
+	Features    []changelogEntry
+	Fixes       []changelogEntry
+	Performance []changelogEntry
+	Other       []changelogEntry
+}
+
+// collectChangelog groups the notable changes of the commits of a release.
+func collectChangelog(version Version, date time.Time, commits []Commit) changelogRelease {
+	release := changelogRelease{Version: version.String(), Date: date}
+
+	// the commit log lists the newest commit first, the changelog lists changes in chronological order
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		if slices.ContainsFunc(workflowSubjects, func(part string) bool { return strings.Contains(commit.Subject, part) }) {
+			continue
+		}
+
+		parsed, ok := parseConventionalCommit(commit)
+		if !ok {
+			release.Other = append(release.Other, changelogEntry{Description: strings.TrimSpace(commit.Subject)})
+			continue
+		}
+
+		entry := changelogEntry{Scope: parsed.Scope, Description: parsed.Description}
+		switch {
+		case parsed.Breaking:
+			release.Breaking = append(release.Breaking, entry)
+		case parsed.Type == "feat":
+			release.Features = append(release.Features, entry)
+		case parsed.Type == "fix":
+			release.Fixes = append(release.Fixes, entry)
+		case parsed.Type == "perf":
+			release.Performance = append(release.Performance, entry)
+		case !slices.Contains(unnotableTypes, parsed.Type):
+			release.Other = append(release.Other, entry)
+		}
+	}
+
+	return release
+}
+
+// changelogPreamble returns the beginning of a new changelog in a format.
+func changelogPreamble(format string) string {
+	if format == conventionalFormat {
+		return "# Changelog\n"
+	}
+	return `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+`
+}
+
+// renderChangelogSection renders the section of a release in a format, below the heading of the format or the heading
+// template under 'changelog.heading', with the version, the date and the variables of the plugin substituted.
+func renderChangelogSection(release changelogRelease, format string) (string, error) {
+	var builder strings.Builder
+
+	heading := changelogHeading
+	if len(heading) == 0 {
+		heading = changelogHeadings[format]
+	}
+	heading = expandTemplate(heading, versionPlaceholder, release.Version, datePlaceholder, release.Date.Format(time.DateOnly))
+
+	writeGroup := func(heading, bullet string, entries ...[]changelogEntry) {
+		items := slices.Concat(entries...)
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&builder, "\n### %v\n\n", heading)
+		for _, item := range items {
+			fmt.Fprintf(&builder, "%v %v\n", bullet, item)
+		}
+	}
+
+	switch format {
+	case keepAChangelogFormat:
+		fmt.Fprintf(&builder, "## %v\n", heading)
+		writeGroup("Added", "-", release.Features)
+		writeGroup("Changed", "-", breakingEntries(release.Breaking), release.Performance, release.Other)
+		writeGroup("Fixed", "-", release.Fixes)
+
+	case conventionalFormat:
+		fmt.Fprintf(&builder, "## %v\n", heading)
+		writeGroup("⚠ BREAKING CHANGES", "*", release.Breaking)
+		writeGroup("Features", "*", release.Features)
+		writeGroup("Bug Fixes", "*", release.Fixes)
+		writeGroup("Performance Improvements", "*", release.Performance)
+
+	default:
+		return "", fmt.Errorf("unsupported changelog format: %v (%v, %v)", format, keepAChangelogFormat, conventionalFormat)
+	}
+
+	return builder.String(), nil
+}
+
+// breakingEntries marks breaking changes, which Keep a Changelog lists with the other changes.
+func breakingEntries(entries []changelogEntry) []changelogEntry {
+	marked := make([]changelogEntry, 0, len(entries))
+	for _, entry := range entries {
+		entry.Description = "**BREAKING:** " + entry.Description
+		marked = append(marked, entry)
+	}
+	return marked
+}
+
+// changelogHasVersion reports whether a changelog already has the section of a version, e.g. '## [1.2.0] - ...',
+// '## 1.2.0 (...)' or '## app 1.2.0' of a heading template.
+func changelogHasVersion(content, version string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		heading, found := strings.CutPrefix(line, "## ")
+		if !found {
+			continue
+		}
+		if fields := strings.Fields(strings.NewReplacer("[", " ", "]", " ").Replace(heading)); slices.Contains(fields, version) {
+			return true
+		}
+	}
+	return false
+}
+
+// insertChangelogSection inserts the section of a release into a changelog above the sections of the previous
+// releases and below the section of unreleased changes, or appends it to a changelog without sections.
+func insertChangelogSection(content, section string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "## ") || strings.HasPrefix(line, unreleasedHeading) {
+			continue
+		}
+		return strings.Join(lines[:i], "\n") + "\n" + section + "\n" + strings.Join(lines[i:], "\n")
+	}
+	return strings.TrimRight(content, "\n") + "\n\n" + section
+}
+
+// updateChangelog adds the section of a release to the changelog of the release branch and commits it, so that the
+// changelog is part of the released version. The changes are the commits of the release branch which are not on the
+// production branch. The hook of the plugin may customize the changelog before it is committed.
+func updateChangelog(plugin Plugin, repository Repository, branchName string, version Version) error {
+	content := changelogPreamble(changelogFormat)
+	if existing, err := repository.ShowFile(branchName, changelogFile); err == nil {
+		content = string(existing)
+	}
+
+	// a continued or repeated finish does not add the section twice
+	if changelogHasVersion(content, version.String()) {
+		return nil
+	}
+
+	commits, err := repository.ProjectCommits(Production.String() + ".." + branchName)
+	if err != nil {
+		return err
+	}
+
+	section, err := renderChangelogSection(collectChangelog(version, time.Now(), commits), changelogFormat)
+	if err != nil {
+		return err
+	}
+
+	if err := repository.WriteFile(changelogFile, insertChangelogSection(content, section)); err != nil {
+		return err
+	}
+
+	if err := GlobalHooks.ExecuteHook(plugin, ReleaseFinishHooks.AfterUpdateChangelogHook, repository); err != nil {
+		return err
+	}
+
+	if err := repository.AddFile(changelogFile); err != nil {
+		return err
+	}
+
+	return repository.CommitChanges(fmt.Sprintf("Update changelog for release %v.", version))
+}
+
+// Changelog writes the changelog of all released versions, newest first, with the commits since their predecessor.
+// The file and the format default to the settings under 'changelog', the file '-' prints the changelog instead.
+func Changelog(projectPath, fileName, format string) error {
+	// apply suitable settings from the global configuration to the core package
+	if err := applySettings(); err != nil {
+		return err
+	}
+
+	// set path to execute workflow commands
+	ProjectPath = projectPath
+
+	// check if project path exists
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return Error(MsgProjectPathMissing, projectPath)
+	}
+
+	if len(fileName) == 0 {
+		fileName = changelogFile
+	}
+	if len(format) == 0 {
+		format = changelogFormat
+	}
+
+	repository := NewRepository(projectPath, Remote)
+
+	releases, err := summarizeReleases(repository, 0)
+	if err != nil {
+		return err
+	}
+
+	if len(releases) == 0 {
+		return Error(MsgChangelogNoReleases, projectPath)
+	}
+
+	content := changelogPreamble(format)
+	for _, summary := range releases {
+		version, _ := ParseVersion(summary.Version)
+		section, err := renderChangelogSection(collectChangelog(version, summary.Date, summary.Commits), format)
+		if err != nil {
+			return err
+		}
+		content += "\n" + section
+	}
+
+	if fileName == "-" {
+		fmt.Print(content)
+		return nil
+	}
+
+	if err := repository.WriteFile(fileName, content); err != nil {
+		return err
+	}
+
+	Success(Message(MsgChangelogWritten, len(releases), fileName))
+	return nil
+}
@@ -0,0 +1,226 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Environment variables of CI systems naming the source branch of a pull or merge request, whose checkout is
+// usually a detached HEAD.
+var pullRequestBranchEnvs = []string{"GITHUB_HEAD_REF", "CI_MERGE_REQUEST_SOURCE_BRANCH_NAME"}
+
+// Violation of a workflow rule, optionally located in a file of the branch.
+type violation struct {
+	file, text string
+}
+
+// Check validates that a branch conforms to the naming and version rules of the workflow, e.g. that a release
+// branch is named after a version greater than the latest tag and its version file has no qualifier. Without
+// branch name, the checked out branch is validated. The repository is only read, so that the check can run in
+// pipelines without write access. Each violation is reported as an annotation and fails the check.
+func Check(branchName, projectPath string) error {
+	pluginRegistryLock.Lock()
+	defer pluginRegistryLock.Unlock()
+
+	// apply suitable settings from the global configuration to the core package
+	if err := applySettings(); err != nil {
+		return err
+	}
+
+	// set path to execute plugin detection and workflow commands
+	ProjectPath = projectPath
+
+	// check if project path exists
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return Error(MsgProjectPathMissing, projectPath)
+	}
+
+	// the plugin of the project reads the version file
+	plugin := projectPlugin()
+	repository := NewRepository(projectPath, Remote)
+
+	branchName, ref, err := checkedBranch(repository, branchName)
+	if err != nil {
+		return err
+	}
+
+	// format check command messages
+	called := Message(MsgCheckCalled, branchName, repository.Local())
+	completed := Message(MsgCheckCompleted, branchName, repository.Local())
+	failed := Message(MsgCheckFailed, branchName, repository.Local())
+
+	Progress(called)
+
+	violations, err := checkBranch(plugin, repository, branchName, ref)
+	if err != nil {
+		Failure(failed)
+		return err
+	}
+
+	for _, v := range violations {
+		annotate(v)
+	}
+	recordViolations(violations)
+
+	if len(violations) > 0 {
+		Failure(failed)
+		return Error(MsgCheckViolations, len(violations), branchName)
+	}
+
+	Success(completed)
+	return nil
+}
+
+// checkedBranch returns the name of the branch to check and the reference of its content: the local branch, the
+// remote branch if it only exists on the remote, or the checkout if no branch name is given.
+func checkedBranch(repository Repository, branchName string) (string, string, error) {
+	if len(branchName) > 0 {
+		if _, err := repository.ResolveRef("refs/heads/" + branchName); err == nil {
+			return branchName, branchName, nil
+		}
+		return branchName, Remote + "/" + branchName, nil
+	}
+
+	current, err := repository.CurrentBranch()
+	if err != nil {
+		return "", "", err
+	}
+
+	// pipelines of pull requests check out a detached HEAD, but name the source branch in the environment
+	for _, env := range pullRequestBranchEnvs {
+		if len(current) == 0 {
+			current = os.Getenv(env)
+		}
+	}
+
+	if len(current) == 0 {
+		return "", "", Error(MsgCheckNoBranch)
+	}
+
+	return current, head, nil
+}
+
+// checkBranch returns the violations of the workflow rules for the type of the branch.
+func checkBranch(plugin Plugin, repository Repository, branchName, ref string) ([]violation, error) {
+	tags, err := knownTags(repository)
+	if err != nil {
+		return nil, err
+	}
+
+	released, latest := releasedVersions(tags)
+
+	switch {
+	case branchName == Development.String():
+		return checkDevelopment(plugin, repository, ref, latest)
+
+	case strings.HasPrefix(branchName, Release.String()+"/"):
+		return checkVersionedBranch(plugin, repository, Release, branchName, ref, func(version Version) *violation {
+			// a release must be newer than every released version
+			if latest != NoVersion && !latest.Less(version) {
+				return &violation{text: Message(MsgCheckVersionNotNewer, version, branchName, latest)}
+			}
+			return nil
+		})
+
+	case strings.HasPrefix(branchName, Hotfix.String()+"/"):
+		return checkVersionedBranch(plugin, repository, Hotfix, branchName, ref, func(version Version) *violation {
+			// a hotfix must not have been released already
+			if released[version] {
+				return &violation{text: Message(MsgAlreadyReleased, version)}
+			}
+			return nil
+		})
+
+	case strings.HasPrefix(branchName, Bugfix.String()+"/"):
+		if name := strings.TrimPrefix(branchName, Bugfix.String()+"/"); len(name) == 0 {
+			return []violation{{text: Message(MsgBugfixNameInvalid, name)}}, nil
+		}
+		return nil, nil
+
+	case strings.HasPrefix(branchName, Support.String()+"/"):
+		if line := strings.TrimPrefix(branchName, Support.String()+"/"); !supportLineExpression.MatchString(line) {
+			return []violation{{text: Message(MsgSupportLineInvalid, line)}}, nil
+		}
+		return nil, nil
+
+	default:
+		fmt.Fprintln(os.Stderr, Message(MsgCheckNoRules, branchName))
+		return nil, nil
+	}
+}
+
+// checkVersionedBranch checks that a release or hotfix branch is named '<prefix>/<version>[/<description>]' and
+// its version file holds the version of the branch name with the qualifier configured for the type of branch.
+func checkVersionedBranch(plugin Plugin, repository Repository, branch Branch, branchName, ref string, rule func(Version) *violation) ([]violation, error) {
+	segment := strings.SplitN(strings.TrimPrefix(branchName, branch.String()+"/"), "/", 2)[0]
+	version, err := ParseVersion(segment)
+	if err != nil || version.String() != segment || !version.plain() {
+		return []violation{{text: Message(MsgCheckBranchName, branchName, branch)}}, nil
+	}
+
+	violations := make([]violation, 0)
+	if v := rule(version); v != nil {
+		violations = append(violations, *v)
+	}
+
+	current, err := readVersionAt(plugin, repository, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// the version carries the qualifier configured for the type of branch, e.g. 'rc' for release candidates, in any
+	// iteration, e.g. 'rc.2', or none
+	qualifier := Qualifier(plugin, branch)
+	if branch == Release && qualifier == noQualifier && qualifierName(current.Qualifier) == candidateQualifier {
+		// release candidates may be written to the version file of the release branch, e.g. '1.2.0-rc.2'
+		qualifier = current.Qualifier
+	}
+	if current.Qualifier != noQualifier && qualifier == noQualifier {
+		violations = append(violations, violation{plugin.VersionFileName(), Message(MsgCheckQualifier, current, plugin.VersionFileName())})
+	} else if qualifierName(current.Qualifier) != qualifierName(qualifier) {
+		violations = append(violations, violation{plugin.VersionFileName(), Message(MsgCheckQualifierMissing, current, plugin.VersionFileName(), qualifier)})
+	} else if current.RemoveQualifier() != version {
+		violations = append(violations, violation{plugin.VersionFileName(), Message(MsgCheckVersionMismatch, current, plugin.VersionFileName(), version)})
+	}
+
+	return violations, nil
+}
+
+// checkDevelopment checks that the development branch holds a development version newer than every release.
+func checkDevelopment(plugin Plugin, repository Repository, ref string, latest Version) ([]violation, error) {
+	current, err := readVersionAt(plugin, repository, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := make([]violation, 0)
+	if qualifier := Qualifier(plugin, Development); len(qualifier) > 0 && qualifierName(current.Qualifier) != qualifierName(qualifier) {
+		violations = append(violations, violation{plugin.VersionFileName(), Message(MsgCheckQualifierMissing, current, plugin.VersionFileName(), qualifier)})
+	}
+
+	if latest != NoVersion && !latest.Less(current.RemoveQualifier()) {
+		violations = append(violations, violation{plugin.VersionFileName(), Message(MsgCheckVersionNotNewer, current, Development, latest)})
+	}
+
+	return violations, nil
+}
+
+// annotate reports a violation as an error annotation of GitHub Actions, or as an error line elsewhere.
+func annotate(v violation) {
+	if os.Getenv("GITHUB_ACTIONS") != "true" {
+		fmt.Fprintln(os.Stderr, Message(MsgCheckViolation, v.text))
+		return
+	}
+
+	if len(v.file) > 0 {
+		fmt.Fprintf(statusOutput(), "::error file=%v::%v\n", v.file, v.text)
+	} else {
+		fmt.Fprintf(statusOutput(), "::error::%v\n", v.text)
+	}
+}
@@ -0,0 +1,220 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// CI settings key.
+const ciKey = "ci"
+
+// CI systems with a tuned invocation mode.
+const (
+	CINone   = ""
+	CIGitLab = "gitlab"
+)
+
+// Predefined variables of GitLab CI and the variable of a token with write access to the repository.
+const (
+	gitlabTokenEnv         = "GITLAB_TOKEN"
+	gitlabJobTokenEnv      = "CI_JOB_TOKEN"
+	gitlabServerURLEnv     = "CI_SERVER_URL"
+	gitlabProjectPathEnv   = "CI_PROJECT_PATH"
+	gitlabCommitBranchEnv  = "CI_COMMIT_BRANCH"
+	gitlabSourceBranchEnv  = "CI_MERGE_REQUEST_SOURCE_BRANCH_NAME"
+	gitlabUserNameEnv      = "GITLAB_USER_NAME"
+	gitlabUserEmailEnv     = "GITLAB_USER_EMAIL"
+	gitlabCollapsedSection = "gitflow_cli"
+)
+
+// Setting which applies the safe defaults of pipelines when a CI system is detected, disabled by --no-ci-defaults.
+const ciDefaultsKey = "ci-defaults"
+
+// CI systems detected by their predefined variables, in addition to GitLab CI.
+const (
+	CIGitHub  = "github"
+	CIJenkins = "jenkins"
+	CIAzure   = "azure"
+	CIGeneric = "generic"
+)
+
+// Predefined variables which identify a CI system, with the value they hold, or any value if it is empty. The CI
+// variable, which most CI systems set, identifies other CI systems.
+var ciDetectors = []struct{ system, variable, value string }{
+	{CIGitHub, "GITHUB_ACTIONS", "true"},
+	{CIGitLab, "GITLAB_CI", "true"},
+	{CIJenkins, "JENKINS_URL", ""},
+	{CIAzure, "TF_BUILD", "True"},
+	{CIGeneric, "CI", "true"},
+}
+
+// CI system detected in the current run whose safe defaults apply, see ApplyCIDefaults.
+var detectedCI = CINone
+
+// DetectCI returns the CI system of the environment by its predefined variables, or CINone outside of pipelines.
+func DetectCI() string {
+	for _, detector := range ciDetectors {
+		if value, ok := os.LookupEnv(detector.variable); ok && len(value) > 0 {
+			if len(detector.value) == 0 || strings.EqualFold(value, detector.value) {
+				return detector.system
+			}
+		}
+	}
+	return CINone
+}
+
+// ApplyCIDefaults applies the safe defaults of pipelines if a CI system is detected and returns it: prompts are
+// confirmed automatically and the output is plain and line-oriented, unless the 'output' setting selects another
+// mode, e.g. with '--output rich'. Failed workflows keep their changes, so that they can be continued, unless
+// 'workflow.rollback' is configured. The defaults are disabled by the 'ci-defaults' setting or --no-ci-defaults.
+// They are not settings themselves, so that they never end up in a configuration file written by a prompt.
+func ApplyCIDefaults() string {
+	detectedCI = CINone
+	if enabled, ok := viper.Get(ciDefaultsKey).(bool); ok && !enabled {
+		return CINone
+	}
+
+	detectedCI = DetectCI()
+	return detectedCI
+}
+
+// CIDetected reports whether the safe defaults of a detected CI system apply, which makes the run non-interactive.
+func CIDetected() bool {
+	return detectedCI != CINone
+}
+
+// CIMode returns the CI system the invocation is tuned for, selected by the 'ci' setting or the --ci flag.
+func CIMode() string {
+	return viper.GetString(ciKey)
+}
+
+// PrepareCI tunes the environment of the git commands for the selected CI system. In GitLab CI, commits get the
+// identity of the user who triggered the pipeline, pushes are authenticated with GITLAB_TOKEN (or the job token),
+// and the detached HEAD of the pipeline is attached to its branch.
+func PrepareCI(projectPath string) error {
+	switch CIMode() {
+	case CINone:
+		return nil
+	case CIGitLab:
+		return prepareGitLab(NewRepository(projectPath, Remote))
+	default:
+		return Error(MsgCIUnsupported, CIMode(), CIGitLab)
+	}
+}
+
+func prepareGitLab(repository Repository) error {
+	// commits of a pipeline are authored by the user who triggered it, unless the runner configures an identity
+	if _, err := repository.Identity(); err != nil {
+		name, email := os.Getenv(gitlabUserNameEnv), os.Getenv(gitlabUserEmailEnv)
+		if len(name) > 0 && len(email) > 0 {
+			for _, env := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+				_ = os.Setenv(env, name)
+			}
+			for _, env := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+				_ = os.Setenv(env, email)
+			}
+			fmt.Fprintln(os.Stderr, Message(MsgCIIdentity, name, email))
+		}
+	}
+
+	// the clone of a pipeline can fetch with the job token, pushing requires a token with write access
+	if pushURL, token, ok := gitlabPushURL(); ok {
+		addGitConfig("remote."+Remote+".pushurl", pushURL)
+		fmt.Fprintln(os.Stderr, Message(MsgCIPushToken, Remote, token))
+	}
+
+	// pipelines check out the commit of the branch or merge request as detached HEAD
+	current, err := repository.CurrentBranch()
+	if err != nil || len(current) > 0 {
+		return nil
+	}
+
+	branchName := os.Getenv(gitlabSourceBranchEnv)
+	if len(branchName) == 0 {
+		branchName = os.Getenv(gitlabCommitBranchEnv)
+	}
+	if len(branchName) == 0 {
+		return nil
+	}
+
+	if _, err := repository.ResolveRef("refs/heads/" + branchName); err == nil {
+		err = repository.CheckoutBranch(branchName)
+	} else {
+		err = repository.CreateBranchFrom(branchName, head)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, Message(MsgCIBranchAttached, branchName))
+	return nil
+}
+
+// gitlabPushURL returns the URL of the project with the credentials of GITLAB_TOKEN, or of the job token.
+func gitlabPushURL() (string, string, bool) {
+	server, err := url.Parse(os.Getenv(gitlabServerURLEnv))
+	project := os.Getenv(gitlabProjectPathEnv)
+	if err != nil || len(server.Host) == 0 || len(project) == 0 {
+		return "", "", false
+	}
+
+	if token := os.Getenv(gitlabTokenEnv); len(token) > 0 {
+		server.User = url.UserPassword("oauth2", token)
+		server.Path = strings.TrimSuffix(server.Path, "/") + "/" + project + ".git"
+		return server.String(), gitlabTokenEnv, true
+	}
+
+	if token := os.Getenv(gitlabJobTokenEnv); len(token) > 0 {
+		server.User = url.UserPassword("gitlab-ci-token", token)
+		server.Path = strings.TrimSuffix(server.Path, "/") + "/" + project + ".git"
+		return server.String(), gitlabJobTokenEnv, true
+	}
+
+	return "", "", false
+}
+
+// addGitConfig passes a configuration value to all git commands of the process, without writing it to the
+// repository configuration where a token would outlive the pipeline job.
+func addGitConfig(key, value string) {
+	count, _ := strconv.Atoi(os.Getenv("GIT_CONFIG_COUNT"))
+	_ = os.Setenv(fmt.Sprintf("GIT_CONFIG_KEY_%v", count), key)
+	_ = os.Setenv(fmt.Sprintf("GIT_CONFIG_VALUE_%v", count), value)
+	_ = os.Setenv("GIT_CONFIG_COUNT", strconv.Itoa(count+1))
+}
+
+// checkMergeRequestBranch ensures that a merge request pipeline in GitLab CI only finishes its source branch.
+func checkMergeRequestBranch(branchName string) error {
+	if CIMode() != CIGitLab {
+		return nil
+	}
+
+	if source := os.Getenv(gitlabSourceBranchEnv); len(source) > 0 && source != branchName {
+		return Error(MsgCIBranchMismatch, source, branchName)
+	}
+	return nil
+}
+
+// startSection starts a collapsible section of the GitLab CI job log, whose header is the next output line.
+func startSection() {
+	if CIMode() == CIGitLab {
+		fmt.Fprintf(statusOutput(), "\x1b[0Ksection_start:%v:%v\r\x1b[0K", time.Now().Unix(), gitlabCollapsedSection)
+	}
+}
+
+// endSection ends the collapsible section of the GitLab CI job log.
+func endSection() {
+	if CIMode() == CIGitLab {
+		fmt.Fprintf(statusOutput(), "\x1b[0Ksection_end:%v:%v\r\x1b[0K\n", time.Now().Unix(), gitlabCollapsedSection)
+	}
+}
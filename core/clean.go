@@ -0,0 +1,79 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"path"
+	"strings"
+)
+
+// Workflow setting of the path patterns of untracked files which do not make the working tree unclean, e.g. '.idea/'
+// or '*.iml'.
+const cleanIgnoreSetting = "clean-ignore"
+
+// Prefix of untracked files in the short status of git.
+const untrackedStatus = "?? "
+
+// Ignored path patterns of the current run.
+var cleanIgnore []string
+
+func applyCleanIgnoreSetting(settings map[string]any) {
+	if list, ok := settings[cleanIgnoreSetting].([]any); ok {
+		for _, item := range list {
+			if pattern, ok := item.(string); ok && len(strings.Trim(pattern, "/")) > 0 {
+				cleanIgnore = append(cleanIgnore, pattern)
+			}
+		}
+	}
+}
+
+func resetCleanIgnoreSetting() {
+	cleanIgnore = nil
+}
+
+// unclean returns the entries of the short status of git which make the working tree unclean: all changes of tracked
+// files, and the untracked files which no pattern of 'workflow.clean-ignore' matches.
+func unclean(status string) []string {
+	entries := make([]string, 0)
+	for _, entry := range strings.Split(status, "\n") {
+		if len(strings.TrimSpace(entry)) == 0 {
+			continue
+		}
+		if name, untracked := strings.CutPrefix(entry, untrackedStatus); untracked && cleanIgnored(name) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// cleanIgnored reports whether an untracked file or directory, relative to the root of the repository, matches a
+// pattern of 'workflow.clean-ignore'. As in .gitignore files, a pattern matches a name in any directory, or a path
+// relative to the root of the repository if it contains a slash, and only directories if it ends with a slash.
+func cleanIgnored(name string) bool {
+	isDir := strings.HasSuffix(name, "/")
+	components := strings.Split(strings.Trim(name, "/"), "/")
+
+	for _, pattern := range cleanIgnore {
+		dirOnly := strings.HasSuffix(pattern, "/")
+		pattern = strings.TrimSuffix(pattern, "/")
+		anchored := strings.Contains(pattern, "/")
+		pattern = strings.TrimPrefix(pattern, "/")
+
+		for i := range components {
+			candidate := components[i]
+			if anchored {
+				candidate = strings.Join(components[:i+1], "/")
+			}
+
+			// a directory pattern matches a parent directory of the file, or the untracked directory itself
+			if matched, _ := path.Match(pattern, candidate); matched && (!dirOnly || i < len(components)-1 || isDir) {
+				return true
+			}
+		}
+	}
+	return false
+}
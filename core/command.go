@@ -0,0 +1,150 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"maps"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Settings which run a command, by group and key.
+var commandSettings = []struct{ group, key string }{
+	{hooksGroup, preTagSetting},
+	{sbomGroup, sbomCommandSetting},
+	{provenanceGroup, provenanceSignSetting},
+	{provenanceGroup, provenanceUploadSetting},
+}
+
+// Config files or GITFLOW_* variables of the settings which run commands, by the keys of CommandSettingsOf.
+var commandSources map[string]string
+
+// SetCommandSources sets the config files or GITFLOW_* variables which the settings running commands come from, so
+// that an invalid command is reported along with the place where it is configured.
+func SetCommandSources(sources map[string]string) {
+	commandSources = sources
+}
+
+// CommandSettingsOf returns the keys of the settings which run commands or push to another repository and which the
+// settings of a config file hold: the pre-tag hook, the SBOM command, the provenance commands, the steps inserted
+// into the workflows, e.g. 'workflow.steps.release-finish.insert', and the GitOps repository.
+func CommandSettingsOf(settings map[string]any) []string {
+	keys := []string{}
+
+	for _, setting := range commandSettings {
+		group, _ := settings[setting.group].(map[string]any)
+		if _, ok := group[setting.key]; ok {
+			keys = append(keys, setting.group+"."+setting.key)
+		}
+	}
+
+	// the steps to skip and to move only customize the workflows, so the inserted steps are the settings alone
+	workflow, _ := settings[workflowGroup].(map[string]any)
+	steps, _ := workflow[stepsSetting].(map[string]any)
+	for _, name := range slices.Sorted(maps.Keys(steps)) {
+		if definition, ok := steps[name].(map[string]any); ok {
+			if _, ok := definition[insertStepsKey]; ok {
+				keys = append(keys, workflowGroup+"."+stepsSetting+"."+name+"."+insertStepsKey)
+			}
+		}
+	}
+
+	if _, ok := settings[gitopsGroup]; ok {
+		keys = append(keys, gitopsGroup)
+	}
+
+	return keys
+}
+
+// RemoveCommandSettings removes the settings of CommandSettingsOf from the settings of a config file and returns
+// their keys. A project config which is versioned with the repository thus cannot run commands on the machines of
+// its contributors, unless they trust the project in their user config.
+func RemoveCommandSettings(settings map[string]any) []string {
+	keys := CommandSettingsOf(settings)
+
+	for _, key := range keys {
+		levels := strings.Split(key, ".")
+		group := settings
+		for _, level := range levels[:len(levels)-1] {
+			group, _ = group[level].(map[string]any)
+		}
+		maps.DeleteFunc(group, func(name string, _ any) bool { return name == levels[len(levels)-1] })
+	}
+
+	return keys
+}
+
+// commandSource returns the config file or the GITFLOW_* variable of a setting which runs commands.
+func commandSource(key string) string {
+	if source, ok := commandSources[key]; ok {
+		return source
+	}
+	return viper.ConfigFileUsed()
+}
+
+// validateCommandSettings fails for a setting which runs commands, but holds no command, and for a command whose
+// executable cannot be found, which is resolved by the PATH or, for a path, in the project path the command runs in.
+// An empty setting disables its command, except for the steps inserted into the workflows, which need a command.
+func validateCommandSettings(all map[string]any) error {
+	for _, setting := range commandSettings {
+		group, _ := all[setting.group].(map[string]any)
+		value, ok := group[setting.key]
+		if !ok || value == nil {
+			continue
+		}
+
+		key := setting.group + "." + setting.key
+		command, ok := value.(string)
+		if !ok || (len(command) > 0 && len(strings.Fields(command)) == 0) {
+			return Error(MsgMissingCommand, key, commandSource(key))
+		}
+		if err := checkCommand(command); err != nil {
+			return Error(MsgInvalidCommand, key, commandSource(key), command, err)
+		}
+	}
+
+	for _, workflow := range slices.Sorted(maps.Keys(stepDefinitions)) {
+		definition, _ := stepDefinitions[workflow].(map[string]any)
+		key := workflowGroup + "." + stepsSetting + "." + workflow + "." + insertStepsKey
+		for _, item := range definitionList(definition[insertStepsKey]) {
+			step := key
+			if name, _ := item[stepNameKey].(string); len(name) > 0 {
+				step += "." + name
+			}
+			command, _ := item[stepRunKey].(string)
+			if len(strings.Fields(command)) == 0 {
+				return Error(MsgMissingCommand, step, commandSource(key))
+			}
+			if err := checkCommand(command); err != nil {
+				return Error(MsgInvalidCommand, step, commandSource(key), command, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkCommand fails if the executable of a command line cannot be found, an empty command line is not checked.
+func checkCommand(command string) error {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	executable := fields[0]
+	if strings.ContainsRune(executable, '/') || strings.ContainsRune(executable, filepath.Separator) {
+		if !filepath.IsAbs(executable) {
+			executable = filepath.Join(ProjectPath, executable)
+		}
+	}
+
+	_, err := exec.LookPath(executable)
+	return err
+}
@@ -0,0 +1,105 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"net/mail"
+	"strings"
+)
+
+// Commit settings keys of the core group, e.g. 'core.commit.sign'.
+const (
+	commitGroup          = "commit"
+	commitSignSetting    = "sign"
+	commitAuthorSetting  = "author"
+	commitNameSetting    = "name"
+	commitEmailSetting   = "email"
+	commitMessageSetting = "message"
+)
+
+// Placeholder in the commit message template which is replaced by the message of the workflow, e.g. 'Remove qualifier
+// from project version.'.
+const messagePlaceholder = "{message}"
+
+// Git command option which passes a configuration value to a single command.
+const configOption = "-c"
+
+// Signing and identity of the commits created by the current run, e.g. version bumps and merge commits. The author
+// is an identity of the form 'Name <email>', the name and email override user.name and user.email.
+var commitSigned = false
+var commitAuthor = ""
+var commitName = ""
+var commitEmail = ""
+var commitMessage = messagePlaceholder
+
+func applyCommitSettings(settings map[string]any) {
+	if v, ok := settings[commitSignSetting].(bool); ok {
+		commitSigned = v
+	}
+	if v, ok := settings[commitAuthorSetting].(string); ok {
+		commitAuthor = strings.TrimSpace(v)
+	}
+	if v, ok := settings[commitNameSetting].(string); ok {
+		commitName = strings.TrimSpace(v)
+	}
+	if v, ok := settings[commitEmailSetting].(string); ok {
+		commitEmail = strings.TrimSpace(v)
+	}
+	if v, ok := settings[commitMessageSetting].(string); ok && len(strings.TrimSpace(v)) > 0 {
+		commitMessage = v
+	}
+}
+
+func resetCommitSettings() {
+	commitSigned = false
+	commitAuthor = ""
+	commitName = ""
+	commitEmail = ""
+	commitMessage = messagePlaceholder
+}
+
+// validateCommitSettings ensures that the configured author is an identity of the form 'Name <email>' and that the
+// commit message template keeps the message of the workflow, by which its commits are recognized, e.g. in reports.
+func validateCommitSettings() error {
+	if !strings.Contains(commitMessage, messagePlaceholder) {
+		return Error(MsgTemplatePlaceholder, legacyGroup+"."+commitGroup+"."+commitMessageSetting, commitMessage, messagePlaceholder)
+	}
+	if len(commitAuthor) == 0 {
+		return nil
+	}
+	if author, err := mail.ParseAddress(commitAuthor); err != nil || len(author.Name) == 0 {
+		return Error(MsgInvalidAuthor, legacyGroup+"."+commitGroup+"."+commitAuthorSetting, commitAuthor)
+	}
+	return nil
+}
+
+// commitMessageOf returns the message of a commit of the workflow from the template under 'core.commit.message', with
+// the message and the variables of the plugin substituted, e.g. 'app: Update changelog for release 1.2.0.' for the
+// template '{artifactId}: {message}'.
+func commitMessageOf(message string) string {
+	return expandTemplate(commitMessage, messagePlaceholder, message)
+}
+
+// withCommitOptions prepends the configuration values of 'core.commit' to the arguments of a git command which
+// creates commits or tags, or resolves their identity. Git applies them to all commits the command creates,
+// including merge commits, which take no author option: the name and email as user.name and user.email, the author
+// as author.name and author.email, and signing as commit.gpgSign.
+func withCommitOptions(args ...string) []string {
+	options := make([]string, 0)
+	if len(commitName) > 0 {
+		options = append(options, configOption, "user.name="+commitName)
+	}
+	if len(commitEmail) > 0 {
+		options = append(options, configOption, "user.email="+commitEmail)
+	}
+	if author, err := mail.ParseAddress(commitAuthor); err == nil && len(commitAuthor) > 0 {
+		options = append(options, configOption, "author.name="+author.Name, configOption, "author.email="+author.Address)
+	}
+	if commitSigned {
+		options = append(options, configOption, "commit.gpgSign=true")
+	}
+	return append(options, args...)
+}
@@ -0,0 +1,351 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// ContractVersion is the version of the JSON documents written in JSON output mode. Within a version, fields are
+// only added, never renamed, removed or changed in meaning, so that integrations (e.g. Jenkins shared libraries)
+// keep working. An incompatible change increments the version and the path of the schema identifiers.
+const ContractVersion = 1
+
+// Status of a command in its result document.
+const (
+	StatusSuccess = "success"
+	StatusFailure = "failure"
+)
+
+// Violation of a workflow rule found by the check command.
+type Violation struct {
+	File    string `json:"file,omitempty"`
+	Message string `json:"message"`
+}
+
+// Result is the document a command writes to standard output in JSON output mode, described by the 'result'
+// schema. Status messages are written to standard error instead.
+type Result struct {
+	SchemaVersion int           `json:"schemaVersion"`
+	Command       string        `json:"command"`
+	Status        string        `json:"status"`
+	Branch        string        `json:"branch,omitempty"`
+	Version       string        `json:"version,omitempty"`
+	Tag           string        `json:"tag,omitempty"`
+	Violations    []Violation   `json:"violations,omitempty"`
+	Notes         *ReleaseNotes `json:"notes,omitempty"`
+	Metrics       *Metrics      `json:"metrics,omitempty"`
+	Links         *Links        `json:"links,omitempty"`
+	Error         string        `json:"error,omitempty"`
+}
+
+// Result of the current command, collected while it runs.
+var result Result
+
+// recordResult records the branch, version and tag a workflow command has started or finished.
+func recordResult(branchName string, version Version, tagName string) {
+	result.Branch, result.Tag = branchName, tagName
+	if version != NoVersion {
+		result.Version = version.String()
+	}
+	result.Links = resultLinks(branchName, tagName)
+}
+
+// recordViolations records the violations of the workflow rules found by the check command.
+func recordViolations(violations []violation) {
+	for _, v := range violations {
+		result.Violations = append(result.Violations, Violation{File: v.file, Message: v.text})
+	}
+}
+
+// RecordNotes records the release notes printed by the notes command.
+func RecordNotes(notes ReleaseNotes) {
+	result.Notes = &notes
+}
+
+// RecordMetrics records the metrics exported by the metrics command.
+func RecordMetrics(metrics Metrics) {
+	result.Metrics = &metrics
+}
+
+// ResetResult discards the result of a command, which outlives it when commands run in-process (e.g. in e2e tests).
+func ResetResult() {
+	result = Result{}
+}
+
+// WriteResult writes the result document of a command, which failed with the given error if it is not nil.
+func WriteResult(w io.Writer, command string, err error) error {
+	document := result
+	document.SchemaVersion = ContractVersion
+	document.Command = command
+	document.Status = StatusSuccess
+
+	if err != nil {
+		document.Status = StatusFailure
+		document.Error = Redact(err.Error())
+	}
+
+	content, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, string(content))
+	return err
+}
+
+// Schema returns the JSON Schema of a document of the machine contract.
+func Schema(name string) (string, error) {
+	schema, ok := schemas[name]
+	if !ok {
+		return "", Error(MsgSchemaUnknown, name, strings.Join(SchemaNames(), ", "))
+	}
+	return schema, nil
+}
+
+// SchemaNames returns the names of the documents of the machine contract in alphabetical order.
+func SchemaNames() []string {
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// JSON Schemas of the documents of the machine contract by name.
+var schemas = map[string]string{
+	"result":        resultSchema,
+	"release-notes": releaseNotesSchema,
+	"metrics":       metricsSchema,
+}
+
+// Schema of the result document written by every command in JSON output mode.
+const resultSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "https://github.com/mercedes-benz/gitflow-cli/schemas/v1/result.json",
+  "title": "gitflow-cli result",
+  "description": "Result of a gitflow-cli command, written to standard output with --output json.",
+  "type": "object",
+  "required": ["schemaVersion", "command", "status"],
+  "properties": {
+    "schemaVersion": {
+      "description": "Version of the contract, incremented on incompatible changes.",
+      "const": 1
+    },
+    "command": {
+      "description": "Command without the program name, e.g. 'release start'.",
+      "type": "string"
+    },
+    "status": {
+      "description": "Outcome of the command, the exit code is non-zero on failure.",
+      "enum": ["success", "failure"]
+    },
+    "branch": {
+      "description": "Branch created by a start command or merged by a finish command.",
+      "type": "string"
+    },
+    "version": {
+      "description": "Version of the started or finished branch.",
+      "type": "string"
+    },
+    "tag": {
+      "description": "Tag created by a finish command.",
+      "type": "string"
+    },
+    "violations": {
+      "description": "Violations of the workflow rules found by the check command.",
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["message"],
+        "properties": {
+          "file": {
+            "description": "File of the branch the violation is located in.",
+            "type": "string"
+          },
+          "message": {
+            "description": "Description of the violation.",
+            "type": "string"
+          }
+        }
+      }
+    },
+    "notes": {
+      "description": "Release notes printed by the release notes command.",
+      "$ref": "https://github.com/mercedes-benz/gitflow-cli/schemas/v1/release-notes.json"
+    },
+    "metrics": {
+      "description": "Metrics exported by the metrics command.",
+      "$ref": "https://github.com/mercedes-benz/gitflow-cli/schemas/v1/metrics.json"
+    },
+    "links": {
+      "description": "Web links of the hosting service of the remote, if it is GitHub, GitLab, Bitbucket or Azure DevOps.",
+      "type": "object",
+      "required": ["repository"],
+      "properties": {
+        "repository": {
+          "description": "Web link of the repository.",
+          "type": "string"
+        },
+        "branch": {
+          "description": "Web link of the branch created by a start command.",
+          "type": "string"
+        },
+        "tag": {
+          "description": "Web link of the tag created by a finish command.",
+          "type": "string"
+        },
+        "compare": {
+          "description": "Web link of the changes of the tag since the previous release, or of the branch compared to the production branch.",
+          "type": "string"
+        }
+      }
+    },
+    "error": {
+      "description": "Error message of a failed command.",
+      "type": "string"
+    }
+  }
+}`
+
+// Schema of the release notes, written by the release notes command with --format json or inside the result.
+const releaseNotesSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "https://github.com/mercedes-benz/gitflow-cli/schemas/v1/release-notes.json",
+  "title": "gitflow-cli release notes",
+  "description": "Changes of a release, grouped by the issues referenced in the commit messages.",
+  "type": "object",
+  "required": ["version", "issues", "changes", "contributors"],
+  "properties": {
+    "version": {
+      "description": "Released version.",
+      "type": "string"
+    },
+    "issues": {
+      "description": "Changes referring to an issue, grouped by issue.",
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["id", "changes"],
+        "properties": {
+          "id": {
+            "description": "Issue reference, e.g. PROJ-123 or #42.",
+            "type": "string"
+          },
+          "link": {
+            "description": "Link of the issue, if a link template is configured.",
+            "type": "string"
+          },
+          "changes": {
+            "type": "array",
+            "items": { "type": "string" }
+          }
+        }
+      }
+    },
+    "changes": {
+      "description": "Changes without issue reference.",
+      "type": "array",
+      "items": { "type": "string" }
+    },
+    "contributors": {
+      "description": "Authors of the changes.",
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["name", "email"],
+        "properties": {
+          "name": { "type": "string" },
+          "email": { "type": "string" },
+          "handle": {
+            "description": "GitHub handle, if resolved via the GitHub API.",
+            "type": "string"
+          }
+        }
+      }
+    },
+    "compare": {
+      "description": "Web link of the changes of the release on the hosting service of the remote.",
+      "type": "string"
+    }
+  }
+}`
+
+// Schema of the metrics, written by the metrics command with --format json or inside the result.
+const metricsSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "https://github.com/mercedes-benz/gitflow-cli/schemas/v1/metrics.json",
+  "title": "gitflow-cli metrics",
+  "description": "DORA-style delivery metrics of the versions released within a period, derived from the version tags.",
+  "type": "object",
+  "required": ["repository", "since", "until", "releases", "hotfixes", "releasesPerWeek", "releaseLeadTimeSeconds", "hotfixLeadTimeSeconds", "versions"],
+  "properties": {
+    "repository": {
+      "description": "GitHub repository, e.g. org/repo, or the name of the project directory.",
+      "type": "string"
+    },
+    "since": {
+      "description": "Start of the period.",
+      "type": "string",
+      "format": "date-time"
+    },
+    "until": {
+      "description": "End of the period, the time of the export.",
+      "type": "string",
+      "format": "date-time"
+    },
+    "releases": {
+      "description": "Number of releases within the period.",
+      "type": "integer"
+    },
+    "hotfixes": {
+      "description": "Number of hotfixes within the period.",
+      "type": "integer"
+    },
+    "releasesPerWeek": {
+      "description": "Released versions, releases and hotfixes, per week within the period.",
+      "type": "number"
+    },
+    "releaseLeadTimeSeconds": {
+      "description": "Median duration from the first commit of a release branch to its tag.",
+      "type": "number"
+    },
+    "hotfixLeadTimeSeconds": {
+      "description": "Median duration from the first commit of a hotfix branch to its tag.",
+      "type": "number"
+    },
+    "versions": {
+      "description": "Versions released within the period, newest first.",
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["version", "kind", "date", "commits"],
+        "properties": {
+          "version": { "type": "string" },
+          "kind": { "enum": ["release", "hotfix"] },
+          "date": {
+            "description": "Time of the version tag.",
+            "type": "string",
+            "format": "date-time"
+          },
+          "leadTimeSeconds": {
+            "description": "Duration from the first commit of the branch to the tag, if the branch was created by the workflow.",
+            "type": "number"
+          },
+          "commits": {
+            "description": "Number of commits since the previous version.",
+            "type": "integer"
+          }
+        }
+      }
+    }
+  }
+}`
@@ -0,0 +1,138 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Bumps of the release version with --bump on release start: 'auto' chooses the increment from the conventional
+// commits since the latest release, the others are the names of the version increments.
+const BumpAuto = "auto"
+
+// ReleaseBump derives the version of a release from the latest release instead of the version of the development
+// branch, e.g. '1.0.1' with 'patch' after '1.0.0'. Without bump, the version of the development branch is released.
+var ReleaseBump string
+
+// Header of a conventional commit, e.g. 'feat(api)!: remove v1 endpoints', with the type, the scope, the breaking
+// marker and the description.
+var conventionalHeader = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!?):\s*(.*)$`)
+
+// Footers of a conventional commit which mark a breaking change.
+var breakingFooters = []string{"BREAKING CHANGE:", "BREAKING-CHANGE:"}
+
+// conventionalCommit is a commit message of the conventional commits specification.
+type conventionalCommit struct {
+	Type        string
+	Scope       string
+	Description string
+	Breaking    bool
+}
+
+// parseConventionalCommit parses the message of a commit, which is no conventional commit without its header.
+func parseConventionalCommit(commit Commit) (conventionalCommit, bool) {
+	matches := conventionalHeader.FindStringSubmatch(strings.TrimSpace(commit.Subject))
+	if matches == nil {
+		return conventionalCommit{}, false
+	}
+
+	parsed := conventionalCommit{
+		Type:        strings.ToLower(matches[1]),
+		Scope:       matches[2],
+		Description: matches[4],
+		Breaking:    matches[3] == "!",
+	}
+
+	for _, footer := range breakingFooters {
+		if strings.HasPrefix(commit.Body, footer) || strings.Contains(commit.Body, "\n"+footer) {
+			parsed.Breaking = true
+		}
+	}
+	return parsed, true
+}
+
+// conventionalIncrement returns the version increment a commit calls for: major for breaking changes, minor for
+// features, patch for fixes, and none for other types or commits without conventional header.
+func conventionalIncrement(commit Commit) VersionIncrement {
+	parsed, ok := parseConventionalCommit(commit)
+	switch {
+	case !ok:
+		return None
+	case parsed.Breaking:
+		return Major
+	case parsed.Type == "feat":
+		return Minor
+	case parsed.Type == "fix":
+		return Incremental
+	default:
+		return None
+	}
+}
+
+// bumpIncrement returns the version increment of a bump, or chooses the highest increment of the commits on the
+// development branch since the latest release with 'auto'.
+func bumpIncrement(repository Repository, bump string, latest Version) (VersionIncrement, error) {
+	if bump != BumpAuto {
+		for increment, name := range versionIncrementNames {
+			if name == bump {
+				return increment, nil
+			}
+		}
+		return None, fmt.Errorf("unsupported version bump: %v", bump)
+	}
+
+	commits, err := repository.Commits(versionTag(latest.String()) + ".." + Development.String())
+	if err != nil {
+		return None, err
+	}
+
+	increment := None
+	for _, commit := range commits {
+		switch next := conventionalIncrement(commit); {
+		case next == Major:
+			return Major, nil
+		case increment == None || next == Minor:
+			increment = next
+		}
+	}
+
+	if increment == None {
+		return None, Error(MsgBumpNoChanges, len(commits), versionTag(latest.String()), Development)
+	}
+	return increment, nil
+}
+
+// bumpedVersion returns the version of the development branch a bumped release is started from, which is the latest
+// release with the increment of the bump and the qualifier of the development branch, e.g. '1.0.1-dev' for a patch
+// after '1.0.0'. Without release, the version of the development branch is kept.
+func bumpedVersion(repository Repository, current Version, bump string) (Version, VersionIncrement, error) {
+	tagNames, err := repository.Tags()
+	if err != nil {
+		return NoVersion, None, err
+	}
+
+	_, latest := releasedVersions(tagNames)
+	if latest == NoVersion {
+		return current, None, nil
+	}
+
+	increment, err := bumpIncrement(repository, bump, latest)
+	if err != nil {
+		return NoVersion, None, err
+	}
+
+	next, err := Scheme().Next(latest, increment)
+	if err != nil {
+		return NoVersion, None, err
+	}
+
+	next = next.AddQualifier(current.Qualifier)
+	fmt.Fprintln(os.Stderr, Message(MsgBumpChosen, increment, latest, next.RemoveQualifier()))
+	return next, increment, nil
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 
@@ -29,6 +30,8 @@ const (
 	Development
 	Release
 	Hotfix
+	Bugfix
+	Support
 )
 
 // Merge types for repository merging operations.
@@ -37,6 +40,7 @@ const (
 	Squash
 	NoFastForward
 	FastForward
+	FastForwardOrCommit
 )
 
 type (
@@ -66,6 +70,10 @@ type (
 		// For example: "SNAPSHOT" for Maven, etc.
 		VersionQualifier() string
 
+		// VersionFileTemplate returns the Go template of the version file for new projects, rendered with the
+		// project name and version. An empty template means the plugin cannot bootstrap new projects.
+		VersionFileTemplate() string
+
 		// RequiredTools returns a list of command-line tools needed to run the plugin.
 		RequiredTools() []string
 
@@ -78,23 +86,62 @@ type (
 		// Stringer returns the human-readable name of the plugin.
 		fmt.Stringer
 	}
+
+	// ProjectFilePlugin is implemented by plugins which detect their projects by a file other than the version
+	// file, e.g. 'go.mod', so that the plugin applies even before the version file has been created.
+	ProjectFilePlugin interface {
+		// RequiredFile returns the name of the file that identifies a project of the plugin.
+		RequiredFile() string
+	}
+
+	// TagPrefixPlugin is implemented by plugins of ecosystems with a tagging convention, e.g. the 'v' prefix of
+	// Terraform modules, which applies unless the prefix is configured under 'workflow.tag-prefix'.
+	TagPrefixPlugin interface {
+		// TagPrefix returns the prefix of the release and hotfix tags of the projects of the plugin.
+		TagPrefix() string
+	}
+
+	// MetadataProvider is implemented by plugins which contribute variables of the project to the templates of the
+	// commit and tag messages and of the changelog, e.g. the artifactId of a Maven project for 'Release {artifactId}'.
+	MetadataProvider interface {
+		// Metadata returns the variables of the project in a directory by their names, without braces.
+		Metadata(projectPath string) map[string]string
+	}
 )
 
 // Configuration groups.
 const (
-	branchesGroup = "branches"
-	workflowGroup = "workflow"
-	loggingKey    = "logging"
-	legacyGroup   = "core"
+	branchesGroup   = "branches"
+	workflowGroup   = "workflow"
+	loggingKey      = "logging"
+	environmentsKey = "environments"
+	legacyGroup     = "core"
 )
 
 // Workflow settings keys.
 const rollbackSetting = "rollback"
 const pushSetting = "push"
+const dryRunSetting = "dry-run"
 const dockerFallbackSetting = "docker-fallback"
+const resolveVersionConflictsSetting = "resolve-version-conflicts"
+const pullStrategySetting = "pull-strategy"
+const fetchSetting = "fetch"
+const pruneSetting = "prune"
+const pruneHintSetting = "prune-hint"
+const releaseHeadTagSetting = "release-head-tag"
+
+// Pull strategy setting which disables pulling of local branches.
+const pullDisabled = "off"
+
+// Fetch settings values for fetching all remotes or only the gitflow branches of the remote.
+const (
+	fetchAllRemotes = "all"
+	fetchGitflow    = "gitflow"
+)
 
 // Git version control system tool commands.
 const (
+	init_         = "init"
 	status        = "status"
 	fetch         = "fetch"
 	pull          = "pull"
@@ -107,6 +154,47 @@ const (
 	push          = "push"
 	clean         = "clean"
 	reset         = "reset"
+	revparse      = "rev-parse"
+	gitpath       = "--git-path"
+	showprefix    = "--show-prefix"
+	remote_       = "remote"
+	geturl        = "get-url"
+	log_          = "log"
+	lsremote      = "ls-remote"
+	updateref     = "update-ref"
+	list          = "--list"
+	merged        = "--merged"
+	refsOnly      = "--refs"
+	nomerges      = "--no-merges"
+	commitFormat  = "--format=%H%x1f%an%x1f%ae%x1f%cI%x1f%s%x1f%b%x1e"
+	symbolicref   = "symbolic-ref"
+	foreachref    = "for-each-ref"
+	refnameFormat = "--format=%(refname)"
+	objectFormat  = "--format=%(refname) %(objectname)"
+	createdFormat = "--format=%(creatordate:iso-strict)"
+	tagsPrefix    = "refs/tags/"
+	revlist       = "rev-list"
+	mergebase     = "merge-base"
+	isancestor    = "--is-ancestor"
+	show          = "show"
+	short         = "--short"
+	count         = "--count"
+	not           = "--not"
+	remotesOf     = "--remotes="
+	head          = "HEAD"
+	rebase_       = "rebase"
+	rebase        = "--rebase"
+	norebase      = "--no-rebase"
+	abort         = "--abort"
+	config        = "config"
+	get           = "--get"
+	getRegexp     = "--get-regexp"
+	typeBool      = "--type=bool"
+	tagSigning    = "tag.gpgSign"
+	var_          = "var"
+	committer     = "GIT_COMMITTER_IDENT"
+	sign          = "--sign"
+	annotated     = "--annotate"
 	create        = "-c"
 	forcedelete   = "-D"
 	dir           = "-d"
@@ -122,8 +210,12 @@ const (
 	squash        = "--squash"
 	nofastforward = "--no-ff"
 	fastforwad    = "--ff-only"
+	fastorcommit  = "--ff"
 	force         = "--force"
 	hard          = "--hard"
+	verify        = "--verify"
+	quiet         = "--quiet"
+	here          = "."
 )
 
 // BranchNames maps branch types to their names.
@@ -132,6 +224,8 @@ var branchNames = map[Branch]string{
 	Development: "develop",
 	Release:     "release",
 	Hotfix:      "hotfix",
+	Bugfix:      "bugfix",
+	Support:     "support",
 }
 
 // BranchSettings maps settings to branch names.
@@ -140,10 +234,22 @@ var branchSettings = map[string]Branch{
 	"development": Development,
 	"release":     Release,
 	"hotfix":      Hotfix,
+	"bugfix":      Bugfix,
+	"support":     Support,
 }
 
 var rollbackChanges = false
 var pushChanges = true
+var dryRun = false
+var resolveVersionConflicts = true
+var pullStrategy PullStrategy
+var fetchGitflowOnly = false
+var pruneRefs = true
+var pruneHint = true
+var releaseHeadTag = ""
+
+// Environments holds the configured promotion targets in promotion order.
+var environments []string
 
 // DockerFallback indicates whether to automatically fall back to Docker when a native tool is missing.
 var DockerFallback = false
@@ -155,21 +261,61 @@ var ProjectPath = "."
 var pluginRegistry Plugins
 var pluginRegistryLock sync.Mutex
 var fallbackPlugin Plugin
+var genericPlugins = 0
 
-// RegisterPlugin adds a plugin to the global list of all registered plugins.
+// RegisterPlugin adds a plugin to the global list of all registered plugins. The generic plugins and the fallback
+// plugin stay last, so that plugins registered after them are detected first, e.g. the terraform plugin for modules
+// with a VERSION file.
 func RegisterPlugin(plugin Plugin) {
 	pluginRegistryLock.Lock()
 	defer pluginRegistryLock.Unlock()
-	pluginRegistry = append(pluginRegistry, plugin)
+	pluginRegistry = slices.Insert(pluginRegistry, len(pluginRegistry)-genericPlugins-fallbackPlugins(), plugin)
+}
+
+// RegisterGenericPlugin adds a plugin which is detected after all plugins of build tools, but before the fallback
+// plugin, e.g. a plugin for files which also occur in projects of build tools, such as API contracts.
+func RegisterGenericPlugin(plugin Plugin) {
+	pluginRegistryLock.Lock()
+	defer pluginRegistryLock.Unlock()
+	pluginRegistry = slices.Insert(pluginRegistry, len(pluginRegistry)-fallbackPlugins(), plugin)
+	genericPlugins++
+}
+
+// fallbackPlugins returns 1 if the fallback plugin is registered as the last plugin, else 0.
+func fallbackPlugins() int {
+	if last := len(pluginRegistry) - 1; last >= 0 && fallbackPlugin != nil && pluginRegistry[last] == fallbackPlugin {
+		return 1
+	}
+	return 0
+}
+
+// RegisterPreferredPlugin adds a plugin which is detected before all other registered plugins, e.g. a plugin set up
+// in the configuration, which then applies even if the project has the files of a built-in plugin as well.
+func RegisterPreferredPlugin(plugin Plugin) {
+	pluginRegistryLock.Lock()
+	defer pluginRegistryLock.Unlock()
+	pluginRegistry = append(Plugins{plugin}, pluginRegistry...)
 }
 
 // RegisterFallbackPlugin RegisterPlugin adds a fallback plugin
 func RegisterFallbackPlugin(plugin Plugin) {
+	pluginRegistryLock.Lock()
+	defer pluginRegistryLock.Unlock()
+	if i := slices.Index(pluginRegistry, plugin); i >= 0 {
+		// Keep the fallback plugin last, also if generic plugins were registered before it
+		pluginRegistry = append(slices.Delete(pluginRegistry, i, i+1), plugin)
+	}
 	fallbackPlugin = plugin
 }
 
 // CheckVersionFile checks if version file is found
 func CheckVersionFile(plugin Plugin) bool {
+	// If plugin detects projects by another file, the version file may still be missing
+	if projectFile, ok := plugin.(ProjectFilePlugin); ok {
+		_, err := os.Stat(filepath.Join(ProjectPath, projectFile.RequiredFile()))
+		return !os.IsNotExist(err)
+	}
+
 	// If plugin supports multiple version files, detect the correct one for the current project
 	if versionFileNames := plugin.VersionFileNames(); len(versionFileNames) > 0 {
 		for _, versionFile := range versionFileNames {
@@ -191,11 +337,20 @@ func CheckVersionFile(plugin Plugin) bool {
 	return false
 }
 
+// PluginSettings returns the configuration group named after a plugin, e.g. 'go', which is empty if the
+// configuration has none.
+func PluginSettings(plugin Plugin) map[string]any {
+	if settings, ok := viper.AllSettings()[plugin.String()].(map[string]any); ok {
+		return settings
+	}
+	return map[string]any{}
+}
+
 // ValidateToolsAvailability Check if some tools are available in the system.
 func ValidateToolsAvailability(tools ...string) error {
 	for _, tool := range append(tools, Git) {
 		if _, err := exec.LookPath(tool); err != nil {
-			return fmt.Errorf("tool '%v' is not available on the system", tool)
+			return Error(MsgToolNotAvailable, tool)
 		}
 	}
 
@@ -213,6 +368,8 @@ func ResetBranchNames() {
 	branchNames[Development] = "develop"
 	branchNames[Release] = "release"
 	branchNames[Hotfix] = "hotfix"
+	branchNames[Bugfix] = "bugfix"
+	branchNames[Support] = "support"
 }
 
 // branchConfigKeys maps Branch constants to their config key names.
@@ -221,6 +378,8 @@ var branchConfigKeys = map[Branch]string{
 	Development: "development",
 	Release:     "release",
 	Hotfix:      "hotfix",
+	Bugfix:      "bugfix",
+	Support:     "support",
 }
 
 // ConfigKey returns the config key name for this branch type.
@@ -228,22 +387,137 @@ func (b Branch) ConfigKey() string {
 	return branchConfigKeys[b]
 }
 
-// Apply suitable settings from the global configuration to the core package.
-func applySettings() {
+// Apply suitable settings from the global configuration to the core package, and fail for invalid names.
+func applySettings() error {
 	all := viper.AllSettings()
 
+	// start from defaults so that settings of a previous run do not leak into this one
+	ResetBranchNames()
+	rollbackChanges = false
+	pushChanges = true
+	dryRun = false
+	DockerFallback = false
+	resolveVersionConflicts = true
+	pullStrategy = 0
+	fetchGitflowOnly = false
+	pruneRefs = true
+	pruneHint = true
+	releaseHeadTag = ""
+	resetTagPrefixSetting()
+	resetTagSettings()
+	resetCommitSettings()
+	resetProjectMetadata()
+	resetCleanIgnoreSetting()
+	resetDevelopmentBumpSetting()
+	resetStepsSetting()
+	resetPresetSettings()
+	resetVersionSchemeSetting()
+	resetLooseVersionsSetting()
+	loggingFlags = 0
+	resetSBOMSettings()
+	resetChangelogSettings()
+	resetProvenanceSettings()
+	resetVersionFileSettings()
+	resetAPICacheSettings()
+	resetAPIRetrySettings()
+	resetMetricsSettings()
+	resetHookSettings()
+	resetStaleSettings()
+	resetGitOpsSettings()
+	resetGitHubSettings()
+	resetGitLabSettings()
+	resetBitbucketSettings()
+
+	// settings of the legacy group apply unless they are set in the current groups, e.g. by a flag
+	if legacy, ok := all[legacyGroup].(map[string]any); ok {
+		applyBranchSettings(legacy)
+		applyWorkflowSettings(legacy)
+	}
+
+	// the tag and commit settings are part of the core group, e.g. 'core.tag.sign'
+	if legacy, ok := all[legacyGroup].(map[string]any); ok {
+		if tg, ok := legacy[tagGroup].(map[string]any); ok {
+			applyTagSettings(tg)
+		}
+		if cm, ok := legacy[commitGroup].(map[string]any); ok {
+			applyCommitSettings(cm)
+		}
+	}
+
 	if branches, ok := all[branchesGroup].(map[string]any); ok {
 		applyBranchSettings(branches)
-	} else if legacy, ok := all[legacyGroup].(map[string]any); ok {
-		applyBranchSettings(legacy)
 	}
 
 	if wf, ok := all[workflowGroup].(map[string]any); ok {
 		applyWorkflowSettings(wf)
-	} else if legacy, ok := all[legacyGroup].(map[string]any); ok {
-		applyWorkflowSettings(legacy)
 	}
 
+	// a recorded execution plan is always a dry run
+	if recordedPlan != nil {
+		dryRun = true
+	}
+
+	if sb, ok := all[sbomGroup].(map[string]any); ok {
+		applySBOMSettings(sb)
+	}
+
+	if cl, ok := all[changelogGroup].(map[string]any); ok {
+		applyChangelogSettings(cl)
+	}
+
+	if pv, ok := all[provenanceGroup].(map[string]any); ok {
+		applyProvenanceSettings(pv)
+	}
+
+	if vf, ok := all[versionFileGroup].(map[string]any); ok {
+		applyVersionFileSettings(vf)
+	}
+	applyFallbackVersionFileName()
+
+	if ac, ok := all[apiCacheGroup].(map[string]any); ok {
+		applyAPICacheSettings(ac)
+	}
+
+	if ar, ok := all[apiRetryGroup].(map[string]any); ok {
+		applyAPIRetrySettings(ar)
+	}
+
+	if mt, ok := all[metricsGroup].(map[string]any); ok {
+		applyMetricsSettings(mt)
+	}
+
+	if hk, ok := all[hooksGroup].(map[string]any); ok {
+		applyHookSettings(hk)
+	}
+
+	if sb, ok := all[staleGroup].(map[string]any); ok {
+		applyStaleSettings(sb)
+	}
+
+	if gt, ok := all[gitopsGroup].(map[string]any); ok {
+		applyGitOpsSettings(gt)
+	}
+
+	if gh, ok := all[githubGroup].(map[string]any); ok {
+		applyGitHubSettings(gh)
+	}
+	if gl, ok := all[gitlabGroup].(map[string]any); ok {
+		applyGitLabSettings(gl)
+	}
+	if bb, ok := all[bitbucketGroup].(map[string]any); ok {
+		applyBitbucketSettings(bb)
+	}
+
+	environments = nil
+	if list, ok := all[environmentsKey].([]any); ok {
+		for _, item := range list {
+			if name, ok := item.(string); ok && len(name) > 0 {
+				environments = append(environments, name)
+			}
+		}
+	}
+	applyPreset(all)
+
 	if v, ok := all[loggingKey].(string); ok {
 		applyLoggingSettings(v)
 	} else if legacy, ok := all[legacyGroup].(map[string]any); ok {
@@ -251,6 +525,8 @@ func applySettings() {
 			applyLoggingSettings(v)
 		}
 	}
+
+	return validateSettings(all)
 }
 
 func applyBranchSettings(settings map[string]any) {
@@ -274,9 +550,44 @@ func applyWorkflowSettings(settings map[string]any) {
 	if v, ok := settings[pushSetting].(bool); ok {
 		pushChanges = v
 	}
+	if v, ok := settings[dryRunSetting].(bool); ok {
+		dryRun = v
+	}
 	if v, ok := settings[dockerFallbackSetting].(bool); ok {
 		DockerFallback = v
 	}
+	if v, ok := settings[resolveVersionConflictsSetting].(bool); ok {
+		resolveVersionConflicts = v
+	}
+	if v, ok := settings[pullStrategySetting].(string); ok {
+		if v == pullDisabled {
+			pullStrategy = 0
+		}
+		for strategy, name := range pullStrategyNames {
+			if v == name {
+				pullStrategy = strategy
+			}
+		}
+	}
+	if v, ok := settings[fetchSetting].(string); ok && (v == fetchAllRemotes || v == fetchGitflow) {
+		fetchGitflowOnly = v == fetchGitflow
+	}
+	if v, ok := settings[pruneSetting].(bool); ok {
+		pruneRefs = v
+	}
+	if v, ok := settings[pruneHintSetting].(bool); ok {
+		pruneHint = v
+	}
+	if v, ok := settings[releaseHeadTagSetting].(string); ok {
+		releaseHeadTag = v
+	}
+	applyTagPrefixSetting(settings)
+	applyCleanIgnoreSetting(settings)
+	applyDevelopmentBumpSetting(settings)
+	applyStepsSetting(settings)
+	applyPresetSettings(settings)
+	applyVersionSchemeSetting(settings)
+	applyLooseVersionsSetting(settings)
 }
 
 func applyLoggingSettings(v string) {
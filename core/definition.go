@@ -0,0 +1,234 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// Workflow setting of the definitions of the workflow commands, keyed by the workflow, e.g. 'release-finish'. A
+// definition skips steps, moves steps and inserts steps which run a command:
+//
+//	steps:
+//	  release-finish:
+//	    skip: [merge-development]
+//	    move:
+//	      - step: generate-sbom
+//	        after: pre-tag-hook
+//	    insert:
+//	      - name: verify-artifacts
+//	        after: tag-release
+//	        run: ./verify.sh {version}
+const stepsSetting = "steps"
+
+// Keys of a workflow definition.
+const (
+	skipStepsKey   = "skip"
+	moveStepsKey   = "move"
+	insertStepsKey = "insert"
+	stepKey        = "step"
+	stepNameKey    = "name"
+	stepBeforeKey  = "before"
+	stepAfterKey   = "after"
+	stepRunKey     = "run"
+)
+
+// Workflows of the start commands, the finish commands are the workflow of their state with the suffix '-finish'.
+const (
+	releaseStartWorkflow = "release-start"
+	hotfixStartWorkflow  = "hotfix-start"
+)
+
+// Placeholder in the command of an inserted step that is replaced by the branch of the workflow.
+const branchPlaceholder = "{branch}"
+
+// Definitions of the workflow commands of the current run.
+var stepDefinitions map[string]any
+
+func applyStepsSetting(settings map[string]any) {
+	if v, ok := settings[stepsSetting].(map[string]any); ok {
+		stepDefinitions = v
+	}
+}
+
+func resetStepsSetting() {
+	stepDefinitions = nil
+}
+
+// customizeSteps applies the definition of a workflow to its steps: the steps to skip are removed first, then the
+// steps to move and the steps to insert are placed before or after a step in the order of the definition. Required
+// steps, e.g. the merges and tags of a finish, can neither be skipped nor moved, and other steps are only moved
+// among the steps between the same required steps, so that the workflow keeps its order. The inserted steps run
+// their command with the version and the branch of the workflow, which the context returns.
+func customizeSteps(workflow string, steps []workflowStep, repository Repository, context func() (version, branch string)) ([]workflowStep, error) {
+	definition, ok := stepDefinitions[workflow].(map[string]any)
+	if !ok {
+		return steps, nil
+	}
+	steps = slices.Clone(steps)
+
+	for _, name := range stringList(definition[skipStepsKey]) {
+		i, err := stepIndex(workflow, steps, name)
+		if err != nil {
+			return nil, err
+		}
+		if steps[i].required {
+			return nil, Error(MsgStepRequired, name, workflow)
+		}
+		steps = slices.Delete(steps, i, i+1)
+	}
+
+	for _, item := range definitionList(definition[moveStepsKey]) {
+		name, _ := item[stepKey].(string)
+		i, err := stepIndex(workflow, steps, name)
+		if err != nil {
+			return nil, err
+		}
+		if steps[i].required {
+			return nil, Error(MsgStepRequired, name, workflow)
+		}
+
+		step := steps[i]
+		steps = slices.Delete(steps, i, i+1)
+		at, err := anchorIndex(workflow, steps, name, item)
+		if err != nil {
+			return nil, err
+		}
+
+		// the steps between the old and the new place must not include a required step
+		between := steps[min(i, at):max(i, at)]
+		if crossed := slices.IndexFunc(between, func(s workflowStep) bool { return s.required }); crossed >= 0 {
+			return nil, Error(MsgStepMovedAcross, name, workflow, between[crossed].name)
+		}
+		steps = slices.Insert(steps, at, step)
+	}
+
+	for _, item := range definitionList(definition[insertStepsKey]) {
+		name, _ := item[stepNameKey].(string)
+		if len(name) == 0 {
+			return nil, Error(MsgStepInvalid, name, workflow, "missing name")
+		}
+		if slices.ContainsFunc(steps, func(s workflowStep) bool { return s.name == name }) {
+			return nil, Error(MsgStepInvalid, name, workflow, "the workflow already has a step of this name")
+		}
+
+		run, _ := item[stepRunKey].(string)
+		command := strings.Fields(run)
+		if len(command) == 0 {
+			return nil, Error(MsgStepInvalid, name, workflow, "missing command to run")
+		}
+
+		at, err := anchorIndex(workflow, steps, name, item)
+		if err != nil {
+			return nil, err
+		}
+		steps = slices.Insert(steps, at, commandStep(workflow, name, command, repository, context))
+	}
+
+	return steps, nil
+}
+
+// stepIndex returns the index of a named step of a workflow.
+func stepIndex(workflow string, steps []workflowStep, name string) (int, error) {
+	if i := slices.IndexFunc(steps, func(s workflowStep) bool { return s.name == name }); i >= 0 {
+		return i, nil
+	}
+
+	names := make([]string, 0, len(steps))
+	for _, step := range steps {
+		names = append(names, step.name)
+	}
+	return -1, Error(MsgStepUnknown, workflow, name, strings.Join(names, ", "))
+}
+
+// anchorIndex returns the index a moved or inserted step is placed at, which is before or after another step.
+func anchorIndex(workflow string, steps []workflowStep, name string, item map[string]any) (int, error) {
+	before, _ := item[stepBeforeKey].(string)
+	after, _ := item[stepAfterKey].(string)
+
+	switch {
+	case len(before) > 0 && len(after) == 0:
+		return stepIndex(workflow, steps, before)
+	case len(after) > 0 && len(before) == 0:
+		i, err := stepIndex(workflow, steps, after)
+		return i + 1, err
+	default:
+		return -1, Error(MsgStepInvalid, name, workflow, "place it either before or after a step")
+	}
+}
+
+// commandStep returns an inserted step, which runs its command in the local repository. A failed command keeps the
+// changes of the workflow, so that a finish command can be continued at the step once the cause is resolved.
+func commandStep(workflow, name string, command []string, repository Repository, context func() (string, string)) workflowStep {
+	return workflowStep{
+		name:        name,
+		description: "run " + strings.Join(command, " "),
+		execute: func() error {
+			version, branch := context()
+			return runStepCommand(workflow, name, command, repository, version, branch)
+		},
+	}
+}
+
+// runStepCommand runs the command of an inserted step. The command gets the version and the branch of the workflow
+// as placeholders and as GITFLOW_VERSION and GITFLOW_BRANCH environment variables, and fails the step by exiting with
+// a non-zero status.
+func runStepCommand(workflow, name string, command []string, repository Repository, version, branch string) error {
+	var err error
+	var run *exec.Cmd
+	var output []byte
+
+	if skipDryRun(repository, fmt.Sprintf("step '%v'", name)) {
+		return nil
+	}
+
+	// log human-readable description of the command
+	defer func() { Log(run, output, err) }()
+
+	replacer := strings.NewReplacer(versionPlaceholder, version, branchPlaceholder, branch)
+	args := make([]string, 0, len(command)-1)
+	for _, arg := range command[1:] {
+		args = append(args, replacer.Replace(arg))
+	}
+
+	run = exec.Command(command[0], args...)
+	run.Dir = repository.Local()
+	run.Env = append(os.Environ(), "GITFLOW_VERSION="+version, "GITFLOW_BRANCH="+branch)
+
+	if output, err = run.CombinedOutput(); err != nil {
+		return Error(MsgStepFailed, name, workflow, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// stringList returns the strings of a list setting.
+func stringList(value any) []string {
+	list, _ := value.([]any)
+	items := make([]string, 0, len(list))
+	for _, item := range list {
+		if v, ok := item.(string); ok && len(v) > 0 {
+			items = append(items, v)
+		}
+	}
+	return items
+}
+
+// definitionList returns the entries of a list setting of moved or inserted steps.
+func definitionList(value any) []map[string]any {
+	list, _ := value.([]any)
+	items := make([]map[string]any, 0, len(list))
+	for _, item := range list {
+		if v, ok := item.(map[string]any); ok {
+			items = append(items, v)
+		}
+	}
+	return items
+}
@@ -0,0 +1,303 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+)
+
+// openRepository enables access to the repository of a workflow command, which only prints the changing git
+// commands in dry-run mode.
+func openRepository(projectPath string) Repository {
+	real := NewRepository(projectPath, Remote).(*repository)
+	if !dryRun {
+		return real
+	}
+
+	fmt.Fprintln(statusOutput(), Message(MsgDryRunStarted, projectPath))
+	recordPlanRefs(real)
+	return &dryRunRepository{repository: real, versions: make(map[string]Version), bases: make(map[string]string)}
+}
+
+// openPlugin returns the plugin of a workflow command, which only prints version changes in dry-run mode, and records
+// it for the telemetry.
+func openPlugin(plugin Plugin, repository Repository) Plugin {
+	recordTelemetryPlugin(plugin)
+	if dry, ok := repository.(*dryRunRepository); ok {
+		return &dryRunPlugin{Plugin: plugin, repository: dry}
+	}
+	return syncVersionLocations(plugin)
+}
+
+// skipDryRun prints a step of the workflow which is skipped in dry-run mode, because it would change the repository
+// or depends on changes which have not been made.
+func skipDryRun(repository Repository, step string) bool {
+	if dry, ok := repository.(*dryRunRepository); ok {
+		recordPlanStep(PlanStep{Skip: step})
+		dry.plan(Message(MsgDryRunSkipped, step))
+		return true
+	}
+	return false
+}
+
+// dryRunRepository reads from the repository but prints the git commands that would change it. It simulates the
+// checked out branch and the versions written on each branch, so that the plan follows the workflow.
+type dryRunRepository struct {
+	*repository
+	branch   string
+	versions map[string]Version
+	bases    map[string]string
+}
+
+// plan prints a step of the execution plan.
+func (r *dryRunRepository) plan(step string) {
+	fmt.Fprintln(statusOutput(), Message(MsgDryRunStep, step))
+}
+
+// planGit prints and records a git command of the execution plan with arguments quoted as needed by a shell.
+func (r *dryRunRepository) planGit(args ...string) {
+	recordPlanStep(PlanStep{Git: args})
+
+	quoted := make([]string, 0, len(args)+1)
+	quoted = append(quoted, Git)
+	for _, arg := range args {
+		if strings.ContainsAny(arg, " '\"^{}") {
+			arg = strconv.Quote(arg)
+		}
+		quoted = append(quoted, arg)
+	}
+	r.plan(strings.Join(quoted, " "))
+}
+
+// CurrentBranch returns the simulated checked out branch.
+func (r *dryRunRepository) CurrentBranch() (string, error) {
+	if len(r.branch) == 0 {
+		return r.repository.CurrentBranch()
+	}
+	return r.branch, nil
+}
+
+// ref returns the reference holding the content of a simulated branch.
+func (r *dryRunRepository) ref(branchName string) string {
+	if base, ok := r.bases[branchName]; ok {
+		return base
+	}
+	if _, err := r.repository.ResolveRef("refs/heads/" + branchName); err == nil {
+		return branchName
+	}
+	return r.remote + "/" + branchName
+}
+
+func (r *dryRunRepository) switchTo(branchName string) {
+	current, _ := r.CurrentBranch()
+	if _, ok := r.bases[branchName]; !ok && len(current) > 0 && branchName != current {
+		if _, known := r.versions[branchName]; !known {
+			if _, err := r.repository.ResolveRef("refs/heads/" + branchName); err != nil {
+				r.bases[branchName] = r.remote + "/" + branchName
+			}
+		}
+	}
+	r.branch = branchName
+}
+
+func (r *dryRunRepository) Initialize(branchName string) error {
+	r.planGit(r.initRepository...)
+	return nil
+}
+
+func (r *dryRunRepository) CheckoutBranch(branchName string) error {
+	branchName = strings.TrimPrefix(branchName, r.remote+"/")
+	r.planGit(append(r.switchBranch, branchName)...)
+	r.switchTo(branchName)
+	return nil
+}
+
+func (r *dryRunRepository) CheckoutFile(fileName string, strategy CheckoutStrategy) error {
+	option := "--theirs"
+	if strategy == Ours {
+		option = "--ours"
+	}
+	r.planGit("checkout", option, fileName)
+	return nil
+}
+
+func (r *dryRunRepository) ContinueMerge() error {
+	r.planGit(commit, "--no-edit")
+	return nil
+}
+
+func (r *dryRunRepository) CreateBranch(branchName string) error {
+	r.planGit(append(r.createBranch, branchName)...)
+	current, _ := r.CurrentBranch()
+	r.bases[branchName] = r.ref(current)
+	if version, ok := r.versions[current]; ok {
+		r.versions[branchName] = version
+	}
+	r.branch = branchName
+	return nil
+}
+
+func (r *dryRunRepository) CreateBranchFrom(branchName, ref string) error {
+	r.planGit(append(r.createBranch, branchName, ref+"^{commit}")...)
+	r.bases[branchName] = ref
+	r.branch = branchName
+	return nil
+}
+
+// MergeBranch prints the merge, whose result is assumed to carry the version of the merged branch.
+func (r *dryRunRepository) MergeBranch(branchName string, mergeType MergeType) error {
+	option := map[MergeType]string{Squash: squash, NoFastForward: nofastforward, FastForward: fastforwad, FastForwardOrCommit: fastorcommit}[mergeType]
+	r.planGit(append(r.mergeBranch, option, branchName)...)
+	current, _ := r.CurrentBranch()
+	if version, ok := r.versions[branchName]; ok {
+		r.versions[current] = version
+	} else {
+		maps.DeleteFunc(r.versions, func(branchName string, _ Version) bool { return branchName == current })
+		r.bases[current] = r.ref(branchName)
+	}
+	return nil
+}
+
+func (r *dryRunRepository) PullBranch(branchName string, strategy PullStrategy) error {
+	option := map[PullStrategy]string{PullMerge: norebase, PullRebase: rebase, PullFastForwardOnly: fastforwad}[strategy]
+	r.planGit(append(r.pullBranch, option, r.remote, branchName)...)
+	return nil
+}
+
+func (r *dryRunRepository) DeleteBranch(branchName string) error {
+	r.planGit(append(r.deleteBranch, branchName)...)
+	return nil
+}
+
+// ResetBranch prints the move of a branch, which then carries the version of the reference.
+func (r *dryRunRepository) ResetBranch(branchName, ref string) error {
+	r.planGit(append(r.moveBranch, branchName, ref+"^{commit}")...)
+	maps.DeleteFunc(r.versions, func(name string, _ Version) bool { return name == branchName })
+	r.bases[branchName] = ref
+	return nil
+}
+
+func (r *dryRunRepository) WriteFile(fileName string, fileContent string) error {
+	recordPlanStep(PlanStep{Write: fileName})
+	r.plan(Message(MsgDryRunWriteFile, fileName))
+	return nil
+}
+
+func (r *dryRunRepository) AddFile(file string) error {
+	r.planGit(append(r.addFile, file)...)
+	return nil
+}
+
+func (r *dryRunRepository) CommitChanges(message string) error {
+	r.planGit(append(r.commitAll, commitMessageOf(message))...)
+	return nil
+}
+
+func (r *dryRunRepository) TagCommit(tagName string) error {
+	args, err := r.tagArgs(tagName)
+	if err != nil {
+		return err
+	}
+	r.planGit(args...)
+	return nil
+}
+
+func (r *dryRunRepository) TagRef(tagName, ref string) error {
+	args, err := r.tagArgs(tagName)
+	if err != nil {
+		return err
+	}
+	r.planGit(append(args, ref+"^{commit}")...)
+	return nil
+}
+
+func (r *dryRunRepository) FastForwardBranch(branchName string) error {
+	if current, _ := r.CurrentBranch(); current == branchName {
+		r.planGit(append(r.mergeBranch, fastforwad, r.remote+"/"+branchName)...)
+	} else {
+		r.planGit(append(r.fastForwardLocal, "refs/remotes/"+r.remote+"/"+branchName+":refs/heads/"+branchName)...)
+	}
+	return nil
+}
+
+func (r *dryRunRepository) Fetch() error {
+	r.planGit(r.fetchArgs()...)
+	return nil
+}
+
+func (r *dryRunRepository) PushChanges(branchName string) error {
+	r.planGit(append(r.pushBranch, branchName)...)
+	return nil
+}
+
+func (r *dryRunRepository) PushAllChanges() error {
+	r.planGit(r.pushAll...)
+	return nil
+}
+
+func (r *dryRunRepository) PushAllTags() error {
+	r.planGit(r.pushTags...)
+	return nil
+}
+
+func (r *dryRunRepository) PushTag(tagName string) error {
+	r.planGit(append(r.pushTag, "refs/tags/"+tagName)...)
+	return nil
+}
+
+func (r *dryRunRepository) PushDeletion(branchName string) error {
+	r.planGit(append(r.pushDeletion, branchName)...)
+	return nil
+}
+
+// Rollback has nothing to revert, because the repository has not been changed.
+func (r *dryRunRepository) Rollback(cause error) error {
+	return cause
+}
+
+// dryRunPlugin reads versions from the simulated branches and prints the version changes.
+type dryRunPlugin struct {
+	Plugin
+	repository *dryRunRepository
+}
+
+// ReadVersion returns the version written on the simulated branch before, or reads the version file of the branch.
+func (p *dryRunPlugin) ReadVersion(repository Repository) (Version, error) {
+	current, err := p.repository.CurrentBranch()
+	if err != nil {
+		return NoVersion, err
+	}
+
+	if version, ok := p.repository.versions[current]; ok {
+		return version, nil
+	}
+
+	// the checked out branch is read from the working tree
+	if checkedOut, err := p.repository.repository.CurrentBranch(); err == nil && checkedOut == current {
+		if _, based := p.repository.bases[current]; !based {
+			return p.Plugin.ReadVersion(p.repository.repository)
+		}
+	}
+
+	// other branches are read from a copy of their version file
+	return readVersionAt(p.Plugin, p.repository, p.repository.ref(current))
+}
+
+// WriteVersion prints the version change and remembers the version for the simulated branch.
+func (p *dryRunPlugin) WriteVersion(repository Repository, version Version) error {
+	current, err := p.repository.CurrentBranch()
+	if err != nil {
+		return err
+	}
+
+	recordPlanStep(PlanStep{Branch: current, Version: version.String(), File: p.VersionFileName()})
+	p.repository.plan(Message(MsgDryRunSetVersion, version, p.VersionFileName(), p.Plugin))
+	p.repository.versions[current] = version
+	return nil
+}
@@ -0,0 +1,193 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Configuration group of the GitHub integration.
+const githubGroup = "github"
+
+// Environment variable of the GitHub API, which GitHub Actions set for GitHub Enterprise Server.
+const githubAPIEnv = "GITHUB_API_URL"
+
+// GitHub settings of the current run.
+var githubSettings = defaultHostingSettings()
+
+func applyGitHubSettings(settings map[string]any) {
+	githubSettings.apply(settings)
+}
+
+func resetGitHubSettings() {
+	githubSettings = defaultHostingSettings()
+}
+
+// githubToken returns the token of the GitHub API from GITHUB_TOKEN, or from 'github.token' otherwise.
+func githubToken() string {
+	if token := os.Getenv(githubTokenEnv); len(token) > 0 {
+		return token
+	}
+	return githubSettings.token
+}
+
+// githubAPI returns the URL of the GitHub API from GITHUB_API_URL, which GitHub Actions set for GitHub Enterprise
+// Server, or from 'github.api-url', and the API of github.com otherwise.
+func githubAPI() string {
+	if api := os.Getenv(githubAPIEnv); len(api) > 0 {
+		return api
+	}
+	if len(githubSettings.apiURL) > 0 {
+		return githubSettings.apiURL
+	}
+	return defaultGitHubAPI
+}
+
+// githubPullRequest is the state of a pull request of the GitHub API.
+type githubPullRequest struct {
+	State          string `json:"state"`
+	Merged         bool   `json:"merged"`
+	MergeCommitSHA string `json:"merge_commit_sha"`
+}
+
+// fetchGitHubPullRequest reads the state of a pull request.
+func fetchGitHubPullRequest(api, name, token string, number int) (githubPullRequest, error) {
+	var pullRequest githubPullRequest
+
+	url := fmt.Sprintf("%v/repos/%v/pulls/%v", strings.TrimSuffix(api, "/"), name, number)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return pullRequest, err
+	}
+
+	request.Header.Set("Accept", "application/vnd.github+json")
+	request.Header.Set("Authorization", "Bearer "+token)
+
+	response, err := apiClient.Do(request)
+	if err != nil {
+		return pullRequest, err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode != http.StatusOK {
+		return pullRequest, fmt.Errorf("status %v", response.Status)
+	}
+
+	if err := json.NewDecoder(response.Body).Decode(&pullRequest); err != nil {
+		return pullRequest, err
+	}
+
+	return pullRequest, nil
+}
+
+// githubProvider is the GitHub API of a repository, authenticated with GITHUB_TOKEN or 'github.token'.
+type githubProvider struct {
+	repository string
+}
+
+func (p *githubProvider) name() string {
+	return "GitHub"
+}
+
+func (p *githubProvider) group() string {
+	return githubGroup
+}
+
+func (p *githubProvider) settings() hostingSettings {
+	return githubSettings
+}
+
+func (p *githubProvider) term() string {
+	return Message(MsgPullRequestTerm)
+}
+
+func (p *githubProvider) reference(number int) string {
+	return fmt.Sprintf("#%v", number)
+}
+
+func (p *githubProvider) tokenSources() (bool, string) {
+	return len(githubToken()) > 0, fmt.Sprintf("%v or '%v.%v'", githubTokenEnv, githubGroup, hostingTokenSetting)
+}
+
+func (p *githubProvider) releases() bool {
+	return true
+}
+
+func (p *githubProvider) draftReleases() bool {
+	return true
+}
+
+func (p *githubProvider) openPullRequest(branchName, base, title, description string) (int, error) {
+	return createGitHubPullRequest(githubAPI(), p.repository, githubToken(), branchName, base, title, description)
+}
+
+func (p *githubProvider) pullRequest(number int) (pullRequestState, error) {
+	pullRequest, err := fetchGitHubPullRequest(githubAPI(), p.repository, githubToken(), number)
+	if err != nil {
+		return pullRequestState{}, err
+	}
+	return pullRequestState{
+		merged:      pullRequest.Merged,
+		closed:      pullRequest.State == "closed",
+		mergeCommit: pullRequest.MergeCommitSHA,
+	}, nil
+}
+
+func (p *githubProvider) createRelease(tagName, title, notes string, draft bool) (string, error) {
+	return createGitHubRelease(githubAPI(), p.repository, githubToken(), tagName, title, notes, draft)
+}
+
+// createGitHubRelease creates the release of an existing tag and returns its web link.
+func createGitHubRelease(api, name, token, tagName, title, notes string, draft bool) (string, error) {
+	body, err := json.Marshal(map[string]any{
+		"tag_name": tagName,
+		"name":     title,
+		"body":     notes,
+		"draft":    draft,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%v/repos/%v/releases", strings.TrimSuffix(api, "/"), name)
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	request.Header.Set("Accept", "application/vnd.github+json")
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+token)
+
+	response, err := apiClient.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("status %v", response.Status)
+	}
+
+	var release struct {
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&release); err != nil {
+		return "", err
+	}
+
+	return release.HTMLURL, nil
+}
+
+// restrictedBranch reports no branch, because GitHub rejects the push of a protected branch by itself.
+func (p *githubProvider) restrictedBranch([]string) (string, error) {
+	return "", nil
+}
@@ -0,0 +1,210 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Configuration group of the GitLab integration.
+const gitlabGroup = "gitlab"
+
+// Environment variable of the GitLab API, which GitLab CI sets, e.g. 'https://gitlab.example.com/api/v4'.
+const gitlabAPIEnv = "CI_API_V4_URL"
+
+// Default GitLab server, whose API is used for remotes on other hosts outside of GitLab CI.
+const defaultGitLabServer = "https://gitlab.com"
+
+// Header of the GitLab API for the job token of GitLab CI, access tokens are sent as bearer tokens.
+const gitlabJobTokenHeader = "JOB-TOKEN"
+
+// GitLab settings of the current run.
+var gitlabSettings = defaultHostingSettings()
+
+func applyGitLabSettings(settings map[string]any) {
+	gitlabSettings.apply(settings)
+}
+
+func resetGitLabSettings() {
+	gitlabSettings = defaultHostingSettings()
+}
+
+// gitlabToken returns the header and the value of the credentials of the GitLab API: a personal, project or group
+// access token from GITLAB_TOKEN or 'gitlab.token', or the job token of GitLab CI from CI_JOB_TOKEN otherwise.
+func gitlabToken() (string, string) {
+	if token := os.Getenv(gitlabTokenEnv); len(token) > 0 {
+		return "Authorization", "Bearer " + token
+	}
+	if len(gitlabSettings.token) > 0 {
+		return "Authorization", "Bearer " + gitlabSettings.token
+	}
+	if token := os.Getenv(gitlabJobTokenEnv); len(token) > 0 {
+		return gitlabJobTokenHeader, token
+	}
+	return "", ""
+}
+
+// gitlabProvider is the GitLab API of a project, authenticated with GITLAB_TOKEN, 'gitlab.token' or CI_JOB_TOKEN.
+type gitlabProvider struct {
+	api     string
+	project string
+}
+
+// newGitLabProvider returns the API of a project, e.g. 'group/project', on a GitLab server. The API is read from
+// CI_API_V4_URL, which GitLab CI sets, or from 'gitlab.api-url', and derived from the server otherwise.
+func newGitLabProvider(project, server string) *gitlabProvider {
+	api := os.Getenv(gitlabAPIEnv)
+	if len(api) == 0 {
+		api = gitlabSettings.apiURL
+	}
+	if len(api) == 0 {
+		if len(server) == 0 {
+			server = defaultGitLabServer
+		}
+		api = strings.TrimSuffix(server, "/") + "/api/v4"
+	}
+
+	return &gitlabProvider{api: strings.TrimSuffix(api, "/"), project: project}
+}
+
+func (p *gitlabProvider) name() string {
+	return "GitLab"
+}
+
+func (p *gitlabProvider) group() string {
+	return gitlabGroup
+}
+
+func (p *gitlabProvider) settings() hostingSettings {
+	return gitlabSettings
+}
+
+func (p *gitlabProvider) term() string {
+	return Message(MsgMergeRequestTerm)
+}
+
+func (p *gitlabProvider) reference(number int) string {
+	return fmt.Sprintf("!%v", number)
+}
+
+func (p *gitlabProvider) tokenSources() (bool, string) {
+	header, _ := gitlabToken()
+	return len(header) > 0, fmt.Sprintf("%v, '%v.%v' or %v", gitlabTokenEnv, gitlabGroup, hostingTokenSetting, gitlabJobTokenEnv)
+}
+
+func (p *gitlabProvider) releases() bool {
+	return true
+}
+
+// draftReleases reports false, because GitLab releases are published when they are created.
+func (p *gitlabProvider) draftReleases() bool {
+	return false
+}
+
+func (p *gitlabProvider) openPullRequest(branchName, base, title, description string) (int, error) {
+	var mergeRequest struct {
+		IID int `json:"iid"`
+	}
+
+	err := p.call(http.MethodPost, "merge_requests", map[string]any{
+		"source_branch": branchName,
+		"target_branch": base,
+		"title":         title,
+		"description":   description,
+	}, http.StatusCreated, &mergeRequest)
+
+	return mergeRequest.IID, err
+}
+
+func (p *gitlabProvider) pullRequest(number int) (pullRequestState, error) {
+	var mergeRequest struct {
+		State           string `json:"state"`
+		MergeCommitSHA  string `json:"merge_commit_sha"`
+		SquashCommitSHA string `json:"squash_commit_sha"`
+		SHA             string `json:"sha"`
+	}
+
+	if err := p.call(http.MethodGet, fmt.Sprintf("merge_requests/%v", number), nil, http.StatusOK, &mergeRequest); err != nil {
+		return pullRequestState{}, err
+	}
+
+	// fast-forward merges have no merge commit, but put the squashed or the last commit onto the target branch
+	mergeCommit := mergeRequest.MergeCommitSHA
+	if len(mergeCommit) == 0 {
+		mergeCommit = mergeRequest.SquashCommitSHA
+	}
+	if len(mergeCommit) == 0 {
+		mergeCommit = mergeRequest.SHA
+	}
+
+	return pullRequestState{
+		merged:      mergeRequest.State == "merged",
+		closed:      mergeRequest.State == "closed" || mergeRequest.State == "locked",
+		mergeCommit: mergeCommit,
+	}, nil
+}
+
+func (p *gitlabProvider) createRelease(tagName, title, notes string, _ bool) (string, error) {
+	var release struct {
+		Links struct {
+			Self string `json:"self"`
+		} `json:"_links"`
+	}
+
+	err := p.call(http.MethodPost, "releases", map[string]any{
+		"tag_name":    tagName,
+		"name":        title,
+		"description": notes,
+	}, http.StatusCreated, &release)
+
+	return release.Links.Self, err
+}
+
+// call sends a request to a resource of the project and decodes the response, which must have the expected status.
+func (p *gitlabProvider) call(method, resource string, payload map[string]any, status int, result any) error {
+	var body []byte
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return err
+		}
+	}
+
+	address := fmt.Sprintf("%v/projects/%v/%v", p.api, url.PathEscape(p.project), resource)
+	request, err := http.NewRequest(method, address, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	header, credentials := gitlabToken()
+	request.Header.Set(header, credentials)
+	if payload != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := apiClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode != status {
+		return fmt.Errorf("status %v", response.Status)
+	}
+
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+// restrictedBranch reports no branch, because GitLab rejects the push of a protected branch by itself.
+func (p *gitlabProvider) restrictedBranch([]string) (string, error) {
+	return "", nil
+}
@@ -0,0 +1,195 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"net/mail"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// GitOps settings keys of the deployment manifests, which release finish updates to the released version in a
+// separate repository, e.g. the image tag in the values of a Helm release of Argo CD.
+const (
+	gitopsGroup             = "gitops"
+	gitopsRepositorySetting = "repository"
+	gitopsBranchSetting     = "branch"
+	gitopsPathSetting       = "path"
+	gitopsPatternSetting    = "pattern"
+)
+
+// Default branch of the GitOps repository the pull request of the manifest update targets.
+const defaultGitOpsBranch = "main"
+
+// Prefix of the branch of the pull request with the manifest update, e.g. 'deploy/my-service/1.2.0'.
+const deployBranchPrefix = "deploy/"
+
+// Remote of the clone of the GitOps repository.
+const gitopsRemote = "origin"
+
+var gitopsRepository = ""
+var gitopsBranch = defaultGitOpsBranch
+var gitopsPath = ""
+var gitopsPattern = ""
+
+func applyGitOpsSettings(settings map[string]any) {
+	if v, ok := settings[gitopsRepositorySetting].(string); ok {
+		gitopsRepository = v
+	}
+	if v, ok := settings[gitopsBranchSetting].(string); ok && len(v) > 0 {
+		gitopsBranch = v
+	}
+	if v, ok := settings[gitopsPathSetting].(string); ok {
+		gitopsPath = v
+	}
+	if v, ok := settings[gitopsPatternSetting].(string); ok {
+		gitopsPattern = v
+	}
+}
+
+func resetGitOpsSettings() {
+	gitopsRepository = ""
+	gitopsBranch = defaultGitOpsBranch
+	gitopsPath = ""
+	gitopsPattern = ""
+}
+
+// gitopsEnabled reports whether release finish updates the deployment manifests of a GitOps repository.
+func gitopsEnabled() bool {
+	return len(gitopsRepository) > 0
+}
+
+// validateGitOpsSettings fails for a manifest outside of the GitOps repository, for a branch git does not accept
+// and for a pattern without group for the version, so that the release does not fail after it has been tagged.
+func validateGitOpsSettings() error {
+	if !gitopsEnabled() {
+		return nil
+	}
+
+	if err := checkRefName(gitopsBranch); err != nil {
+		return Error(MsgInvalidRefSetting, gitopsGroup+"."+gitopsBranchSetting, gitopsBranch)
+	}
+
+	if !filepath.IsLocal(gitopsPath) {
+		return Error(MsgInvalidPathSetting, gitopsGroup+"."+gitopsPathSetting, gitopsPath)
+	}
+
+	if expression, err := regexp.Compile(gitopsPattern); err != nil || len(gitopsPattern) == 0 || expression.NumSubexp() == 0 {
+		return Error(MsgGitOpsPattern, gitopsGroup+"."+gitopsPatternSetting, gitopsPattern)
+	}
+
+	return nil
+}
+
+// deployBranchName returns the branch of the pull request with the manifest update of a project and version.
+func deployBranchName(project string, version Version) string {
+	return fmt.Sprintf("%v%v/%v", deployBranchPrefix, project, version)
+}
+
+// updateManifests clones the GitOps repository, replaces the version of the manifest by the released version and
+// pushes the change to a branch of its own, whose pull request into the configured branch is opened via the GitHub
+// API, or reported if no GITHUB_TOKEN is set, so that the deployment is reviewed like any other change.
+func updateManifests(repository Repository, release Version) error {
+	if skipDryRun(repository, fmt.Sprintf("update of '%v' in '%v' to %v", gitopsPath, gitopsRepository, release)) {
+		return nil
+	}
+
+	directory, err := ScratchDir("gitops")
+	if err != nil {
+		return err
+	}
+
+	// a shallow clone of the target branch suffices for a commit on top of it
+	clone := filepath.Join(directory, "repository")
+	if err := os.RemoveAll(clone); err != nil {
+		return err
+	}
+	identity, err := commitIdentity(repository)
+	if err != nil {
+		return err
+	}
+	if err := cloneRepository(gitopsRepository, gitopsBranch, clone, identity); err != nil {
+		return Error(MsgGitOpsFailed, gitopsRepository, err)
+	}
+	manifests := NewRepository(clone, gitopsRemote)
+
+	project := filepath.Base(repository.Local())
+	branchName := deployBranchName(project, release)
+	if err := manifests.CreateBranch(branchName); err != nil {
+		return Error(MsgGitOpsFailed, gitopsRepository, err)
+	}
+
+	// the version of the manifest is the first group of the pattern, like the version locations of the project
+	location := versionLocation{
+		file:    filepath.ToSlash(filepath.Clean(gitopsPath)),
+		pattern: regexp.MustCompile(gitopsPattern),
+		setting: gitopsGroup + "." + gitopsPathSetting,
+	}
+	if err := location.write(manifests, NoVersion, release); err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("Deploy %v version %v.", project, release)
+	if err := manifests.CommitChanges(subject); err != nil {
+		return Error(MsgGitOpsFailed, gitopsRepository, err)
+	}
+
+	if err := manifests.PushChanges(branchName); err != nil {
+		return Error(MsgGitOpsFailed, gitopsRepository, err)
+	}
+
+	token := githubToken()
+	if len(token) == 0 {
+		fmt.Fprintln(os.Stderr, Message(MsgGitOpsOpenManually, branchName, gitopsBranch, gitopsRepository))
+		return nil
+	}
+
+	body := fmt.Sprintf("Deployment of the release %v of %v, merge to update '%v'.", release, project, gitopsPath)
+	number, err := createGitHubPullRequest(githubAPI(), githubRepository(gitopsRepository), token, branchName, gitopsBranch, subject, body)
+	if err != nil {
+		return Error(MsgBumpPullRequestFailed, Message(MsgPullRequestTerm), branchName, gitopsBranch, err)
+	}
+
+	fmt.Fprintln(os.Stderr, Message(MsgGitOpsPullRequest, number, branchName, gitopsBranch, gitopsRepository))
+	return nil
+}
+
+// commitIdentity returns the name and email of the commit identity of the project, which the commits in the clone
+// of the GitOps repository get as well, e.g. 'Name <email>' of 'Name <email> 1700000000 +0100'.
+func commitIdentity(repository Repository) (*mail.Address, error) {
+	ident, err := repository.Identity()
+	if err != nil {
+		return nil, Error(MsgIdentityMissing, repository.Local(), err)
+	}
+
+	if end := strings.LastIndex(ident, ">"); end >= 0 {
+		ident = ident[:end+1]
+	}
+	return mail.ParseAddress(ident)
+}
+
+// cloneRepository clones the branch of a repository without history into a directory, whose configuration holds
+// the commit identity.
+func cloneRepository(url, branchName, directory string, identity *mail.Address) error {
+	var err error
+	var clone *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(clone, output, err) }()
+
+	clone = exec.Command(Git, "clone", "--quiet", "--depth", "1", "--branch", branchName, "--origin", gitopsRemote,
+		"--config", "user.name="+identity.Name, "--config", "user.email="+identity.Address, "--", url, directory)
+	if output, err = clone.CombinedOutput(); err != nil {
+		return fmt.Errorf("git '%v' failed with %v: %s", clone, err, output)
+	}
+
+	return nil
+}
@@ -5,6 +5,8 @@ SPDX-License-Identifier: MIT
 
 package core
 
+import "fmt"
+
 // HookType defines the different hook types
 type HookType string
 
@@ -17,6 +19,15 @@ var ReleaseStartHooks = struct {
 	AfterUpdateProjectVersionHook: "ReleaseStart_AfterUpdateProjectVersionHook",
 }
 
+// ReleaseFinishHooks groups all hooks for the ReleaseFinish workflow
+var ReleaseFinishHooks = struct {
+	AfterUpdateProjectVersionHook HookType
+	AfterUpdateChangelogHook      HookType
+}{
+	AfterUpdateProjectVersionHook: "ReleaseFinish_AfterUpdateProjectVersionHook",
+	AfterUpdateChangelogHook:      "ReleaseFinish_AfterUpdateChangelogHook",
+}
+
 // HotfixStartHooks groups all hooks for the HotfixStart workflow
 var HotfixStartHooks = struct {
 	BeforeHotfixStartHook HookType
@@ -57,6 +68,10 @@ func (r *HookRegistry) RegisterHook(pluginName string, hookType HookType, hookFu
 // ExecuteHook runs a hook if it is registered for the specified plugin
 func (r *HookRegistry) ExecuteHook(plugin Plugin, hookType HookType, repository Repository) error {
 	if hookFunction, ok := r.hooks[hookType][plugin.String()]; ok {
+		// hooks change the working tree directly, which a dry run must not do
+		if skipDryRun(repository, fmt.Sprintf("%v hook '%v'", plugin, hookType)) {
+			return nil
+		}
 		return hookFunction(repository)
 	}
 	return nil
@@ -0,0 +1,189 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Hosting services whose web links are generated from the remote URL.
+const (
+	hostingGitHub    = "github"
+	hostingGitLab    = "gitlab"
+	hostingBitbucket = "bitbucket"
+	hostingAzure     = "azure"
+)
+
+// Remote URL in the scp-like syntax of ssh, e.g. 'git@github.com:owner/repo.git'.
+var scpRemoteExpression = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+
+// Links are the web links of the hosting service to the branch, tag and changes of a command.
+type Links struct {
+	Repository string `json:"repository"`
+	Branch     string `json:"branch,omitempty"`
+	Tag        string `json:"tag,omitempty"`
+	Compare    string `json:"compare,omitempty"`
+}
+
+// hostedRepository is the web location of a repository on a hosting service, e.g. 'https://github.com/owner/repo'.
+type hostedRepository struct {
+	hosting string
+	scheme  string
+	host    string
+	path    string
+}
+
+// parseRemoteURL parses a https or ssh remote URL of GitHub, GitLab, Bitbucket or Azure DevOps, including their
+// self-hosted instances whose host names tell the hosting service, e.g. 'gitlab.example.com'. The credentials of
+// the URL are dropped. Other remotes, e.g. local paths, are not hosted.
+func parseRemoteURL(remoteURL string) (hostedRepository, bool) {
+	hosted := hostedRepository{scheme: "https"}
+
+	if parsed, err := url.Parse(remoteURL); err == nil && len(parsed.Scheme) > 0 && len(parsed.Host) > 0 {
+		// the web interface of an ssh remote is served without the port of the ssh daemon
+		hosted.host, hosted.path = parsed.Hostname(), parsed.Path
+		if parsed.Scheme == "http" || parsed.Scheme == "https" {
+			hosted.scheme, hosted.host = parsed.Scheme, parsed.Host
+		}
+	} else if matches := scpRemoteExpression.FindStringSubmatch(remoteURL); matches != nil && len(matches[1]) > 1 {
+		hosted.host, hosted.path = matches[1], matches[2]
+	} else {
+		return hostedRepository{}, false
+	}
+
+	hosted.path = strings.Trim(strings.TrimSuffix(strings.Trim(hosted.path, "/"), ".git"), "/")
+	host := strings.ToLower(hosted.host)
+
+	switch {
+	case host == "ssh.dev.azure.com" || host == "vs-ssh.visualstudio.com":
+		// ssh remotes of Azure DevOps are 'v3/<organization>/<project>/<repository>'
+		segments := strings.Split(hosted.path, "/")
+		if len(segments) != 4 || segments[0] != "v3" {
+			return hostedRepository{}, false
+		}
+		hosted.hosting, hosted.host = hostingAzure, "dev.azure.com"
+		hosted.path = strings.Join([]string{segments[1], segments[2], "_git", segments[3]}, "/")
+
+	case host == "dev.azure.com" || strings.HasSuffix(host, ".visualstudio.com"):
+		if !strings.Contains(hosted.path, "/_git/") {
+			return hostedRepository{}, false
+		}
+		hosted.hosting = hostingAzure
+
+	case strings.Contains(host, hostingGitHub):
+		hosted.hosting = hostingGitHub
+	case strings.Contains(host, hostingGitLab):
+		hosted.hosting = hostingGitLab
+	case strings.Contains(host, hostingBitbucket):
+		hosted.hosting = hostingBitbucket
+
+	default:
+		return hostedRepository{}, false
+	}
+
+	// owner and name, or the groups of GitLab, precede the repository
+	if !strings.Contains(hosted.path, "/") {
+		return hostedRepository{}, false
+	}
+	return hosted, true
+}
+
+// remoteRepository returns the hosted repository of the remote of a repository.
+func remoteRepository(repository Repository) (hostedRepository, bool) {
+	remoteURL, err := repository.RemoteURL()
+	if err != nil {
+		return hostedRepository{}, false
+	}
+	return parseRemoteURL(remoteURL)
+}
+
+// web returns the web link of the repository.
+func (h hostedRepository) web() string {
+	return fmt.Sprintf("%v://%v/%v", h.scheme, h.host, h.path)
+}
+
+// branchLink returns the web link of a branch, e.g. 'https://github.com/owner/repo/tree/release/1.2.0'.
+func (h hostedRepository) branchLink(branchName string) string {
+	switch h.hosting {
+	case hostingGitLab:
+		return h.web() + "/-/tree/" + branchName
+	case hostingBitbucket:
+		return h.web() + "/src/" + branchName
+	case hostingAzure:
+		return h.web() + "?version=GB" + url.QueryEscape(branchName)
+	default:
+		return h.web() + "/tree/" + branchName
+	}
+}
+
+// tagLink returns the web link of a tag, e.g. 'https://github.com/owner/repo/releases/tag/1.2.0'.
+func (h hostedRepository) tagLink(tagName string) string {
+	switch h.hosting {
+	case hostingGitLab:
+		return h.web() + "/-/tags/" + tagName
+	case hostingBitbucket:
+		return h.web() + "/src/" + tagName
+	case hostingAzure:
+		return h.web() + "?version=GT" + url.QueryEscape(tagName)
+	default:
+		return h.web() + "/releases/tag/" + tagName
+	}
+}
+
+// compareLink returns the web link of the changes between two branches, or two tags, e.g.
+// 'https://github.com/owner/repo/compare/1.1.0...1.2.0'.
+func (h hostedRepository) compareLink(from, to string, tags bool) string {
+	switch h.hosting {
+	case hostingGitLab:
+		return h.web() + "/-/compare/" + from + "..." + to
+	case hostingBitbucket:
+		return h.web() + "/branches/compare/" + to + "%0D" + from
+	case hostingAzure:
+		kind := "GB"
+		if tags {
+			kind = "GT"
+		}
+		return fmt.Sprintf("%v/branchCompare?baseVersion=%v%v&targetVersion=%v%v",
+			h.web(), kind, url.QueryEscape(from), kind, url.QueryEscape(to))
+	default:
+		return h.web() + "/compare/" + from + "..." + to
+	}
+}
+
+// resultLinks returns the web links of the branch or tag of a command: the changes of a tagged release since the
+// previous release, or the changes of a branch compared to the production branch. Repositories without hosted
+// remote do not have links.
+func resultLinks(branchName, tagName string) *Links {
+	repository := NewRepository(ProjectPath, Remote)
+	hosted, ok := remoteRepository(repository)
+	if !ok {
+		return nil
+	}
+
+	links := Links{Repository: hosted.web()}
+	switch {
+	case len(tagName) > 0:
+		links.Tag = hosted.tagLink(tagName)
+		if release, ok := releaseTag(tagName); ok {
+			if tagNames, err := repository.Tags(); err == nil {
+				if previous := previousReleaseTag(tagNames, release); len(previous) > 0 {
+					links.Compare = hosted.compareLink(previous, tagName, true)
+				}
+			}
+		}
+
+	case len(branchName) > 0:
+		links.Branch = hosted.branchLink(branchName)
+		if branchName != Production.String() {
+			links.Compare = hosted.compareLink(Production.String(), branchName, false)
+		}
+	}
+
+	return &links
+}
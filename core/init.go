@@ -0,0 +1,170 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ProjectConfigFileName is the name of the project-local configuration file in the root of the repository.
+const ProjectConfigFileName = ".gitflow-cli.yaml"
+
+// Initial project-local configuration with the branch names of the repository.
+const projectConfigTemplate = `branches:
+  production: %v
+  development: %v
+  release: %v
+  hotfix: %v
+  bugfix: %v
+  support: %v
+
+workflow:
+  push: true
+  rollback: false
+  docker-fallback: true
+`
+
+// Init bootstraps a repository for the workflow: it creates the development branch if missing, writes the
+// project-local configuration, optionally creates the version file, and pushes everything to the remote.
+func Init(createVersionFile bool, projectPath string) error {
+	pluginRegistryLock.Lock()
+	defer pluginRegistryLock.Unlock()
+
+	// apply suitable settings from the global configuration to the core package
+	if err := applySettings(); err != nil {
+		return err
+	}
+
+	// set path to execute plugin detection and workflow commands
+	ProjectPath = projectPath
+
+	// check if project path exists
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return Error(MsgProjectPathMissing, projectPath)
+	}
+
+	// the plugin of the project creates the version file, the fallback plugin if no version file exists yet
+	var plugin Plugin
+	for _, candidate := range pluginRegistry {
+		if CheckVersionFile(candidate) {
+			plugin = candidate
+			break
+		}
+	}
+	if plugin == nil {
+		plugin = fallbackPlugin
+	}
+
+	// check if required tools are available
+	if createVersionFile {
+		if err := ValidateToolsAvailability(plugin.RequiredTools()...); err != nil {
+			return err
+		}
+	}
+
+	repository := openRepository(projectPath)
+	plugin = openPlugin(plugin, repository)
+
+	// check if the repository prerequisites are met
+	if err := repository.IsClean(); err != nil {
+		return err
+	}
+
+	// check if the initial commit gets the same identity as manual commits in the project path
+	if err := checkIdentity(repository); err != nil {
+		return err
+	}
+
+	// ensure production branch exists as base of the development branch
+	if err := syncBranches(repository, Production); err != nil {
+		return err
+	}
+
+	// format init command messages
+	called := Message(MsgInitCalled, repository.Local())
+	completed := Message(MsgInitCompleted, repository.Local())
+	failed := Message(MsgInitFailed, repository.Local())
+
+	Progress(called)
+
+	if err := initRepository(plugin, repository, createVersionFile); err != nil {
+		Failure(failed)
+		return err
+	}
+
+	Success(completed)
+	return nil
+}
+
+func initRepository(plugin Plugin, repository Repository, createVersionFile bool) error {
+	var added []string
+
+	// create the development branch from the production branch if the remote does not have it
+	if found, _, err := repository.HasBranch(Development); err != nil {
+		return err
+	} else if !found {
+		if err := checkoutBranch(repository, Production.String()); err != nil {
+			return err
+		}
+
+		if err := repository.CreateBranch(Development.String()); err != nil {
+			return repository.Rollback(err)
+		}
+
+		fmt.Fprintln(os.Stderr, Message(MsgInitBranchCreated, Development, Production))
+	} else if err := checkoutBranch(repository, Development.String()); err != nil {
+		return err
+	}
+
+	// write the project-local configuration with the branch names in use, but never overwrite it
+	configPath := filepath.Join(repository.Local(), ProjectConfigFileName)
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		content := fmt.Sprintf(projectConfigTemplate, Production, Development, Release, Hotfix, Bugfix, Support)
+		if err := repository.WriteFile(ProjectConfigFileName, content); err != nil {
+			return repository.Rollback(err)
+		}
+
+		added = append(added, ProjectConfigFileName)
+	}
+
+	// create the version file with the initial development version if the project does not have a version yet
+	if createVersionFile {
+		if _, err := plugin.ReadVersion(repository); err != nil {
+			initial, err := InitialVersion()
+			if err != nil {
+				return err
+			}
+
+			if err := plugin.WriteVersion(repository, initial.AddQualifier(Qualifier(plugin, Development))); err != nil {
+				return repository.Rollback(err)
+			}
+
+			added = append(added, plugin.VersionFileName())
+		}
+	}
+
+	// commit the new files on the development branch
+	if len(added) > 0 {
+		for _, file := range added {
+			if err := repository.AddFile(file); err != nil {
+				return repository.Rollback(err)
+			}
+		}
+
+		if err := repository.CommitChanges("Initialize gitflow-cli configuration."); err != nil {
+			return repository.Rollback(err)
+		}
+	}
+
+	// push all branches to remotes
+	if err := pushIfEnabled(repository.PushAllChanges); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Issue tracker settings keys.
+const (
+	trackerGroup             = "tracker"
+	trackerTypeSetting       = "type"
+	trackerURLSetting        = "url"
+	trackerRepositorySetting = "repository"
+)
+
+// Supported issue trackers.
+const (
+	trackerJira   = "jira"
+	trackerGitHub = "github"
+)
+
+// Environment variables with the credentials of the issue trackers.
+const (
+	jiraUserEnv  = "JIRA_USER"
+	jiraTokenEnv = "JIRA_TOKEN"
+)
+
+// Maximum length of the branch description derived from an issue.
+const issueDescriptionLength = 60
+
+// IssueKey is the key of the issue a release or hotfix branch is started for, e.g. 'PROJ-123' or '#42'.
+var IssueKey string
+
+// Issue keys of JIRA (PROJ-123) and GitHub (#42).
+var (
+	jiraKeyExpression   = regexp.MustCompile(`^[A-Z][A-Z0-9]+-\d+$`)
+	githubKeyExpression = regexp.MustCompile(`^#?(\d+)$`)
+)
+
+// Characters which are not allowed in branch descriptions.
+var descriptionExpression = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Issue is a ticket of the issue tracker that a branch is started for.
+type Issue struct {
+	Key   string
+	Title string
+}
+
+// Description returns the branch description of the issue, e.g. 'PROJ-123-login-fails-on-safari'.
+func (i Issue) Description() string {
+	if len(i.Key) == 0 {
+		return ""
+	}
+
+	title := strings.Trim(descriptionExpression.ReplaceAllString(strings.ToLower(i.Title), "-"), "-")
+	description := strings.TrimPrefix(i.Key, "#")
+	if len(title) > 0 {
+		description += "-" + title
+	}
+
+	if len(description) > issueDescriptionLength {
+		description = strings.TrimRight(description[:issueDescriptionLength], "-")
+	}
+
+	return description
+}
+
+// BranchName appends the description of the issue to a branch name, e.g. 'hotfix/1.2.1/PROJ-123-login-fails'.
+func (i Issue) BranchName(branchName string) string {
+	if len(i.Key) == 0 {
+		return branchName
+	}
+	return branchName + "/" + i.Description()
+}
+
+// CommitMessage links a commit message to the issue by its key and title.
+func (i Issue) CommitMessage(message string) string {
+	if len(i.Key) == 0 {
+		return message
+	}
+	return fmt.Sprintf("%v: %v\n\n%v %v", i.Key, message, i.Key, i.Title)
+}
+
+// fetchIssue looks up the title of an issue in the configured issue tracker.
+func fetchIssue(repository Repository, key string) (Issue, error) {
+	if len(key) == 0 {
+		return Issue{}, nil
+	}
+
+	settings, _ := viper.AllSettings()[trackerGroup].(map[string]any)
+	tracker, _ := settings[trackerTypeSetting].(string)
+	url, _ := settings[trackerURLSetting].(string)
+
+	// without configured tracker, the format of the key selects the tracker
+	if len(tracker) == 0 {
+		if jiraKeyExpression.MatchString(key) {
+			tracker = trackerJira
+		} else if githubKeyExpression.MatchString(key) {
+			tracker = trackerGitHub
+		}
+	}
+
+	switch tracker {
+	case trackerJira:
+		if !jiraKeyExpression.MatchString(key) {
+			return Issue{}, Error(MsgIssueKeyInvalid, key, tracker)
+		}
+		if len(url) == 0 {
+			return Issue{}, Error(MsgTrackerNotConfigured, tracker, trackerGroup+"."+trackerURLSetting)
+		}
+		return fetchJiraIssue(url, key)
+
+	case trackerGitHub:
+		match := githubKeyExpression.FindStringSubmatch(key)
+		if match == nil {
+			return Issue{}, Error(MsgIssueKeyInvalid, key, tracker)
+		}
+		if len(url) == 0 {
+			url = defaultGitHubAPI
+		}
+		name, _ := settings[trackerRepositorySetting].(string)
+		if len(name) == 0 {
+			remoteURL, err := repository.RemoteURL()
+			if err != nil {
+				return Issue{}, err
+			}
+			name = githubRepository(remoteURL)
+		}
+		return fetchGitHubIssue(url, name, match[1])
+
+	default:
+		return Issue{}, Error(MsgIssueKeyInvalid, key, tracker)
+	}
+}
+
+// githubRepository returns 'owner/name' of a GitHub repository from the last two segments of its remote URL.
+func githubRepository(remoteURL string) string {
+	segments := strings.FieldsFunc(strings.TrimSuffix(remoteURL, ".git"), func(r rune) bool { return r == '/' || r == ':' })
+	if len(segments) < 2 {
+		return ""
+	}
+	return segments[len(segments)-2] + "/" + segments[len(segments)-1]
+}
+
+// fetchJiraIssue reads the summary of a JIRA issue via the REST API.
+func fetchJiraIssue(url, key string) (Issue, error) {
+	var issue struct {
+		Fields struct {
+			Summary string `json:"summary"`
+		} `json:"fields"`
+	}
+
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/rest/api/2/issue/%v?fields=summary", strings.TrimSuffix(url, "/"), key), nil)
+	if err != nil {
+		return Issue{}, err
+	}
+
+	// JIRA Cloud uses API tokens with basic authentication, JIRA Data Center personal access tokens
+	if token := os.Getenv(jiraTokenEnv); len(token) > 0 {
+		if user := os.Getenv(jiraUserEnv); len(user) > 0 {
+			request.SetBasicAuth(user, token)
+		} else {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	if err := fetchTrackerJSON(request, &issue); err != nil {
+		return Issue{}, err
+	}
+
+	return Issue{Key: key, Title: issue.Fields.Summary}, nil
+}
+
+// fetchGitHubIssue reads the title of a GitHub issue via the REST API.
+func fetchGitHubIssue(api, repository, number string) (Issue, error) {
+	var issue struct {
+		Title string `json:"title"`
+	}
+
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v/repos/%v/issues/%v", strings.TrimSuffix(api, "/"), repository, number), nil)
+	if err != nil {
+		return Issue{}, err
+	}
+
+	request.Header.Set("Accept", "application/vnd.github+json")
+	if token := os.Getenv(githubTokenEnv); len(token) > 0 {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	if err := fetchTrackerJSON(request, &issue); err != nil {
+		return Issue{}, err
+	}
+
+	return Issue{Key: "#" + number, Title: issue.Title}, nil
+}
+
+// fetchTrackerJSON sends a request to an issue tracker and decodes the JSON response.
+func fetchTrackerJSON(request *http.Request, result any) error {
+	if len(request.Header.Get("Accept")) == 0 {
+		request.Header.Set("Accept", "application/json")
+	}
+
+	response, err := apiClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("issue tracker request '%v' failed with %v", request.URL, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("issue tracker request '%v' failed with status %v", request.URL, response.Status)
+	}
+
+	if err := json.NewDecoder(response.Body).Decode(result); err != nil {
+		return fmt.Errorf("decoding issue tracker response '%v' failed with %v", request.URL, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Git configuration of the nvie git-flow extension written by 'git flow init'.
+const nvieConfigPattern = `^gitflow\.(branch|prefix)\.`
+
+// Keys of the nvie git-flow configuration mapped to the branch types they name.
+var nvieConfigKeys = map[string]Branch{
+	"gitflow.branch.master":  Production,
+	"gitflow.branch.develop": Development,
+	"gitflow.prefix.release": Release,
+	"gitflow.prefix.hotfix":  Hotfix,
+	"gitflow.prefix.bugfix":  Bugfix,
+	"gitflow.prefix.support": Support,
+}
+
+// Branch names of the default nvie git-flow layout.
+var nvieBranchNames = map[Branch]string{
+	Production:  "master",
+	Development: "develop",
+}
+
+// Prefixes of work branches which only exist in repositories following the nvie git-flow layout.
+var nviePrefixes = []string{"feature", "release", "hotfix", "support"}
+
+// Order in which the branch names of a detected layout are reported.
+var layoutBranches = []Branch{Production, Development, Release, Hotfix, Bugfix, Support}
+
+// detectLayout adopts the nvie git-flow layout of the repository for the current run if the configured branch is
+// missing but the layout names an existing one. The layout is read from the git-flow configuration of the repository,
+// or derived from existing 'master' and 'develop' branches accompanied by nvie-style work branches.
+func detectLayout(repository Repository, branchType Branch) (bool, error) {
+	layout, err := nvieLayout(repository)
+	if err != nil {
+		return false, err
+	}
+
+	name, ok := layout[branchType]
+	if !ok || name == branchNames[branchType] {
+		return false, nil
+	}
+
+	if found, err := repository.HasRemoteBranch(name); err != nil || !found {
+		return false, err
+	}
+
+	configured := branchNames[branchType]
+	described := make([]string, 0, len(layout))
+	for _, branch := range layoutBranches {
+		if name, ok := layout[branch]; ok {
+			branchNames[branch] = name
+			described = append(described, fmt.Sprintf("%v: %v", branch.ConfigKey(), name))
+		}
+	}
+
+	fmt.Fprintln(os.Stderr, Message(MsgLayoutDetected, configured, strings.Join(described, ", ")))
+	return true, nil
+}
+
+// nvieLayout returns the branch names of the nvie git-flow layout of the repository, which is empty if the
+// repository does not follow it.
+func nvieLayout(repository Repository) (map[Branch]string, error) {
+	layout := make(map[Branch]string)
+
+	values, err := repository.ConfigValues(nvieConfigPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range values {
+		if branch, ok := nvieConfigKeys[key]; ok && len(strings.TrimSuffix(value, "/")) > 0 {
+			layout[branch] = strings.TrimSuffix(value, "/")
+		}
+	}
+
+	if len(layout) > 0 {
+		return layout, nil
+	}
+
+	// without git-flow configuration, the default branches must exist together with nvie-style work branches
+	for _, name := range nvieBranchNames {
+		if found, err := repository.HasRemoteBranch(name); err != nil || !found {
+			return layout, err
+		}
+	}
+
+	for _, prefix := range nviePrefixes {
+		if branches, err := repository.TrackedBranches(prefix); err != nil {
+			return nil, err
+		} else if len(branches) > 0 {
+			return nvieBranchNames, nil
+		}
+	}
+
+	return layout, nil
+}
@@ -43,35 +43,35 @@ var loggingNames = map[Logging]string{
 // Internal flags for controlling core package behavior.
 var loggingFlags Logging = 0
 
-// Log a message to Go standard logging based on logging flags and variadic arguments.
+// Log a message to Go standard logging based on logging flags and variadic arguments, with secrets redacted.
 func Log(message ...any) {
 	printLine := func() {
 		for _, msg := range message {
 			switch msg := msg.(type) {
 			case string:
 				if len(msg) > 0 && (loggingFlags&CmdLine != 0 || loggingFlags&Output != 0) {
-					log.Println(msg)
+					log.Println(Redact(msg))
 				}
 
 			case *exec.Cmd:
 				if msg != nil && len(msg.String()) > 0 && loggingFlags&CmdLine != 0 {
-					log.Println(msg.String())
+					log.Println(Redact(msg.String()))
 				}
 
 			case []byte:
 				if len(msg) > 0 && loggingFlags&Output != 0 {
 					output := strings.TrimRight(string(msg), "\n\r")
-					log.Println(output)
+					log.Println(Redact(output))
 				}
 
 			case error:
 				if msg != nil && len(msg.Error()) > 0 && loggingFlags&Output != 0 {
-					log.Println(msg.Error())
+					log.Println(Redact(msg.Error()))
 				}
 
 			default:
 				if msg != nil && len(fmt.Sprintf("%v", msg)) > 0 && loggingFlags&Output != 0 {
-					log.Println(msg)
+					log.Println(Redact(fmt.Sprintf("%v", msg)))
 				}
 			}
 		}
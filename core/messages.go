@@ -0,0 +1,699 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Locale settings key and environment variable.
+const (
+	localeKey = "locale"
+	localeEnv = "GITFLOW_LOCALE"
+)
+
+// Supported locales of user-facing messages.
+const (
+	English = "en"
+	German  = "de"
+)
+
+// Keys of user-facing messages in the message catalog.
+const (
+	MsgStartCalled           = "start.called"
+	MsgStartCompleted        = "start.completed"
+	MsgStartFailed           = "start.failed"
+	MsgFinishCalled          = "finish.called"
+	MsgFinishCompleted       = "finish.completed"
+	MsgFinishFailed          = "finish.failed"
+	MsgPromoteCalled         = "promote.called"
+	MsgPromoteCompleted      = "promote.completed"
+	MsgPromoteFailed         = "promote.failed"
+	MsgCandidateCalled       = "candidate.called"
+	MsgCandidateCompleted    = "candidate.completed"
+	MsgCandidateFailed       = "candidate.failed"
+	MsgVerifyTagsCalled      = "verify.tags.called"
+	MsgVerifyTagsCompleted   = "verify.tags.completed"
+	MsgVerifyTagsFailed      = "verify.tags.failed"
+	MsgCheckCalled           = "check.called"
+	MsgCheckCompleted        = "check.completed"
+	MsgCheckFailed           = "check.failed"
+	MsgBugfixStartCalled     = "bugfix.start.called"
+	MsgBugfixStartCompleted  = "bugfix.start.completed"
+	MsgBugfixStartFailed     = "bugfix.start.failed"
+	MsgBugfixFinishCalled    = "bugfix.finish.called"
+	MsgBugfixFinishCompleted = "bugfix.finish.completed"
+	MsgBugfixFinishFailed    = "bugfix.finish.failed"
+	MsgSupportStartCalled    = "support.start.called"
+	MsgSupportStartCompleted = "support.start.completed"
+	MsgSupportStartFailed    = "support.start.failed"
+	MsgInitCalled            = "init.called"
+	MsgInitCompleted         = "init.completed"
+	MsgInitFailed            = "init.failed"
+	MsgInitBranchCreated     = "info.init-branch-created"
+	MsgBootstrapCalled       = "bootstrap.called"
+	MsgBootstrapCompleted    = "bootstrap.completed"
+	MsgBootstrapFailed       = "bootstrap.failed"
+	MsgBootstrapNoRemote     = "info.bootstrap-no-remote"
+	MsgProjectPathMissing    = "error.project-path-missing"
+	MsgUnsupportedBranch     = "error.unsupported-branch"
+	MsgBranchAlreadyExists   = "error.branch-already-exists"
+	MsgNoBranchToFinish      = "error.no-branch-to-finish"
+	MsgMultipleBranches      = "error.multiple-branches"
+	MsgCandidateNoBranch     = "error.candidate-no-branch"
+	MsgToolNotAvailable      = "error.tool-not-available"
+	MsgRepositoryNotClean    = "error.repository-not-clean"
+	MsgRebaseInProgress      = "error.rebase-in-progress"
+	MsgMergeInProgress       = "error.merge-in-progress"
+	MsgCherryPickInProgress  = "error.cherry-pick-in-progress"
+	MsgRevertInProgress      = "error.revert-in-progress"
+	MsgBisectInProgress      = "error.bisect-in-progress"
+	MsgEnvironmentUnknown    = "error.environment-unknown"
+	MsgVersionNotReleased    = "error.version-not-released"
+	MsgAlreadyReleased       = "error.already-released"
+	MsgReleaseVersionInvalid = "error.release-version-invalid"
+	MsgInitialVersionInvalid = "error.initial-version-invalid"
+	MsgVersionFileMissing    = "error.version-file-missing"
+	MsgPromotionOutOfOrder   = "error.promotion-out-of-order"
+	MsgAlreadyPromoted       = "error.already-promoted"
+	MsgMergeConflictPaused   = "error.merge-conflict-paused"
+	MsgWorkflowInterrupted   = "error.workflow-interrupted"
+	MsgNothingToContinue     = "error.nothing-to-continue"
+	MsgContinueOtherFlow     = "error.continue-other-flow"
+	MsgStateFileInvalid      = "error.state-file-invalid"
+	MsgWorkflowResumable     = "info.workflow-resumable"
+	MsgNothingToRecover      = "recover.nothing"
+	MsgRecoverFromState      = "recover.from-state"
+	MsgRecoverFromHistory    = "recover.from-history"
+	MsgRecoverExecute        = "recover.execute"
+	MsgInvalidRefSetting     = "error.invalid-ref-setting"
+	MsgInvalidQualifier      = "error.invalid-qualifier"
+	MsgInvalidPathSetting    = "error.invalid-path-setting"
+	MsgInvalidPattern        = "error.invalid-pattern"
+	MsgInvalidAuthor         = "error.invalid-author"
+	MsgTemplatePlaceholder   = "error.template-placeholder"
+	MsgInvalidChoice         = "error.invalid-choice"
+	MsgMissingCommand        = "error.missing-command"
+	MsgInvalidCommand        = "error.invalid-command"
+	MsgMainlineSetting       = "error.mainline-setting"
+	MsgMainlineNoRelease     = "error.mainline-no-release"
+	MsgEnvironmentDiverged   = "error.environment-diverged"
+	MsgVersionSyncMissing    = "error.version-sync-missing"
+	MsgPreTagVetoed          = "error.pre-tag-vetoed"
+	MsgStaleBranchAge        = "warn.stale-branch-age"
+	MsgStaleBranchBehind     = "warn.stale-branch-behind"
+	MsgDetachedHead          = "error.detached-head"
+	MsgUnpushedWorkBranch    = "error.unpushed-work-branch"
+	MsgSwitchingToBase       = "info.switching-to-base"
+	MsgBranchFastForwarded   = "info.branch-fast-forwarded"
+	MsgPruneHint             = "info.prune-hint"
+	MsgBranchDiverged        = "warn.branch-diverged"
+	MsgFastForwardFailed     = "warn.fast-forward-failed"
+	MsgDivergentBranches     = "error.divergent-branches"
+	MsgBugfixNameInvalid     = "error.bugfix-name-invalid"
+	MsgBugfixAlreadyExists   = "error.bugfix-already-exists"
+	MsgBugfixNotFound        = "error.bugfix-not-found"
+	MsgBugfixAmbiguous       = "error.bugfix-ambiguous"
+	MsgIdentityMissing       = "error.identity-missing"
+	MsgIssueKeyInvalid       = "error.issue-key-invalid"
+	MsgTrackerNotConfigured  = "error.tracker-not-configured"
+	MsgSupportLineInvalid    = "error.support-line-invalid"
+	MsgSupportAlreadyExists  = "error.support-already-exists"
+	MsgSupportNotFound       = "error.support-not-found"
+	MsgTemplateUnknown       = "error.template-unknown"
+	MsgRepositoryNotEmpty    = "error.repository-not-empty"
+	MsgDryRunUnsupported     = "error.dry-run-unsupported"
+	MsgPlanInvalid           = "error.plan-invalid"
+	MsgPlanMismatch          = "error.plan-mismatch"
+	MsgBumpPullRequest       = "info.bump-pull-request"
+	MsgBumpOpenManually      = "info.bump-open-manually"
+	MsgBumpPullRequestFailed = "error.bump-pr-failed"
+	MsgGitOpsPattern         = "error.gitops-pattern"
+	MsgGitOpsFailed          = "error.gitops-failed"
+	MsgGitOpsPullRequest     = "info.gitops-pull-request"
+	MsgGitOpsOpenManually    = "info.gitops-open-manually"
+	MsgViaPullRequestNoPush  = "error.via-pr-no-push"
+	MsgHostingTokenMissing   = "error.hosting-token-missing"
+	MsgPullRequestOpened     = "info.pull-request-opened"
+	MsgPullRequestFailed     = "error.pull-request-failed"
+	MsgPullRequestClosed     = "error.pull-request-closed"
+	MsgPullRequestsOpen      = "error.pull-requests-open"
+	MsgPullRequestsTimeout   = "error.pull-requests-timeout"
+	MsgPullRequestsWait      = "info.pull-requests-wait"
+	MsgPullRequestTerm       = "info.pull-request-term"
+	MsgMergeRequestTerm      = "info.merge-request-term"
+	MsgPublishTokenMissing   = "error.publish-token-missing"
+	MsgPublishDraftMissing   = "error.publish-draft-missing"
+	MsgPublishUnsupported    = "error.publish-unsupported"
+	MsgBranchRestricted      = "error.branch-restricted"
+	MsgRestrictionsUnread    = "warn.restrictions-unread"
+	MsgHostedRelease         = "info.hosted-release"
+	MsgHostedReleaseDraft    = "info.hosted-release-draft"
+	MsgHostedReleaseFailed   = "error.hosted-release-failed"
+	MsgScaffoldInvalidName   = "error.scaffold-invalid-name"
+	MsgScaffoldVersionFile   = "error.scaffold-version-file"
+	MsgScaffoldNoSourceTree  = "error.scaffold-no-source-tree"
+	MsgScaffoldPluginExists  = "error.scaffold-plugin-exists"
+	MsgScaffoldNoImports     = "error.scaffold-no-imports"
+	MsgScaffoldCreated       = "info.scaffold-created"
+	MsgBumpChosen            = "info.bump-chosen"
+	MsgBumpNoChanges         = "error.bump-no-changes"
+	MsgTagDrift              = "error.tag-drift"
+	MsgTagUnreachable        = "warn.tag-unreachable"
+	MsgTagVersionMismatch    = "warn.tag-version-mismatch"
+	MsgCheckViolations       = "error.check-violations"
+	MsgCheckNoBranch         = "error.check-no-branch"
+	MsgCheckViolation        = "check.violation"
+	MsgCheckBranchName       = "check.branch-name"
+	MsgCheckVersionNotNewer  = "check.version-not-newer"
+	MsgCheckQualifier        = "check.qualifier"
+	MsgCheckQualifierMissing = "check.qualifier-missing"
+	MsgCheckVersionMismatch  = "check.version-mismatch"
+	MsgCheckNoRules          = "info.check-no-rules"
+	MsgDryRunStarted         = "info.dry-run-started"
+	MsgDryRunStep            = "info.dry-run-step"
+	MsgDryRunWriteFile       = "info.dry-run-write-file"
+	MsgDryRunSetVersion      = "info.dry-run-set-version"
+	MsgDryRunSkipped         = "info.dry-run-skipped"
+	MsgCIUnsupported         = "error.ci-unsupported"
+	MsgCIBranchMismatch      = "error.ci-branch-mismatch"
+	MsgCIIdentity            = "info.ci-identity"
+	MsgCIPushToken           = "info.ci-push-token"
+	MsgCIBranchAttached      = "info.ci-branch-attached"
+	MsgCIDetected            = "info.ci-detected"
+	MsgSchemaUnknown         = "error.schema-unknown"
+	MsgAPIRetry              = "info.api-retry"
+	MsgAPITimeout            = "error.api-timeout"
+	MsgReportWritten         = "info.report-written"
+	MsgReportNoReleases      = "error.report-no-releases"
+	MsgChangelogWritten      = "info.changelog-written"
+	MsgChangelogNoReleases   = "error.changelog-no-releases"
+	MsgStepUnknown           = "error.step-unknown"
+	MsgStepRequired          = "error.step-required"
+	MsgStepMovedAcross       = "error.step-moved-across"
+	MsgStepInvalid           = "error.step-invalid"
+	MsgStepFailed            = "error.step-failed"
+	MsgMetricsWritten        = "info.metrics-written"
+	MsgMetricsPushed         = "info.metrics-pushed"
+	MsgMetricsNoGateway      = "error.metrics-no-gateway"
+	MsgMetricsPushFailed     = "error.metrics-push-failed"
+	MsgBranchCreatingAuto    = "prompt.branch-creating-auto"
+	MsgBranchUsingAuto       = "prompt.branch-using-auto"
+	MsgLayoutDetected        = "info.layout-detected"
+	MsgBranchNotFound        = "prompt.branch-not-found"
+	MsgBranchEnterOrCreate   = "prompt.branch-enter-or-create"
+	MsgBranchCreating        = "prompt.branch-creating"
+	MsgBranchEnterDefault    = "prompt.branch-enter-default"
+	MsgBranchEnterExisting   = "prompt.branch-enter-existing"
+	MsgBranchNotOnRemote     = "prompt.branch-not-on-remote"
+	MsgConfigSaveFailed      = "prompt.config-save-failed"
+	MsgConfigSaved           = "prompt.config-saved"
+	MsgDockerFallbackAuto    = "prompt.docker-fallback-auto"
+	MsgSnapshotDependencies  = "warning.snapshot-dependencies"
+	MsgDockerFallbackPrompt  = "prompt.docker-fallback"
+	MsgConfigUsing           = "config.using"
+	MsgConfigCreated         = "config.created"
+	MsgConfigCreateFailed    = "config.create-failed"
+	MsgConfigReadFailed      = "config.read-failed"
+	MsgConfigIgnored         = "config.ignored"
+)
+
+// Message catalogs by locale, every catalog must provide all keys of the English catalog.
+var messageCatalogs = map[string]map[string]string{
+	English: {
+		MsgStartCalled:           "%v Plugin Start on branch %v called: %v",
+		MsgStartCompleted:        "%v Plugin Start on branch %v completed: %v",
+		MsgStartFailed:           "%v Plugin Start on branch %v failed: %v",
+		MsgFinishCalled:          "%v Plugin Finish on branch %v called: %v",
+		MsgFinishCompleted:       "%v Plugin Finish on branch %v completed: %v",
+		MsgFinishFailed:          "%v Plugin Finish on branch %v failed: %v",
+		MsgPromoteCalled:         "Promote %v to environment %v called: %v",
+		MsgPromoteCompleted:      "Promote %v to environment %v completed: %v",
+		MsgPromoteFailed:         "Promote %v to environment %v failed: %v",
+		MsgCandidateCalled:       "Tag release candidate of %v branch called: %v",
+		MsgCandidateCompleted:    "Tag release candidate of %v branch completed: %v",
+		MsgCandidateFailed:       "Tag release candidate of %v branch failed: %v",
+		MsgVerifyTagsCalled:      "Verify tags against %v branch called: %v",
+		MsgVerifyTagsCompleted:   "Verify tags against %v branch completed: %v",
+		MsgVerifyTagsFailed:      "Verify tags against %v branch failed: %v",
+		MsgCheckCalled:           "Check of branch %v called: %v",
+		MsgCheckCompleted:        "Check of branch %v completed: %v",
+		MsgCheckFailed:           "Check of branch %v failed: %v",
+		MsgBugfixStartCalled:     "Bugfix Start of %v called: %v",
+		MsgBugfixStartCompleted:  "Bugfix Start of %v completed: %v",
+		MsgBugfixStartFailed:     "Bugfix Start of %v failed: %v",
+		MsgBugfixFinishCalled:    "Bugfix Finish of %v called: %v",
+		MsgBugfixFinishCompleted: "Bugfix Finish of %v completed: %v",
+		MsgBugfixFinishFailed:    "Bugfix Finish of %v failed: %v",
+		MsgSupportStartCalled:    "Support Start of %v called: %v",
+		MsgSupportStartCompleted: "Support Start of %v completed: %v",
+		MsgSupportStartFailed:    "Support Start of %v failed: %v",
+		MsgInitCalled:            "Init called: %v",
+		MsgInitCompleted:         "Init completed: %v",
+		MsgInitFailed:            "Init failed: %v",
+		MsgInitBranchCreated:     "INFO: creating branch '%v' from '%v'",
+		MsgBootstrapCalled:       "Bootstrap with template %v called: %v",
+		MsgBootstrapCompleted:    "Bootstrap with template %v completed: %v",
+		MsgBootstrapFailed:       "Bootstrap with template %v failed: %v",
+		MsgBootstrapNoRemote:     "INFO: repository has no remote '%v', branches and tags are not pushed",
+		MsgProjectPathMissing:    "project path '%v' does not exist",
+		MsgUnsupportedBranch:     "unsupported branch: %v",
+		MsgBranchAlreadyExists:   "repository already has a '%v' branch and only one '%v' branch is allowed at a time",
+		MsgNoBranchToFinish:      "repository does not have a '%v' branch to finish",
+		MsgMultipleBranches:      "repository must not have multiple '%v' branches",
+		MsgCandidateNoBranch:     "repository does not have a '%v' branch to tag a release candidate of",
+		MsgToolNotAvailable:      "tool '%v' is not available on the system",
+		MsgRepositoryNotClean:    "repository under project path '%v' is not clean",
+		MsgRebaseInProgress:      "a rebase is in progress in '%v': finish it with 'git rebase --continue' or abort it with 'git rebase --abort' first",
+		MsgMergeInProgress:       "a merge is in progress in '%v': commit it with 'git commit' or abort it with 'git merge --abort' first",
+		MsgCherryPickInProgress:  "a cherry-pick is in progress in '%v': finish it with 'git cherry-pick --continue' or abort it with 'git cherry-pick --abort' first",
+		MsgRevertInProgress:      "a revert is in progress in '%v': finish it with 'git revert --continue' or abort it with 'git revert --abort' first",
+		MsgBisectInProgress:      "a bisect is in progress in '%v': end it with 'git bisect reset' first",
+		MsgEnvironmentUnknown:    "environment '%v' is not configured (configured environments: %v)",
+		MsgVersionNotReleased:    "version '%v' has not been released (tag '%v' not found)",
+		MsgAlreadyReleased:       "version '%v' has already been released",
+		MsgReleaseVersionInvalid: "release version '%v' must be a version without qualifier (e.g. '2.5.0')",
+		MsgInitialVersionInvalid: "initial version '%v' of setting '%v.%v' must be a version without qualifier (e.g. '1.0.0')",
+		MsgVersionFileMissing:    "project has no version file '%v' and creating it is disabled by setting '%v.%v'",
+		MsgPromotionOutOfOrder:   "version '%v' must be promoted to '%v' before '%v'",
+		MsgAlreadyPromoted:       "version '%v' has already been promoted to '%v'",
+		MsgMergeConflictPaused:   "merging '%v' paused due to conflicts in: %v (resolve the conflicts and commit the merge to continue the finish)",
+		MsgWorkflowInterrupted:   "%v finish of '%v' was interrupted at step '%v': run '%v continue' to resume it",
+		MsgNothingToContinue:     "no interrupted %v finish to continue",
+		MsgContinueOtherFlow:     "the interrupted finish is a %v finish: run '%v continue'",
+		MsgStateFileInvalid:      "workflow state file '%v' is invalid: %v (delete it to finish from the start)",
+		MsgWorkflowResumable:     "INFO: %v finish stopped at step '%v': resolve the cause and run '%v continue' to resume it",
+		MsgNothingToRecover:      "no half-completed release or hotfix finish found",
+		MsgRecoverFromState:      "%v finish of '%v' was interrupted according to its state file, remaining steps from '%v':",
+		MsgRecoverFromHistory:    "%v finish of '%v' is half-completed according to the branches and tags, remaining steps from '%v':",
+		MsgRecoverExecute:        "run 'recover --execute' to execute the remaining steps",
+		MsgInvalidRefSetting:     "setting '%v' holds '%v', which is no valid git branch or tag name",
+		MsgInvalidQualifier:      "setting '%v' holds '%v', which is no valid version qualifier (letters and digits, separated by dots or hyphens)",
+		MsgInvalidPathSetting:    "setting '%v' holds '%v', which is no relative path inside the project",
+		MsgInvalidPattern:        "setting '%v' holds '%v', which is no valid path pattern",
+		MsgInvalidAuthor:         "setting '%v' holds '%v', which is no identity of the form 'Name <email>'",
+		MsgTemplatePlaceholder:   "setting '%v' holds '%v', which lacks the placeholder %v",
+		MsgInvalidChoice:         "setting '%v' holds '%v', which is none of %v",
+		MsgMissingCommand:        "setting '%v' of %v holds no command",
+		MsgInvalidCommand:        "setting '%v' of %v holds '%v', whose executable cannot be found: %v",
+		MsgMainlineSetting:       "setting '%v' holds '%v', which needs a development branch apart from the production branch '%v'",
+		MsgMainlineNoRelease:     "hotfixes of the mainline '%v' start from the latest release, but there is no release yet",
+		MsgEnvironmentDiverged:   "environment branch '%v' has commits which are not part of version '%v': environment branches only receive promoted versions",
+		MsgVersionSyncMissing:    "file '%v' of setting '%v' does not hold the version of the project",
+		MsgPreTagVetoed:          "pre-tag hook vetoed tag '%v' of commit %v: %v",
+		MsgStaleBranchAge:        "WARN: branch '%v' has been open for %v days (limit %v): finish or delete it",
+		MsgStaleBranchBehind:     "WARN: branch '%v' is %v commits behind '%v' (limit %v): finish or delete it",
+		MsgDetachedHead:          "HEAD is detached: check out a branch (e.g. 'git switch develop') before starting a %v",
+		MsgUnpushedWorkBranch:    "branch '%v' has %v unpushed commit(s): push or discard them before starting a %v",
+		MsgSwitchingToBase:       "INFO: leaving branch '%v' and switching to '%v' to start the %v",
+		MsgBranchFastForwarded:   "INFO: fast-forwarded local branch '%v' by %v commit(s) to '%v'",
+		MsgPruneHint:             "INFO: deleted '%v' on the remote; collaborators remove its remote-tracking branch with 'git fetch --prune %v'",
+		MsgBranchDiverged:        "WARN: local branch '%v' has diverged from '%v' and was not fast-forwarded: reconcile the branches manually",
+		MsgFastForwardFailed:     "WARN: fast-forwarding local branches failed for '%v': %v",
+		MsgDivergentBranches:     "branch '%v' has diverged from '%v' and cannot be pulled with strategy '%v': reconcile the branches manually or change 'workflow.pull-strategy'",
+		MsgBugfixNameInvalid:     "bugfix name '%v' must not be empty or contain whitespace",
+		MsgBugfixAlreadyExists:   "repository already has a bugfix branch '%v'",
+		MsgBugfixNotFound:        "repository does not have a bugfix branch '%v' to finish",
+		MsgBugfixAmbiguous:       "repository has multiple bugfix branches (%v): name the one to finish",
+		MsgIdentityMissing:       "git has no commit identity for '%v': configure user.name and user.email for this directory (%v)",
+		MsgIssueKeyInvalid:       "issue '%v' is not a valid key for issue tracker '%v' (e.g. 'PROJ-123' for jira, '#42' for github)",
+		MsgTrackerNotConfigured:  "issue tracker '%v' requires the setting '%v'",
+		MsgSupportLineInvalid:    "support line '%v' must be a major and minor version (e.g. '1.2')",
+		MsgSupportAlreadyExists:  "repository already has a support branch '%v'",
+		MsgSupportNotFound:       "repository does not have a support branch '%v' (create it with 'support start')",
+		MsgTemplateUnknown:       "template '%v' is not available (available templates: %v)",
+		MsgRepositoryNotEmpty:    "repository under project path '%v' already has commits: use 'init' to prepare existing projects",
+		MsgDryRunUnsupported:     "command '%v' does not support a dry run",
+		MsgPlanInvalid:           "execution plan '%v' is invalid: %v",
+		MsgPlanMismatch:          "execution plan '%v' does not match the repository anymore: %v",
+		MsgBumpPullRequest:       "INFO: opened %v %v of '%v' into '%v' with the next development version",
+		MsgBumpOpenManually:      "INFO: open a %v of '%v' into '%v' to deliver the next development version",
+		MsgBumpPullRequestFailed: "opening the %v of '%v' into '%v' failed with %v, the branch has been pushed",
+		MsgGitOpsPattern:         "setting '%v' holds '%v', which is no regular expression with a group for the version",
+		MsgGitOpsFailed:          "updating the deployment manifests of '%v' failed: %v",
+		MsgGitOpsPullRequest:     "INFO: opened pull request #%v of '%v' into '%v' of '%v' with the released version",
+		MsgGitOpsOpenManually:    "INFO: open a pull request of '%v' into '%v' of '%v' to deploy the released version",
+		MsgViaPullRequestNoPush:  "finishing via pull or merge requests pushes the finished branch, but pushing is disabled (--no-push or 'workflow.push: false')",
+		MsgHostingTokenMissing:   "finishing via %vs requires a %v token in %v",
+		MsgPullRequestOpened:     "INFO: opened %v %v of '%v' into '%v'",
+		MsgPullRequestFailed:     "%v of '%v' into '%v' failed with %v",
+		MsgPullRequestClosed:     "%v %v of '%v' into '%v' has been closed without merging it: reopen and merge it, then continue the finish",
+		MsgPullRequestsOpen:      "%v(s) %v of '%v' are not merged yet: merge them and run '%v continue' to tag the merge, or wait for the merge with --wait",
+		MsgPullRequestsTimeout:   "%v(s) %v of '%v' were not merged within %v (the limit is configured under '%v'): run '%v continue' after the merge",
+		MsgPullRequestsWait:      "INFO: waiting for the merge of %v(s) %v (at most %v)",
+		MsgPullRequestTerm:       "pull request",
+		MsgMergeRequestTerm:      "merge request",
+		MsgPublishTokenMissing:   "publishing the %v release requires a %v token in %v",
+		MsgPublishDraftMissing:   "%v has no draft releases: publish the release without --draft",
+		MsgPublishUnsupported:    "%v has no releases: finish the release without --publish",
+		MsgBranchRestricted:      "branch '%v' accepts changes by %vs only on %v: finish with --via-pr",
+		MsgRestrictionsUnread:    "WARN: the branch restrictions of %v could not be read (%v), the finish pushes its merges",
+		MsgHostedRelease:         "INFO: published the %v release of '%v': %v",
+		MsgHostedReleaseDraft:    "INFO: drafted the %v release of '%v', publish it when it is complete: %v",
+		MsgHostedReleaseFailed:   "%v release of '%v' failed with %v",
+		MsgScaffoldInvalidName:   "plugin name '%v' is no valid Go package name: use lowercase letters and digits, starting with a letter",
+		MsgScaffoldVersionFile:   "version file '%v' of the plugin must be a path relative to the project",
+		MsgScaffoldNoSourceTree:  "path '%v' is no source tree of %v, which plugins are scaffolded in (use --path)",
+		MsgScaffoldPluginExists:  "plugin '%v' already exists in '%v' or is registered",
+		MsgScaffoldNoImports:     "plugin imports not found in '%v'",
+		MsgScaffoldCreated:       "Plugin '%v' scaffolded in '%v' and imported in '%v': adapt the TODOs and run 'go test ./plugin/...'",
+		MsgBumpChosen:            "INFO: %v bump of release %v to %v",
+		MsgBumpNoChanges:         "none of the %v commit(s) since '%v' on '%v' is a feature, fix or breaking change: choose the bump with --bump",
+		MsgTagDrift:              "found %v drift(s) between the version tags and '%v'",
+		MsgTagUnreachable:        "WARN: tag '%v' is not reachable from '%v'",
+		MsgTagVersionMismatch:    "WARN: version %v in '%v' of '%v' does not match the latest tag %v",
+		MsgCheckViolations:       "found %v violation(s) of the workflow rules on branch '%v'",
+		MsgCheckNoBranch:         "branch to check is unknown in a detached HEAD: pass its name (e.g. 'check release/1.2.0')",
+		MsgCheckViolation:        "ERROR: %v",
+		MsgCheckBranchName:       "branch '%v' is not named '%v/<version>' with a version like '1.2.0'",
+		MsgCheckVersionNotNewer:  "version %v of branch '%v' is not greater than the latest tag %v",
+		MsgCheckQualifier:        "version %v in '%v' still has a qualifier",
+		MsgCheckQualifierMissing: "version %v in '%v' does not have the qualifier '%v'",
+		MsgCheckVersionMismatch:  "version %v in '%v' does not match the version %v of the branch name",
+		MsgCheckNoRules:          "INFO: branch '%v' is not a workflow branch, no rules apply",
+		MsgDryRunStarted:         "INFO: dry run, the repository under project path '%v' is not changed",
+		MsgDryRunStep:            "DRY-RUN: %v",
+		MsgDryRunWriteFile:       "write file '%v'",
+		MsgDryRunSetVersion:      "set version %v in '%v' (%v plugin)",
+		MsgDryRunSkipped:         "skip %v",
+		MsgCIUnsupported:         "unsupported CI system '%v' (supported: %v)",
+		MsgCIBranchMismatch:      "the merge request pipeline of '%v' cannot finish '%v'",
+		MsgCIIdentity:            "INFO: committing as '%v <%v>', the user who triggered the pipeline",
+		MsgCIPushToken:           "INFO: pushing to '%v' with the token of %v",
+		MsgCIBranchAttached:      "INFO: checked out branch '%v' at the commit of the pipeline",
+		MsgCIDetected:            "INFO: detected CI system '%v': prompts are confirmed, output is plain, changes are not rolled back (disable with --no-ci-defaults)",
+		MsgSchemaUnknown:         "unknown schema '%v' (available: %v)",
+		MsgAPIRetry:              "INFO: %v responded with %v, retrying in %v",
+		MsgAPITimeout:            "request '%v' did not succeed within %v (rate limited or unavailable), the limit is configured under '%v.%v'",
+		MsgReportWritten:         "Report of %v release(s) written to '%v'",
+		MsgReportNoReleases:      "repository under project path '%v' has no release tags to report",
+		MsgChangelogWritten:      "Changelog of %v release(s) written to '%v'",
+		MsgChangelogNoReleases:   "repository under project path '%v' has no release tags for a changelog",
+		MsgStepUnknown:           "workflow '%v' has no step '%v' (steps: %v)",
+		MsgStepRequired:          "step '%v' of workflow '%v' is required and cannot be skipped or moved",
+		MsgStepMovedAcross:       "step '%v' of workflow '%v' cannot be moved across the required step '%v'",
+		MsgStepInvalid:           "invalid definition of step '%v' of workflow '%v': %v",
+		MsgStepFailed:            "step '%v' of workflow '%v' failed: %v",
+		MsgMetricsWritten:        "Metrics of %v released version(s) written to '%v'",
+		MsgMetricsPushed:         "Metrics of %v released version(s) pushed to '%v'",
+		MsgMetricsNoGateway:      "no Prometheus pushgateway is configured under '%v.%v'",
+		MsgMetricsPushFailed:     "pushing metrics to '%v' failed with %v",
+		MsgBranchCreatingAuto:    "INFO: creating %v branch '%v' from '%v'",
+		MsgBranchUsingAuto:       "INFO: %v branch '%v' not found, using '%v'",
+		MsgLayoutDetected:        "INFO: branch '%v' not found, using the git-flow layout of the repository (%v)",
+		MsgBranchNotFound:        "%v branch '%v' not found.",
+		MsgBranchEnterOrCreate:   "Enter branch name or press Enter to create '%v': ",
+		MsgBranchCreating:        "Creating '%v' from '%v'...",
+		MsgBranchEnterDefault:    "Enter branch name [%v]: ",
+		MsgBranchEnterExisting:   "Enter existing branch name: ",
+		MsgBranchNotOnRemote:     "Branch '%v' does not exist on remote.",
+		MsgConfigSaveFailed:      "WARN: could not save config: %v",
+		MsgConfigSaved:           "Configured '%v: %v'",
+		MsgDockerFallbackAuto:    "INFO: %v not found, using Docker (%v)",
+		MsgSnapshotDependencies:  "WARNING: SNAPSHOT dependencies without a released version remain in the release, set '%v.%v' to '%v' to prevent this",
+		MsgDockerFallbackPrompt:  "%v not found. Use Docker (%v) instead? [Y/n] ",
+		MsgConfigUsing:           "Using config file: %v",
+		MsgConfigCreated:         "Created default config file: %v",
+		MsgConfigCreateFailed:    "Warning: could not create default config: %v",
+		MsgConfigReadFailed:      "Warning: could not read config file %v: %v",
+		MsgConfigIgnored:         "Warning: ignoring setting '%v' of config file %v, which runs commands, unless '%v' of the user config trusts the project",
+	},
+	German: {
+		MsgStartCalled:           "%v-Plugin: Start auf Branch %v aufgerufen: %v",
+		MsgStartCompleted:        "%v-Plugin: Start auf Branch %v abgeschlossen: %v",
+		MsgStartFailed:           "%v-Plugin: Start auf Branch %v fehlgeschlagen: %v",
+		MsgFinishCalled:          "%v-Plugin: Abschluss auf Branch %v aufgerufen: %v",
+		MsgFinishCompleted:       "%v-Plugin: Abschluss auf Branch %v abgeschlossen: %v",
+		MsgFinishFailed:          "%v-Plugin: Abschluss auf Branch %v fehlgeschlagen: %v",
+		MsgPromoteCalled:         "Freigabe von %v für Umgebung %v aufgerufen: %v",
+		MsgPromoteCompleted:      "Freigabe von %v für Umgebung %v abgeschlossen: %v",
+		MsgPromoteFailed:         "Freigabe von %v für Umgebung %v fehlgeschlagen: %v",
+		MsgCandidateCalled:       "Tag eines Release-Kandidaten des Branches %v aufgerufen: %v",
+		MsgCandidateCompleted:    "Tag eines Release-Kandidaten des Branches %v abgeschlossen: %v",
+		MsgCandidateFailed:       "Tag eines Release-Kandidaten des Branches %v fehlgeschlagen: %v",
+		MsgVerifyTagsCalled:      "Prüfung der Tags gegen Branch %v aufgerufen: %v",
+		MsgVerifyTagsCompleted:   "Prüfung der Tags gegen Branch %v abgeschlossen: %v",
+		MsgVerifyTagsFailed:      "Prüfung der Tags gegen Branch %v fehlgeschlagen: %v",
+		MsgCheckCalled:           "Prüfung von Branch %v aufgerufen: %v",
+		MsgCheckCompleted:        "Prüfung von Branch %v abgeschlossen: %v",
+		MsgCheckFailed:           "Prüfung von Branch %v fehlgeschlagen: %v",
+		MsgBugfixStartCalled:     "Bugfix: Start von %v aufgerufen: %v",
+		MsgBugfixStartCompleted:  "Bugfix: Start von %v abgeschlossen: %v",
+		MsgBugfixStartFailed:     "Bugfix: Start von %v fehlgeschlagen: %v",
+		MsgBugfixFinishCalled:    "Bugfix: Abschluss von %v aufgerufen: %v",
+		MsgBugfixFinishCompleted: "Bugfix: Abschluss von %v abgeschlossen: %v",
+		MsgBugfixFinishFailed:    "Bugfix: Abschluss von %v fehlgeschlagen: %v",
+		MsgSupportStartCalled:    "Support: Start von %v aufgerufen: %v",
+		MsgSupportStartCompleted: "Support: Start von %v abgeschlossen: %v",
+		MsgSupportStartFailed:    "Support: Start von %v fehlgeschlagen: %v",
+		MsgInitCalled:            "Initialisierung aufgerufen: %v",
+		MsgInitCompleted:         "Initialisierung abgeschlossen: %v",
+		MsgInitFailed:            "Initialisierung fehlgeschlagen: %v",
+		MsgInitBranchCreated:     "INFO: Branch '%v' wird aus '%v' erstellt",
+		MsgBootstrapCalled:       "Projektanlage mit Vorlage %v aufgerufen: %v",
+		MsgBootstrapCompleted:    "Projektanlage mit Vorlage %v abgeschlossen: %v",
+		MsgBootstrapFailed:       "Projektanlage mit Vorlage %v fehlgeschlagen: %v",
+		MsgBootstrapNoRemote:     "INFO: Repository hat kein Remote '%v', Branches und Tags werden nicht gepusht",
+		MsgProjectPathMissing:    "Projektpfad '%v' existiert nicht",
+		MsgUnsupportedBranch:     "nicht unterstützter Branch: %v",
+		MsgBranchAlreadyExists:   "Repository hat bereits einen '%v'-Branch und es ist nur ein '%v'-Branch gleichzeitig erlaubt",
+		MsgNoBranchToFinish:      "Repository hat keinen '%v'-Branch, der abgeschlossen werden kann",
+		MsgMultipleBranches:      "Repository darf nicht mehrere '%v'-Branches haben",
+		MsgCandidateNoBranch:     "Repository hat keinen '%v'-Branch, für den ein Release-Kandidat getaggt werden kann",
+		MsgToolNotAvailable:      "Werkzeug '%v' ist auf dem System nicht verfügbar",
+		MsgRepositoryNotClean:    "Repository im Projektpfad '%v' hat nicht übernommene Änderungen",
+		MsgRebaseInProgress:      "in '%v' läuft ein Rebase: zuerst mit 'git rebase --continue' abschließen oder mit 'git rebase --abort' abbrechen",
+		MsgMergeInProgress:       "in '%v' läuft ein Merge: zuerst mit 'git commit' committen oder mit 'git merge --abort' abbrechen",
+		MsgCherryPickInProgress:  "in '%v' läuft ein Cherry-Pick: zuerst mit 'git cherry-pick --continue' abschließen oder mit 'git cherry-pick --abort' abbrechen",
+		MsgRevertInProgress:      "in '%v' läuft ein Revert: zuerst mit 'git revert --continue' abschließen oder mit 'git revert --abort' abbrechen",
+		MsgBisectInProgress:      "in '%v' läuft ein Bisect: zuerst mit 'git bisect reset' beenden",
+		MsgEnvironmentUnknown:    "Umgebung '%v' ist nicht konfiguriert (konfigurierte Umgebungen: %v)",
+		MsgVersionNotReleased:    "Version '%v' wurde nicht veröffentlicht (Tag '%v' nicht gefunden)",
+		MsgAlreadyReleased:       "Version '%v' wurde bereits veröffentlicht",
+		MsgReleaseVersionInvalid: "Release-Version '%v' muss eine Version ohne Qualifier sein (z. B. '2.5.0')",
+		MsgInitialVersionInvalid: "Initiale Version '%v' der Einstellung '%v.%v' muss eine Version ohne Qualifier sein (z. B. '1.0.0')",
+		MsgVersionFileMissing:    "Projekt hat keine Versionsdatei '%v' und das Erstellen ist durch die Einstellung '%v.%v' deaktiviert",
+		MsgPromotionOutOfOrder:   "Version '%v' muss für '%v' freigegeben werden, bevor sie für '%v' freigegeben wird",
+		MsgAlreadyPromoted:       "Version '%v' wurde bereits für '%v' freigegeben",
+		MsgMergeConflictPaused:   "Zusammenführen von '%v' wegen Konflikten angehalten in: %v (Konflikte auflösen und den Merge committen, um den Abschluss fortzusetzen)",
+		MsgWorkflowInterrupted:   "%v-Abschluss von '%v' wurde bei Schritt '%v' unterbrochen: mit '%v continue' fortsetzen",
+		MsgNothingToContinue:     "kein unterbrochener %v-Abschluss zum Fortsetzen",
+		MsgContinueOtherFlow:     "der unterbrochene Abschluss ist ein %v-Abschluss: '%v continue' ausführen",
+		MsgStateFileInvalid:      "Workflow-Statusdatei '%v' ist ungültig: %v (löschen, um den Abschluss von vorne zu beginnen)",
+		MsgWorkflowResumable:     "INFO: %v-Abschluss bei Schritt '%v' angehalten: Ursache beheben und mit '%v continue' fortsetzen",
+		MsgNothingToRecover:      "kein halb abgeschlossener Release- oder Hotfix-Abschluss gefunden",
+		MsgRecoverFromState:      "%v-Abschluss von '%v' wurde laut Statusdatei unterbrochen, verbleibende Schritte ab '%v':",
+		MsgRecoverFromHistory:    "%v-Abschluss von '%v' ist laut Branches und Tags halb abgeschlossen, verbleibende Schritte ab '%v':",
+		MsgRecoverExecute:        "mit 'recover --execute' die verbleibenden Schritte ausführen",
+		MsgInvalidRefSetting:     "Einstellung '%v' enthält '%v', das kein gültiger Git-Branch- oder Tag-Name ist",
+		MsgInvalidQualifier:      "Einstellung '%v' enthält '%v', das kein gültiger Versions-Qualifier ist (Buchstaben und Ziffern, getrennt durch Punkte oder Bindestriche)",
+		MsgInvalidPathSetting:    "Einstellung '%v' enthält '%v', das kein relativer Pfad innerhalb des Projekts ist",
+		MsgInvalidPattern:        "Einstellung '%v' enthält '%v', das kein gültiges Pfadmuster ist",
+		MsgInvalidAuthor:         "Einstellung '%v' enthält '%v', das keine Identität der Form 'Name <email>' ist",
+		MsgTemplatePlaceholder:   "Einstellung '%v' enthält '%v', dem der Platzhalter %v fehlt",
+		MsgInvalidChoice:         "Einstellung '%v' enthält '%v', das keiner von %v ist",
+		MsgMissingCommand:        "Einstellung '%v' von %v enthält keinen Befehl",
+		MsgInvalidCommand:        "Einstellung '%v' von %v enthält '%v', dessen Programm nicht gefunden wird: %v",
+		MsgMainlineSetting:       "Einstellung '%v' enthält '%v', das einen Entwicklungs-Branch neben dem Produktions-Branch '%v' erfordert",
+		MsgMainlineNoRelease:     "Hotfixes der Mainline '%v' beginnen beim letzten Release, aber es gibt noch kein Release",
+		MsgEnvironmentDiverged:   "Umgebungs-Branch '%v' hat Commits, die nicht Teil von Version '%v' sind: Umgebungs-Branches erhalten nur freigegebene Versionen",
+		MsgVersionSyncMissing:    "Datei '%v' der Einstellung '%v' enthält die Version des Projekts nicht",
+		MsgPreTagVetoed:          "Pre-Tag-Hook hat Tag '%v' von Commit %v abgelehnt: %v",
+		MsgStaleBranchAge:        "WARNUNG: Branch '%v' ist seit %v Tagen offen (Grenze %v): abschließen oder löschen",
+		MsgStaleBranchBehind:     "WARNUNG: Branch '%v' liegt %v Commits hinter '%v' (Grenze %v): abschließen oder löschen",
+		MsgDetachedHead:          "HEAD ist losgelöst: vor dem Start eines %v einen Branch auschecken (z. B. 'git switch develop')",
+		MsgUnpushedWorkBranch:    "Branch '%v' hat %v nicht gepushte(n) Commit(s): vor dem Start eines %v pushen oder verwerfen",
+		MsgSwitchingToBase:       "INFO: Branch '%v' wird verlassen und zu '%v' gewechselt, um den %v zu starten",
+		MsgBranchFastForwarded:   "INFO: Lokaler Branch '%v' um %v Commit(s) auf '%v' vorgespult",
+		MsgPruneHint:             "INFO: '%v' auf dem Remote gelöscht; Mitwirkende entfernen den Remote-Tracking-Branch mit 'git fetch --prune %v'",
+		MsgBranchDiverged:        "WARNUNG: Lokaler Branch '%v' ist von '%v' abgewichen und wurde nicht vorgespult: Branches manuell abgleichen",
+		MsgFastForwardFailed:     "WARNUNG: Vorspulen lokaler Branches für '%v' fehlgeschlagen: %v",
+		MsgDivergentBranches:     "Branch '%v' ist von '%v' abgewichen und kann mit der Strategie '%v' nicht gepullt werden: Branches manuell abgleichen oder 'workflow.pull-strategy' ändern",
+		MsgBugfixNameInvalid:     "Bugfix-Name '%v' darf nicht leer sein oder Leerzeichen enthalten",
+		MsgBugfixAlreadyExists:   "Repository hat bereits einen Bugfix-Branch '%v'",
+		MsgBugfixNotFound:        "Repository hat keinen Bugfix-Branch '%v', der abgeschlossen werden kann",
+		MsgBugfixAmbiguous:       "Repository hat mehrere Bugfix-Branches (%v): den abzuschließenden Branch angeben",
+		MsgIdentityMissing:       "Git hat keine Commit-Identität für '%v': user.name und user.email für dieses Verzeichnis konfigurieren (%v)",
+		MsgIssueKeyInvalid:       "Ticket '%v' ist kein gültiger Schlüssel für den Issue-Tracker '%v' (z. B. 'PROJ-123' für jira, '#42' für github)",
+		MsgTrackerNotConfigured:  "Issue-Tracker '%v' benötigt die Einstellung '%v'",
+		MsgSupportLineInvalid:    "Support-Linie '%v' muss eine Haupt- und Nebenversion sein (z. B. '1.2')",
+		MsgSupportAlreadyExists:  "Repository hat bereits einen Support-Branch '%v'",
+		MsgSupportNotFound:       "Repository hat keinen Support-Branch '%v' (mit 'support start' erstellen)",
+		MsgTemplateUnknown:       "Vorlage '%v' ist nicht verfügbar (verfügbare Vorlagen: %v)",
+		MsgRepositoryNotEmpty:    "Repository unter Projektpfad '%v' hat bereits Commits: bestehende Projekte mit 'init' vorbereiten",
+		MsgDryRunUnsupported:     "Befehl '%v' unterstützt keinen Probelauf",
+		MsgPlanInvalid:           "Ausführungsplan '%v' ist ungültig: %v",
+		MsgPlanMismatch:          "Ausführungsplan '%v' passt nicht mehr zum Repository: %v",
+		MsgBumpPullRequest:       "INFO: %v %v von '%v' nach '%v' mit der nächsten Entwicklungsversion erstellt",
+		MsgBumpOpenManually:      "INFO: ein %v von '%v' nach '%v' liefert die nächste Entwicklungsversion aus",
+		MsgBumpPullRequestFailed: "Erstellen des %vs von '%v' nach '%v' fehlgeschlagen mit %v, der Branch wurde übertragen",
+		MsgGitOpsPattern:         "Einstellung '%v' enthält '%v', das kein regulärer Ausdruck mit einer Gruppe für die Version ist",
+		MsgGitOpsFailed:          "Aktualisierung der Deployment-Manifeste von '%v' fehlgeschlagen: %v",
+		MsgGitOpsPullRequest:     "INFO: Pull-Request #%v von '%v' nach '%v' von '%v' mit der freigegebenen Version erstellt",
+		MsgGitOpsOpenManually:    "INFO: ein Pull-Request von '%v' nach '%v' von '%v' liefert die freigegebene Version aus",
+		MsgViaPullRequestNoPush:  "der Abschluss über Pull- oder Merge-Requests überträgt den abgeschlossenen Branch, aber das Übertragen ist deaktiviert (--no-push oder 'workflow.push: false')",
+		MsgHostingTokenMissing:   "der Abschluss über %vs erfordert ein %v-Token in %v",
+		MsgPullRequestOpened:     "INFO: %v %v von '%v' nach '%v' erstellt",
+		MsgPullRequestFailed:     "%v von '%v' nach '%v' fehlgeschlagen mit %v",
+		MsgPullRequestClosed:     "%v %v von '%v' nach '%v' wurde ohne Merge geschlossen: erneut öffnen und mergen, dann den Abschluss fortsetzen",
+		MsgPullRequestsOpen:      "%v(s) %v von '%v' noch nicht gemergt: mergen und '%v continue' ausführen, um den Merge zu taggen, oder mit --wait auf den Merge warten",
+		MsgPullRequestsTimeout:   "%v(s) %v von '%v' wurden nicht innerhalb von %v gemergt (das Limit ist unter '%v' konfiguriert): nach dem Merge '%v continue' ausführen",
+		MsgPullRequestsWait:      "INFO: warte auf den Merge von %v(s) %v (höchstens %v)",
+		MsgPullRequestTerm:       "Pull-Request",
+		MsgMergeRequestTerm:      "Merge-Request",
+		MsgPublishTokenMissing:   "das Veröffentlichen des %v-Releases erfordert ein %v-Token in %v",
+		MsgPublishDraftMissing:   "%v hat keine Release-Entwürfe: das Release ohne --draft veröffentlichen",
+		MsgPublishUnsupported:    "%v hat keine Releases: das Release ohne --publish abschließen",
+		MsgBranchRestricted:      "Branch '%v' nimmt Änderungen nur über %vs auf %v an: mit --via-pr abschließen",
+		MsgRestrictionsUnread:    "WARNUNG: die Branch-Beschränkungen von %v konnten nicht gelesen werden (%v), der Abschluss überträgt seine Merges",
+		MsgHostedRelease:         "INFO: %v-Release von '%v' veröffentlicht: %v",
+		MsgHostedReleaseDraft:    "INFO: Entwurf des %v-Releases von '%v' erstellt, bei Vollständigkeit veröffentlichen: %v",
+		MsgHostedReleaseFailed:   "%v-Release von '%v' fehlgeschlagen mit %v",
+		MsgScaffoldInvalidName:   "Plugin-Name '%v' ist kein gültiger Go-Paketname: Kleinbuchstaben und Ziffern verwenden, beginnend mit einem Buchstaben",
+		MsgScaffoldVersionFile:   "Versionsdatei '%v' des Plugins muss ein Pfad relativ zum Projekt sein",
+		MsgScaffoldNoSourceTree:  "Pfad '%v' ist kein Quellbaum von %v, in dem Plugins erzeugt werden (--path verwenden)",
+		MsgScaffoldPluginExists:  "Plugin '%v' existiert bereits in '%v' oder ist registriert",
+		MsgScaffoldNoImports:     "Plugin-Importe in '%v' nicht gefunden",
+		MsgScaffoldCreated:       "Plugin '%v' in '%v' erzeugt und in '%v' importiert: die TODOs anpassen und 'go test ./plugin/...' ausführen",
+		MsgBumpChosen:            "INFO: %v-Erhöhung von Release %v auf %v",
+		MsgBumpNoChanges:         "keiner der %v Commit(s) seit '%v' auf '%v' ist ein Feature, Fix oder Breaking Change: Erhöhung mit --bump wählen",
+		MsgTagDrift:              "%v Abweichung(en) zwischen den Versions-Tags und '%v' gefunden",
+		MsgTagUnreachable:        "WARNUNG: Tag '%v' ist von '%v' aus nicht erreichbar",
+		MsgTagVersionMismatch:    "WARNUNG: Version %v in '%v' von '%v' entspricht nicht dem neuesten Tag %v",
+		MsgCheckViolations:       "%v Verstoß/Verstöße gegen die Workflow-Regeln auf Branch '%v' gefunden",
+		MsgCheckNoBranch:         "zu prüfender Branch ist bei einem losgelösten HEAD unbekannt: Namen angeben (z. B. 'check release/1.2.0')",
+		MsgCheckViolation:        "FEHLER: %v",
+		MsgCheckBranchName:       "Branch '%v' ist nicht '%v/<Version>' mit einer Version wie '1.2.0' benannt",
+		MsgCheckVersionNotNewer:  "Version %v von Branch '%v' ist nicht größer als das neueste Tag %v",
+		MsgCheckQualifier:        "Version %v in '%v' hat noch einen Qualifier",
+		MsgCheckQualifierMissing: "Version %v in '%v' hat nicht den Qualifier '%v'",
+		MsgCheckVersionMismatch:  "Version %v in '%v' entspricht nicht der Version %v des Branch-Namens",
+		MsgCheckNoRules:          "INFO: Branch '%v' ist kein Workflow-Branch, es gelten keine Regeln",
+		MsgDryRunStarted:         "INFO: Probelauf, das Repository unter Projektpfad '%v' wird nicht verändert",
+		MsgDryRunStep:            "DRY-RUN: %v",
+		MsgDryRunWriteFile:       "Datei '%v' schreiben",
+		MsgDryRunSetVersion:      "Version %v in '%v' setzen (%v-Plugin)",
+		MsgDryRunSkipped:         "%v überspringen",
+		MsgCIUnsupported:         "nicht unterstütztes CI-System '%v' (unterstützt: %v)",
+		MsgCIBranchMismatch:      "die Merge-Request-Pipeline von '%v' kann '%v' nicht abschließen",
+		MsgCIIdentity:            "INFO: Commits als '%v <%v>', der Benutzer, der die Pipeline ausgelöst hat",
+		MsgCIPushToken:           "INFO: Push nach '%v' mit dem Token aus %v",
+		MsgCIBranchAttached:      "INFO: Branch '%v' am Commit der Pipeline ausgecheckt",
+		MsgCIDetected:            "INFO: CI-System '%v' erkannt: Abfragen werden bestätigt, Ausgabe ist schlicht, Änderungen werden nicht zurückgerollt (abschalten mit --no-ci-defaults)",
+		MsgSchemaUnknown:         "unbekanntes Schema '%v' (verfügbar: %v)",
+		MsgAPIRetry:              "INFO: %v antwortete mit %v, neuer Versuch in %v",
+		MsgAPITimeout:            "Anfrage '%v' war nicht innerhalb von %v erfolgreich (Ratenlimit oder nicht erreichbar), das Limit wird unter '%v.%v' konfiguriert",
+		MsgReportWritten:         "Bericht über %v Release(s) nach '%v' geschrieben",
+		MsgReportNoReleases:      "Repository unter Projektpfad '%v' hat keine Release-Tags für einen Bericht",
+		MsgChangelogWritten:      "Changelog über %v Release(s) nach '%v' geschrieben",
+		MsgChangelogNoReleases:   "Repository unter Projektpfad '%v' hat keine Release-Tags für ein Changelog",
+		MsgStepUnknown:           "Workflow '%v' hat keinen Schritt '%v' (Schritte: %v)",
+		MsgStepRequired:          "Schritt '%v' von Workflow '%v' ist erforderlich und kann nicht übersprungen oder verschoben werden",
+		MsgStepMovedAcross:       "Schritt '%v' von Workflow '%v' kann nicht über den erforderlichen Schritt '%v' verschoben werden",
+		MsgStepInvalid:           "Ungültige Definition von Schritt '%v' von Workflow '%v': %v",
+		MsgStepFailed:            "Schritt '%v' von Workflow '%v' fehlgeschlagen: %v",
+		MsgMetricsWritten:        "Metriken von %v veröffentlichten Version(en) nach '%v' geschrieben",
+		MsgMetricsPushed:         "Metriken von %v veröffentlichten Version(en) an '%v' übertragen",
+		MsgMetricsNoGateway:      "unter '%v.%v' ist kein Prometheus-Pushgateway konfiguriert",
+		MsgMetricsPushFailed:     "Übertragen der Metriken an '%v' fehlgeschlagen mit %v",
+		MsgBranchCreatingAuto:    "INFO: %v-Branch '%v' wird aus '%v' erstellt",
+		MsgBranchUsingAuto:       "INFO: %v-Branch '%v' nicht gefunden, verwende '%v'",
+		MsgLayoutDetected:        "INFO: Branch '%v' nicht gefunden, verwende das git-flow-Layout des Repositorys (%v)",
+		MsgBranchNotFound:        "%v-Branch '%v' nicht gefunden.",
+		MsgBranchEnterOrCreate:   "Branch-Namen eingeben oder Enter drücken, um '%v' zu erstellen: ",
+		MsgBranchCreating:        "'%v' wird aus '%v' erstellt...",
+		MsgBranchEnterDefault:    "Branch-Namen eingeben [%v]: ",
+		MsgBranchEnterExisting:   "Namen eines vorhandenen Branches eingeben: ",
+		MsgBranchNotOnRemote:     "Branch '%v' existiert nicht auf dem Remote.",
+		MsgConfigSaveFailed:      "WARNUNG: Konfiguration konnte nicht gespeichert werden: %v",
+		MsgConfigSaved:           "Konfiguriert '%v: %v'",
+		MsgDockerFallbackAuto:    "INFO: %v nicht gefunden, verwende Docker (%v)",
+		MsgSnapshotDependencies:  "WARNUNG: SNAPSHOT-Abhängigkeiten ohne freigegebene Version bleiben im Release, '%v.%v' auf '%v' setzen, um dies zu verhindern",
+		MsgDockerFallbackPrompt:  "%v nicht gefunden. Stattdessen Docker (%v) verwenden? [J/n] ",
+		MsgConfigUsing:           "Verwende Konfigurationsdatei: %v",
+		MsgConfigCreated:         "Standard-Konfigurationsdatei erstellt: %v",
+		MsgConfigCreateFailed:    "Warnung: Standard-Konfiguration konnte nicht erstellt werden: %v",
+		MsgConfigReadFailed:      "Warnung: Konfigurationsdatei %v konnte nicht gelesen werden: %v",
+		MsgConfigIgnored:         "Warnung: Einstellung '%v' der Konfigurationsdatei %v führt Befehle aus und wird ignoriert, sofern '%v' der Benutzerkonfiguration dem Projekt nicht vertraut",
+	},
+}
+
+// Locale returns the locale of user-facing messages, selected by configuration, GITFLOW_LOCALE, or the system locale.
+func Locale() string {
+	candidates := []string{
+		viper.GetString(localeKey),
+		os.Getenv(localeEnv),
+		os.Getenv("LC_ALL"),
+		os.Getenv("LC_MESSAGES"),
+		os.Getenv("LANG"),
+	}
+
+	// the first set variable determines the locale, e.g. 'de_DE.UTF-8' selects German
+	for _, candidate := range candidates {
+		if len(candidate) == 0 {
+			continue
+		}
+		parts := strings.FieldsFunc(candidate, func(r rune) bool { return r == '_' || r == '-' || r == '.' })
+		if len(parts) > 0 {
+			if _, ok := messageCatalogs[strings.ToLower(parts[0])]; ok {
+				return strings.ToLower(parts[0])
+			}
+		}
+		return English
+	}
+
+	return English
+}
+
+// Message formats a user-facing message from the catalog of the current locale.
+func Message(key string, args ...any) string {
+	format, ok := messageCatalogs[Locale()][key]
+	if !ok {
+		format = messageCatalogs[English][key]
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// messageError is an error with a user-facing message, whose key tells the kind of failure independent of the locale.
+type messageError struct {
+	key     string
+	message string
+}
+
+func (e *messageError) Error() string {
+	return e.message
+}
+
+// Error creates an error with a user-facing message from the catalog of the current locale.
+func Error(key string, args ...any) error {
+	return &messageError{key: key, message: Message(key, args...)}
+}
+
+// errorKey returns the key of the message of an error created by Error, or an empty string for other errors.
+func errorKey(err error) string {
+	var message *messageError
+	if errors.As(err, &message) {
+		return message.key
+	}
+	return ""
+}
+
+// IsAffirmative reports whether an answer to a yes/no prompt confirms it in the current locale.
+func IsAffirmative(answer string) bool {
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "", "y", "yes":
+		return true
+	case "j", "ja":
+		return Locale() == German
+	default:
+		return false
+	}
+}
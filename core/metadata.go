@@ -0,0 +1,36 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Variables of the plugin of the project, resolved once per command.
+var projectMetadata map[string]string
+
+func resetProjectMetadata() {
+	projectMetadata = nil
+}
+
+// expandTemplate substitutes the placeholders of a message template: the pairs of placeholder and value of the
+// message, e.g. '{version}', and the variables of the plugin of the project, e.g. '{artifactId}'. The placeholders of
+// the message take precedence over variables of the same name, unknown placeholders are kept.
+func expandTemplate(template string, replacements ...string) string {
+	if projectMetadata == nil {
+		projectMetadata = make(map[string]string)
+		if provider, ok := projectPlugin().(MetadataProvider); ok {
+			maps.Copy(projectMetadata, provider.Metadata(ProjectPath))
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(projectMetadata)) {
+		replacements = append(replacements, "{"+name+"}", projectMetadata[name])
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
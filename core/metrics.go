@@ -0,0 +1,241 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Configuration group and settings of the metrics export.
+const (
+	metricsGroup              = "metrics"
+	metricsPushgatewaySetting = "pushgateway"
+	metricsJobSetting         = "job"
+)
+
+// Default job of the metrics pushed to a Prometheus pushgateway.
+const defaultMetricsJob = "gitflow-cli"
+
+// Metrics settings of the current run.
+var metricsPushgateway string
+var metricsJob = defaultMetricsJob
+
+func applyMetricsSettings(settings map[string]any) {
+	if v, ok := settings[metricsPushgatewaySetting].(string); ok {
+		metricsPushgateway = strings.TrimSuffix(v, "/")
+	}
+	if v, ok := settings[metricsJobSetting].(string); ok && len(v) > 0 {
+		metricsJob = v
+	}
+}
+
+func resetMetricsSettings() {
+	metricsPushgateway = ""
+	metricsJob = defaultMetricsJob
+}
+
+// Metrics are the DORA-style delivery metrics of a repository over a period, described by the 'metrics' schema.
+// Every released version counts as a deployment, and its lead time is the duration from the first commit of its
+// release or hotfix branch to its tag.
+type Metrics struct {
+	Repository             string           `json:"repository"`
+	Since                  time.Time        `json:"since"`
+	Until                  time.Time        `json:"until"`
+	Releases               int              `json:"releases"`
+	Hotfixes               int              `json:"hotfixes"`
+	ReleasesPerWeek        float64          `json:"releasesPerWeek"`
+	ReleaseLeadTimeSeconds float64          `json:"releaseLeadTimeSeconds"`
+	HotfixLeadTimeSeconds  float64          `json:"hotfixLeadTimeSeconds"`
+	Versions               []VersionMetrics `json:"versions"`
+}
+
+// VersionMetrics are the metrics of a released version.
+type VersionMetrics struct {
+	Version         string    `json:"version"`
+	Kind            string    `json:"kind"`
+	Date            time.Time `json:"date"`
+	LeadTimeSeconds *float64  `json:"leadTimeSeconds,omitempty"`
+	Commits         int       `json:"commits"`
+}
+
+// CollectMetrics derives the metrics of the versions released within the given number of days, or of all released
+// versions for zero days, from the version tags. The lead times are the medians of the versions whose branch was
+// created by the workflow.
+func CollectMetrics(projectPath string, days int) (Metrics, error) {
+	// apply suitable settings from the global configuration to the core package
+	if err := applySettings(); err != nil {
+		return Metrics{}, err
+	}
+
+	// set path to execute workflow commands
+	ProjectPath = projectPath
+
+	// check if project path exists
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return Metrics{}, Error(MsgProjectPathMissing, projectPath)
+	}
+
+	repository := NewRepository(projectPath, Remote)
+
+	releases, err := summarizeReleases(repository, 0)
+	if err != nil {
+		return Metrics{}, err
+	}
+
+	// without a number of days, the period starts with the oldest released version
+	until := time.Now().UTC().Truncate(time.Second)
+	since := until.AddDate(0, 0, -days)
+	if days <= 0 && len(releases) > 0 {
+		since = releases[len(releases)-1].Date.UTC()
+	}
+
+	metrics := Metrics{
+		Repository: filepath.Base(repository.Local()),
+		Since:      since,
+		Until:      until,
+		Versions:   make([]VersionMetrics, 0),
+	}
+
+	if remoteURL, err := repository.RemoteURL(); err == nil && len(githubRepository(remoteURL)) > 0 {
+		metrics.Repository = githubRepository(remoteURL)
+	}
+
+	var releaseLeadTimes, hotfixLeadTimes []time.Duration
+	for _, release := range releases {
+		if release.Date.Before(metrics.Since) {
+			continue
+		}
+
+		version := VersionMetrics{Version: release.Version, Kind: release.Kind, Date: release.Date.UTC(), Commits: len(release.Commits)}
+		if release.Started {
+			seconds := release.Duration.Seconds()
+			version.LeadTimeSeconds = &seconds
+		}
+		metrics.Versions = append(metrics.Versions, version)
+
+		if release.Kind == hotfixKind {
+			metrics.Hotfixes++
+			if release.Started {
+				hotfixLeadTimes = append(hotfixLeadTimes, release.Duration)
+			}
+		} else {
+			metrics.Releases++
+			if release.Started {
+				releaseLeadTimes = append(releaseLeadTimes, release.Duration)
+			}
+		}
+	}
+
+	if weeks := until.Sub(since).Hours() / (7 * 24); weeks > 0 {
+		metrics.ReleasesPerWeek = float64(metrics.Releases+metrics.Hotfixes) / weeks
+	}
+	metrics.ReleaseLeadTimeSeconds = median(releaseLeadTimes).Seconds()
+	metrics.HotfixLeadTimeSeconds = median(hotfixLeadTimes).Seconds()
+
+	return metrics, nil
+}
+
+// median returns the median of durations, or zero if there are none.
+func median(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+// CSV returns the metrics of the released versions as comma-separated values with a header row.
+func (m Metrics) CSV() string {
+	var content bytes.Buffer
+	writer := csv.NewWriter(&content)
+
+	_ = writer.Write([]string{"version", "kind", "date", "lead_time_seconds", "commits"})
+	for _, version := range m.Versions {
+		leadTime := ""
+		if version.LeadTimeSeconds != nil {
+			leadTime = strconv.FormatFloat(*version.LeadTimeSeconds, 'f', -1, 64)
+		}
+		_ = writer.Write([]string{version.Version, version.Kind, version.Date.Format(time.RFC3339), leadTime, strconv.Itoa(version.Commits)})
+	}
+
+	writer.Flush()
+	return content.String()
+}
+
+// Prometheus returns the metrics in the text exposition format of Prometheus.
+func (m Metrics) Prometheus() string {
+	var builder strings.Builder
+
+	gauge := func(name, help string, samples ...string) {
+		fmt.Fprintf(&builder, "# HELP %v %v\n# TYPE %v gauge\n", name, help, name)
+		for _, sample := range samples {
+			fmt.Fprintf(&builder, "%v%v\n", name, sample)
+		}
+	}
+
+	gauge("gitflow_released_versions", "Number of versions released within the period.",
+		fmt.Sprintf(`{kind="%v"} %v`, releaseKind, m.Releases), fmt.Sprintf(`{kind="%v"} %v`, hotfixKind, m.Hotfixes))
+	gauge("gitflow_releases_per_week", "Released versions per week within the period.", fmt.Sprintf(" %v", m.ReleasesPerWeek))
+	gauge("gitflow_lead_time_seconds", "Median duration from the first commit of a branch to the tag of its version.",
+		fmt.Sprintf(`{kind="%v"} %v`, releaseKind, m.ReleaseLeadTimeSeconds), fmt.Sprintf(`{kind="%v"} %v`, hotfixKind, m.HotfixLeadTimeSeconds))
+	gauge("gitflow_metrics_period_seconds", "Duration of the period of the metrics.", fmt.Sprintf(" %v", m.Until.Sub(m.Since).Seconds()))
+
+	if len(m.Versions) > 0 {
+		gauge("gitflow_last_release_timestamp_seconds", "Time of the tag of the latest released version.",
+			fmt.Sprintf(" %v", m.Versions[0].Date.Unix()))
+	}
+
+	return builder.String()
+}
+
+// PushMetrics replaces the metrics of the repository at the Prometheus pushgateway configured under
+// 'metrics.pushgateway'. The metrics are grouped by job and repository, which is base64-encoded because it may
+// contain slashes.
+func PushMetrics(metrics Metrics) error {
+	if len(metricsPushgateway) == 0 {
+		return Error(MsgMetricsNoGateway, metricsGroup, metricsPushgatewaySetting)
+	}
+
+	endpoint := fmt.Sprintf("%v/metrics/job/%v/repository@base64/%v", metricsPushgateway, url.PathEscape(metricsJob),
+		base64.RawURLEncoding.EncodeToString([]byte(metrics.Repository)))
+
+	request, err := http.NewRequest(http.MethodPut, endpoint, strings.NewReader(metrics.Prometheus()))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	response, err := apiClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode/100 != 2 {
+		return Error(MsgMetricsPushFailed, metricsPushgateway, response.Status)
+	}
+
+	Success(Message(MsgMetricsPushed, len(metrics.Versions), metricsPushgateway))
+	return nil
+}
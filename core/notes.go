@@ -0,0 +1,432 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Release notes settings keys.
+const (
+	notesGroup           = "notes"
+	notesIssuesSetting   = "issues"
+	issuePatternSetting  = "pattern"
+	issueLinkSetting     = "link"
+	githubHandlesSetting = "github-handles"
+	githubAPISetting     = "github-api"
+)
+
+// GitHub API defaults for resolving contributor handles.
+const (
+	defaultGitHubAPI = "https://api.github.com"
+	githubTokenEnv   = "GITHUB_TOKEN"
+)
+
+// GitHub noreply addresses contain the handle of the account, e.g. 123+octocat@users.noreply.github.com.
+var githubNoreplyExpression = regexp.MustCompile(`^(?:\d+\+)?([^@]+)@users\.noreply\.github\.com$`)
+
+// Placeholder in issue link templates that is replaced by the issue identifier.
+const issueIDPlaceholder = "{id}"
+
+// IssuePattern recognizes issue references in commit messages and links them to the issue tracker.
+type IssuePattern struct {
+	Expression *regexp.Regexp
+	Link       string
+}
+
+// Default issue patterns for JIRA keys (PROJ-123) and hosting issue numbers (#456).
+var defaultIssuePatterns = []IssuePattern{
+	{Expression: regexp.MustCompile(`\b[A-Z][A-Z0-9]+-\d+\b`)},
+	{Expression: regexp.MustCompile(`#(\d+)\b`)},
+}
+
+// issuePatternSettings returns the configured issue patterns or the default patterns.
+func issuePatternSettings() ([]IssuePattern, error) {
+	settings, ok := viper.AllSettings()[notesGroup].(map[string]any)
+	if !ok {
+		return defaultIssuePatterns, nil
+	}
+
+	issues, ok := settings[notesIssuesSetting].([]any)
+	if !ok {
+		return defaultIssuePatterns, nil
+	}
+
+	patterns := make([]IssuePattern, 0, len(issues))
+	for _, issue := range issues {
+		entry, ok := issue.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid issue pattern configuration: %v", issue)
+		}
+
+		pattern, _ := entry[issuePatternSetting].(string)
+		if len(pattern) == 0 {
+			return nil, fmt.Errorf("issue pattern configuration without pattern: %v", issue)
+		}
+
+		expression, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid issue pattern '%v': %v", pattern, err)
+		}
+
+		link, _ := entry[issueLinkSetting].(string)
+		patterns = append(patterns, IssuePattern{Expression: expression, Link: link})
+	}
+
+	return patterns, nil
+}
+
+// IssueReference groups the changes of a release that refer to the same issue.
+type IssueReference struct {
+	ID      string   `json:"id"`
+	Link    string   `json:"link,omitempty"`
+	Changes []string `json:"changes"`
+}
+
+// Contributor is an author of changes included in a release.
+type Contributor struct {
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Handle string `json:"handle,omitempty"`
+}
+
+// ReleaseNotes summarizes the changes of a release.
+type ReleaseNotes struct {
+	Version      string           `json:"version"`
+	Issues       []IssueReference `json:"issues"`
+	Changes      []string         `json:"changes"`
+	Contributors []Contributor    `json:"contributors"`
+	Compare      string           `json:"compare,omitempty"`
+}
+
+// Notes collects the release notes of a released version or, without version, of the current release branch.
+func Notes(version, projectPath string) (ReleaseNotes, error) {
+	// apply suitable settings from the global configuration to the core package
+	if err := applySettings(); err != nil {
+		return ReleaseNotes{}, err
+	}
+
+	patterns, err := issuePatternSettings()
+	if err != nil {
+		return ReleaseNotes{}, err
+	}
+
+	// set path to execute workflow commands
+	ProjectPath = projectPath
+
+	// check if project path exists
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return ReleaseNotes{}, Error(MsgProjectPathMissing, projectPath)
+	}
+
+	repository := NewRepository(projectPath, Remote)
+
+	release, revisionRange, err := notesRange(repository, version)
+	if err != nil {
+		return ReleaseNotes{}, err
+	}
+
+	commits, err := repository.ProjectCommits(revisionRange)
+	if err != nil {
+		return ReleaseNotes{}, err
+	}
+
+	notes := collectNotes(release, commits, patterns)
+	notes.Compare = compareLink(repository, revisionRange)
+
+	// optionally resolve the GitHub handles of contributors without noreply address
+	if settings, ok := viper.AllSettings()[notesGroup].(map[string]any); ok {
+		if enabled, _ := settings[githubHandlesSetting].(bool); enabled {
+			api := defaultGitHubAPI
+			if v, ok := settings[githubAPISetting].(string); ok && len(v) > 0 {
+				api = v
+			}
+			if err := resolveGitHubHandles(repository, &notes, commits, api); err != nil {
+				return ReleaseNotes{}, err
+			}
+		}
+	}
+
+	return notes, nil
+}
+
+// notesRange determines the released version and the revision range of its changes.
+func notesRange(repository Repository, version string) (Version, string, error) {
+	// without version, the notes cover the current release branch compared to the production branch
+	if len(version) == 0 {
+		if err := repository.Fetch(); err != nil {
+			return Version{}, "", err
+		}
+		if found, branches, err := repository.HasBranch(Release); err != nil {
+			return Version{}, "", err
+		} else if !found {
+			return Version{}, "", fmt.Errorf("repository does not have a '%v' branch to collect release notes for", Release)
+		} else if len(branches) > 1 {
+			return Version{}, "", Error(MsgMultipleBranches, Release)
+		} else {
+			return branches[0].Version, fmt.Sprintf("%v/%v..%v", Remote, Production, branches[0].Ref()), nil
+		}
+	}
+
+	// with version, the notes cover the changes since the previous release tag
+	release, err := ParseVersion(version)
+	if err != nil {
+		return Version{}, "", err
+	}
+
+	if found, err := repository.HasTag(versionTag(release.String())); err != nil {
+		return Version{}, "", err
+	} else if !found {
+		return Version{}, "", Error(MsgVersionNotReleased, release, release)
+	}
+
+	tags, err := repository.Tags()
+	if err != nil {
+		return Version{}, "", err
+	}
+
+	previous := previousReleaseTag(tags, release)
+	if previous == "" {
+		return release, versionTag(release.String()), nil
+	}
+
+	return release, fmt.Sprintf("%v..%v", previous, versionTag(release.String())), nil
+}
+
+// previousReleaseTag returns the tag of the latest release preceding a release, or an empty string for the first.
+func previousReleaseTag(tags []string, release Version) string {
+	previous := ""
+	var previousVersion Version
+	for _, tagName := range tags {
+		if candidate, ok := releaseTag(tagName); !ok {
+			continue
+		} else if candidate.Less(release) && (previous == "" || previousVersion.Less(candidate)) {
+			previous, previousVersion = tagName, candidate
+		}
+	}
+	return previous
+}
+
+// compareLink returns the web link of the changes of a revision range on the hosting service of the remote, e.g.
+// the changes between two release tags, or of the release branch compared to the production branch.
+func compareLink(repository Repository, revisionRange string) string {
+	from, to, found := strings.Cut(revisionRange, "..")
+	if !found {
+		return ""
+	}
+
+	hosted, ok := remoteRepository(repository)
+	if !ok {
+		return ""
+	}
+
+	// a remote branch is linked by its name
+	from, _ = strings.CutPrefix(from, Remote+"/")
+	to, _ = strings.CutPrefix(to, Remote+"/")
+	_, tags := releaseTag(to)
+	return hosted.compareLink(from, to, tags)
+}
+
+// collectNotes groups the commits of a release by the issues they refer to.
+func collectNotes(release Version, commits []Commit, patterns []IssuePattern) ReleaseNotes {
+	notes := ReleaseNotes{
+		Version:      release.String(),
+		Issues:       make([]IssueReference, 0),
+		Changes:      make([]string, 0),
+		Contributors: make([]Contributor, 0),
+	}
+	positions := make(map[string]int)
+	contributors := make(map[string]bool)
+
+	// the commit log lists the newest commit first, the notes list changes in chronological order
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		references := issueReferences(commit.Subject+"\n"+commit.Body, patterns)
+
+		// contributors are deduplicated by their email address
+		if key := strings.ToLower(commit.AuthorEmail); !contributors[key] {
+			contributors[key] = true
+			contributor := Contributor{Name: commit.AuthorName, Email: commit.AuthorEmail}
+			if match := githubNoreplyExpression.FindStringSubmatch(key); match != nil {
+				contributor.Handle = match[1]
+			}
+			notes.Contributors = append(notes.Contributors, contributor)
+		}
+
+		if len(references) == 0 {
+			notes.Changes = append(notes.Changes, commit.Subject)
+			continue
+		}
+
+		for _, reference := range references {
+			position, ok := positions[reference.ID]
+			if !ok {
+				position = len(notes.Issues)
+				positions[reference.ID] = position
+				notes.Issues = append(notes.Issues, reference)
+			}
+			notes.Issues[position].Changes = append(notes.Issues[position].Changes, commit.Subject)
+		}
+	}
+
+	return notes
+}
+
+// issueReferences extracts the distinct issue references of a commit message.
+func issueReferences(text string, patterns []IssuePattern) []IssueReference {
+	references := make([]IssueReference, 0)
+	seen := make(map[string]bool)
+
+	for _, pattern := range patterns {
+		for _, match := range pattern.Expression.FindAllStringSubmatch(text, -1) {
+			if seen[match[0]] {
+				continue
+			}
+			seen[match[0]] = true
+
+			// the first capture group is the identifier for links, e.g. 456 for #456
+			id := match[0]
+			if len(match) > 1 && len(match[1]) > 0 {
+				id = match[1]
+			}
+
+			reference := IssueReference{ID: match[0]}
+			if len(pattern.Link) > 0 {
+				reference.Link = strings.ReplaceAll(pattern.Link, issueIDPlaceholder, id)
+			}
+			references = append(references, reference)
+		}
+	}
+
+	return references
+}
+
+// Markdown renders the release notes as Markdown document.
+func (n ReleaseNotes) Markdown() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "# Release %v\n", n.Version)
+
+	if len(n.Issues) > 0 {
+		builder.WriteString("\n## Issues\n\n")
+		for _, issue := range n.Issues {
+			reference := issue.ID
+			if len(issue.Link) > 0 {
+				reference = fmt.Sprintf("[%v](%v)", issue.ID, issue.Link)
+			}
+			fmt.Fprintf(&builder, "- %v: %v\n", reference, strings.Join(issue.Changes, "; "))
+		}
+	}
+
+	if len(n.Changes) > 0 {
+		builder.WriteString("\n## Other Changes\n\n")
+		for _, change := range n.Changes {
+			fmt.Fprintf(&builder, "- %v\n", change)
+		}
+	}
+
+	if len(n.Contributors) > 0 {
+		builder.WriteString("\n## Contributors\n\n")
+		for _, contributor := range n.Contributors {
+			if len(contributor.Handle) > 0 {
+				fmt.Fprintf(&builder, "- %v (@%v)\n", contributor.Name, contributor.Handle)
+			} else {
+				fmt.Fprintf(&builder, "- %v <%v>\n", contributor.Name, contributor.Email)
+			}
+		}
+	}
+
+	if len(n.Compare) > 0 {
+		fmt.Fprintf(&builder, "\n**Full Changelog**: %v\n", n.Compare)
+	}
+
+	return builder.String()
+}
+
+// resolveGitHubHandles looks up the GitHub handles of contributors via the commits API of the hosting repository.
+func resolveGitHubHandles(repository Repository, notes *ReleaseNotes, commits []Commit, api string) error {
+	url, err := repository.RemoteURL()
+	if err != nil {
+		return err
+	}
+
+	// the owner and name of the repository are the last two segments of the remote URL
+	name := githubRepository(url)
+	if len(name) == 0 {
+		return fmt.Errorf("cannot determine GitHub repository from remote URL '%v'", url)
+	}
+
+	for i, contributor := range notes.Contributors {
+		if len(contributor.Handle) > 0 {
+			continue
+		}
+
+		// any commit of the contributor identifies the associated GitHub account
+		for _, commit := range commits {
+			if !strings.EqualFold(commit.AuthorEmail, contributor.Email) {
+				continue
+			}
+
+			handle, err := githubCommitAuthor(fmt.Sprintf("%v/repos/%v/commits/%v", strings.TrimSuffix(api, "/"), name, commit.Hash))
+			if err != nil {
+				return err
+			}
+
+			notes.Contributors[i].Handle = handle
+			break
+		}
+	}
+
+	return nil
+}
+
+// githubCommitAuthor returns the login of the GitHub account that authored a commit, if any.
+func githubCommitAuthor(url string) (string, error) {
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	request.Header.Set("Accept", "application/vnd.github+json")
+	if token := os.Getenv(githubTokenEnv); len(token) > 0 {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	response, err := apiClient.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("GitHub request '%v' failed with %v", url, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	// commits of authors without GitHub account are not an error
+	if response.StatusCode == http.StatusNotFound || response.StatusCode == http.StatusUnprocessableEntity {
+		return "", nil
+	} else if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GitHub request '%v' failed with status %v", url, response.Status)
+	}
+
+	var commit struct {
+		Author *struct {
+			Login string `json:"login"`
+		} `json:"author"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&commit); err != nil {
+		return "", fmt.Errorf("decoding GitHub response '%v' failed with %v", url, err)
+	}
+
+	if commit.Author == nil {
+		return "", nil
+	}
+
+	return commit.Author.Login, nil
+}
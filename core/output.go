@@ -0,0 +1,108 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Output settings key.
+const outputKey = "output"
+
+// Output modes of user-facing messages.
+const (
+	OutputAuto  = "auto"
+	OutputPlain = "plain"
+	OutputRich  = "rich"
+	OutputJSON  = "json"
+)
+
+// ANSI escape sequences and glyphs of the rich output mode.
+const (
+	colorReset  = "\x1b[0m"
+	colorCyan   = "\x1b[36m"
+	colorGreen  = "\x1b[32m"
+	colorRed    = "\x1b[31m"
+	glyphArrow  = "▸"
+	glyphCheck  = "✔"
+	glyphCross  = "✖"
+	promptColor = "\x1b[1m"
+)
+
+// PlainOutput reports whether user-facing messages are written as plain line-oriented text without colors and
+// glyphs, which is the default in every mode but the rich mode. The rich mode is an explicit opt-in by the 'output'
+// setting, and NO_COLOR or TERM=dumb keep the output plain even then, so that screen readers and log collectors get
+// clean text.
+func PlainOutput() bool {
+	if viper.GetString(outputKey) != OutputRich {
+		return true
+	}
+
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return true
+	}
+	return os.Getenv("TERM") == "dumb"
+}
+
+// JSONOutput reports whether commands write a result document to standard output, see Result.
+func JSONOutput() bool {
+	return viper.GetString(outputKey) == OutputJSON
+}
+
+// Status messages are discarded while an approved execution plan is verified.
+var statusSilenced = false
+
+// statusOutput returns the writer of status messages, which is standard error in JSON output mode, so that
+// standard output only holds the result document.
+func statusOutput() io.Writer {
+	if statusSilenced {
+		return io.Discard
+	}
+	if JSONOutput() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// Progress writes a message about a started workflow step to standard output.
+func Progress(message string) {
+	startSection()
+	writeStatus(message, colorCyan, glyphArrow)
+}
+
+// Success writes a message about a completed workflow step to standard output.
+func Success(message string) {
+	writeStatus(message, colorGreen, glyphCheck)
+	endSection()
+}
+
+// Failure writes a message about a failed workflow step to standard output.
+func Failure(message string) {
+	writeStatus(message, colorRed, glyphCross)
+	endSection()
+}
+
+func writeStatus(message, color, glyph string) {
+	if PlainOutput() {
+		_, _ = fmt.Fprintln(statusOutput(), message)
+		return
+	}
+	_, _ = fmt.Fprintf(statusOutput(), "%v%v%v %v\n", color, glyph, colorReset, message)
+}
+
+// Prompt writes an interactive prompt, which is terminated by a line break in plain output mode.
+func Prompt(w io.Writer, message string) {
+	if PlainOutput() {
+		_, _ = fmt.Fprintln(w, strings.TrimRight(message, " "))
+		return
+	}
+	_, _ = fmt.Fprint(w, promptColor+message+colorReset)
+}
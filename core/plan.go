@@ -0,0 +1,194 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// Plan is the execution plan of a workflow command recorded in dry-run mode, which can be approved, e.g. by a policy
+// engine, and then executed as approved: the command, the commits of the gitflow branches the plan is based on, and
+// the steps which would change the repository.
+type Plan struct {
+	Command string            `json:"command"`
+	Refs    map[string]string `json:"refs"`
+	Steps   []PlanStep        `json:"steps"`
+}
+
+// PlanStep is a step of an execution plan: a git command, a version change, a written file, or a step which is
+// skipped in dry-run mode, e.g. a plugin hook.
+type PlanStep struct {
+	Git     []string `json:"git,omitempty"`
+	Branch  string   `json:"branch,omitempty"`
+	Version string   `json:"version,omitempty"`
+	File    string   `json:"file,omitempty"`
+	Write   string   `json:"write,omitempty"`
+	Skip    string   `json:"skip,omitempty"`
+}
+
+// Plan recorded by the current command, which then runs in dry-run mode.
+var recordedPlan *Plan
+
+// RecordPlan records the execution plan of a command, which runs in dry-run mode regardless of the configuration.
+func RecordPlan(command string) {
+	recordedPlan = &Plan{Command: command, Steps: make([]PlanStep, 0)}
+}
+
+// ResetPlan stops recording the execution plan.
+func ResetPlan() {
+	recordedPlan = nil
+}
+
+// WritePlan writes the recorded execution plan to a file, which fails for commands without dry run.
+func WritePlan(fileName, command string) error {
+	if recordedPlan == nil || recordedPlan.Refs == nil {
+		return Error(MsgDryRunUnsupported, command)
+	}
+
+	content, err := json.MarshalIndent(recordedPlan, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, append(content, '\n'), 0644)
+}
+
+// ReadPlan reads an approved execution plan from a file.
+func ReadPlan(fileName string) (Plan, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	var plan Plan
+	if err := json.Unmarshal(content, &plan); err != nil {
+		return Plan{}, Error(MsgPlanInvalid, fileName, err)
+	}
+	return plan, nil
+}
+
+// VerifyPlan runs a command silently in dry-run mode and fails unless its execution plan is the approved plan, so
+// that the command is only executed if neither the repository nor the configuration changed since the approval.
+func VerifyPlan(approved Plan, fileName, command string, run func() error) error {
+	RecordPlan(command)
+	statusSilenced = true
+
+	err := run()
+
+	current := recordedPlan
+	statusSilenced = false
+	ResetPlan()
+	ResetResult()
+
+	if err != nil {
+		return err
+	}
+	if current.Refs == nil {
+		return Error(MsgDryRunUnsupported, command)
+	}
+
+	if difference := planDifference(approved, *current); len(difference) > 0 {
+		return Error(MsgPlanMismatch, fileName, difference)
+	}
+	return nil
+}
+
+// planDifference describes the first difference of the current plan from the approved plan, or is empty if the
+// plans are the same.
+func planDifference(approved, current Plan) string {
+	if approved.Command != current.Command {
+		return fmt.Sprintf("command '%v' instead of '%v'", current.Command, approved.Command)
+	}
+
+	for _, ref := range slices.Sorted(maps.Keys(approved.Refs)) {
+		if commit, ok := current.Refs[ref]; !ok {
+			return fmt.Sprintf("'%v' was removed", ref)
+		} else if commit != approved.Refs[ref] {
+			return fmt.Sprintf("'%v' moved from %v to %v", ref, approved.Refs[ref], commit)
+		}
+	}
+	for _, ref := range slices.Sorted(maps.Keys(current.Refs)) {
+		if _, ok := approved.Refs[ref]; !ok {
+			return fmt.Sprintf("'%v' was added", ref)
+		}
+	}
+
+	for i := range max(len(approved.Steps), len(current.Steps)) {
+		switch {
+		case i >= len(current.Steps):
+			return fmt.Sprintf("step %v '%v' is missing", i+1, approved.Steps[i])
+		case i >= len(approved.Steps):
+			return fmt.Sprintf("step %v '%v' was not approved", i+1, current.Steps[i])
+		case !planStepEqual(approved.Steps[i], current.Steps[i]):
+			return fmt.Sprintf("step %v is '%v' instead of '%v'", i+1, current.Steps[i], approved.Steps[i])
+		}
+	}
+	return ""
+}
+
+func planStepEqual(a, b PlanStep) bool {
+	return slices.Equal(a.Git, b.Git) && a.Branch == b.Branch && a.Version == b.Version && a.File == b.File &&
+		a.Write == b.Write && a.Skip == b.Skip
+}
+
+// String describes a plan step for the messages of a plan mismatch.
+func (s PlanStep) String() string {
+	switch {
+	case len(s.Git) > 0:
+		return strings.Join(append([]string{Git}, s.Git...), " ")
+	case len(s.Version) > 0:
+		return fmt.Sprintf("set version %v in '%v' on '%v'", s.Version, s.File, s.Branch)
+	case len(s.Write) > 0:
+		return fmt.Sprintf("write file '%v'", s.Write)
+	default:
+		return fmt.Sprintf("skip %v", s.Skip)
+	}
+}
+
+// recordPlanStep adds a step to the recorded execution plan, if a plan is recorded.
+func recordPlanStep(step PlanStep) {
+	if recordedPlan != nil {
+		recordedPlan.Steps = append(recordedPlan.Steps, step)
+	}
+}
+
+// recordPlanRefs records the commits of the checked out branch and of the local and remote-tracking gitflow branches
+// the plan is based on, once per plan.
+func recordPlanRefs(repository *repository) {
+	if recordedPlan == nil || recordedPlan.Refs != nil {
+		return
+	}
+
+	refs := make(map[string]string)
+	if commit, err := repository.ResolveRef(head); err == nil {
+		refs[head] = commit
+	}
+
+	patterns := make([]string, 0)
+	for _, branch := range []Branch{Production, Development, Release, Hotfix, Bugfix, Support} {
+		patterns = append(patterns, "refs/heads/"+branch.String(), "refs/remotes/"+repository.remote+"/"+branch.String())
+	}
+
+	list := exec.Command(Git, append(repository.refCommits, patterns...)...)
+	list.Dir = repository.projectPath
+	output, err := list.CombinedOutput()
+	Log(list, output, err)
+
+	if err == nil {
+		for _, line := range strings.Split(string(output), "\n") {
+			if name, commit, ok := strings.Cut(strings.TrimSpace(line), " "); ok {
+				refs[name] = commit
+			}
+		}
+	}
+
+	recordedPlan.Refs = refs
+}
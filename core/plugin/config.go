@@ -15,6 +15,8 @@ type Config struct {
 	VersionFileNames []string
 	// Qualifier for SNAPSHOT versions
 	VersionQualifier string
+	// Template is the Go template content of the version file for new projects, rendered with {{.Name}} and {{.Version}}
+	Template string
 	// Required external tools
 	RequiredTools []string
 	// DockerImage is the container image for docker execution mode (empty = native only)
@@ -42,4 +44,12 @@ type TestConfig struct {
 	// EmptyContent is the content of an empty version file used in before-hook tests.
 	// For JSON-based plugins this is "{}"; for text-based plugins it can be empty bytes.
 	EmptyContent []byte
+	// ProjectFiles are committed with the initial commit of the test repository, e.g. the 'go.mod' file which
+	// identifies the projects of a plugin besides its version file.
+	ProjectFiles map[string]string
+	// VersionNotation converts a version to its notation in the version file, if it differs from the semantic
+	// version, e.g. '1.2.0-dev' to '1.2.0.dev' for Ruby gems.
+	VersionNotation func(version string) string
+	// TagPrefix is the prefix of the release and hotfix tags of the plugin, e.g. 'v' for Terraform modules.
+	TagPrefix string
 }
@@ -10,6 +10,7 @@ import (
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/mercedes-benz/gitflow-cli/core"
@@ -43,6 +44,7 @@ func (e *Executor) Command(workDir string, name string, args ...string) *exec.Cm
 		log.Printf("[executor] native: %s %v (dir=%s)", name, args, workDir)
 		cmd := exec.Command(name, args...)
 		cmd.Dir = workDir
+		cmd.Env = e.scratchEnv()
 		return cmd
 	}
 
@@ -78,6 +80,27 @@ func (e *Executor) Command(workDir string, name string, args ...string) *exec.Cm
 	return exec.Command("docker", dockerArgs...)
 }
 
+// scratchEnv returns the environment of native commands, whose temporary files and python bytecode caches are
+// written to the scratch directory of the plugin instead of the working tree, or nil for the inherited environment.
+func (e *Executor) scratchEnv() []string {
+	if len(e.PluginName) == 0 {
+		return nil
+	}
+
+	scratch, err := core.ScratchDir(e.PluginName)
+	if err != nil {
+		log.Printf("[executor] no scratch directory: %v", err)
+		return nil
+	}
+
+	return append(os.Environ(),
+		"TMPDIR="+scratch,
+		"TMP="+scratch,
+		"TEMP="+scratch,
+		"PYTHONPYCACHEPREFIX="+filepath.Join(scratch, "pycache"),
+	)
+}
+
 // RequiredTools returns the tools that must be available on the system.
 // In docker mode, only "docker" is required. In native mode, the plugin's own tools are needed.
 func (e *Executor) RequiredTools(nativeTools []string) []string {
@@ -111,7 +134,7 @@ func (e *Executor) handleMissingTool(tool string) error {
 
 	// Auto-fallback if configured
 	if core.DockerFallback {
-		fmt.Fprintf(os.Stderr, "INFO: %s not found, using Docker (%s)\n", tool, e.Image)
+		fmt.Fprintln(os.Stderr, core.Message(core.MsgDockerFallbackAuto, tool, e.Image))
 		ExecutorModeOverride = ModeDocker
 		return nil
 	}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/mercedes-benz/gitflow-cli/core"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExecutor_Mode_DefaultIsNative(t *testing.T) {
@@ -47,6 +48,33 @@ func TestExecutor_Mode_CLINative_Override(t *testing.T) {
 	assert.Equal(t, ModeNative, executor.mode())
 }
 
+func TestExecutor_Command_NativeMode_ScratchDir(t *testing.T) {
+	ExecutorModeOverride = ""
+	t.Cleanup(core.RemoveScratchDirs)
+
+	executor := Executor{PluginName: "test-plugin", Image: "test-image:1.0"}
+
+	cmd := executor.Command("/tmp/project", "test-cmd", "arg1")
+
+	// temporary files of the tool are written to the scratch directory of the plugin
+	scratch, err := core.ScratchDir("test-plugin")
+	require.NoError(t, err)
+	assert.Contains(t, cmd.Env, "TMPDIR="+scratch)
+	assert.DirExists(t, scratch)
+	assert.NotContains(t, scratch, "/tmp/project")
+
+	// the scratch directories are removed when the command exits
+	core.RemoveScratchDirs()
+	assert.NoDirExists(t, scratch)
+}
+
+func TestScratchDir_InvalidName(t *testing.T) {
+	for _, name := range []string{"", ".", "..", "a/b"} {
+		_, err := core.ScratchDir(name)
+		assert.Error(t, err, name)
+	}
+}
+
 func TestExecutor_Command_NativeMode(t *testing.T) {
 	ExecutorModeOverride = ""
 
@@ -0,0 +1,175 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// The colon and the string value following a key of a JSON object, e.g. ': "1.2.0"'.
+var jsonStringValueRegex = regexp.MustCompile(`^\s*:\s*("(?:[^"\\]|\\.)*")`)
+
+// The first property of a JSON object with its indentation, e.g. '\n  "name"'.
+var jsonFirstPropertyRegex = regexp.MustCompile(`^\{(\s*?\n([ \t]*))?\s*"`)
+
+// ReadJSONVersion reads the top-level 'version' of a JSON file, e.g. package.json or composer.json, for plugins
+// which edit the file in-process when their build tool is not installed.
+func ReadJSONVersion(fileName string) (string, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return "", err
+	}
+
+	var document struct {
+		Version *string `json:"version"`
+	}
+	if err := json.Unmarshal(content, &document); err != nil {
+		return "", fmt.Errorf("invalid JSON in %v: %v", fileName, err)
+	}
+	if document.Version == nil {
+		return "", fmt.Errorf("no version found in %v", fileName)
+	}
+
+	return *document.Version, nil
+}
+
+// WriteJSONVersion writes the top-level 'version' of a JSON file in place, which keeps the formatting and the order
+// of the properties. A missing version is added as the first property with the indentation of the file.
+func WriteJSONVersion(fileName, version string) error {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	start, end, err := jsonValueRange(content, []string{"version"})
+	if err != nil {
+		return fmt.Errorf("invalid JSON in %v: %v", fileName, err)
+	}
+
+	quoted, _ := json.Marshal(version)
+
+	var updated []byte
+	if start >= 0 {
+		updated = append(append(append([]byte{}, content[:start]...), quoted...), content[end:]...)
+	} else {
+		updated = addJSONVersion(content, quoted)
+	}
+
+	return os.WriteFile(fileName, updated, 0644)
+}
+
+// ReadJSONValue reads the string at a path of object keys of a JSON file, e.g. 'app' and 'version', for plugins
+// whose version file is configured by the user.
+func ReadJSONValue(fileName string, keys []string) (string, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return "", err
+	}
+
+	start, end, err := jsonValueRange(content, keys)
+	if err != nil {
+		return "", fmt.Errorf("invalid JSON in %v: %v", fileName, err)
+	} else if start < 0 {
+		return "", fmt.Errorf("no %v found in %v", strings.Join(keys, "."), fileName)
+	}
+
+	var value string
+	if err := json.Unmarshal(content[start:end], &value); err != nil {
+		return "", fmt.Errorf("invalid JSON in %v: %v", fileName, err)
+	}
+
+	return value, nil
+}
+
+// WriteJSONValue writes the string at a path of object keys of a JSON file in place, which must exist already.
+func WriteJSONValue(fileName string, keys []string, value string) error {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	start, end, err := jsonValueRange(content, keys)
+	if err != nil {
+		return fmt.Errorf("invalid JSON in %v: %v", fileName, err)
+	} else if start < 0 {
+		return fmt.Errorf("no %v found in %v", strings.Join(keys, "."), fileName)
+	}
+
+	quoted, _ := json.Marshal(value)
+	updated := append(append(append([]byte{}, content[:start]...), quoted...), content[end:]...)
+
+	return os.WriteFile(fileName, updated, 0644)
+}
+
+// jsonValueRange returns the range of the quoted string at a path of object keys in the content of a JSON object,
+// which starts at -1 if the path does not exist.
+func jsonValueRange(content []byte, keys []string) (int, int, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+
+	if token, err := decoder.Token(); err != nil {
+		return -1, -1, err
+	} else if token != json.Delim('{') {
+		return -1, -1, fmt.Errorf("top-level value is no object")
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return -1, -1, err
+		}
+
+		// the offset after a key is the start of its value, including the colon
+		offset := int(decoder.InputOffset())
+		if key, ok := token.(string); ok && key == keys[0] && len(keys) == 1 {
+			if match := jsonStringValueRegex.FindSubmatchIndex(content[offset:]); match != nil {
+				return offset + match[2], offset + match[3], nil
+			}
+			return -1, -1, fmt.Errorf("%v is no string", key)
+		} else if ok && key == keys[0] {
+			// descend into the object of the key, whose keys are read by the loop from now on
+			if token, err := decoder.Token(); err != nil {
+				return -1, -1, err
+			} else if token != json.Delim('{') {
+				return -1, -1, nil
+			}
+			keys = keys[1:]
+			continue
+		}
+
+		// skip the value of another key
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return -1, -1, err
+		}
+	}
+
+	return -1, -1, nil
+}
+
+// addJSONVersion inserts the version as the first property of the content of a JSON object.
+func addJSONVersion(content, quoted []byte) []byte {
+	trimmed := bytes.TrimLeft(content, " \t\r\n")
+	prefix := string(content[:len(content)-len(trimmed)])
+	body := bytes.TrimLeft(trimmed[1:], " \t\r\n")
+
+	// an empty object gets the version on a line of its own, indented by two spaces
+	if bytes.HasPrefix(body, []byte("}")) {
+		return []byte(prefix + "{\n  \"version\": " + string(quoted) + "\n" + string(body))
+	}
+
+	// an object on a single line gets the version on the same line
+	match := jsonFirstPropertyRegex.FindSubmatch(trimmed)
+	if match == nil || len(match[1]) == 0 {
+		return []byte(prefix + "{\"version\": " + string(quoted) + ", " + string(body))
+	}
+
+	return []byte(prefix + "{\n" + string(match[2]) + "\"version\": " + string(quoted) + "," + string(trimmed[1:]))
+}
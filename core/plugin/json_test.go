@@ -0,0 +1,112 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadJSONVersion(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "package.json")
+
+	require.NoError(t, os.WriteFile(fileName, []byte(`{"name": "app", "config": {"version": "0.1.0"}, "version": "1.2.0-dev"}`), 0644))
+	version, err := ReadJSONVersion(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.0-dev", version)
+
+	require.NoError(t, os.WriteFile(fileName, []byte(`{"name": "app", "config": {"version": "0.1.0"}}`), 0644))
+	_, err = ReadJSONVersion(fileName)
+	assert.Error(t, err, "nested version is no project version")
+
+	require.NoError(t, os.WriteFile(fileName, []byte(`{"name": `), 0644))
+	_, err = ReadJSONVersion(fileName)
+	assert.Error(t, err)
+}
+
+func TestWriteJSONVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "ReplaceVersion",
+			content:  "{\n  \"name\": \"app\",\n  \"config\": {\n    \"version\": \"0.1.0\"\n  },\n  \"version\" : \"1.1.0-dev\"\n}\n",
+			expected: "{\n  \"name\": \"app\",\n  \"config\": {\n    \"version\": \"0.1.0\"\n  },\n  \"version\" : \"1.2.0\"\n}\n",
+		},
+		{
+			name:     "KeepTabs",
+			content:  "{\n\t\"version\": \"1.1.0\",\n\t\"private\": true\n}",
+			expected: "{\n\t\"version\": \"1.2.0\",\n\t\"private\": true\n}",
+		},
+		{
+			name:     "AddVersion",
+			content:  "{\n    \"name\": \"vendor/app\",\n    \"type\": \"project\"\n}\n",
+			expected: "{\n    \"version\": \"1.2.0\",\n    \"name\": \"vendor/app\",\n    \"type\": \"project\"\n}\n",
+		},
+		{
+			name:     "AddVersionSingleLine",
+			content:  `{"name": "app"}`,
+			expected: `{"version": "1.2.0", "name": "app"}`,
+		},
+		{
+			name:     "AddVersionEmptyObject",
+			content:  "{}",
+			expected: "{\n  \"version\": \"1.2.0\"\n}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileName := filepath.Join(t.TempDir(), "package.json")
+			require.NoError(t, os.WriteFile(fileName, []byte(tt.content), 0644))
+
+			require.NoError(t, WriteJSONVersion(fileName, "1.2.0"))
+
+			content, err := os.ReadFile(fileName)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(content))
+
+			version, err := ReadJSONVersion(fileName)
+			require.NoError(t, err)
+			assert.Equal(t, "1.2.0", version)
+		})
+	}
+
+	t.Run("NoObject", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "package.json")
+		require.NoError(t, os.WriteFile(fileName, []byte(`["1.1.0"]`), 0644))
+		assert.Error(t, WriteJSONVersion(fileName, "1.2.0"))
+	})
+}
+
+func TestJSONValue(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "app.json")
+	content := "{\n  \"version\": \"0.1.0\",\n  \"app\": {\n    \"name\": \"app\",\n    \"release\": {\"version\":\"1.1.0-dev\"}\n  }\n}\n"
+	require.NoError(t, os.WriteFile(fileName, []byte(content), 0644))
+
+	version, err := ReadJSONValue(fileName, []string{"app", "release", "version"})
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0-dev", version)
+
+	require.NoError(t, WriteJSONValue(fileName, []string{"app", "release", "version"}, "1.2.0"))
+	updated, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"version\": \"0.1.0\",\n  \"app\": {\n    \"name\": \"app\",\n    \"release\": {\"version\":\"1.2.0\"}\n  }\n}\n", string(updated))
+
+	_, err = ReadJSONValue(fileName, []string{"app", "version"})
+	assert.Error(t, err, "missing key")
+
+	_, err = ReadJSONValue(fileName, []string{"app", "name", "version"})
+	assert.Error(t, err, "string instead of object")
+
+	assert.Error(t, WriteJSONValue(fileName, []string{"app", "version"}, "1.2.0"))
+}
@@ -41,6 +41,11 @@ func (p *Plugin) VersionQualifier() string {
 	return p.Config.VersionQualifier
 }
 
+// VersionFileTemplate returns the template of the version file for new projects.
+func (p *Plugin) VersionFileTemplate() string {
+	return p.Config.Template
+}
+
 // RequiredTools returns list of required command line tools.
 // Resolves execution mode (applying docker fallback if needed) then delegates
 // to the executor to determine whether "docker" or the native tools are required.
@@ -0,0 +1,103 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReadYAMLValue reads the scalar at a path of mapping keys of a YAML file, e.g. 'info' and 'version', for plugins
+// whose version is nested in a YAML document.
+func ReadYAMLValue(fileName string, keys []string) (string, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return "", err
+	}
+
+	node, err := yamlNode(content, keys)
+	if err != nil {
+		return "", fmt.Errorf("%v in %v file", err, filepath.Base(fileName))
+	}
+
+	return node.Value, nil
+}
+
+// WriteYAMLValue writes the scalar at a path of mapping keys of a YAML file in place, which must exist already.
+// Only the scalar is replaced, which keeps the formatting, the comments and the quotation marks of the file.
+func WriteYAMLValue(fileName string, keys []string, value string) error {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	node, err := yamlNode(content, keys)
+	if err != nil {
+		return fmt.Errorf("%v in %v file", err, filepath.Base(fileName))
+	}
+
+	lines := strings.Split(string(content), "\n")
+	if node.Line > len(lines) {
+		return fmt.Errorf("%v at line %v not found in %v file", strings.Join(keys, "."), node.Line, filepath.Base(fileName))
+	}
+	lines[node.Line-1] = replaceYAMLScalar(lines[node.Line-1], node, value)
+
+	return os.WriteFile(fileName, []byte(strings.Join(lines, "\n")), 0644)
+}
+
+// yamlNode returns the scalar at a path of mapping keys of the first document of a YAML file.
+func yamlNode(content []byte, keys []string) (*yaml.Node, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %v", err)
+	}
+
+	node := &document
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	for _, key := range keys {
+		var value *yaml.Node
+		for i := 0; node.Kind == yaml.MappingNode && i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				value = node.Content[i+1]
+			}
+		}
+		if value == nil {
+			return nil, fmt.Errorf("no %v found", strings.Join(keys, "."))
+		}
+		node = value
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		return nil, fmt.Errorf("%v is not a scalar", strings.Join(keys, "."))
+	}
+	return node, nil
+}
+
+// replaceYAMLScalar replaces the scalar of a node in its line with a value, keeping its quotation marks.
+func replaceYAMLScalar(line string, node *yaml.Node, value string) string {
+	start := node.Column - 1
+	if start > len(line) {
+		return line
+	}
+
+	quote := ""
+	switch node.Style {
+	case yaml.SingleQuotedStyle:
+		quote = "'"
+	case yaml.DoubleQuotedStyle:
+		quote = "\""
+	}
+
+	end := min(start+len(node.Value)+2*len(quote), len(line))
+	return line[:start] + quote + value + quote + line[end:]
+}
@@ -0,0 +1,38 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYAMLValue(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "openapi.yaml")
+	content := "openapi: 3.1.0\ninfo:\n  title: Example API\n  version: '1.1.0-dev' # released with the service\npaths: {}\n"
+	require.NoError(t, os.WriteFile(fileName, []byte(content), 0644))
+
+	version, err := ReadYAMLValue(fileName, []string{"info", "version"})
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0-dev", version)
+
+	require.NoError(t, WriteYAMLValue(fileName, []string{"info", "version"}, "1.2.0"))
+	updated, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, "openapi: 3.1.0\ninfo:\n  title: Example API\n  version: '1.2.0' # released with the service\npaths: {}\n", string(updated))
+
+	_, err = ReadYAMLValue(fileName, []string{"info", "license"})
+	assert.Error(t, err, "missing key")
+
+	_, err = ReadYAMLValue(fileName, []string{"info"})
+	assert.Error(t, err, "mapping instead of scalar")
+
+	assert.Error(t, WriteYAMLValue(fileName, []string{"info", "license"}, "1.2.0"))
+}
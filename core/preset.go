@@ -0,0 +1,135 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"slices"
+	"strings"
+)
+
+// Workflow settings of the branching model preset and of the promotion of released versions.
+const (
+	presetSetting    = "preset"
+	promotionSetting = "promotion"
+)
+
+// Presets of the branching model: classic gitflow with a development branch of its own, OneFlow with a single
+// mainline, and GitLab Flow with a mainline and environment branches the released versions are promoted to.
+const (
+	gitflowPreset    = "gitflow"
+	oneflowPreset    = "oneflow"
+	gitlabFlowPreset = "gitlab-flow"
+)
+
+// Promotions of released versions: an environment tag, e.g. 'prod/1.2.0', or the environment branch, e.g.
+// 'production', which is fast-forwarded to the release.
+const (
+	promoteTag    = "tag"
+	promoteBranch = "branch"
+)
+
+// workflowPreset holds the defaults of a branching model. On a mainline, the development branch is the production
+// branch: releases and hotfixes are merged into it and tagged there, and it carries the next development version.
+type workflowPreset struct {
+	mainline     bool
+	environments []string
+	promotion    string
+}
+
+// Built-in presets, whose defaults apply to the settings which are not configured.
+var workflowPresets = map[string]workflowPreset{
+	gitflowPreset:    {promotion: promoteTag},
+	oneflowPreset:    {mainline: true, promotion: promoteTag},
+	gitlabFlowPreset: {mainline: true, environments: []string{"pre-production", "production"}, promotion: promoteBranch},
+}
+
+// Preset and promotion of the current run.
+var preset = gitflowPreset
+var promotion string
+
+func applyPresetSettings(settings map[string]any) {
+	if v, ok := settings[presetSetting].(string); ok && len(v) > 0 {
+		preset = v
+	}
+	if v, ok := settings[promotionSetting].(string); ok && len(v) > 0 {
+		promotion = v
+	}
+}
+
+func resetPresetSettings() {
+	preset = gitflowPreset
+	promotion = ""
+}
+
+// applyPreset applies the defaults of the preset to the settings which are not configured: the development branch
+// of a mainline is the production branch, unless it is configured explicitly.
+func applyPreset(all map[string]any) {
+	defaults, ok := workflowPresets[preset]
+	if !ok {
+		return
+	}
+
+	if defaults.mainline && !branchConfigured(all, Development) {
+		branchNames[Development] = branchNames[Production]
+	}
+	if len(environments) == 0 {
+		environments = slices.Clone(defaults.environments)
+	}
+	if len(promotion) == 0 {
+		promotion = defaults.promotion
+	}
+}
+
+// branchConfigured reports whether the name of a branch type is configured, also in the legacy group.
+func branchConfigured(all map[string]any, branch Branch) bool {
+	for _, group := range []string{branchesGroup, legacyGroup} {
+		if settings, ok := all[group].(map[string]any); ok {
+			if v, ok := settings[branch.ConfigKey()].(string); ok && len(v) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validatePresetSettings fails for unknown presets and promotions, and for settings which need a development branch
+// of its own on a mainline.
+func validatePresetSettings() error {
+	if _, ok := workflowPresets[preset]; !ok {
+		presets := []string{gitflowPreset, oneflowPreset, gitlabFlowPreset}
+		return Error(MsgInvalidChoice, workflowGroup+"."+presetSetting, preset, strings.Join(presets, ", "))
+	}
+
+	if promotion != promoteTag && promotion != promoteBranch {
+		return Error(MsgInvalidChoice, workflowGroup+"."+promotionSetting, promotion, promoteTag+", "+promoteBranch)
+	}
+
+	if mainline() && developmentBump == bumpPullRequest {
+		return Error(MsgMainlineSetting, workflowGroup+"."+developmentBumpSetting, developmentBump, Production)
+	}
+
+	return nil
+}
+
+// mainline reports whether the production branch is the development branch as well, e.g. with the OneFlow preset.
+func mainline() bool {
+	return Production.String() == Development.String()
+}
+
+// latestRelease returns the latest released version, which hotfixes of a mainline start from, because the mainline
+// already carries the next development version.
+func latestRelease(repository Repository) (Version, error) {
+	tagNames, err := repository.Tags()
+	if err != nil {
+		return NoVersion, err
+	}
+
+	_, latest := releasedVersions(tagNames)
+	if latest == NoVersion {
+		return NoVersion, Error(MsgMainlineNoRelease, Production)
+	}
+	return latest, nil
+}
@@ -0,0 +1,83 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Hook settings keys.
+const (
+	hooksGroup     = "hooks"
+	preTagSetting  = "pre-tag"
+	preTagHookStep = "pre-tag hook"
+)
+
+// Placeholders in the pre-tag command that are replaced by the commit to be tagged and its version.
+const (
+	commitPlaceholder  = "{commit}"
+	versionPlaceholder = "{version}"
+)
+
+var preTagCommand []string
+
+func applyHookSettings(settings map[string]any) {
+	if v, ok := settings[preTagSetting].(string); ok {
+		preTagCommand = strings.Fields(v)
+	}
+}
+
+func resetHookSettings() {
+	preTagCommand = nil
+}
+
+// hookTools returns the command-line tools required to run the configured hooks.
+func hookTools() []string {
+	if len(preTagCommand) == 0 {
+		return nil
+	}
+	return []string{preTagCommand[0]}
+}
+
+// runPreTagHook runs the command configured under 'hooks.pre-tag' on the commit which is about to be tagged with
+// the release or hotfix version, e.g. to verify reproducible-build hashes or to run a security scan. The command
+// gets the commit and the version as placeholders and as GITFLOW_COMMIT and GITFLOW_VERSION environment variables,
+// and vetoes the tag by exiting with a non-zero status.
+func runPreTagHook(repository Repository, version string) error {
+	var err error
+	var run *exec.Cmd
+	var output []byte
+
+	if len(preTagCommand) == 0 || skipDryRun(repository, preTagHookStep) {
+		return nil
+	}
+
+	commit, err := repository.ResolveRef("HEAD")
+	if err != nil {
+		return err
+	}
+
+	// log human-readable description of the command
+	defer func() { Log(run, output, err) }()
+
+	replacer := strings.NewReplacer(commitPlaceholder, commit, versionPlaceholder, version)
+	args := make([]string, 0, len(preTagCommand)-1)
+	for _, arg := range preTagCommand[1:] {
+		args = append(args, replacer.Replace(arg))
+	}
+
+	run = exec.Command(preTagCommand[0], args...)
+	run.Dir = repository.Local()
+	run.Env = append(os.Environ(), "GITFLOW_COMMIT="+commit, "GITFLOW_VERSION="+version)
+
+	if output, err = run.CombinedOutput(); err != nil {
+		return Error(MsgPreTagVetoed, version, commit, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"os"
+)
+
+// Promote marks a released version as deployed to a target environment by tagging it with an environment ref.
+func Promote(version, environment, projectPath string) error {
+	// apply suitable settings from the global configuration to the core package
+	if err := applySettings(); err != nil {
+		return err
+	}
+
+	// set path to execute workflow commands
+	ProjectPath = projectPath
+
+	// check if project path exists
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return Error(MsgProjectPathMissing, projectPath)
+	}
+
+	// the version to promote must be a plain release version
+	release, err := ParseVersion(version)
+	if err != nil {
+		return err
+	}
+
+	// the target environment must be part of the configured promotion order
+	position := -1
+	for i, name := range environments {
+		if name == environment {
+			position = i
+		}
+	}
+	if position < 0 {
+		return Error(MsgEnvironmentUnknown, environment, environments)
+	}
+
+	repository := openRepository(projectPath)
+
+	// format promote command messages
+	called := Message(MsgPromoteCalled, release, environment, repository.Local())
+	completed := Message(MsgPromoteCompleted, release, environment, repository.Local())
+	failed := Message(MsgPromoteFailed, release, environment, repository.Local())
+
+	Progress(called)
+
+	if err := promote(repository, release, position); err != nil {
+		Failure(failed)
+		return err
+	}
+
+	Success(completed)
+	return nil
+}
+
+func promote(repository Repository, release Version, position int) error {
+	// only released versions can be promoted
+	if found, err := repository.HasTag(versionTag(release.String())); err != nil {
+		return err
+	} else if !found {
+		return Error(MsgVersionNotReleased, release, release)
+	}
+
+	// environment branches, e.g. of GitLab Flow, are moved to the release instead of tagging it
+	if promotion == promoteBranch {
+		return promoteBranchTo(repository, release, position)
+	}
+
+	// environments are promoted in the configured order
+	if position > 0 {
+		previous := environments[position-1]
+		if found, err := repository.HasTag(release.EnvironmentTag(previous)); err != nil {
+			return err
+		} else if !found {
+			return Error(MsgPromotionOutOfOrder, release, previous, environments[position])
+		}
+	}
+
+	tagName := release.EnvironmentTag(environments[position])
+	if found, err := repository.HasTag(tagName); err != nil {
+		return err
+	} else if found {
+		return Error(MsgAlreadyPromoted, release, environments[position])
+	}
+
+	// tag the release commit with the environment ref
+	if err := repository.TagRef(tagName, versionTag(release.String())); err != nil {
+		return err
+	}
+
+	// push the environment tag to remotes
+	if err := pushIfEnabled(func() error { return repository.PushTag(tagName) }); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// promoteBranchTo fast-forwards the environment branch to the release commit and pushes it, e.g. 'production' of
+// GitLab Flow. The environment branch is created at the release if it does not exist yet, and must not have commits
+// of its own, because environment branches only receive promoted versions.
+func promoteBranchTo(repository Repository, release Version, position int) error {
+	tagName := versionTag(release.String())
+	environment := environments[position]
+
+	// the environment branches are compared as they are on the remote
+	if err := fetchRemotes(repository); err != nil {
+		return err
+	}
+
+	// environments are promoted in the configured order
+	if position > 0 {
+		previous := environments[position-1]
+		if promoted, err := environmentHolds(repository, previous, tagName); err != nil {
+			return err
+		} else if !promoted {
+			return Error(MsgPromotionOutOfOrder, release, previous, environment)
+		}
+	}
+
+	if promoted, err := environmentHolds(repository, environment, tagName); err != nil {
+		return err
+	} else if promoted {
+		return Error(MsgAlreadyPromoted, release, environment)
+	}
+
+	if found, err := repository.HasRemoteBranch(environment); err != nil {
+		return err
+	} else if found {
+		if forward, err := repository.IsAncestor(Remote+"/"+environment, tagName); err != nil {
+			return err
+		} else if !forward {
+			return Error(MsgEnvironmentDiverged, environment, release)
+		}
+	}
+
+	// move the local environment branch to the release commit, which creates it if needed
+	if err := repository.ResetBranch(environment, tagName); err != nil {
+		return err
+	}
+
+	// push the environment branch to remotes
+	return pushIfEnabled(func() error { return repository.PushChanges(environment) })
+}
+
+// environmentHolds reports whether the remote environment branch holds a released version.
+func environmentHolds(repository Repository, environment, tagName string) (bool, error) {
+	if found, err := repository.HasRemoteBranch(environment); err != nil || !found {
+		return false, err
+	}
+	return repository.IsAncestor(tagName, Remote+"/"+environment)
+}
@@ -0,0 +1,226 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Provenance settings keys.
+const (
+	provenanceGroup          = "provenance"
+	provenanceEnabledSetting = "enabled"
+	provenancePathSetting    = "path"
+	provenanceBuilderSetting = "builder"
+	provenanceSignSetting    = "sign"
+	provenanceUploadSetting  = "upload"
+)
+
+// Placeholder in sign and upload commands that is replaced by the path of the provenance statement.
+const provenanceFilePlaceholder = "{file}"
+
+// Provenance statement defaults.
+const (
+	defaultProvenanceDir = "provenance"
+	defaultBuilderID     = "https://github.com/mercedes-benz/gitflow-cli"
+	provenanceBuildType  = "https://github.com/mercedes-benz/gitflow-cli/release@v1"
+	inTotoStatementType  = "https://in-toto.io/Statement/v1"
+	slsaPredicateType    = "https://slsa.dev/provenance/v1"
+)
+
+var provenanceEnabled = false
+var provenancePath string
+var provenanceBuilder = defaultBuilderID
+var provenanceSign []string
+var provenanceUpload []string
+
+func applyProvenanceSettings(settings map[string]any) {
+	if v, ok := settings[provenanceEnabledSetting].(bool); ok {
+		provenanceEnabled = v
+	}
+	if v, ok := settings[provenancePathSetting].(string); ok && len(v) > 0 {
+		provenancePath = v
+	}
+	if v, ok := settings[provenanceBuilderSetting].(string); ok && len(v) > 0 {
+		provenanceBuilder = v
+	}
+	if v, ok := settings[provenanceSignSetting].(string); ok {
+		provenanceSign = strings.Fields(v)
+	}
+	if v, ok := settings[provenanceUploadSetting].(string); ok {
+		provenanceUpload = strings.Fields(v)
+	}
+}
+
+func resetProvenanceSettings() {
+	provenanceEnabled = false
+	provenancePath = ""
+	provenanceBuilder = defaultBuilderID
+	provenanceSign = nil
+	provenanceUpload = nil
+}
+
+// provenanceTools returns the command-line tools required to sign and upload the provenance statement.
+func provenanceTools() []string {
+	tools := make([]string, 0)
+	if !provenanceEnabled {
+		return tools
+	}
+	if len(provenanceSign) > 0 {
+		tools = append(tools, provenanceSign[0])
+	}
+	if len(provenanceUpload) > 0 {
+		tools = append(tools, provenanceUpload[0])
+	}
+	return tools
+}
+
+// In-toto resource descriptor of a provenance statement.
+type resourceDescriptor struct {
+	Name   string            `json:"name,omitempty"`
+	URI    string            `json:"uri,omitempty"`
+	Digest map[string]string `json:"digest"`
+}
+
+// In-toto statement with a SLSA provenance predicate.
+type provenanceStatement struct {
+	Type          string               `json:"_type"`
+	Subject       []resourceDescriptor `json:"subject"`
+	PredicateType string               `json:"predicateType"`
+	Predicate     provenancePredicate  `json:"predicate"`
+}
+
+type provenancePredicate struct {
+	BuildDefinition struct {
+		BuildType            string               `json:"buildType"`
+		ExternalParameters   map[string]string    `json:"externalParameters"`
+		ResolvedDependencies []resourceDescriptor `json:"resolvedDependencies"`
+	} `json:"buildDefinition"`
+	RunDetails struct {
+		Builder struct {
+			ID string `json:"id"`
+		} `json:"builder"`
+		Metadata struct {
+			StartedOn  string `json:"startedOn"`
+			FinishedOn string `json:"finishedOn"`
+		} `json:"metadata"`
+	} `json:"runDetails"`
+}
+
+// emitProvenance writes a SLSA provenance statement for the release tag and optionally signs and uploads it.
+func emitProvenance(repository Repository, version Version, startedOn time.Time) error {
+	if !provenanceEnabled {
+		return nil
+	}
+
+	// the release tag of a dry run does not exist
+	if skipDryRun(repository, "provenance statement") {
+		return nil
+	}
+
+	// resolve the commit the release tag points to
+	tagName := versionTag(version.String())
+	commitHash, err := repository.ResolveRef("refs/tags/" + tagName)
+	if err != nil {
+		return err
+	}
+
+	// the remote URL identifies the source repository, local-only repositories fall back to the path
+	sourceURI := repository.Local()
+	if url, err := repository.RemoteURL(); err == nil {
+		sourceURI = url
+	}
+
+	statement := provenanceStatement{
+		Type:          inTotoStatementType,
+		PredicateType: slsaPredicateType,
+		Subject: []resourceDescriptor{{
+			Name:   tagName,
+			Digest: map[string]string{"gitCommit": commitHash},
+		}},
+	}
+
+	predicate := &statement.Predicate
+	predicate.BuildDefinition.BuildType = provenanceBuildType
+	predicate.BuildDefinition.ExternalParameters = map[string]string{
+		"repository": sourceURI,
+		"ref":        "refs/tags/" + tagName,
+		"version":    version.String(),
+	}
+	predicate.BuildDefinition.ResolvedDependencies = []resourceDescriptor{{
+		URI:    fmt.Sprintf("git+%v@refs/tags/%v", sourceURI, tagName),
+		Digest: map[string]string{"gitCommit": commitHash},
+	}}
+	predicate.RunDetails.Builder.ID = provenanceBuilder
+	predicate.RunDetails.Metadata.StartedOn = startedOn.UTC().Format(time.RFC3339)
+	predicate.RunDetails.Metadata.FinishedOn = time.Now().UTC().Format(time.RFC3339)
+
+	content, err := json.MarshalIndent(statement, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding provenance statement failed with %v", err)
+	}
+
+	// write the statement outside the working tree, so that the repository stays clean, by default in the git
+	// directory of the repository or linked worktree
+	directory := provenancePath
+	if len(directory) == 0 {
+		if directory, err = gitflowPath(repository.Local(), defaultProvenanceDir); err != nil {
+			return err
+		}
+	} else if !filepath.IsAbs(directory) {
+		directory = filepath.Join(repository.Local(), directory)
+	}
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return fmt.Errorf("creating provenance directory '%v' failed with %v", directory, err)
+	}
+
+	file := filepath.Join(directory, tagName+".intoto.json")
+	if err := os.WriteFile(file, content, 0644); err != nil {
+		return fmt.Errorf("writing provenance statement '%v' failed with %v", file, err)
+	}
+
+	// sign the statement, e.g. with 'cosign sign-blob --yes --bundle {file}.bundle {file}'
+	if err := runProvenanceCommand(repository, provenanceSign, file); err != nil {
+		return err
+	}
+
+	// upload the statement, e.g. to an attestation store
+	return runProvenanceCommand(repository, provenanceUpload, file)
+}
+
+// runProvenanceCommand runs a sign or upload command with the provenance statement path substituted.
+func runProvenanceCommand(repository Repository, commandLine []string, file string) error {
+	var err error
+	var run *exec.Cmd
+	var output []byte
+
+	if len(commandLine) == 0 {
+		return nil
+	}
+
+	// log human-readable description of the command
+	defer func() { Log(run, output, err) }()
+
+	args := make([]string, 0, len(commandLine)-1)
+	for _, arg := range commandLine[1:] {
+		args = append(args, strings.ReplaceAll(arg, provenanceFilePlaceholder, file))
+	}
+
+	run = exec.Command(commandLine[0], args...)
+	run.Dir = repository.Local()
+
+	if output, err = run.CombinedOutput(); err != nil {
+		return fmt.Errorf("provenance command '%v' failed with %v: %s", run, err, output)
+	}
+
+	return nil
+}
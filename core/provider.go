@@ -0,0 +1,304 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Settings of the integrations of the hosting services, under the group of the service, e.g. 'github.token'.
+const (
+	hostingTokenSetting        = "token"
+	hostingAPIURLSetting       = "api-url"
+	hostingMergeTimeoutSetting = "merge-timeout"
+	hostingPublishSetting      = "publish"
+	hostingDraftSetting        = "draft"
+)
+
+// Default time to wait for the merge of the pull requests of a branch finished via pull requests.
+const defaultMergeTimeout = 30 * time.Minute
+
+// Interval between the checks whether the pull requests of a branch have been merged.
+var mergePollInterval = 15 * time.Second
+
+// ViaPullRequest finishes a release or hotfix by pull or merge requests into the production and development
+// branches, which are merged on the hosting service, instead of pushing the merges, e.g. for protected branches
+// which reject direct pushes.
+var ViaPullRequest bool
+
+// WaitForMerge waits for the pull requests of a branch finished via pull requests to be merged, instead of stopping
+// the finish until it is continued after the merge.
+var WaitForMerge bool
+
+// hostingSettings are the settings of the integration of a hosting service.
+type hostingSettings struct {
+	token        string
+	apiURL       string
+	mergeTimeout time.Duration
+	publish      bool
+	draft        bool
+}
+
+func defaultHostingSettings() hostingSettings {
+	return hostingSettings{mergeTimeout: defaultMergeTimeout}
+}
+
+func (s *hostingSettings) apply(settings map[string]any) {
+	if v, ok := settings[hostingTokenSetting].(string); ok {
+		s.token = v
+	}
+	if v, ok := settings[hostingAPIURLSetting].(string); ok {
+		s.apiURL = v
+	}
+	if v, ok := settings[hostingMergeTimeoutSetting].(string); ok {
+		if timeout, err := time.ParseDuration(v); err == nil && timeout > 0 {
+			s.mergeTimeout = timeout
+		}
+	}
+	if v, ok := settings[hostingPublishSetting].(bool); ok {
+		s.publish = v
+	}
+	if v, ok := settings[hostingDraftSetting].(bool); ok {
+		s.draft = v
+	}
+}
+
+// pullRequestState is the state of a pull or merge request of a hosting service.
+type pullRequestState struct {
+	merged      bool
+	closed      bool
+	mergeCommit string
+}
+
+// hostingProvider is the API of the hosting service of the remote, which merges finished branches by pull or merge
+// requests and creates the releases of their tags.
+type hostingProvider interface {
+	// name returns the name of the hosting service, e.g. 'GitHub'.
+	name() string
+
+	// group returns the configuration group of the integration, e.g. 'github'.
+	group() string
+
+	// settings returns the settings of the integration.
+	settings() hostingSettings
+
+	// term returns the localized name of the requests of the hosting service, e.g. 'pull request'.
+	term() string
+
+	// reference returns the reference of a request, e.g. '#12' or '!12'.
+	reference(number int) string
+
+	// tokenSources returns whether a token of the API is available, and where it is read from.
+	tokenSources() (bool, string)
+
+	// releases reports whether the hosting service has releases of tags.
+	releases() bool
+
+	// draftReleases reports whether releases can be created as drafts.
+	draftReleases() bool
+
+	// openPullRequest opens a request of a branch into a base branch and returns its number.
+	openPullRequest(branchName, base, title, description string) (int, error)
+
+	// pullRequest reads the state of a request.
+	pullRequest(number int) (pullRequestState, error)
+
+	// createRelease creates the release of an existing tag and returns its web link.
+	createRelease(tagName, title, notes string, draft bool) (string, error)
+
+	// restrictedBranch returns the first of the branches which the hosting service only changes by requests, or an
+	// empty name if all of them accept direct pushes or their restrictions are not checked.
+	restrictedBranch(branchNames []string) (string, error)
+}
+
+// hostingProviderOf returns the hosting service of the remote of a repository. Remotes on GitLab, and remotes on
+// other hosts if GitLab CI runs the command or 'gitlab.api-url' is configured, are GitLab projects. Remotes on
+// Bitbucket, and remotes on other hosts if 'bitbucket.api-url' is configured or Bitbucket Pipelines run the command,
+// are Bitbucket repositories. All other remotes are GitHub repositories, including those of GitHub Enterprise Server.
+func hostingProviderOf(repository Repository) hostingProvider {
+	remoteURL, _ := repository.RemoteURL()
+	return remoteHostingProvider(remoteURL)
+}
+
+// remoteHostingProvider returns the hosting service of a remote URL.
+func remoteHostingProvider(remoteURL string) hostingProvider {
+	hosted, ok := parseRemoteURL(remoteURL)
+
+	switch {
+	case ok && hosted.hosting == hostingGitLab:
+		return newGitLabProvider(hosted.path, hosted.scheme+"://"+hosted.host)
+	case ok && hosted.hosting == hostingBitbucket:
+		return newBitbucketProvider(hosted)
+	case !ok && len(bitbucketSettings.apiURL) > 0:
+		return newBitbucketProvider(hostedRepository{})
+	case !ok && (len(os.Getenv(gitlabServerURLEnv)) > 0 || len(gitlabSettings.apiURL) > 0):
+		return newGitLabProvider(os.Getenv(gitlabProjectPathEnv), os.Getenv(gitlabServerURLEnv))
+	case !ok && len(os.Getenv(bitbucketRepositoryEnv)) > 0:
+		return newBitbucketProvider(hostedRepository{})
+	default:
+		return &githubProvider{repository: githubRepository(remoteURL)}
+	}
+}
+
+// pullRequestBases returns the branches a finished branch is merged into by pull requests. The first branch is the
+// production or support branch, whose merge is tagged. Hotfixes are merged into the release branch as well, if there
+// is one, and releases and hotfixes into the development branch, unless it is the production branch of a mainline.
+func pullRequestBases(state *workflowState) []string {
+	bases := []string{hotfixBase(state.Line)}
+	if len(state.Line) > 0 || mainline() {
+		return bases
+	}
+
+	if state.Workflow == Hotfix.ConfigKey() && len(state.ReleaseBranch) > 0 {
+		bases = append(bases, state.ReleaseBranch)
+	}
+	return append(bases, Development.String())
+}
+
+// checkViaPullRequest fails if a branch cannot be finished via pull requests, because nothing is pushed or the API
+// of the hosting service cannot be accessed.
+func checkViaPullRequest(repository Repository) error {
+	if !pushChanges {
+		return Error(MsgViaPullRequestNoPush)
+	}
+
+	provider := hostingProviderOf(repository)
+	if _, ok := repository.(*dryRunRepository); !ok {
+		if available, sources := provider.tokenSources(); !available {
+			return Error(MsgHostingTokenMissing, provider.term(), provider.name(), sources)
+		}
+	}
+	return nil
+}
+
+// checkBranchPermissions fails before a direct finish if the hosting service only changes one of the branches the
+// finished branch is merged into by pull requests, so that the finish is not rejected after the merges and the tag
+// have been created, but is run with --via-pr instead. Without token, the restrictions are not checked.
+func checkBranchPermissions(repository Repository, state *workflowState) error {
+	if _, ok := repository.(*dryRunRepository); ok || !pushChanges {
+		return nil
+	}
+
+	provider := hostingProviderOf(repository)
+	if available, _ := provider.tokenSources(); !available {
+		return nil
+	}
+
+	restricted, err := provider.restrictedBranch(pullRequestBases(state))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, Message(MsgRestrictionsUnread, provider.name(), err))
+		return nil
+	} else if len(restricted) > 0 {
+		return Error(MsgBranchRestricted, restricted, provider.term(), provider.name())
+	}
+	return nil
+}
+
+// openPullRequests opens the pull requests of the finished branch into its bases, and records their numbers in the
+// state, so that a continued finish does not open them again.
+func openPullRequests(repository Repository, state *workflowState) error {
+	provider := hostingProviderOf(repository)
+	bases := pullRequestBases(state)
+	if skipDryRun(repository, fmt.Sprintf("%vs of '%v' into %v", provider.term(), state.Branch, strings.Join(bases, ", "))) {
+		return nil
+	}
+
+	if state.PullRequests == nil {
+		state.PullRequests = make(map[string]int)
+	}
+
+	title := fmt.Sprintf("%v %v", strings.ToUpper(state.Workflow[:1])+state.Workflow[1:], state.Version)
+	for _, base := range bases {
+		if _, ok := state.PullRequests[base]; ok {
+			continue
+		}
+
+		body := fmt.Sprintf("%v, merge to finish it on '%v'. The merge into '%v' is tagged once all %vs of '%v' are merged.",
+			title, base, bases[0], provider.term(), state.Branch)
+		number, err := provider.openPullRequest(state.Branch, base, title, body)
+		if err != nil {
+			return Error(MsgPullRequestFailed, provider.term(), state.Branch, base, err)
+		}
+
+		state.PullRequests[base] = number
+		if err := saveWorkflowState(repository, state); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stderr, Message(MsgPullRequestOpened, provider.term(), provider.reference(number), state.Branch, base))
+	}
+
+	return nil
+}
+
+// waitForPullRequests checks whether the pull requests of the finished branch have been merged, and records the merge
+// commit of the production or support branch in the state. With WaitForMerge, it checks until they are merged or the
+// merge timeout expires, and fails otherwise, so that the finish is continued after the merge.
+func waitForPullRequests(repository Repository, state *workflowState) error {
+	provider := hostingProviderOf(repository)
+	bases := pullRequestBases(state)
+	if skipDryRun(repository, fmt.Sprintf("wait for the merge of the %vs", provider.term())) {
+		state.MergeCommit = Remote + "/" + bases[0]
+		return nil
+	}
+
+	timeout := provider.settings().mergeTimeout
+	deadline := time.Now().Add(timeout)
+	for waiting := false; ; waiting = true {
+		pending := make([]string, 0)
+		for _, base := range bases {
+			number := state.PullRequests[base]
+			pullRequest, err := provider.pullRequest(number)
+			if err != nil {
+				return Error(MsgPullRequestFailed, provider.term(), state.Branch, base, err)
+			}
+
+			switch {
+			case pullRequest.merged:
+				// services which report no merge commit have merged it into the base branch on the remote
+				if base == bases[0] {
+					state.MergeCommit = pullRequest.mergeCommit
+					if len(state.MergeCommit) == 0 {
+						state.MergeCommit = Remote + "/" + base
+					}
+				}
+			case pullRequest.closed:
+				return Error(MsgPullRequestClosed, provider.term(), provider.reference(number), state.Branch, base)
+			default:
+				pending = append(pending, provider.reference(number))
+			}
+		}
+
+		// the merge commit must be fetched before it can be tagged
+		if len(pending) == 0 {
+			return repository.Fetch()
+		}
+
+		if !WaitForMerge {
+			return Error(MsgPullRequestsOpen, provider.term(), strings.Join(pending, ", "), state.Branch, state.Workflow)
+		} else if time.Now().Add(mergePollInterval).After(deadline) {
+			return Error(MsgPullRequestsTimeout, provider.term(), strings.Join(pending, ", "), state.Branch, timeout,
+				provider.group()+"."+hostingMergeTimeoutSetting, state.Workflow)
+		}
+
+		if !waiting {
+			fmt.Fprintln(os.Stderr, Message(MsgPullRequestsWait, provider.term(), strings.Join(pending, ", "), timeout))
+		}
+		time.Sleep(mergePollInterval)
+	}
+}
+
+// checkoutMergedBranch checks out a branch which a pull request has been merged into, and fast-forwards it to the
+// merge on the remote.
+func checkoutMergedBranch(repository Repository, branchName string) error {
+	if err := repository.CheckoutBranch(branchName); err != nil {
+		return err
+	}
+	return repository.FastForwardBranch(branchName)
+}
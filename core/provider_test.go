@@ -0,0 +1,61 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRemoteHostingProvider tests the hosting service and the API selected for remotes.
+func TestRemoteHostingProvider(t *testing.T) {
+	for _, env := range []string{gitlabServerURLEnv, gitlabAPIEnv, gitlabProjectPathEnv, bitbucketRepositoryEnv} {
+		t.Setenv(env, "")
+	}
+
+	github, ok := remoteHostingProvider("git@github.com:owner/repo.git").(*githubProvider)
+	require.True(t, ok)
+	assert.Equal(t, "owner/repo", github.repository)
+
+	gitlab, ok := remoteHostingProvider("git@gitlab.example.com:group/sub/project.git").(*gitlabProvider)
+	require.True(t, ok)
+	assert.Equal(t, "https://gitlab.example.com/api/v4", gitlab.api)
+	assert.Equal(t, "group/sub/project", gitlab.project)
+	assert.Equal(t, "!4", gitlab.reference(4))
+
+	cloud, ok := remoteHostingProvider("git@bitbucket.org:workspace/repo.git").(*bitbucketProvider)
+	require.True(t, ok)
+	assert.True(t, cloud.cloud)
+	assert.Equal(t, "https://api.bitbucket.org/2.0/repositories/workspace/repo", cloud.repositoryAPI())
+
+	server, ok := remoteHostingProvider("https://bitbucket.example.com/context/scm/proj/repo.git").(*bitbucketProvider)
+	require.True(t, ok)
+	assert.False(t, server.cloud)
+	assert.Equal(t, "https://bitbucket.example.com/context/rest/api/1.0/projects/proj/repos/repo", server.repositoryAPI())
+
+	server, ok = remoteHostingProvider("ssh://git@bitbucket.example.com:7999/proj/repo.git").(*bitbucketProvider)
+	require.True(t, ok)
+	assert.Equal(t, "https://bitbucket.example.com/rest/api/1.0/projects/proj/repos/repo", server.repositoryAPI())
+
+	// remotes on other hosts are GitHub repositories outside of GitLab CI and Bitbucket Pipelines
+	_, ok = remoteHostingProvider("https://git.example.com/group/project.git").(*githubProvider)
+	assert.True(t, ok)
+
+	t.Setenv(gitlabServerURLEnv, "https://git.example.com")
+	t.Setenv(gitlabProjectPathEnv, "group/project")
+	gitlab, ok = remoteHostingProvider("https://git.example.com/group/project.git").(*gitlabProvider)
+	require.True(t, ok)
+	assert.Equal(t, "https://git.example.com/api/v4", gitlab.api)
+	assert.Equal(t, "group/project", gitlab.project)
+
+	t.Setenv(gitlabServerURLEnv, "")
+	t.Setenv(bitbucketRepositoryEnv, "workspace/repo")
+	cloud, ok = remoteHostingProvider("https://git.example.com/workspace/repo.git").(*bitbucketProvider)
+	require.True(t, ok)
+	assert.Equal(t, "https://api.bitbucket.org/2.0/repositories/workspace/repo", cloud.repositoryAPI())
+}
@@ -0,0 +1,116 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Publish creates the release of the hosting service, e.g. a GitHub or GitLab release, for the tag of a finished
+// release, with the changes of the release as notes.
+var Publish bool
+
+// PublishDraft creates the release of a finished release as draft without assets, which is published by hand, e.g.
+// after the build has attached its artifacts.
+var PublishDraft bool
+
+// publishing reports whether the release of the hosting service is created for a finished release, and whether as
+// draft, by --publish and --draft or by 'publish' and 'draft' of the group of the hosting service, e.g.
+// 'github.publish'.
+func publishing(provider hostingProvider) (bool, bool) {
+	draft := PublishDraft || provider.settings().draft
+	return Publish || provider.settings().publish || draft, draft
+}
+
+// checkPublish fails if the release of a finished release cannot be created, because the hosting service has no
+// releases or drafts, or its API cannot be accessed. It is checked before the finish starts, so that the release is not tagged
+// without its release on the hosting service.
+func checkPublish(repository Repository, provider hostingProvider, draft bool) error {
+	if !provider.releases() {
+		return Error(MsgPublishUnsupported, provider.name())
+	}
+	if draft && !provider.draftReleases() {
+		return Error(MsgPublishDraftMissing, provider.name())
+	}
+	if _, ok := repository.(*dryRunRepository); ok || !pushChanges {
+		return nil
+	}
+	if available, sources := provider.tokenSources(); !available {
+		return Error(MsgPublishTokenMissing, provider.name(), provider.name(), sources)
+	}
+	return nil
+}
+
+// publishReleaseStep returns the step which creates the release of the pushed tag of a finished release.
+func publishReleaseStep(repository Repository, state *workflowState) workflowStep {
+	return workflowStep{
+		name:        "publish-release",
+		description: "create the GitHub or GitLab release of the tag with the changes of the release as notes, if enabled by --publish or 'publish' of the hosting service",
+		execute: func() error {
+			if !state.Publish {
+				return nil
+			}
+			return pushIfEnabled(func() error { return publishRelease(repository, state) })
+		},
+	}
+}
+
+// publishRelease creates the release of the tag of a finished release via the API of the hosting service of the
+// remote.
+func publishRelease(repository Repository, state *workflowState) error {
+	provider := hostingProviderOf(repository)
+	tagName := versionTag(state.Version)
+	if skipDryRun(repository, fmt.Sprintf("%v release of tag '%v'", provider.name(), tagName)) {
+		return nil
+	}
+
+	notes, err := hostedReleaseNotes(repository, tagName, state.version())
+	if err != nil {
+		return err
+	}
+
+	link, err := provider.createRelease(tagName, state.Version, notes, state.Draft)
+	if err != nil {
+		return Error(MsgHostedReleaseFailed, provider.name(), tagName, err)
+	}
+
+	if state.Draft {
+		fmt.Fprintln(os.Stderr, Message(MsgHostedReleaseDraft, provider.name(), tagName, link))
+	} else {
+		fmt.Fprintln(os.Stderr, Message(MsgHostedRelease, provider.name(), tagName, link))
+	}
+	return nil
+}
+
+// hostedReleaseNotes renders the changes of a release since the previous release in the format of the changelog,
+// without the heading of its section, whose version is the title of the release on the hosting service.
+func hostedReleaseNotes(repository Repository, tagName string, version Version) (string, error) {
+	tags, err := repository.Tags()
+	if err != nil {
+		return "", err
+	}
+
+	revisionRange := tagName
+	if previous := previousReleaseTag(tags, version); len(previous) > 0 {
+		revisionRange = previous + ".." + tagName
+	}
+
+	commits, err := repository.ProjectCommits(revisionRange)
+	if err != nil {
+		return "", err
+	}
+
+	section, err := renderChangelogSection(collectChangelog(version, time.Now(), commits), changelogFormat)
+	if err != nil {
+		return "", err
+	}
+
+	_, notes, _ := strings.Cut(section, "\n")
+	return strings.TrimSpace(notes), nil
+}
@@ -0,0 +1,26 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+// Setting of a plugin group with the qualifiers of the versions per branch type, e.g. 'mvn.qualifiers.release'.
+const qualifiersSetting = "qualifiers"
+
+// Qualifier returns the qualifier of the versions on a type of branch, configured under
+// '<plugin>.qualifiers.<development|release|hotfix>'. Development versions default to the qualifier of the plugin,
+// e.g. 'SNAPSHOT', while release and hotfix versions have no qualifier unless one is configured, e.g. 'rc'. The
+// qualifier is read from the configuration on every call, so that it never outlives the run of a command.
+func Qualifier(plugin Plugin, branch Branch) string {
+	if qualifiers, ok := PluginSettings(plugin)[qualifiersSetting].(map[string]any); ok {
+		if v, ok := qualifiers[branch.ConfigKey()].(string); ok {
+			return v
+		}
+	}
+
+	if branch == Development {
+		return plugin.VersionQualifier()
+	}
+	return noQualifier
+}
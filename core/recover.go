@@ -0,0 +1,219 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Subject of the commit of the next development version on release finish.
+const nextVersionSubject = "Set next minor project version."
+
+// Recover inspects the repository for a half-completed release or hotfix finish and proposes its remaining steps,
+// which are executed as well if execute is set. The remaining steps are taken from the state file of an interrupted
+// finish if there is one, otherwise they are derived from the branches and tags of the repository, e.g. for a
+// release branch that has been merged into the production branch but not into the development branch.
+func Recover(projectPath string, execute bool) error {
+	pluginRegistryLock.Lock()
+	defer pluginRegistryLock.Unlock()
+
+	// apply suitable settings from the global configuration to the core package
+	if err := applySettings(); err != nil {
+		return err
+	}
+
+	// set path to execute plugin detection and workflow commands
+	ProjectPath = projectPath
+
+	// check if project path exists
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return Error(MsgProjectPathMissing, projectPath)
+	}
+
+	repository := openRepository(projectPath)
+	plugin := openPlugin(projectPlugin(), repository)
+
+	state, err := loadWorkflowState(repository)
+	if err != nil {
+		return err
+	}
+
+	if state != nil {
+		restoreBranchNames(state)
+		fmt.Fprintln(statusOutput(), Message(MsgRecoverFromState, state.Workflow, state.Branch, state.Step))
+	} else {
+		if err := fetchRemotes(repository); err != nil {
+			return err
+		}
+		if state, err = inspectWorkflowState(repository); err != nil {
+			return err
+		} else if state == nil {
+			fmt.Fprintln(statusOutput(), Message(MsgNothingToRecover))
+			return nil
+		}
+		fmt.Fprintln(statusOutput(), Message(MsgRecoverFromHistory, state.Workflow, state.Branch, state.Step))
+	}
+
+	branch := branchSettings[state.Workflow]
+	steps, err := customizeFinishSteps(repository, state, finishSteps(plugin, repository, state))
+	if err != nil {
+		return err
+	}
+	for _, step := range remainingSteps(steps, state.Step) {
+		fmt.Fprintln(statusOutput(), fmt.Sprintf("  %v: %v", step.name, step.Describe()))
+	}
+
+	if !execute {
+		fmt.Fprintln(statusOutput(), Message(MsgRecoverExecute))
+		return nil
+	}
+
+	// check if required tools are available
+	if err := ValidateToolsAvailability(plugin.RequiredTools()...); err != nil {
+		return err
+	}
+
+	// check if the repository prerequisites are met
+	if err := repository.IsClean(); err != nil {
+		return err
+	}
+
+	// check if workflow commits get the same identity as manual commits in the project path
+	if err := checkIdentity(repository); err != nil {
+		return err
+	}
+
+	// format finish command messages
+	called := Message(MsgFinishCalled, plugin, branch, repository.Local())
+	completed := Message(MsgFinishCompleted, plugin, branch, repository.Local())
+	failed := Message(MsgFinishFailed, plugin, branch, repository.Local())
+
+	Progress(called)
+
+	if err := runWorkflow(plugin, repository, state, steps); err != nil {
+		Failure(failed)
+		return err
+	}
+
+	Success(completed)
+	return nil
+}
+
+// remainingSteps returns the steps of a finish command from the named step on, or all steps if there is none.
+func remainingSteps(steps []workflowStep, name string) []workflowStep {
+	for i, step := range steps {
+		if step.name == name {
+			return steps[i:]
+		}
+	}
+	return steps
+}
+
+// inspectWorkflowState derives the state of a half-completed finish without state file from the branches and tags
+// of the repository, which is nil if no release or hotfix branch has been merged or tagged yet. Hotfixes of a
+// maintenance line are not inspected.
+func inspectWorkflowState(repository Repository) (*workflowState, error) {
+	_, releases, err := repository.HasBranch(Release)
+	if err != nil {
+		return nil, err
+	}
+
+	hotfixes, err := hotfixBranches(repository, "")
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := map[Branch][]BranchInfo{Release: releases, Hotfix: hotfixes}
+	for _, branch := range []Branch{Release, Hotfix} {
+		for _, info := range candidates[branch] {
+			step, err := inspectFinishStep(repository, branch, info)
+			if err != nil {
+				return nil, err
+			} else if len(step) > 0 {
+				state := newWorkflowState(branch, info, "")
+				state.Step = step
+				return state, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// inspectFinishStep returns the step to resume the finish of a release or hotfix branch at, which is empty if the
+// finish has not started yet. The checkout steps before merges are resumed, because merging a branch again which
+// has already been merged changes nothing.
+func inspectFinishStep(repository Repository, branch Branch, info BranchInfo) (string, error) {
+	ref, local := branchRef(repository, info)
+
+	tagged, err := repository.HasTag(versionTag(info.Version.String()))
+	if err != nil {
+		return "", err
+	}
+
+	production, _ := branchRef(repository, BranchInfo{Name: Production.String(), Remote: info.Remote})
+	merged, err := repository.IsAncestor(ref, production)
+	if err != nil {
+		return "", err
+	}
+
+	// the finish of a branch which has neither been merged into the production branch nor tagged has not started
+	if !merged && !tagged {
+		return "", nil
+	} else if !tagged && branch == Release {
+		return "checkout-production", nil
+	} else if !tagged {
+		return "checkout-base", nil
+	}
+
+	development, _ := branchRef(repository, BranchInfo{Name: Development.String(), Remote: info.Remote})
+	if merged, err = repository.IsAncestor(ref, development); err != nil {
+		return "", err
+	} else if !merged && branch == Release {
+		return "checkout-development", nil
+	} else if !merged {
+		return "checkout-release", nil
+	}
+
+	// the next development version is committed after the release branch has been merged into develop
+	if branch == Release {
+		commits, err := repository.Commits(ref + ".." + development)
+		if err != nil {
+			return "", err
+		}
+
+		next := false
+		for _, commit := range commits {
+			next = next || strings.TrimSpace(commit.Subject) == nextVersionSubject
+		}
+
+		if !next {
+			return "checkout-development", nil
+		}
+	}
+
+	// the branch has been deleted locally but not pushed yet, or not deleted at all
+	if local {
+		return "delete-branch", nil
+	}
+	return "fast-forward", nil
+}
+
+// branchRef returns the local branch as reference if it exists, which may hold commits not pushed yet, and the
+// remote-tracking branch otherwise.
+func branchRef(repository Repository, info BranchInfo) (string, bool) {
+	if _, err := repository.ResolveRef("refs/heads/" + info.Name); err == nil {
+		return info.Name, true
+	}
+
+	remote := info.Remote
+	if len(remote) == 0 {
+		remote = Remote
+	}
+	return remote + "/" + info.Name, false
+}
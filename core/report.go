@@ -0,0 +1,209 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Kinds of released versions in the report.
+const (
+	releaseKind = "release"
+	hotfixKind  = "hotfix"
+)
+
+// releaseSummary describes a released version of the report.
+type releaseSummary struct {
+	Version  string
+	Kind     string
+	Date     time.Time
+	Started  bool
+	Duration time.Duration
+	Commits  []Commit
+}
+
+// Report writes a static HTML summary of the latest released versions to a file, to share the release history with
+// stakeholders. Each release or hotfix lists its tag date, the duration from the first commit of its branch to the
+// tag, and the commits since the previous version. A limit of zero reports all versions.
+func Report(projectPath, fileName string, limit int) error {
+	// apply suitable settings from the global configuration to the core package
+	if err := applySettings(); err != nil {
+		return err
+	}
+
+	// set path to execute workflow commands
+	ProjectPath = projectPath
+
+	// check if project path exists
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return Error(MsgProjectPathMissing, projectPath)
+	}
+
+	repository := NewRepository(projectPath, Remote)
+
+	releases, err := summarizeReleases(repository, limit)
+	if err != nil {
+		return err
+	}
+
+	if len(releases) == 0 {
+		return Error(MsgReportNoReleases, projectPath)
+	}
+
+	name := filepath.Base(repository.Local())
+	if url, err := repository.RemoteURL(); err == nil && len(githubRepository(url)) > 0 {
+		name = githubRepository(url)
+	}
+
+	var content bytes.Buffer
+	if err := reportTemplate.Execute(&content, struct {
+		Name      string
+		Generated time.Time
+		Releases  []releaseSummary
+	}{name, time.Now(), releases}); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(fileName, content.Bytes(), 0644); err != nil {
+		return err
+	}
+
+	Success(Message(MsgReportWritten, len(releases), fileName))
+	return nil
+}
+
+// summarizeReleases returns the latest released versions, newest first, with the commits since their predecessor.
+func summarizeReleases(repository Repository, limit int) ([]releaseSummary, error) {
+	tags, err := repository.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]Version, 0)
+	for _, tagName := range tags {
+		if release, ok := releaseTag(tagName); ok {
+			versions = append(versions, release)
+		}
+	}
+
+	slices.SortFunc(versions, Version.Compare)
+
+	releases := make([]releaseSummary, 0)
+	for i := len(versions) - 1; i >= 0 && (limit <= 0 || len(releases) < limit); i-- {
+		revisionRange := versionTag(versions[i].String())
+		if i > 0 {
+			revisionRange = fmt.Sprintf("%v..%v", versionTag(versions[i-1].String()), revisionRange)
+		}
+
+		summary, err := summarizeRelease(repository, versions[i], revisionRange)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, summary)
+	}
+
+	return releases, nil
+}
+
+// summarizeRelease describes a released version. Hotfixes are told apart by their patch version, and the branch
+// starts with the commit that set its version, if it was created by the workflow.
+func summarizeRelease(repository Repository, version Version, revisionRange string) (releaseSummary, error) {
+	date, err := repository.TagDate(versionTag(version.String()))
+	if err != nil {
+		return releaseSummary{}, err
+	}
+
+	commits, err := repository.ProjectCommits(revisionRange)
+	if err != nil {
+		return releaseSummary{}, err
+	}
+
+	summary := releaseSummary{Version: version.String(), Kind: releaseKind, Date: date, Commits: commits}
+
+	startMessage := releaseStartMessage
+	if version.Incremental != "0" {
+		summary.Kind, startMessage = hotfixKind, hotfixStartMessage
+	}
+
+	// commits are listed newest first, so the last match is the first commit of the branch
+	for _, commit := range commits {
+		if strings.Contains(commit.Subject, startMessage) && !commit.Date.After(date) {
+			summary.Started, summary.Duration = true, date.Sub(commit.Date)
+		}
+	}
+
+	return summary, nil
+}
+
+// formatDuration formats the duration of a release in days, hours and minutes, e.g. '2d 4h'.
+func formatDuration(duration time.Duration) string {
+	if duration < time.Minute {
+		return "<1m"
+	}
+
+	days, hours, minutes := int(duration.Hours())/24, int(duration.Hours())%24, int(duration.Minutes())%60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// Template of the HTML report, which is self-contained so that it can be shared as a single file.
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"date":     func(t time.Time) string { return t.Format("2006-01-02 15:04") },
+	"duration": formatDuration,
+	"short":    func(hash string) string { return hash[:min(len(hash), 7)] },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Releases of {{.Name}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem auto; max-width: 60rem; color: #222; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2rem; }
+th, td { text-align: left; padding: .4rem .8rem; border-bottom: 1px solid #ddd; }
+th { background: #f4f4f4; }
+.hotfix { color: #b00; }
+.release { color: #070; }
+code { color: #666; }
+footer { color: #888; font-size: .85rem; }
+</style>
+</head>
+<body>
+<h1>Releases of {{.Name}}</h1>
+<table>
+<tr><th>Version</th><th>Type</th><th>Date</th><th>Duration</th><th>Commits</th></tr>
+{{- range .Releases}}
+<tr><td><a href="#{{.Version}}">{{.Version}}</a></td><td class="{{.Kind}}">{{.Kind}}</td><td>{{date .Date}}</td><td>{{if .Started}}{{duration .Duration}}{{else}}–{{end}}</td><td>{{len .Commits}}</td></tr>
+{{- end}}
+</table>
+{{- range .Releases}}
+<section id="{{.Version}}">
+<h2>{{.Version}} <small class="{{.Kind}}">{{.Kind}}</small></h2>
+<ul>
+{{- range .Commits}}
+<li><code>{{short .Hash}}</code> {{.Subject}} ({{.AuthorName}})</li>
+{{- else}}
+<li>No changes</li>
+{{- end}}
+</ul>
+</section>
+{{- end}}
+<footer>Generated by gitflow-cli on {{date .Generated}}</footer>
+</body>
+</html>
+`))
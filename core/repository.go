@@ -11,11 +11,36 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type CheckoutStrategy int
 
+// PullStrategy represents how remote changes are integrated into a local branch.
+type PullStrategy int
+
+// Pull strategies for integrating remote changes into local branches.
+const (
+	_ PullStrategy = iota
+	PullMerge
+	PullRebase
+	PullFastForwardOnly
+)
+
+// Names of the pull strategies in the configuration.
+var pullStrategyNames = map[PullStrategy]string{
+	PullMerge:           "merge",
+	PullRebase:          "rebase",
+	PullFastForwardOnly: "ff-only",
+}
+
+// String returns the configuration name of the pull strategy.
+func (s PullStrategy) String() string {
+	return pullStrategyNames[s]
+}
+
 const (
 	Theirs CheckoutStrategy = iota
 	Ours
@@ -26,26 +51,75 @@ type ConflictMap struct {
 	TheirVersion string
 }
 
+// BranchInfo describes a remote branch of the repository.
+type BranchInfo struct {
+	Name      string
+	Remote    string
+	Version   Version
+	Versioned bool
+}
+
+// Ref returns the remote-tracking reference of the branch, e.g. 'origin/release/1.2.0'.
+func (b BranchInfo) Ref() string {
+	return b.Remote + "/" + b.Name
+}
+
+// Commit represents a commit in the history of the repository.
+type Commit struct {
+	Hash        string
+	AuthorName  string
+	AuthorEmail string
+	Date        time.Time
+	Subject     string
+	Body        string
+}
+
 type (
 	// Repository represents a git repository.
 	Repository interface {
 		Local() string
+		Initialize(branchName string) error
+		HasCommits() (bool, error)
 		IsClean() error
-		HasBranch(branch Branch) (bool, []string, error)
+		HasBranch(branch Branch) (bool, []BranchInfo, error)
+		TrackedBranches(prefix string) ([]BranchInfo, error)
 		CheckoutBranch(branchName string) error
 		CheckoutFile(fileName string, strategy CheckoutStrategy) error
 		ContinueMerge() error
 		GetMergeConflicts() (map[string][]ConflictMap, error)
 		CreateBranch(branchName string) error
+		CreateBranchFrom(branchName, ref string) error
 		MergeBranch(branchName string, mergeType MergeType) error
-		PullBranch(branchName string) error
+		PullBranch(branchName string, strategy PullStrategy) error
 		DeleteBranch(branchName string) error
+		ResetBranch(branchName, ref string) error
 		AddFile(file string) error
 		CommitChanges(message string) error
 		TagCommit(tagName string) error
+		TagRef(tagName, ref string) error
+		HasTag(tagName string) (bool, error)
+		ResolveRef(ref string) (string, error)
+		RemoteURL() (string, error)
+		CurrentBranch() (string, error)
+		Identity() (string, error)
+		SignsTags() (bool, error)
+		ConfigValues(pattern string) (map[string]string, error)
+		UnpushedCommits(branchName string) (int, error)
+		BehindCommits(branchName string) (int, error)
+		FastForwardBranch(branchName string) error
+		Fetch() error
+		Tags() ([]string, error)
+		MergedTags(ref string) ([]string, error)
+		RemoteTags() ([]string, error)
+		TagDate(tagName string) (time.Time, error)
+		Commits(revisionRange string) ([]Commit, error)
+		ProjectCommits(revisionRange string) ([]Commit, error)
+		IsAncestor(ancestor, descendant string) (bool, error)
+		ShowFile(ref, fileName string) ([]byte, error)
 		PushChanges(branchName string) error
 		PushAllChanges() error
 		PushAllTags() error
+		PushTag(tagName string) error
 		PushDeletion(branchName string) error
 		Rollback(cause error) error
 		CompareFiles(sourceBranch, targetBranch, sourceFile, targetFile string) (bool, error)
@@ -57,23 +131,52 @@ type (
 // Implementation of the Repository interface.
 type repository struct {
 	projectPath, remote string
+	initRepository      []string
+	unbornBranch        []string
 	statusClean         []string
+	gitPath             []string
+	showPrefix          []string
 	fetchAll            []string
+	fetchRemote         []string
 	allRemotes          []string
+	refCommits          []string
 	allLocals           []string
 	switchBranch        []string
 	createBranch        []string
 	mergeBranch         []string
 	pullBranch          []string
+	fastForwardLocal    []string
 	deleteBranch        []string
 	forceDeleteBranch   []string
+	moveBranch          []string
 	addFile             []string
 	commitAll           []string
 	tagCommit           []string
+	signedTag           []string
+	annotatedTag        []string
+	fetchTags           []string
+	verifyRef           []string
+	resolveRef          []string
+	remoteURL           []string
+	listTags            []string
+	mergedTags          []string
+	remoteTags          []string
+	tagDate             []string
+	currentBranch       []string
+	identity            []string
+	tagSignConfig       []string
+	configValues        []string
+	countCommits        []string
+	logCommits          []string
+	ancestorCheck       []string
+	showFile            []string
 	pushBranch          []string
 	pushAll             []string
 	pushTags            []string
+	pushTag             []string
 	pushDeletion        []string
+	remoteHeads         []string
+	deleteRef           []string
 	cleanAll            []string
 	resetBranch         []string
 }
@@ -83,23 +186,52 @@ func NewRepository(projectPath, remote string) Repository {
 	return &repository{
 		projectPath:       projectPath,
 		remote:            remote,
+		initRepository:    []string{init_, quiet},
+		unbornBranch:      []string{symbolicref, head},
 		statusClean:       []string{status, porcelain},
-		fetchAll:          []string{fetch, all, prune},
-		allRemotes:        []string{branch, remotes},
+		gitPath:           []string{revparse},
+		showPrefix:        []string{revparse, showprefix},
+		fetchAll:          []string{fetch, all},
+		fetchRemote:       []string{fetch},
+		allRemotes:        []string{foreachref, refnameFormat},
+		refCommits:        []string{foreachref, objectFormat},
 		allLocals:         []string{branch},
 		switchBranch:      []string{switch_},
 		createBranch:      []string{switch_, create},
 		mergeBranch:       []string{merge},
-		pullBranch:        []string{pull, remote},
+		pullBranch:        []string{pull},
+		fastForwardLocal:  []string{fetch, here},
 		deleteBranch:      []string{branch, delete},
 		forceDeleteBranch: []string{branch, forcedelete},
+		moveBranch:        []string{branch, force},
 		addFile:           []string{add},
 		commitAll:         []string{commit, all, message},
 		tagCommit:         []string{tag},
+		signedTag:         []string{tag, sign, message},
+		annotatedTag:      []string{tag, annotated, message},
+		fetchTags:         []string{fetch, remote, tags},
+		verifyRef:         []string{revparse, verify, quiet},
+		resolveRef:        []string{revparse, verify},
+		remoteURL:         []string{remote_, geturl, remote},
+		listTags:          []string{tag, list},
+		mergedTags:        []string{tag, list, merged},
+		remoteTags:        []string{lsremote, tags, refsOnly},
+		tagDate:           []string{foreachref, createdFormat},
+		currentBranch:     []string{symbolicref, quiet, short, head},
+		identity:          []string{var_, committer},
+		tagSignConfig:     []string{config, typeBool, get, tagSigning},
+		configValues:      []string{config, getRegexp},
+		countCommits:      []string{revlist, count},
+		logCommits:        []string{log_, nomerges, commitFormat},
+		ancestorCheck:     []string{mergebase, isancestor},
+		showFile:          []string{show},
 		pushBranch:        []string{push, upstream, remote},
 		pushAll:           []string{push, all, remote},
 		pushTags:          []string{push, tags, remote},
+		pushTag:           []string{push, remote},
 		pushDeletion:      []string{push, delete, remote},
+		remoteHeads:       []string{lsremote, remote},
+		deleteRef:         []string{updateref, dir},
 		cleanAll:          []string{clean, force, dir, ignored},
 		resetBranch:       []string{reset, hard},
 	}
@@ -110,6 +242,75 @@ func (r *repository) Local() string {
 	return r.projectPath
 }
 
+// Initialize Create the repository if it does not exist and let the first commit of an empty repository start
+// the given branch.
+func (r *repository) Initialize(branchName string) error {
+	// reject names which git would take for an option or does not accept
+	if err := checkRefName(branchName); err != nil {
+		return err
+	}
+
+	var logs []any = make([]any, 0)
+
+	// log human-readable description of the git commands
+	defer func() { Log(logs...) }()
+
+	// create an empty repository, which is safe for an existing repository
+	initialize := exec.Command(Git, r.initRepository...)
+	initialize.Dir = r.projectPath
+
+	// run git command to create the repository
+	if output, err := initialize.CombinedOutput(); err != nil {
+		logs = append(logs, initialize, output, err)
+		return fmt.Errorf("git '%v' failed with %v: %s", initialize, err, output)
+	} else {
+		logs = append(logs, initialize, output)
+	}
+
+	// the current branch of a repository with commits is left untouched
+	if found, err := r.HasCommits(); err != nil || found {
+		return err
+	}
+
+	// point HEAD to the branch the first commit is made on
+	unborn := exec.Command(Git, append(r.unbornBranch, "refs/heads/"+branchName)...)
+	unborn.Dir = r.projectPath
+
+	// run git command to set the unborn branch
+	if output, err := unborn.CombinedOutput(); err != nil {
+		logs = append(logs, unborn, output, err)
+		return fmt.Errorf("git '%v' failed with %v: %s", unborn, err, output)
+	} else {
+		logs = append(logs, unborn, output)
+	}
+
+	return nil
+}
+
+// HasCommits Check if the repository has at least one commit on its current branch.
+func (r *repository) HasCommits() (bool, error) {
+	var err error
+	var verify *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(verify, output, err) }()
+
+	// verify that HEAD points to a commit
+	verify = exec.Command(Git, append(r.verifyRef, head)...)
+	verify.Dir = r.projectPath
+
+	// a failing verification without output means HEAD is an unborn branch
+	if output, err = verify.CombinedOutput(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 && len(output) == 0 {
+			return false, nil
+		}
+		return false, fmt.Errorf("git '%v' failed with %v: %s", verify, err, output)
+	}
+
+	return true, nil
+}
+
 // GetMergeConflicts checks all files for merge conflicts and returns a map of files to their conflicts.
 // Each file with conflicts has an entry in the map with a slice of all conflicts found in that file.
 func (r *repository) GetMergeConflicts() (map[string][]ConflictMap, error) {
@@ -234,18 +435,39 @@ func (r *repository) CheckoutFile(fileName string, strategy CheckoutStrategy) er
 }
 
 func (r *repository) ContinueMerge() error {
-	cmd := exec.Command("git", "commit", "--no-edit")
+	cmd := exec.Command(Git, withCommitOptions(commit, "--no-edit")...)
 	cmd.Dir = r.projectPath
 
 	return cmd.Run()
 }
 
-// IsClean Check if the repository under the project path is clean.
+// Git operations which stop for the user to continue them, by the file or directory marking them in the git directory,
+// and the message with the guidance to resolve them.
+var pendingOperations = []struct {
+	marker  string
+	message string
+}{
+	{"rebase-merge", MsgRebaseInProgress},
+	{"rebase-apply", MsgRebaseInProgress},
+	{"MERGE_HEAD", MsgMergeInProgress},
+	{"CHERRY_PICK_HEAD", MsgCherryPickInProgress},
+	{"REVERT_HEAD", MsgRevertInProgress},
+	{"BISECT_LOG", MsgBisectInProgress},
+}
+
+// IsClean Check if the repository under the project path is clean: no rebase, merge, cherry-pick, revert or bisect
+// is in progress, and there are no changes apart from untracked files matching the patterns of
+// 'workflow.clean-ignore'.
 func (r *repository) IsClean() error {
 	var err error
 	var status *exec.Cmd
 	var output []byte
 
+	// a pending operation fails the workflow with confusing git errors later, even if the working tree is clean
+	if err := r.checkPendingOperations(); err != nil {
+		return err
+	}
+
 	// log human-readable description of the git command
 	defer func() { Log(status, output, err) }()
 
@@ -256,59 +478,132 @@ func (r *repository) IsClean() error {
 	// run git command to get the status
 	if output, err = status.CombinedOutput(); err != nil {
 		return fmt.Errorf("git 'status' failed with %v: %s", err, output)
-	} else if len(output) != 0 {
-		return fmt.Errorf("repository under project path '%v' is not clean", status.Dir)
+	} else if len(unclean(string(output))) != 0 {
+		return Error(MsgRepositoryNotClean, status.Dir)
+	}
+
+	return nil
+}
+
+// checkPendingOperations (private) Fail for an operation which stopped for the user to continue it, with guidance
+// how to finish or abort it.
+func (r *repository) checkPendingOperations() error {
+	var err error
+	var gitPath *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(gitPath, output, err) }()
+
+	// the paths of the markers respect linked worktrees, whose git directory is not '.git'
+	args := append([]string{}, r.gitPath...)
+	for _, operation := range pendingOperations {
+		args = append(args, gitpath, operation.marker)
+	}
+	gitPath = exec.Command(Git, args...)
+	gitPath.Dir = r.projectPath
+
+	if output, err = gitPath.Output(); err != nil {
+		return fmt.Errorf("git 'rev-parse' failed with %v: %s", err, output)
+	}
+
+	paths := strings.Split(strings.TrimSpace(string(output)), "\n")
+	for i, operation := range pendingOperations {
+		if i >= len(paths) {
+			break
+		}
+		path := paths[i]
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(r.projectPath, path)
+		}
+		if _, statErr := os.Stat(path); statErr == nil {
+			return Error(operation.message, r.projectPath)
+		}
 	}
 
 	return nil
 }
 
-// HasBranch Check if a branch exists in the repository.
-func (r *repository) HasBranch(branch Branch) (bool, []string, error) {
-	var remotes []string
+// HasBranch Check if a branch exists on the remote as of the last fetch. Production and development branches must
+// match the configured name exactly, release and hotfix branches must be named '<prefix>/<version>[/<description>]',
+// and bugfix branches '<prefix>/<name>'.
+func (r *repository) HasBranch(branch Branch) (bool, []BranchInfo, error) {
+	infos, err := r.TrackedBranches(branch.String())
+	if err != nil {
+		return false, nil, err
+	}
+
+	branches := make([]BranchInfo, 0, len(infos))
+	for _, info := range infos {
+		switch branch {
+		case Release, Hotfix:
+			if info.Versioned {
+				branches = append(branches, info)
+			}
+		case Bugfix, Support:
+			if info.Name != branch.String() {
+				branches = append(branches, info)
+			}
+		default:
+			if info.Name == branch.String() {
+				branches = append(branches, info)
+			}
+		}
+	}
+
+	return len(branches) > 0, branches, nil
+}
+
+// TrackedBranches Return the remote-tracking branches named like the prefix or located below it as of the last
+// fetch, e.g. 'release/1.2.0', without fetching the remotes.
+func (r *repository) TrackedBranches(prefix string) ([]BranchInfo, error) {
 	var logs []any = make([]any, 0)
 
 	// log human-readable description of the git command
 	defer func() { Log(logs...) }()
 
-	// fetch and prune all remote branches
-	fetch := exec.Command(Git, r.fetchAll...)
-	fetch.Dir = r.projectPath
+	// list the remote refs which match the prefix completely or up to a slash
+	remoteRefs := "refs/remotes/" + r.remote + "/"
+	list := exec.Command(Git, append(r.allRemotes, remoteRefs+prefix)...)
+	list.Dir = r.projectPath
 
-	// run git command to fetch all remotes
-	if output, err := fetch.CombinedOutput(); err != nil {
-		logs = append(logs, fetch, output, err)
-		return false, nil, fmt.Errorf("fetching all remotes failed with %v: %s", err, output)
-	} else {
-		logs = append(logs, fetch, output)
+	// run git command to list the matching remote branches
+	output, err := list.CombinedOutput()
+	if err != nil {
+		logs = append(logs, list, output, err)
+		return nil, fmt.Errorf("listing remote branches failed with %v: %s", err, output)
 	}
+	logs = append(logs, list, output)
 
-	// list all remotes of the repository
-	all := exec.Command(Git, r.allRemotes...)
-	all.Dir = r.projectPath
+	branches := make([]BranchInfo, 0)
+	for _, ref := range strings.Split(string(output), "\n") {
+		name := strings.TrimPrefix(strings.TrimSpace(ref), remoteRefs)
+		if len(name) == 0 || (name != prefix && !strings.HasPrefix(name, prefix+"/")) {
+			continue
+		}
 
-	// run git command to list all remotes
-	if output, err := all.CombinedOutput(); err != nil {
-		logs = append(logs, all, output, err)
-		return false, nil, fmt.Errorf("getting all remotes failed with %v: %s", err, output)
-	} else {
-		logs = append(logs, all, output)
+		info := BranchInfo{Name: name, Remote: r.remote}
 
-		// check every line of the output for the branch name
-		prefix := r.remote + "/" + branch.String()
-		for _, remote := range strings.Split(string(output), "\n") {
-			remote = strings.TrimSpace(remote)
-			if remote == prefix || strings.HasPrefix(remote, prefix+"/") {
-				remotes = append(remotes, remote)
-			}
+		// the segment after the prefix holds the version, optionally followed by a description
+		segment := strings.SplitN(strings.TrimPrefix(name, prefix+"/"), "/", 2)[0]
+		if version, err := ParseVersion(segment); err == nil && version.String() == segment && name != prefix {
+			info.Version = version
+			info.Versioned = true
 		}
+
+		branches = append(branches, info)
 	}
 
-	return len(remotes) > 0, remotes, nil
+	return branches, nil
 }
 
 // CheckoutBranch Checkout a specific branch in the repository.
 func (r *repository) CheckoutBranch(branchName string) error {
+	// reject names which git would take for an option or does not accept
+	if err := checkRefName(branchName); err != nil {
+		return err
+	}
+
 	var err error
 	var checkout *exec.Cmd
 	var output []byte
@@ -333,6 +628,11 @@ func (r *repository) CheckoutBranch(branchName string) error {
 
 // CreateBranch Create a new branch in the repository with a specific name.
 func (r *repository) CreateBranch(branchName string) error {
+	// reject names which git would take for an option or does not accept
+	if err := checkRefName(branchName); err != nil {
+		return err
+	}
+
 	var err error
 	var create *exec.Cmd
 	var output []byte
@@ -352,8 +652,39 @@ func (r *repository) CreateBranch(branchName string) error {
 	return nil
 }
 
+// CreateBranchFrom Create a new branch in the repository with a specific name at a reference, e.g. a tag.
+func (r *repository) CreateBranchFrom(branchName, ref string) error {
+	// reject names which git would take for an option or does not accept
+	if err := checkRefName(branchName); err != nil {
+		return err
+	}
+
+	var err error
+	var create *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(create, output, err) }()
+
+	// create a new branch with the specific name at the commit the reference points to
+	create = exec.Command(Git, append(r.createBranch, branchName, ref+"^{commit}")...)
+	create.Dir = r.projectPath
+
+	// run git command to create a new branch
+	if output, err = create.CombinedOutput(); err != nil {
+		return fmt.Errorf("git create new '%v' from '%v' failed with %v: %s", branchName, ref, err, output)
+	}
+
+	return nil
+}
+
 // MergeBranch Merge a branch into the current branch in the repository with a specific merge type.
 func (r *repository) MergeBranch(branchName string, mergeType MergeType) error {
+	// reject names which git would take for an option or does not accept
+	if err := checkRefName(branchName); err != nil {
+		return err
+	}
+
 	var option string
 	var err error
 	var merge *exec.Cmd
@@ -373,13 +704,16 @@ func (r *repository) MergeBranch(branchName string, mergeType MergeType) error {
 	case FastForward:
 		option = fastforwad
 
+	case FastForwardOrCommit:
+		option = fastorcommit
+
 	default:
 		err = fmt.Errorf("unsupported merge type: %v", mergeType)
 		return err
 	}
 
 	// merge branch into the current branch
-	merge = exec.Command(Git, append(r.mergeBranch, option, branchName)...)
+	merge = exec.Command(Git, withCommitOptions(append(r.mergeBranch, option, branchName)...)...)
 	merge.Dir = r.projectPath
 
 	// run git command to merge branch
@@ -390,29 +724,98 @@ func (r *repository) MergeBranch(branchName string, mergeType MergeType) error {
 	return nil
 }
 
-// PullBranch Pull changes in a branch from the remote repository.
-func (r *repository) PullBranch(branchName string) error {
+// PullBranch Pull changes in a branch from the remote repository with an explicit pull strategy,
+// so that the result does not depend on the pull.rebase setting of the user.
+func (r *repository) PullBranch(branchName string, strategy PullStrategy) error {
+	// reject names which git would take for an option or does not accept
+	if err := checkRefName(branchName); err != nil {
+		return err
+	}
+
 	var err error
 	var pull *exec.Cmd
 	var output []byte
+	var logs []any = make([]any, 0)
 
 	// log human-readable description of the git command
-	defer func() { Log(pull, output, err) }()
+	defer func() { Log(append([]any{pull, output, err}, logs...)...) }()
+
+	// a failed merge or rebase is aborted, a fast-forward leaves nothing to abort
+	var option string
+	var abortArgs []string
+	switch strategy {
+	case PullMerge:
+		option, abortArgs = norebase, []string{merge, abort}
+	case PullRebase:
+		option, abortArgs = rebase, []string{rebase_, abort}
+	case PullFastForwardOnly:
+		option = fastforwad
+	default:
+		return fmt.Errorf("unsupported pull strategy: %v", strategy)
+	}
 
 	// pull changes from the remote repository
-	pull = exec.Command(Git, append(r.pullBranch, branchName)...)
+	pull = exec.Command(Git, withCommitOptions(append(r.pullBranch, option, r.remote, branchName)...)...)
 	pull.Dir = r.projectPath
 
 	// run git command to pull changes
 	if output, err = pull.CombinedOutput(); err != nil {
+		// abort the merge or rebase the pull has interrupted, so that the branch is left as it was before the pull
+		if abortArgs != nil && r.pullInterrupted(strategy) {
+			abortPull := exec.Command(Git, abortArgs...)
+			abortPull.Dir = r.projectPath
+			abortOutput, abortErr := abortPull.CombinedOutput()
+			logs = append(logs, abortPull, abortOutput, abortErr)
+		}
+
+		if strings.Contains(string(output), "divergent branches") || strings.Contains(string(output), "Not possible to fast-forward") {
+			return Error(MsgDivergentBranches, branchName, r.remote+"/"+branchName, strategy)
+		}
 		return fmt.Errorf("git '%v' failed with %v: %s", pull, err, output)
 	}
 
 	return nil
 }
 
+// pullInterrupted (private) Check whether a failed pull has left the merge or rebase of its strategy in progress,
+// whose markers respect linked worktrees.
+func (r *repository) pullInterrupted(strategy PullStrategy) bool {
+	markers := []string{"MERGE_HEAD"}
+	if strategy == PullRebase {
+		markers = []string{"rebase-merge", "rebase-apply"}
+	}
+
+	args := append([]string{}, r.gitPath...)
+	for _, marker := range markers {
+		args = append(args, gitpath, marker)
+	}
+	gitPath := exec.Command(Git, args...)
+	gitPath.Dir = r.projectPath
+
+	output, err := gitPath.Output()
+	Log(gitPath, output, err)
+	if err != nil {
+		return false
+	}
+
+	for _, path := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(r.projectPath, path)
+		}
+		if _, statErr := os.Stat(path); statErr == nil {
+			return true
+		}
+	}
+	return false
+}
+
 // DeleteBranch Delete a local branch in the repository with a specific name.
 func (r *repository) DeleteBranch(branchName string) error {
+	// reject names which git would take for an option or does not accept
+	if err := checkRefName(branchName); err != nil {
+		return err
+	}
+
 	var err error
 	var delete *exec.Cmd
 	var output []byte
@@ -432,8 +835,37 @@ func (r *repository) DeleteBranch(branchName string) error {
 	return nil
 }
 
+// ResetBranch Move a local branch, which is not checked out, to the commit a reference points to.
+func (r *repository) ResetBranch(branchName, ref string) error {
+	// reject names which git would take for an option or does not accept
+	if err := checkRefName(branchName); err != nil {
+		return err
+	}
+
+	var err error
+	var reset *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(reset, output, err) }()
+
+	// move the branch with the specific name to the commit of the reference
+	reset = exec.Command(Git, append(r.moveBranch, branchName, ref+"^{commit}")...)
+	reset.Dir = r.projectPath
+
+	// run git command to move the branch
+	if output, err = reset.CombinedOutput(); err != nil {
+		return fmt.Errorf("git reset '%v' to '%v' failed with %v: %s", branchName, ref, err, output)
+	}
+
+	return nil
+}
+
 func (r *repository) WriteFile(fileName string, fileContent string) error {
 	filePath := filepath.Join(r.projectPath, fileName)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory of fileName %v: %v", fileName, err)
+	}
 	if err := os.WriteFile(filePath, []byte(fileContent), 0644); err != nil {
 		return fmt.Errorf("failed to write in fileName %v: %v", fileName, err)
 	}
@@ -461,7 +893,7 @@ func (r *repository) AddFile(file string) error {
 	return nil
 }
 
-// CommitChanges Stage and commit changes in the repository with a specific message.
+// CommitChanges Stage and commit changes in the repository with a specific message, in the commit message template.
 func (r *repository) CommitChanges(message string) error {
 	var err error
 	var commit *exec.Cmd
@@ -471,7 +903,7 @@ func (r *repository) CommitChanges(message string) error {
 	defer func() { Log(commit, output, err) }()
 
 	// automatically stage all modified and deleted files and do the commit
-	commit = exec.Command(Git, append(r.commitAll, fmt.Sprintf("%v", message))...)
+	commit = exec.Command(Git, withCommitOptions(append(r.commitAll, commitMessageOf(message))...)...)
 	commit.Dir = r.projectPath
 
 	// run git command to stage and commit changes
@@ -484,6 +916,11 @@ func (r *repository) CommitChanges(message string) error {
 
 // TagCommit Tag the latest commit in the repository with a specific tag name.
 func (r *repository) TagCommit(tagName string) error {
+	// reject names which git would take for an option or does not accept
+	if err := checkRefName(tagName); err != nil {
+		return err
+	}
+
 	var err error
 	var tag *exec.Cmd
 	var output []byte
@@ -491,8 +928,14 @@ func (r *repository) TagCommit(tagName string) error {
 	// log human-readable description of the git command
 	defer func() { Log(tag, output, err) }()
 
+	// honor the tag signing configuration, signed tags must be annotated
+	args, err := r.tagArgs(tagName)
+	if err != nil {
+		return err
+	}
+
 	// tag the latest commit with the specific tag name
-	tag = exec.Command(Git, append(r.tagCommit, tagName)...)
+	tag = exec.Command(Git, withCommitOptions(args...)...)
 	tag.Dir = r.projectPath
 
 	// run git command to tag the latest commit
@@ -503,101 +946,802 @@ func (r *repository) TagCommit(tagName string) error {
 	return nil
 }
 
-// PushChanges Push changes in a branch to the remote repository.
-func (r *repository) PushChanges(branchName string) error {
+// TagRef Tag a specific commit reference in the repository with a specific tag name.
+func (r *repository) TagRef(tagName, ref string) error {
+	// reject names which git would take for an option or does not accept
+	if err := checkRefName(tagName); err != nil {
+		return err
+	}
+
 	var err error
-	var push *exec.Cmd
+	var tag *exec.Cmd
 	var output []byte
 
 	// log human-readable description of the git command
-	defer func() { Log(push, output, err) }()
+	defer func() { Log(tag, output, err) }()
 
-	// push changes to the remote repository
-	push = exec.Command(Git, append(r.pushBranch, branchName)...)
-	push.Dir = r.projectPath
+	// honor the tag signing configuration, signed tags must be annotated
+	args, err := r.tagArgs(tagName)
+	if err != nil {
+		return err
+	}
 
-	// run git command to push changes
-	if output, err = push.CombinedOutput(); err != nil {
-		return fmt.Errorf("git '%v' failed with %v: %s", push, err, output)
+	// tag the commit the reference points to (peeling annotated tags)
+	tag = exec.Command(Git, withCommitOptions(append(args, ref+"^{commit}")...)...)
+	tag.Dir = r.projectPath
+
+	// run git command to tag the referenced commit
+	if output, err = tag.CombinedOutput(); err != nil {
+		return fmt.Errorf("git '%v' failed with %v: %s", tag, err, output)
 	}
 
 	return nil
 }
 
-// PushAllChanges Push all local changes in the repository to the remote repository.
-func (r *repository) PushAllChanges() error {
-	var err error
-	var push *exec.Cmd
-	var output []byte
+// HasTag Check if a tag exists in the repository after fetching all tags from the remote repository.
+func (r *repository) HasTag(tagName string) (bool, error) {
+	var logs []any = make([]any, 0)
 
 	// log human-readable description of the git command
-	defer func() { Log(push, output, err) }()
+	defer func() { Log(logs...) }()
 
-	// push all changes to the remote repository
-	push = exec.Command(Git, r.pushAll...)
-	push.Dir = r.projectPath
+	// fetch all tags from the remote repository
+	fetch := exec.Command(Git, r.fetchTags...)
+	fetch.Dir = r.projectPath
 
-	// run git command to push all changes
-	if output, err = push.CombinedOutput(); err != nil {
-		return fmt.Errorf("git '%v' failed with %v: %s", push, err, output)
+	// run git command to fetch all tags
+	if output, err := fetch.CombinedOutput(); err != nil {
+		logs = append(logs, fetch, output, err)
+		return false, fmt.Errorf("fetching all tags failed with %v: %s", err, output)
+	} else {
+		logs = append(logs, fetch, output)
 	}
 
-	return nil
+	// verify that the tag reference exists
+	verify := exec.Command(Git, append(r.verifyRef, "refs/tags/"+tagName)...)
+	verify.Dir = r.projectPath
+
+	// a failing verification without output means the tag does not exist
+	output, err := verify.CombinedOutput()
+	logs = append(logs, verify, output)
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 && len(output) == 0 {
+			return false, nil
+		}
+		return false, fmt.Errorf("git '%v' failed with %v: %s", verify, err, output)
+	}
+
+	return true, nil
 }
 
-// PushAllTags Push all local tags in the repository to the remote repository.
-func (r *repository) PushAllTags() error {
+// ResolveRef Resolve a reference in the repository to the hash of the commit it points to.
+func (r *repository) ResolveRef(ref string) (string, error) {
 	var err error
-	var push *exec.Cmd
+	var resolve *exec.Cmd
 	var output []byte
 
 	// log human-readable description of the git command
-	defer func() { Log(push, output, err) }()
+	defer func() { Log(resolve, output, err) }()
 
-	// push all tags to the remote repository
-	push = exec.Command(Git, r.pushTags...)
-	push.Dir = r.projectPath
+	// resolve the reference to a commit hash
+	resolve = exec.Command(Git, append(r.resolveRef, ref+"^{commit}")...)
+	resolve.Dir = r.projectPath
 
-	// run git command to push all tags
-	if output, err = push.CombinedOutput(); err != nil {
-		return fmt.Errorf("git '%v' failed with %v: %s", push, err, output)
+	// run git command to resolve the reference
+	if output, err = resolve.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("git '%v' failed with %v: %s", resolve, err, output)
 	}
 
-	return nil
+	return strings.TrimSpace(string(output)), nil
 }
 
-// PushDeletion Push a local branch deletion in the repository to the remote repository.
-func (r *repository) PushDeletion(branchName string) error {
+// RemoteURL Return the URL of the remote repository.
+func (r *repository) RemoteURL() (string, error) {
 	var err error
-	var push *exec.Cmd
+	var url *exec.Cmd
 	var output []byte
 
 	// log human-readable description of the git command
-	defer func() { Log(push, output, err) }()
+	defer func() { Log(url, output, err) }()
 
-	// push the branch deletion to the remote repository
-	push = exec.Command(Git, append(r.pushDeletion, branchName)...)
-	push.Dir = r.projectPath
+	// get the URL of the remote repository
+	url = exec.Command(Git, r.remoteURL...)
+	url.Dir = r.projectPath
 
-	// run git command to push the branch deletion
-	if output, err = push.CombinedOutput(); err != nil {
-		return fmt.Errorf("git '%v' failed with %v: %s", push, err, output)
+	// run git command to get the remote URL
+	if output, err = url.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("git '%v' failed with %v: %s", url, err, output)
 	}
 
-	return nil
+	return strings.TrimSpace(string(output)), nil
 }
 
-// Rollback reverts all local changes in the repository and synchronizes with the remote repository.
-func (r *repository) Rollback(cause error) error {
-	var logs []any = make([]any, 0)
+// CurrentBranch Return the name of the checked out branch, which is empty for a detached HEAD.
+func (r *repository) CurrentBranch() (string, error) {
+	var err error
+	var current *exec.Cmd
+	var output []byte
 
 	// log human-readable description of the git command
-	defer func() { Log(logs...) }()
+	defer func() { Log(current, output, err) }()
 
-	if !rollbackChanges {
-		return cause
+	// get the branch HEAD refers to
+	current = exec.Command(Git, r.currentBranch...)
+	current.Dir = r.projectPath
+
+	// a failing lookup without output means HEAD is detached
+	if output, err = current.CombinedOutput(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 && len(output) == 0 {
+			err = nil
+			return "", nil
+		}
+		return "", fmt.Errorf("git '%v' failed with %v: %s", current, err, output)
 	}
 
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Identity Return the committer identity git uses in the repository, which takes conditional includes
+// (e.g. includeIf "gitdir:") and worktree-level configuration into account.
+func (r *repository) Identity() (string, error) {
+	var err error
+	var ident *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(ident, output, err) }()
+
+	// let git resolve the identity the same way as for manual commits in the project path
+	ident = exec.Command(Git, withCommitOptions(r.identity...)...)
+	ident.Dir = r.projectPath
+
+	// run git command to resolve the committer identity
+	if output, err = ident.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("git '%v' failed with %v: %s", ident, err, output)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// SignsTags Check if the git configuration of the repository requests signed tags (tag.gpgSign).
+func (r *repository) SignsTags() (bool, error) {
+	var err error
+	var get *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(get, output, err) }()
+
+	// read the effective setting including conditional includes and worktree-level configuration
+	get = exec.Command(Git, r.tagSignConfig...)
+	get.Dir = r.projectPath
+
+	// a failing lookup without output means the setting is not configured
+	if output, err = get.CombinedOutput(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 && len(output) == 0 {
+			err = nil
+			return false, nil
+		}
+		return false, fmt.Errorf("git '%v' failed with %v: %s", get, err, output)
+	}
+
+	return strings.TrimSpace(string(output)) == "true", nil
+}
+
+// ConfigValues Return the git configuration values whose keys match the regular expression.
+func (r *repository) ConfigValues(pattern string) (map[string]string, error) {
+	var err error
+	var get *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(get, output, err) }()
+
+	// read the effective settings including conditional includes
+	get = exec.Command(Git, append(r.configValues, pattern)...)
+	get.Dir = r.projectPath
+
+	// a failing lookup without output means no setting matches
+	values := make(map[string]string)
+	if output, err = get.CombinedOutput(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 && len(output) == 0 {
+			err = nil
+			return values, nil
+		}
+		return nil, fmt.Errorf("git '%v' failed with %v: %s", get, err, output)
+	}
+
+	// each line holds a key and its value separated by a space
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if key, value, found := strings.Cut(line, " "); found {
+			values[key] = value
+		} else if len(key) > 0 {
+			values[key] = ""
+		}
+	}
+
+	return values, nil
+}
+
+// Return the arguments to create a tag, which is signed if configured under 'core.tag.sign' or if the repository
+// requests signed tags, and annotated if signed or configured under 'core.tag.annotated'.
+func (r *repository) tagArgs(tagName string) ([]string, error) {
+	signed := tagSigned
+	if !signed {
+		var err error
+		if signed, err = r.SignsTags(); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case signed:
+		return append(append([]string{}, r.signedTag...), tagMessageOf(tagName), tagName), nil
+	case tagAnnotated:
+		return append(append([]string{}, r.annotatedTag...), tagMessageOf(tagName), tagName), nil
+	default:
+		return append(append([]string{}, r.tagCommit...), tagName), nil
+	}
+}
+
+// Return the arguments to fetch all remotes, or only the gitflow branches of the remote. Pruning a targeted fetch
+// only deletes remote-tracking branches in the namespaces of the gitflow branches.
+func (r *repository) fetchArgs() []string {
+	if !fetchGitflowOnly {
+		args := append([]string{}, r.fetchAll...)
+		if pruneRefs {
+			args = append(args, prune)
+		}
+		return args
+	}
+
+	args := append([]string{}, r.fetchRemote...)
+	if pruneRefs {
+		args = append(args, prune)
+	}
+	args = append(args, r.remote)
+
+	// production and development are patterns, so that a missing branch does not fail the fetch
+	for _, branch := range []Branch{Production, Development} {
+		args = append(args, fmt.Sprintf("+refs/heads/%v*:refs/remotes/%v/%v*", branch, r.remote, branch))
+	}
+	for _, branch := range []Branch{Release, Hotfix, Bugfix, Support} {
+		args = append(args, fmt.Sprintf("+refs/heads/%v/*:refs/remotes/%v/%v/*", branch, r.remote, branch))
+	}
+
+	return args
+}
+
+// UnpushedCommits Return the number of commits of a local branch that are not on any remote branch.
+func (r *repository) UnpushedCommits(branchName string) (int, error) {
+	var err error
+	var revList *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(revList, output, err) }()
+
+	// count the commits of the branch which are not reachable from the remote branches
+	revList = exec.Command(Git, append(r.countCommits, branchName, not, remotesOf+r.remote)...)
+	revList.Dir = r.projectPath
+
+	// run git command to count the commits
+	if output, err = revList.CombinedOutput(); err != nil {
+		return 0, fmt.Errorf("git '%v' failed with %v: %s", revList, err, output)
+	}
+
+	return strconv.Atoi(strings.TrimSpace(string(output)))
+}
+
+// BehindCommits Return the number of commits of the remote branch that are not on the local branch, which is zero
+// if the local or the remote branch does not exist.
+func (r *repository) BehindCommits(branchName string) (int, error) {
+	var logs []any = make([]any, 0)
+
+	// log human-readable description of the git commands
+	defer func() { Log(logs...) }()
+
+	localRef := "refs/heads/" + branchName
+	remoteRef := "refs/remotes/" + r.remote + "/" + branchName
+
+	// only a branch which exists locally and remotely can lag behind
+	for _, ref := range []string{localRef, remoteRef} {
+		verify := exec.Command(Git, append(r.verifyRef, ref)...)
+		verify.Dir = r.projectPath
+
+		// a failing verification without output means the reference does not exist
+		output, err := verify.CombinedOutput()
+		logs = append(logs, verify, output)
+		if err != nil {
+			if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 && len(output) == 0 {
+				return 0, nil
+			}
+			return 0, fmt.Errorf("git '%v' failed with %v: %s", verify, err, output)
+		}
+	}
+
+	// count the commits of the remote branch which are not reachable from the local branch
+	revList := exec.Command(Git, append(r.countCommits, localRef+".."+remoteRef)...)
+	revList.Dir = r.projectPath
+
+	// run git command to count the commits
+	output, err := revList.CombinedOutput()
+	logs = append(logs, revList, output)
+	if err != nil {
+		return 0, fmt.Errorf("git '%v' failed with %v: %s", revList, err, output)
+	}
+
+	return strconv.Atoi(strings.TrimSpace(string(output)))
+}
+
+// FastForwardBranch Fast-forward a local branch to its remote branch, which fails if the branches have diverged.
+func (r *repository) FastForwardBranch(branchName string) error {
+	// reject names which git would take for an option or does not accept
+	if err := checkRefName(branchName); err != nil {
+		return err
+	}
+
+	var err error
+	var forward *exec.Cmd
+	var output []byte
+
+	current, err := r.CurrentBranch()
+	if err != nil {
+		return err
+	}
+
+	// log human-readable description of the git command
+	defer func() { Log(forward, output, err) }()
+
+	if current == branchName {
+		// fast-forward the checked out branch together with the working tree
+		forward = exec.Command(Git, append(r.mergeBranch, fastforwad, r.remote+"/"+branchName)...)
+	} else {
+		// fast-forward the branch without checking it out
+		forward = exec.Command(Git, append(r.fastForwardLocal, "refs/remotes/"+r.remote+"/"+branchName+":refs/heads/"+branchName)...)
+	}
+	forward.Dir = r.projectPath
+
+	// run git command to fast-forward the branch
+	if output, err = forward.CombinedOutput(); err != nil {
+		return fmt.Errorf("git '%v' failed with %v: %s", forward, err, output)
+	}
+
+	return nil
+}
+
+// Fetch Fetch the remote branches and prune the deleted ones, as configured by the fetch and prune settings.
+func (r *repository) Fetch() error {
+	var err error
+	var fetch *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(fetch, output, err) }()
+
+	// fetch the remote branches as configured
+	fetch = exec.Command(Git, r.fetchArgs()...)
+	fetch.Dir = r.projectPath
+
+	// run git command to fetch the remotes
+	if output, err = fetch.CombinedOutput(); err != nil {
+		return fmt.Errorf("fetching all remotes failed with %v: %s", err, output)
+	}
+
+	return nil
+}
+
+// Tags Return the names of all tags in the repository.
+func (r *repository) Tags() ([]string, error) {
+	var err error
+	var list *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(list, output, err) }()
+
+	// list all tags of the repository
+	list = exec.Command(Git, r.listTags...)
+	list.Dir = r.projectPath
+
+	// run git command to list all tags
+	if output, err = list.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("git '%v' failed with %v: %s", list, err, output)
+	}
+
+	tags := make([]string, 0)
+	for _, name := range strings.Split(string(output), "\n") {
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			tags = append(tags, name)
+		}
+	}
+
+	return tags, nil
+}
+
+// MergedTags Return the names of the tags reachable from a ref, which takes a single walk of its history instead of
+// an ancestry check per tag.
+func (r *repository) MergedTags(ref string) ([]string, error) {
+	var err error
+	var list *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(list, output, err) }()
+
+	// list the tags whose commits are ancestors of the ref
+	list = exec.Command(Git, append(r.mergedTags, ref)...)
+	list.Dir = r.projectPath
+
+	// run git command to list the reachable tags
+	if output, err = list.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("git '%v' failed with %v: %s", list, err, output)
+	}
+
+	tags := make([]string, 0)
+	for _, name := range strings.Split(string(output), "\n") {
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			tags = append(tags, name)
+		}
+	}
+
+	return tags, nil
+}
+
+// RemoteTags Return the names of the tags of the remote, without fetching them or their history, e.g. in shallow
+// clones of pipelines which have no tags.
+func (r *repository) RemoteTags() ([]string, error) {
+	var err error
+	var list *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(list, output, err) }()
+
+	// list the tag references of the remote, without the peeled references of annotated tags
+	list = exec.Command(Git, append(r.remoteTags, r.remote)...)
+	list.Dir = r.projectPath
+
+	// run git command to list the remote tags
+	if output, err = list.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("git '%v' failed with %v: %s", list, err, output)
+	}
+
+	// each line holds the object name and the reference of a tag
+	tags := make([]string, 0)
+	for _, line := range strings.Split(string(output), "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 {
+			tags = append(tags, strings.TrimPrefix(fields[1], tagsPrefix))
+		}
+	}
+
+	return tags, nil
+}
+
+// TagDate Return the date a tag was created, which is the commit date for lightweight tags.
+func (r *repository) TagDate(tagName string) (time.Time, error) {
+	var err error
+	var created *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(created, output, err) }()
+
+	// read the creation date of the tag reference
+	created = exec.Command(Git, append(r.tagDate, tagsPrefix+tagName)...)
+	created.Dir = r.projectPath
+
+	// run git command to read the date
+	if output, err = created.CombinedOutput(); err != nil {
+		return time.Time{}, fmt.Errorf("git '%v' failed with %v: %s", created, err, output)
+	}
+
+	date, err := time.Parse(time.RFC3339, strings.TrimSpace(string(output)))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("git '%v' returned no date of tag '%v'", created, tagName)
+	}
+
+	return date, nil
+}
+
+// Commits Return the non-merge commits of a revision range in the repository, newest first.
+func (r *repository) Commits(revisionRange string) ([]Commit, error) {
+	return r.commits(revisionRange)
+}
+
+// ProjectCommits Return the non-merge commits of a revision range which change the project, newest first. A project
+// in a subdirectory of the repository, e.g. one of the projects of a monorepo, only gets the commits which change
+// files below its directory, a project at the top level of the repository all commits.
+func (r *repository) ProjectCommits(revisionRange string) ([]Commit, error) {
+	var err error
+	var prefix *exec.Cmd
+	var output []byte
+
+	// the prefix of the project directory relative to the top level of the repository, empty at the top level
+	prefix = exec.Command(Git, r.showPrefix...)
+	prefix.Dir = r.projectPath
+	output, err = prefix.CombinedOutput()
+	Log(prefix, output, err)
+	if err != nil {
+		return nil, fmt.Errorf("git '%v' failed with %v: %s", prefix, err, output)
+	}
+
+	if len(strings.TrimSpace(string(output))) == 0 {
+		return r.commits(revisionRange)
+	}
+	return r.commits(revisionRange, "--", ".")
+}
+
+// commits (private) Return the non-merge commits of a revision range, optionally limited to the commits changing the
+// paths of a pathspec.
+func (r *repository) commits(revisionRange string, pathspec ...string) ([]Commit, error) {
+	var err error
+	var history *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(history, err) }()
+
+	// list all commits of the revision range with fields separated by unit separators
+	history = exec.Command(Git, append(append(append([]string{}, r.logCommits...), revisionRange), pathspec...)...)
+	history.Dir = r.projectPath
+
+	// run git command to list the commits
+	if output, err = history.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("git '%v' failed with %v: %s", history, err, output)
+	}
+
+	commits := make([]Commit, 0)
+	for _, record := range strings.Split(string(output), "\x1e") {
+		fields := strings.Split(strings.TrimSpace(record), "\x1f")
+		if len(fields) != 6 {
+			continue
+		}
+
+		date, _ := time.Parse(time.RFC3339, fields[3])
+		commits = append(commits, Commit{
+			Hash:        fields[0],
+			AuthorName:  fields[1],
+			AuthorEmail: fields[2],
+			Date:        date,
+			Subject:     fields[4],
+			Body:        strings.TrimSpace(fields[5]),
+		})
+	}
+
+	return commits, nil
+}
+
+// IsAncestor Check if a commit is reachable from another commit, e.g. a tag from a branch.
+func (r *repository) IsAncestor(ancestor, descendant string) (bool, error) {
+	var err error
+	var check *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(check, output, err) }()
+
+	// check if the ancestor is in the history of the descendant
+	check = exec.Command(Git, append(r.ancestorCheck, ancestor, descendant)...)
+	check.Dir = r.projectPath
+
+	// a failing check without output means the commit is not reachable
+	if output, err = check.CombinedOutput(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 && len(output) == 0 {
+			err = nil
+			return false, nil
+		}
+		return false, fmt.Errorf("git '%v' failed with %v: %s", check, err, output)
+	}
+
+	return true, nil
+}
+
+// ShowFile Return the content of a file at a reference without checking it out.
+func (r *repository) ShowFile(ref, fileName string) ([]byte, error) {
+	var err error
+	var show *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(show, err) }()
+
+	// show the file content of the commit the reference points to
+	show = exec.Command(Git, append(r.showFile, ref+":"+fileName)...)
+	show.Dir = r.projectPath
+
+	// run git command to show the file, which fails if it does not exist at the reference
+	if output, err = show.Output(); err != nil {
+		return nil, fmt.Errorf("git '%v' failed with %v", show, err)
+	}
+
+	return output, nil
+}
+
+// PushChanges Push changes in a branch to the remote repository.
+func (r *repository) PushChanges(branchName string) error {
+	// reject names which git would take for an option or does not accept
+	if err := checkRefName(branchName); err != nil {
+		return err
+	}
+
+	var err error
+	var push *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(push, output, err) }()
+
+	// push changes to the remote repository
+	push = exec.Command(Git, append(r.pushBranch, branchName)...)
+	push.Dir = r.projectPath
+
+	// run git command to push changes
+	if output, err = push.CombinedOutput(); err != nil {
+		return fmt.Errorf("git '%v' failed with %v: %s", push, err, output)
+	}
+
+	return nil
+}
+
+// PushAllChanges Push all local changes in the repository to the remote repository.
+func (r *repository) PushAllChanges() error {
+	var err error
+	var push *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(push, output, err) }()
+
+	// push all changes to the remote repository
+	push = exec.Command(Git, r.pushAll...)
+	push.Dir = r.projectPath
+
+	// run git command to push all changes
+	if output, err = push.CombinedOutput(); err != nil {
+		return fmt.Errorf("git '%v' failed with %v: %s", push, err, output)
+	}
+
+	return nil
+}
+
+// PushAllTags Push all local tags in the repository to the remote repository.
+func (r *repository) PushAllTags() error {
+	var err error
+	var push *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(push, output, err) }()
+
+	// push all tags to the remote repository
+	push = exec.Command(Git, r.pushTags...)
+	push.Dir = r.projectPath
+
+	// run git command to push all tags
+	if output, err = push.CombinedOutput(); err != nil {
+		return fmt.Errorf("git '%v' failed with %v: %s", push, err, output)
+	}
+
+	return nil
+}
+
+// PushTag Push a single local tag in the repository to the remote repository.
+func (r *repository) PushTag(tagName string) error {
+	// reject names which git would take for an option or does not accept
+	if err := checkRefName(tagName); err != nil {
+		return err
+	}
+
+	var err error
+	var push *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(push, output, err) }()
+
+	// push the tag to the remote repository
+	push = exec.Command(Git, append(r.pushTag, "refs/tags/"+tagName)...)
+	push.Dir = r.projectPath
+
+	// run git command to push the tag
+	if output, err = push.CombinedOutput(); err != nil {
+		return fmt.Errorf("git '%v' failed with %v: %s", push, err, output)
+	}
+
+	return nil
+}
+
+// PushDeletion Push a local branch deletion in the repository to the remote repository.
+func (r *repository) PushDeletion(branchName string) error {
+	// reject names which git would take for an option or does not accept
+	if err := checkRefName(branchName); err != nil {
+		return err
+	}
+
+	var err error
+	var push *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(push, output, err) }()
+
+	// push the branch deletion to the remote repository
+	push = exec.Command(Git, append(r.pushDeletion, branchName)...)
+	push.Dir = r.projectPath
+
+	// run git command to push the branch deletion
+	if output, err = push.CombinedOutput(); err != nil {
+		// the hosting service may have deleted the branch already, e.g. when merging its pull request
+		if exists, lsErr := r.hasRemoteHead(branchName); lsErr != nil || exists {
+			return fmt.Errorf("git '%v' failed with %v: %s", push, err, output)
+		}
+		return r.pruneTrackingBranch(branchName)
+	}
+
+	return nil
+}
+
+// hasRemoteHead (private) Check if a branch exists on the remote, without fetching it.
+func (r *repository) hasRemoteHead(branchName string) (bool, error) {
+	var err error
+	var list *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(list, output, err) }()
+
+	// list the head of the branch on the remote, which is missing if the branch has been deleted
+	list = exec.Command(Git, append(r.remoteHeads, "refs/heads/"+branchName)...)
+	list.Dir = r.projectPath
+
+	if output, err = list.CombinedOutput(); err != nil {
+		return false, fmt.Errorf("git '%v' failed with %v: %s", list, err, output)
+	}
+
+	return len(strings.TrimSpace(string(output))) > 0, nil
+}
+
+// pruneTrackingBranch (private) Delete the remote-tracking branch of a branch deleted on the remote, which a push
+// of the deletion would have deleted, so that the clone does not list it anymore.
+func (r *repository) pruneTrackingBranch(branchName string) error {
+	var err error
+	var prune *exec.Cmd
+	var output []byte
+
+	// log human-readable description of the git command
+	defer func() { Log(prune, output, err) }()
+
+	// deleting a missing remote-tracking branch succeeds as well
+	prune = exec.Command(Git, append(r.deleteRef, "refs/remotes/"+r.remote+"/"+branchName)...)
+	prune.Dir = r.projectPath
+
+	if output, err = prune.CombinedOutput(); err != nil {
+		return fmt.Errorf("git '%v' failed with %v: %s", prune, err, output)
+	}
+
+	return nil
+}
+
+// Rollback reverts all local changes in the repository and synchronizes with the remote repository.
+func (r *repository) Rollback(cause error) error {
+	var logs []any = make([]any, 0)
+
+	// log human-readable description of the git command
+	defer func() { Log(logs...) }()
+
+	if !rollbackChanges {
+		return cause
+	}
+
+	// the reset repository cannot continue an interrupted finish command
+	if err := removeWorkflowState(r.projectPath); err != nil {
+		logs = append(logs, err)
+	}
+
+	// backups and caches of the plugin tools belong to the rolled back changes
+	RemoveScratchDirs()
+
 	// abort any in-progress merge (ignore error if no merge is running)
 	abortMerge := exec.Command(Git, "merge", "--abort")
 	abortMerge.Dir = r.projectPath
@@ -654,6 +1798,7 @@ func (r *repository) Rollback(cause error) error {
 
 		releasePrefix := branchNames[Release] + "/"
 		hotfixPrefix := branchNames[Hotfix] + "/"
+		bugfixPrefix := branchNames[Bugfix] + "/"
 
 		for _, local := range strings.Split(string(output), "\n") {
 			local = strings.Trim(local, "* \n\r")
@@ -662,8 +1807,8 @@ func (r *repository) Rollback(cause error) error {
 				continue
 			}
 
-			// only delete branches created by the workflow (release/* or hotfix/*)
-			if !strings.HasPrefix(local, releasePrefix) && !strings.HasPrefix(local, hotfixPrefix) {
+			// only delete branches created by the workflow (release/*, hotfix/* or bugfix/*)
+			if !strings.HasPrefix(local, releasePrefix) && !strings.HasPrefix(local, hotfixPrefix) && !strings.HasPrefix(local, bugfixPrefix) {
 				continue
 			}
 
@@ -682,17 +1827,14 @@ func (r *repository) Rollback(cause error) error {
 	return cause
 }
 
-// HasRemoteBranch checks if a specific branch name exists on the remote.
+// HasRemoteBranch checks if a specific branch name exists on the remote as of the last fetch.
 func (r *repository) HasRemoteBranch(name string) (bool, error) {
-	all := exec.Command(Git, r.allRemotes...)
-	all.Dir = r.projectPath
-	output, err := all.CombinedOutput()
+	branches, err := r.TrackedBranches(name)
 	if err != nil {
-		return false, fmt.Errorf("listing remotes failed: %v: %s", err, output)
+		return false, err
 	}
-	target := r.remote + "/" + name
-	for _, remote := range strings.Split(string(output), "\n") {
-		if strings.TrimSpace(remote) == target {
+	for _, info := range branches {
+		if info.Name == name {
 			return true, nil
 		}
 	}
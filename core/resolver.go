@@ -36,6 +36,30 @@ var branchCandidates = map[Branch][]string{
 	Development: {"develop", "dev", "development"},
 }
 
+// fetchRemotes fetches the remote branches once for a command, whose lookups of branches read the remote-tracking
+// branches of this fetch. Dry runs fetch as well, because their plan follows the branches of the remote.
+func fetchRemotes(repository Repository) error {
+	if dry, ok := repository.(*dryRunRepository); ok {
+		return dry.repository.Fetch()
+	}
+	return repository.Fetch()
+}
+
+// syncBranches fetches the remotes and checks that the configured branches exist on remote, in the given order, e.g.
+// the production branch before the development branch, which may be created from it.
+func syncBranches(repository Repository, branchTypes ...Branch) error {
+	if err := fetchRemotes(repository); err != nil {
+		return err
+	}
+
+	for _, branchType := range branchTypes {
+		if err := syncBranch(repository, branchType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // syncBranch checks that the configured branch exists on remote.
 // If not found, it invokes BranchSync to offer resolution.
 func syncBranch(repository Repository, branchType Branch) error {
@@ -47,6 +71,13 @@ func syncBranch(repository Repository, branchType Branch) error {
 		return nil
 	}
 
+	// repositories following the nvie git-flow layout are used as they are
+	if detected, err := detectLayout(repository, branchType); err != nil {
+		return err
+	} else if detected {
+		return nil
+	}
+
 	candidates := findCandidates(repository, branchType)
 
 	if BranchSync == nil {
@@ -0,0 +1,201 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// SBOM settings keys.
+const (
+	sbomGroup            = "sbom"
+	sbomGeneratorSetting = "generator"
+	sbomCommandSetting   = "command"
+	sbomPathSetting      = "path"
+)
+
+// Built-in SBOM generators.
+const (
+	syftGenerator      = "syft"
+	cyclonedxGenerator = "cyclonedx-gomod"
+	goGenerator        = "go"
+)
+
+// Default file the SBOM is committed to.
+const defaultSBOMPath = "sbom.cdx.json"
+
+// sbomGenerators maps generator names to the command lines that print a CycloneDX SBOM to stdout.
+var sbomGenerators = map[string][]string{
+	syftGenerator:      {"syft", "scan", "dir:.", "-o", "cyclonedx-json"},
+	cyclonedxGenerator: {"cyclonedx-gomod", "mod", "-json"},
+	goGenerator:        {"go", "list", "-m", "-json", "all"},
+}
+
+var sbomGenerator = ""
+var sbomCommand []string
+var sbomPath = defaultSBOMPath
+
+func applySBOMSettings(settings map[string]any) {
+	if v, ok := settings[sbomGeneratorSetting].(string); ok {
+		sbomGenerator = v
+	}
+	if v, ok := settings[sbomCommandSetting].(string); ok {
+		sbomCommand = strings.Fields(v)
+	}
+	if v, ok := settings[sbomPathSetting].(string); ok && len(v) > 0 {
+		sbomPath = v
+	}
+}
+
+func resetSBOMSettings() {
+	sbomGenerator = ""
+	sbomCommand = nil
+	sbomPath = defaultSBOMPath
+}
+
+// sbomEnabled reports whether an SBOM should be generated on release finish.
+func sbomEnabled() bool {
+	return len(sbomCommand) > 0 || sbomGenerator != ""
+}
+
+// sbomCommandLine returns the configured command line of the SBOM generator.
+func sbomCommandLine() ([]string, error) {
+	if len(sbomCommand) > 0 {
+		return sbomCommand, nil
+	}
+	if commandLine, ok := sbomGenerators[sbomGenerator]; ok {
+		return commandLine, nil
+	}
+	return nil, fmt.Errorf("unsupported SBOM generator '%v' (supported: %v, %v, %v)",
+		sbomGenerator, syftGenerator, cyclonedxGenerator, goGenerator)
+}
+
+// sbomTools returns the command-line tools required to generate the SBOM.
+func sbomTools() ([]string, error) {
+	if !sbomEnabled() {
+		return nil, nil
+	}
+	commandLine, err := sbomCommandLine()
+	if err != nil {
+		return nil, err
+	}
+	return []string{commandLine[0]}, nil
+}
+
+// generateSBOM generates the SBOM of the current branch and commits it to the configured path.
+func generateSBOM(repository Repository, version Version) error {
+	var err error
+	var generate *exec.Cmd
+	var output []byte
+
+	if skipDryRun(repository, "SBOM generation") {
+		return nil
+	}
+
+	commandLine, err := sbomCommandLine()
+	if err != nil {
+		return err
+	}
+
+	// log human-readable description of the generator command
+	defer func() { Log(generate, err) }()
+
+	generate = exec.Command(commandLine[0], commandLine[1:]...)
+	generate.Dir = repository.Local()
+
+	var stderr bytes.Buffer
+	generate.Stderr = &stderr
+
+	// run the generator which prints the SBOM to stdout
+	if output, err = generate.Output(); err != nil {
+		return fmt.Errorf("SBOM generation '%v' failed with %v: %s", generate, err, stderr.Bytes())
+	}
+
+	// the built-in generator converts the Go module list into a CycloneDX document
+	if len(sbomCommand) == 0 && sbomGenerator == goGenerator {
+		if output, err = goModulesToCycloneDX(output, version); err != nil {
+			return err
+		}
+	}
+
+	if err = repository.WriteFile(sbomPath, string(output)); err != nil {
+		return err
+	}
+
+	if err = repository.AddFile(sbomPath); err != nil {
+		return err
+	}
+
+	return repository.CommitChanges(fmt.Sprintf("Add SBOM for release %v.", version))
+}
+
+// Go module as reported by 'go list -m -json'.
+type goModule struct {
+	Path    string
+	Version string
+	Main    bool
+}
+
+// CycloneDX component of an SBOM document.
+type cycloneDXComponent struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	PURL    string `json:"purl"`
+}
+
+// goModulesToCycloneDX converts the output of 'go list -m -json all' into a CycloneDX JSON document.
+func goModulesToCycloneDX(modules []byte, version Version) ([]byte, error) {
+	var mainModule cycloneDXComponent
+	components := make([]cycloneDXComponent, 0)
+
+	decoder := json.NewDecoder(bytes.NewReader(modules))
+	for {
+		var module goModule
+		if err := decoder.Decode(&module); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("parsing Go module list failed with %v", err)
+		}
+
+		if module.Main {
+			mainModule = cycloneDXComponent{
+				Type:    "application",
+				Name:    module.Path,
+				Version: version.String(),
+				PURL:    fmt.Sprintf("pkg:golang/%v@%v", module.Path, version),
+			}
+			continue
+		}
+
+		components = append(components, cycloneDXComponent{
+			Type:    "library",
+			Name:    module.Path,
+			Version: module.Version,
+			PURL:    fmt.Sprintf("pkg:golang/%v@%v", module.Path, module.Version),
+		})
+	}
+
+	document := map[string]any{
+		"bomFormat":   "CycloneDX",
+		"specVersion": "1.5",
+		"version":     1,
+		"metadata": map[string]any{
+			"timestamp": time.Now().UTC().Format(time.RFC3339),
+			"component": mainModule,
+		},
+		"components": components,
+	}
+
+	return json.MarshalIndent(document, "", "  ")
+}
@@ -0,0 +1,389 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"bytes"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// Module of the source tree plugins are scaffolded in.
+const sourceModule = "github.com/mercedes-benz/gitflow-cli"
+
+// File of the source tree which imports all plugins, so that they register themselves.
+const pluginImportsFile = "plugin/plugin.go"
+
+// Names of scaffolded plugins, which are also the names of their Go packages, e.g. 'bazel'.
+var pluginNameExpression = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// scaffoldData holds the values the files of a scaffolded plugin are rendered with.
+type scaffoldData struct {
+	Name        string
+	VersionFile string
+	Qualifier   string
+	DockerImage string
+}
+
+// Implementation of a scaffolded plugin, whose version file holds a 'version: x.y.z' or 'version = x.y.z' line.
+const pluginSourceTemplate = `/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package [[.Name]]
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+)
+
+// The version of [[.VersionFile]], e.g. 'version: 1.2.0' or 'version = "1.2.0"'.
+// TODO: adapt the expression to the format of the version file.
+var versionRegex = regexp.MustCompile(` + "`" + `(?m)^(version[ \t]*[:=][ \t]*)(['"]?)([^'"\s#]+)(['"]?)` + "`" + `)
+
+// Version file template for new projects created with the bootstrap command.
+const versionFileTemplate = ` + "`" + `version: {{.Version}}
+` + "`" + `
+
+// Fixed configuration for the [[.Name]] plugin
+var pluginConfig = plugin.Config{
+	Name:             "[[.Name]]",
+	VersionFileName:  "[[.VersionFile]]",
+	VersionQualifier: "[[.Qualifier]]",
+	Template:         versionFileTemplate,
+	RequiredTools:    []string{},
+	DockerImage:      "[[.DockerImage]]",
+}
+
+// [[.Name]]Plugin is the plugin for [[.Name]] projects.
+type [[.Name]]Plugin struct {
+	plugin.Plugin
+}
+
+// Register the [[.Name]] plugin
+func init() {
+	pluginFactory := plugin.NewFactory()
+
+	// Create plugin with pluginFactory to get hooks and other dependencies
+	[[.Name]]Plugin := &[[.Name]]Plugin{
+		Plugin: pluginFactory.NewPlugin(pluginConfig),
+	}
+
+	// Register hooks, e.g. core.ReleaseStartHooks.AfterUpdateProjectVersionHook to update further files
+	[[.Name]]Plugin.RegisterHook(core.ReleaseFinishHooks.AfterUpdateProjectVersionHook, [[.Name]]Plugin.afterReleaseFinishUpdate)
+
+	// Register plugin directly in core
+	core.RegisterPlugin([[.Name]]Plugin)
+}
+
+// ReadVersion reads the version from [[.VersionFile]]
+func (p *[[.Name]]Plugin) ReadVersion(repository core.Repository) (core.Version, error) {
+	versionFile := filepath.Join(repository.Local(), p.VersionFileName())
+
+	data, err := os.ReadFile(versionFile)
+	if err != nil {
+		return core.NoVersion, fmt.Errorf("failed to read [[.Name]] version file: %v", err)
+	}
+
+	matches := versionRegex.FindAllStringSubmatch(string(data), -1)
+	if len(matches) > 1 {
+		return core.NoVersion, fmt.Errorf("multiple version entries found in %v file", p.VersionFileName())
+	} else if len(matches) == 0 {
+		return core.NoVersion, fmt.Errorf("no version found in %v file", p.VersionFileName())
+	}
+
+	return core.ParseVersion(matches[0][3])
+}
+
+// WriteVersion writes the version to [[.VersionFile]]
+func (p *[[.Name]]Plugin) WriteVersion(repository core.Repository, version core.Version) error {
+	versionFile := filepath.Join(repository.Local(), p.VersionFileName())
+
+	data, err := os.ReadFile(versionFile)
+	if err != nil {
+		return fmt.Errorf("[[.Name]] version update failed: %v", err)
+	}
+
+	if !versionRegex.Match(data) {
+		return fmt.Errorf("version key not found in %v file", p.VersionFileName())
+	}
+
+	// keep the quotation marks of the version
+	newContent := versionRegex.ReplaceAllString(string(data), "${1}${2}"+version.String()+"${4}")
+
+	return os.WriteFile(versionFile, []byte(newContent), 0644)
+}
+
+// afterReleaseFinishUpdate runs after the next development version has been written on release finish, and its
+// changes are part of the commit of the next version.
+// TODO: update further files of the project, e.g. a lock file, or remove the hook.
+func (p *[[.Name]]Plugin) afterReleaseFinishUpdate(repository core.Repository) error {
+	return nil
+}
+`
+
+// Unit and e2e tests of a scaffolded plugin, which run the generic workflow tests with its version file template.
+const pluginTestTemplate = `/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package [[.Name]]
+
+import (
+	_ "embed"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+	"github.com/mercedes-benz/gitflow-cli/e2e/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/e2e/[[.VersionFile]].tpl
+var versionTemplate string
+
+var testConfigs = []plugin.TestConfig{
+	{
+		Name:             "[[.Name]]",
+		PluginName:       "[[.Name]]",
+		DockerImage:      pluginConfig.DockerImage,
+		VersionQualifier: "[[.Qualifier]]",
+		VersionFileName:  "[[.VersionFile]]",
+		Template:         versionTemplate,
+	},
+}
+
+func TestE2E_ReleaseStart(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStart(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseStartMajor(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStartMajor(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseStartMinor(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStartMinor(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseFinish(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseFinish(t, tc)
+		})
+	}
+}
+
+func TestE2E_HotfixStart(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunHotfixStart(t, tc)
+		})
+	}
+}
+
+func TestE2E_HotfixFinish(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunHotfixFinish(t, tc)
+		})
+	}
+}
+
+func setupTest(t *testing.T, content string) (string, core.Repository, *[[.Name]]Plugin) {
+	t.Helper()
+	tempDir := t.TempDir()
+	testFilePath := filepath.Join(tempDir, "[[.VersionFile]]")
+	require.NoError(t, os.MkdirAll(filepath.Dir(testFilePath), 0755))
+	require.NoError(t, os.WriteFile(testFilePath, []byte(content), 0644))
+
+	p := &[[.Name]]Plugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+	return testFilePath, core.NewRepository(tempDir, ""), p
+}
+
+// TODO: add the formats of the version file the plugin supports.
+func TestVersionReadWrite(t *testing.T) {
+	testCases := []struct {
+		name           string
+		initialContent string
+		expectedResult string
+	}{
+		{"Plain", "version: 1.2.3\n", "version: 1.2.3-[[.Qualifier]]\n"},
+		{"Quoted", "version = \"1.2.3\"\n", "version = \"1.2.3-[[.Qualifier]]\"\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(test *testing.T) {
+			testFilePath, repository, p := setupTest(test, testCase.initialContent)
+
+			version, err := p.ReadVersion(repository)
+			require.NoError(test, err)
+			assert.Equal(test, "1.2.3", version.String())
+
+			version.Qualifier = "[[.Qualifier]]"
+			require.NoError(test, p.WriteVersion(repository, version))
+
+			result, err := os.ReadFile(testFilePath)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expectedResult, string(result))
+		})
+	}
+}
+`
+
+// Version file of the e2e tests of a scaffolded plugin, rendered with the versions of the workflows.
+const pluginVersionFileTemplate = `version: {{.Version}}
+`
+
+// ScaffoldPlugin generates the skeleton of a new plugin in the source tree of the tool: the implementation with its
+// configuration, stubs of ReadVersion and WriteVersion and the registration of a hook, the unit and e2e tests, the
+// template of the version file of the e2e tests, and the import which registers the plugin.
+func ScaffoldPlugin(name, versionFile, qualifier, dockerImage, sourcePath string) error {
+	pluginRegistryLock.Lock()
+	defer pluginRegistryLock.Unlock()
+
+	// apply suitable settings from the global configuration to the core package
+	if err := applySettings(); err != nil {
+		return err
+	}
+
+	if !pluginNameExpression.MatchString(name) || token.IsKeyword(name) {
+		return Error(MsgScaffoldInvalidName, name)
+	}
+
+	if len(versionFile) == 0 || filepath.IsAbs(versionFile) || strings.HasPrefix(filepath.Clean(versionFile), "..") {
+		return Error(MsgScaffoldVersionFile, versionFile)
+	}
+
+	// plugins are built into the tool, so they are scaffolded in its source tree only
+	if module, err := os.ReadFile(filepath.Join(sourcePath, "go.mod")); err != nil || !strings.HasPrefix(string(module), "module "+sourceModule+"\n") {
+		return Error(MsgScaffoldNoSourceTree, sourcePath, sourceModule)
+	}
+
+	pluginPath := filepath.Join(sourcePath, "plugin", name)
+	if _, err := os.Stat(pluginPath); err == nil {
+		return Error(MsgScaffoldPluginExists, name, pluginPath)
+	}
+	if slices.ContainsFunc(pluginRegistry, func(registered Plugin) bool { return registered.String() == name }) {
+		return Error(MsgScaffoldPluginExists, name, pluginPath)
+	}
+
+	data := scaffoldData{
+		Name:        name,
+		VersionFile: filepath.ToSlash(filepath.Clean(versionFile)),
+		Qualifier:   qualifier,
+		DockerImage: dockerImage,
+	}
+
+	files := []struct {
+		path     string
+		template string
+		source   bool
+	}{
+		{filepath.Join(pluginPath, name+".go"), pluginSourceTemplate, true},
+		{filepath.Join(pluginPath, name+"_test.go"), pluginTestTemplate, true},
+		{filepath.Join(pluginPath, "testdata", "e2e", data.VersionFile+".tpl"), pluginVersionFileTemplate, false},
+	}
+
+	for _, file := range files {
+		content, err := renderScaffold(file.template, data, file.source)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(file.path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(file.path, content, 0644); err != nil {
+			return err
+		}
+	}
+
+	if err := addPluginImport(filepath.Join(sourcePath, pluginImportsFile), name); err != nil {
+		return err
+	}
+
+	Success(Message(MsgScaffoldCreated, name, pluginPath, pluginImportsFile))
+	return nil
+}
+
+// renderScaffold renders a file of a scaffolded plugin, and formats it if it is Go source code.
+func renderScaffold(content string, data scaffoldData, source bool) ([]byte, error) {
+	// the delimiters differ from the ones of the version file templates, which are part of the content
+	tmpl, err := template.New("scaffold").Delims("[[", "]]").Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, data); err != nil {
+		return nil, err
+	}
+
+	if !source {
+		return buffer.Bytes(), nil
+	}
+	return format.Source(buffer.Bytes())
+}
+
+// addPluginImport adds the blank import of a plugin to the imports of all plugins, in alphabetical order.
+func addPluginImport(fileName, name string) error {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(content), "\n")
+	imports := make([]int, 0)
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), `_ "`+sourceModule+"/plugin/") {
+			imports = append(imports, i)
+		}
+	}
+
+	if len(imports) == 0 {
+		return Error(MsgScaffoldNoImports, fileName)
+	}
+
+	// the imports are sorted, so the new one goes before the first which follows it
+	line := "\t_ \"" + sourceModule + "/plugin/" + name + "\""
+	position := imports[len(imports)-1] + 1
+	for _, i := range imports {
+		if strings.TrimSpace(lines[i]) > strings.TrimSpace(line) {
+			position = i
+			break
+		}
+	}
+
+	lines = slices.Insert(lines, position, line)
+	return os.WriteFile(fileName, []byte(strings.Join(lines, "\n")), 0644)
+}
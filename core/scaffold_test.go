@@ -0,0 +1,90 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSourceTree creates a minimal source tree of the tool with the imports of two plugins.
+func setupSourceTree(t *testing.T) string {
+	t.Helper()
+	sourcePath := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(sourcePath, "go.mod"), []byte("module "+sourceModule+"\n\ngo 1.24\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(sourcePath, "plugin"), 0755))
+	imports := "package plugin\n\nimport (\n\t_ \"" + sourceModule + "/plugin/dart\"\n\t_ \"" + sourceModule + "/plugin/npm\"\n)\n"
+	require.NoError(t, os.WriteFile(filepath.Join(sourcePath, pluginImportsFile), []byte(imports), 0644))
+
+	return sourcePath
+}
+
+// TestScaffoldPlugin tests the files and the import of a scaffolded plugin.
+func TestScaffoldPlugin(t *testing.T) {
+	sourcePath := setupSourceTree(t)
+
+	require.NoError(t, ScaffoldPlugin("bazel", "config/project.yaml", "dev", "alpine:3", sourcePath))
+
+	source, err := os.ReadFile(filepath.Join(sourcePath, "plugin", "bazel", "bazel.go"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(source), "/*\nSPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH\n"))
+	assert.Contains(t, string(source), "package bazel\n")
+	assert.Contains(t, string(source), `VersionFileName:  "config/project.yaml",`)
+	assert.Contains(t, string(source), "func (p *bazelPlugin) ReadVersion(repository core.Repository) (core.Version, error) {")
+	assert.Contains(t, string(source), "const versionFileTemplate = `version: {{.Version}}\n`")
+
+	tests, err := os.ReadFile(filepath.Join(sourcePath, "plugin", "bazel", "bazel_test.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(tests), "//go:embed testdata/e2e/config/project.yaml.tpl\n")
+	assert.Contains(t, string(tests), "workflow.RunReleaseFinish(t, tc)")
+
+	template, err := os.ReadFile(filepath.Join(sourcePath, "plugin", "bazel", "testdata", "e2e", "config", "project.yaml.tpl"))
+	require.NoError(t, err)
+	assert.Equal(t, "version: {{.Version}}\n", string(template))
+
+	// the import is added in alphabetical order
+	imports, err := os.ReadFile(filepath.Join(sourcePath, pluginImportsFile))
+	require.NoError(t, err)
+	lines := strings.Split(string(imports), "\n")
+	assert.Equal(t, []string{"\t_ \"" + sourceModule + "/plugin/bazel\"", "\t_ \"" + sourceModule + "/plugin/dart\""}, lines[3:5])
+}
+
+// TestScaffoldPluginRejected tests the plugins which are not scaffolded.
+func TestScaffoldPluginRejected(t *testing.T) {
+	sourcePath := setupSourceTree(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(sourcePath, "plugin", "dart"), 0755))
+
+	tests := []struct {
+		name        string
+		plugin      string
+		versionFile string
+		sourcePath  string
+		message     string
+	}{
+		{"invalid name", "my-plugin", "project.yaml", sourcePath, "is no valid Go package name"},
+		{"keyword", "func", "project.yaml", sourcePath, "is no valid Go package name"},
+		{"existing plugin", "dart", "pubspec.yaml", sourcePath, "already exists"},
+		{"version file outside project", "bazel", "../project.yaml", sourcePath, "must be a path relative to the project"},
+		{"no source tree", "bazel", "project.yaml", t.TempDir(), "is no source tree of " + sourceModule},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ScaffoldPlugin(test.plugin, test.versionFile, "dev", "alpine:3", test.sourcePath)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.message)
+		})
+	}
+
+	_, err := os.Stat(filepath.Join(sourcePath, "plugin", "bazel"))
+	assert.True(t, os.IsNotExist(err))
+}
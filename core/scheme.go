@@ -0,0 +1,155 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Workflow setting of the versioning scheme, which calculates the release and next versions of the workflows.
+const versionSchemeSetting = "version-scheme"
+
+// Versioning schemes: Semantic Versioning with major, minor, and patch, and Calendar Versioning with year, month, and
+// micro, e.g. '2025.04.1'.
+const (
+	semverScheme = "semver"
+	calverScheme = "calver"
+)
+
+// VersionScheme calculates the versions of the workflows, so that date-based release teams use the same branches
+// as teams with semantic versions.
+type VersionScheme interface {
+	// Name returns the name of the scheme in the 'workflow.version-scheme' setting.
+	Name() string
+
+	// Next returns the version following a version by an increment, which keeps the qualifier.
+	Next(version Version, increment VersionIncrement) (Version, error)
+
+	// Release returns the version without qualifier the release of a development version gets.
+	Release(version Version) (Version, error)
+}
+
+// Registered versioning schemes by name.
+var versionSchemes = map[string]VersionScheme{
+	semverScheme: semanticVersioning{},
+	calverScheme: calendarVersioning{now: time.Now},
+}
+
+// Name of the versioning scheme of the current run.
+var versionScheme = semverScheme
+
+func applyVersionSchemeSetting(settings map[string]any) {
+	if v, ok := settings[versionSchemeSetting].(string); ok && len(v) > 0 {
+		versionScheme = v
+	}
+}
+
+func resetVersionSchemeSetting() {
+	versionScheme = semverScheme
+}
+
+// validateVersionSchemeSetting fails for an unknown versioning scheme.
+func validateVersionSchemeSetting() error {
+	if _, ok := versionSchemes[versionScheme]; !ok {
+		return Error(MsgInvalidChoice, workflowGroup+"."+versionSchemeSetting, versionScheme, semverScheme+", "+calverScheme)
+	}
+	return nil
+}
+
+// Scheme returns the versioning scheme of the current run, which is Semantic Versioning unless configured otherwise.
+func Scheme() VersionScheme {
+	if scheme, ok := versionSchemes[versionScheme]; ok {
+		return scheme
+	}
+	return versionSchemes[semverScheme]
+}
+
+// semanticVersioning increments the major, minor, or patch version, and releases a development version as is.
+type semanticVersioning struct{}
+
+func (semanticVersioning) Name() string {
+	return semverScheme
+}
+
+func (semanticVersioning) Next(version Version, increment VersionIncrement) (Version, error) {
+	return version.Next(increment)
+}
+
+func (semanticVersioning) Release(version Version) (Version, error) {
+	return version.RemoveQualifier(), nil
+}
+
+// calendarVersioning dates releases with year and month, e.g. '2025.04.0', whose micro version numbers the further
+// releases and hotfixes of the month, e.g. '2025.04.1'.
+type calendarVersioning struct {
+	now func() time.Time
+}
+
+func (calendarVersioning) Name() string {
+	return calverScheme
+}
+
+// Next returns the next micro version for a patch, and the first version of the current month for a major or minor
+// increment, or the next micro version if the version already is of the current month.
+func (s calendarVersioning) Next(version Version, increment VersionIncrement) (Version, error) {
+	year, month, micro, err := s.parts(version)
+	if err != nil {
+		return NoVersion, err
+	}
+
+	switch increment {
+	case Incremental:
+		return s.version(year, month, micro+1, version.Qualifier, increment), nil
+
+	case Major, Minor:
+		if current := s.month(); current > year*100+month {
+			return s.version(current/100, current%100, 0, version.Qualifier, increment), nil
+		}
+		return s.version(year, month, micro+1, version.Qualifier, increment), nil
+
+	default:
+		return NoVersion, fmt.Errorf("unsupported version increment type: %v", increment)
+	}
+}
+
+// Release returns the first version of the current month for a development version of an earlier month, so that
+// the release is dated when it is started, and the development version without qualifier otherwise.
+func (s calendarVersioning) Release(version Version) (Version, error) {
+	year, month, _, err := s.parts(version)
+	if err != nil {
+		return NoVersion, err
+	}
+
+	if current := s.month(); current > year*100+month {
+		return s.version(current/100, current%100, 0, noQualifier, None), nil
+	}
+	return version.RemoveQualifier(), nil
+}
+
+// parts (private) Return the year, month, and micro version of a calendar version.
+func (calendarVersioning) parts(version Version) (int, int, int, error) {
+	year, errYear := strconv.Atoi(version.Major)
+	month, errMonth := strconv.Atoi(version.Minor)
+	micro, errMicro := strconv.Atoi(version.Incremental)
+
+	if errYear != nil || errMonth != nil || errMicro != nil || month < 1 || month > 12 {
+		return 0, 0, 0, fmt.Errorf("invalid calendar version: %v", version)
+	}
+	return year, month, micro, nil
+}
+
+// month (private) Return the current year and month as a single number, e.g. 202504 for April 2025.
+func (s calendarVersioning) month() int {
+	now := s.now()
+	return now.Year()*100 + int(now.Month())
+}
+
+// version (private) Create a calendar version with a zero-padded month, e.g. '2025.04.1'.
+func (calendarVersioning) version(year, month, micro int, qualifier string, increment VersionIncrement) Version {
+	return NewVersion(strconv.Itoa(year), fmt.Sprintf("%02d", month), strconv.Itoa(micro), qualifier, increment)
+}
@@ -0,0 +1,55 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Temporary directory of the scratch directories of the current command, created on first use.
+var scratchRoot = ""
+var scratchLock sync.Mutex
+
+// ScratchDir returns the scratch directory of a plugin, e.g. for backups, caches or temporary files of its tools,
+// which are kept out of the working tree so that they do not fail the check for a clean repository. The directory
+// is created outside of the repository on first use and removed with all others when the command exits or its
+// changes are rolled back.
+func ScratchDir(name string) (string, error) {
+	if len(name) == 0 || name != filepath.Base(name) || name == "." || name == ".." {
+		return "", fmt.Errorf("invalid scratch directory name '%v'", name)
+	}
+
+	scratchLock.Lock()
+	defer scratchLock.Unlock()
+
+	if len(scratchRoot) == 0 {
+		root, err := os.MkdirTemp("", "gitflow-cli-scratch-")
+		if err != nil {
+			return "", err
+		}
+		scratchRoot = root
+	}
+
+	directory := filepath.Join(scratchRoot, name)
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return "", err
+	}
+	return directory, nil
+}
+
+// RemoveScratchDirs removes the scratch directories of the current command, if there are any.
+func RemoveScratchDirs() {
+	scratchLock.Lock()
+	defer scratchLock.Unlock()
+
+	if len(scratchRoot) > 0 {
+		Log(fmt.Sprintf("Removing scratch directory: %s", scratchRoot), os.RemoveAll(scratchRoot))
+		scratchRoot = ""
+	}
+}
@@ -0,0 +1,101 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Configuration group and settings of the warnings about stalled release and hotfix branches.
+const (
+	staleGroup            = "stale-branches"
+	staleMaxAgeSetting    = "max-age-days"
+	staleMaxBehindSetting = "max-behind"
+)
+
+// Default limits of open release and hotfix branches, a limit of zero disables its warning.
+const (
+	defaultStaleMaxAgeDays = 30
+	defaultStaleMaxBehind  = 100
+)
+
+// Stale branch limits of the current run.
+var staleMaxAgeDays = defaultStaleMaxAgeDays
+var staleMaxBehind = defaultStaleMaxBehind
+
+func applyStaleSettings(settings map[string]any) {
+	if v, ok := settings[staleMaxAgeSetting].(int); ok && v >= 0 {
+		staleMaxAgeDays = v
+	}
+	if v, ok := settings[staleMaxBehindSetting].(int); ok && v >= 0 {
+		staleMaxBehind = v
+	}
+}
+
+func resetStaleSettings() {
+	staleMaxAgeDays = defaultStaleMaxAgeDays
+	staleMaxBehind = defaultStaleMaxBehind
+}
+
+// WarnStaleBranches warns about open release and hotfix branches which are older than the limit configured under
+// 'stale-branches.max-age-days', or lag behind their base branch by more commits than 'stale-branches.max-behind',
+// so that stalled branches are finished or deleted. It inspects the remote-tracking branches as of the last fetch
+// and never fails the command, e.g. outside of a repository.
+func WarnStaleBranches(projectPath string) {
+	if err := applySettings(); err != nil || (staleMaxAgeDays == 0 && staleMaxBehind == 0) {
+		return
+	}
+
+	repository := NewRepository(projectPath, Remote)
+	for _, branch := range []Branch{Release, Hotfix} {
+		infos, err := repository.TrackedBranches(branch.String())
+		if err != nil {
+			return
+		}
+
+		for _, info := range infos {
+			if info.Versioned {
+				warnStaleBranch(repository, branch, info)
+			}
+		}
+	}
+}
+
+// warnStaleBranch warns if a release or hotfix branch exceeds the limits. Its age is the age of its first commit,
+// which sets the version when the branch is started, and it lags behind the new commits of its base branch.
+func warnStaleBranch(repository Repository, branch Branch, info BranchInfo) {
+	base := Remote + "/" + staleBase(repository, branch, info)
+
+	if staleMaxAgeDays > 0 {
+		if commits, err := repository.Commits(base + ".." + info.Ref()); err == nil && len(commits) > 0 {
+			if days := int(time.Since(commits[len(commits)-1].Date).Hours() / 24); days > staleMaxAgeDays {
+				fmt.Fprintln(os.Stderr, Message(MsgStaleBranchAge, info.Name, days, staleMaxAgeDays))
+			}
+		}
+	}
+
+	if staleMaxBehind > 0 {
+		if commits, err := repository.Commits(info.Ref() + ".." + base); err == nil && len(commits) > staleMaxBehind {
+			fmt.Fprintln(os.Stderr, Message(MsgStaleBranchBehind, info.Name, len(commits), base, staleMaxBehind))
+		}
+	}
+}
+
+// staleBase returns the branch a release or hotfix branch is compared with: the development branch for releases,
+// and the support branch of the maintenance line or else the production branch for hotfixes.
+func staleBase(repository Repository, branch Branch, info BranchInfo) string {
+	if branch == Release {
+		return Development.String()
+	}
+
+	support := supportBranchName(info.Version.Line())
+	if _, err := repository.ResolveRef(Remote + "/" + support); err == nil {
+		return support
+	}
+	return Production.String()
+}
@@ -0,0 +1,285 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Directory of the files of gitflow-cli in the git directory of a repository.
+const gitflowDir = "gitflow-cli"
+
+// State file of an interrupted finish command, in the directory of gitflow-cli.
+const workflowStatePath = "state.json"
+
+// State of a running finish command, which is persisted before each step so that an interrupted run can be
+// resumed at the failed step, e.g. after resolving a merge conflict or a rejected push.
+type workflowState struct {
+	Workflow        string            `json:"workflow"`
+	Branch          string            `json:"branch"`
+	Version         string            `json:"version"`
+	Line            string            `json:"line,omitempty"`
+	ReleaseBranch   string            `json:"releaseBranch,omitempty"`
+	Next            string            `json:"next,omitempty"`
+	MergeProduction bool              `json:"mergeProduction,omitempty"`
+	ViaPullRequest  bool              `json:"viaPullRequest,omitempty"`
+	PullRequests    map[string]int    `json:"pullRequests,omitempty"`
+	MergeCommit     string            `json:"mergeCommit,omitempty"`
+	Publish         bool              `json:"publish,omitempty"`
+	Draft           bool              `json:"draft,omitempty"`
+	Branches        map[string]string `json:"branches"`
+	Step            string            `json:"step"`
+	StartedOn       time.Time         `json:"startedOn"`
+}
+
+// newWorkflowState creates the state of a finish command for a branch, which records the branch names in use.
+func newWorkflowState(branch Branch, info BranchInfo, line string) *workflowState {
+	state := &workflowState{
+		Workflow:  branch.ConfigKey(),
+		Branch:    info.Name,
+		Version:   info.Version.String(),
+		Line:      line,
+		Branches:  make(map[string]string),
+		StartedOn: time.Now(),
+	}
+
+	// the branch names may come from a detected layout which is not in the configuration
+	for _, b := range layoutBranches {
+		state.Branches[b.ConfigKey()] = b.String()
+	}
+
+	return state
+}
+
+// version returns the version the finished branch is tagged with.
+func (s *workflowState) version() Version {
+	version, _ := ParseVersion(s.Version)
+	return version
+}
+
+// runWorkflow runs the steps of a finish command from the current step of the state. The state is persisted
+// before each step and removed once all steps have completed.
+func runWorkflow(plugin Plugin, repository Repository, state *workflowState, steps []workflowStep) error {
+	checkpoint := func(step workflowStep) error {
+		state.Step = step.name
+		return saveWorkflowState(repository, state)
+	}
+
+	steps, err := customizeFinishSteps(repository, state, steps)
+	if err != nil {
+		return err
+	}
+
+	if err := executeSteps(plugin, repository, state.Workflow+"-finish", remainingSteps(steps, state.Step), checkpoint); err != nil {
+		// a rollback removes the state, because the repository has been reset and the workflow starts over
+		if saved, _ := loadWorkflowState(repository); saved != nil {
+			fmt.Fprintln(os.Stderr, Message(MsgWorkflowResumable, state.Workflow, state.Step, state.Workflow))
+		}
+		return err
+	}
+
+	// publish the finished branch and its tag to a GitHub Actions workflow
+	if err := setOutputs(state.Branch, state.version(), versionTag(state.Version)); err != nil {
+		return err
+	}
+
+	// a dry run leaves the state of an interrupted run as it is
+	if _, ok := repository.(*dryRunRepository); ok {
+		return nil
+	}
+
+	return removeWorkflowState(repository.Local())
+}
+
+// Continue resumes an interrupted finish command at the failed step.
+func Continue(branch Branch, projectPath string) error {
+	pluginRegistryLock.Lock()
+	defer pluginRegistryLock.Unlock()
+
+	// apply suitable settings from the global configuration to the core package
+	if err := applySettings(); err != nil {
+		return err
+	}
+
+	// set path to execute plugin detection and workflow commands
+	ProjectPath = projectPath
+
+	// check if project path exists
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return Error(MsgProjectPathMissing, projectPath)
+	}
+
+	repository := openRepository(projectPath)
+	plugin := openPlugin(projectPlugin(), repository)
+
+	state, err := loadWorkflowState(repository)
+	if err != nil {
+		return err
+	} else if state == nil {
+		return Error(MsgNothingToContinue, branch.ConfigKey())
+	} else if state.Workflow != branch.ConfigKey() {
+		return Error(MsgContinueOtherFlow, state.Workflow, state.Workflow)
+	}
+
+	// continue with the branch names of the interrupted run
+	restoreBranchNames(state)
+
+	// check if required tools are available
+	if err := ValidateToolsAvailability(plugin.RequiredTools()...); err != nil {
+		return err
+	}
+
+	// check if the interrupting problem has been resolved, e.g. the merge of a conflict has been committed
+	if err := repository.IsClean(); err != nil {
+		return err
+	}
+
+	// check if workflow commits get the same identity as manual commits in the project path
+	if err := checkIdentity(repository); err != nil {
+		return err
+	}
+
+	// format finish command messages
+	called := Message(MsgFinishCalled, plugin, branch, repository.Local())
+	completed := Message(MsgFinishCompleted, plugin, branch, repository.Local())
+	failed := Message(MsgFinishFailed, plugin, branch, repository.Local())
+
+	Progress(called)
+
+	if err := runWorkflow(plugin, repository, state, finishSteps(plugin, repository, state)); err != nil {
+		Failure(failed)
+		return err
+	}
+
+	Success(completed)
+	return nil
+}
+
+// restoreBranchNames applies the branch names of an interrupted run, which may differ from the configuration.
+func restoreBranchNames(state *workflowState) {
+	for key, name := range state.Branches {
+		if b, ok := branchSettings[key]; ok && len(name) > 0 {
+			branchNames[b] = name
+		}
+	}
+}
+
+// finishSteps returns the steps of the release or hotfix finish command of a state.
+func finishSteps(plugin Plugin, repository Repository, state *workflowState) []workflowStep {
+	hotfix := state.Workflow == Hotfix.ConfigKey()
+	switch {
+	case hotfix && state.ViaPullRequest:
+		return hotfixFinishViaPullRequestSteps(plugin, repository, state)
+	case hotfix:
+		return hotfixFinishSteps(plugin, repository, state)
+	case state.ViaPullRequest:
+		return releaseFinishViaPullRequestSteps(plugin, repository, state)
+	default:
+		return releaseFinishSteps(plugin, repository, state)
+	}
+}
+
+// customizeFinishSteps applies the definition of the release or hotfix finish command of a state to its steps.
+func customizeFinishSteps(repository Repository, state *workflowState, steps []workflowStep) ([]workflowStep, error) {
+	return customizeSteps(state.Workflow+"-finish", steps, repository, func() (string, string) {
+		return state.version().String(), state.Branch
+	})
+}
+
+// checkInterruptedWorkflow fails if a finish command has been interrupted, because it must be continued first.
+func checkInterruptedWorkflow(repository Repository) error {
+	if state, err := loadWorkflowState(repository); err != nil {
+		return err
+	} else if state != nil {
+		return Error(MsgWorkflowInterrupted, state.Workflow, state.Branch, state.Step, state.Workflow)
+	}
+	return nil
+}
+
+// gitflowPath returns the absolute path of a file in the directory of gitflow-cli, which is resolved by git, so that
+// linked worktrees, whose '.git' is a file, keep their files in their own git directory.
+func gitflowPath(projectPath, name string) (string, error) {
+	gitPath := exec.Command(Git, revparse, gitpath, gitflowDir+"/"+name)
+	gitPath.Dir = projectPath
+
+	output, err := gitPath.Output()
+	Log(gitPath, output, err)
+	if err != nil {
+		return "", fmt.Errorf("git 'rev-parse' failed with %v: %s", err, output)
+	}
+
+	path := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(projectPath, path)
+	}
+	return path, nil
+}
+
+// loadWorkflowState reads the state of an interrupted finish command, which is nil if there is none.
+func loadWorkflowState(repository Repository) (*workflowState, error) {
+	file, err := gitflowPath(repository.Local(), workflowStatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var state workflowState
+	if err := json.Unmarshal(content, &state); err != nil {
+		return nil, Error(MsgStateFileInvalid, file, err)
+	}
+
+	if _, err := ParseVersion(state.Version); err != nil || len(state.Branch) == 0 || len(state.Step) == 0 {
+		return nil, Error(MsgStateFileInvalid, file, "incomplete workflow state")
+	}
+
+	return &state, nil
+}
+
+// saveWorkflowState persists the state of a running finish command, which a dry run does not.
+func saveWorkflowState(repository Repository, state *workflowState) error {
+	if _, ok := repository.(*dryRunRepository); ok {
+		return nil
+	}
+
+	file, err := gitflowPath(repository.Local(), workflowStatePath)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, append(content, '\n'), 0644)
+}
+
+// removeWorkflowState deletes the state file of a completed or reset finish command.
+func removeWorkflowState(projectPath string) error {
+	file, err := gitflowPath(projectPath, workflowStatePath)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import "fmt"
+
+// Step of a workflow command, which the start and finish commands are composed of. The name identifies the step in
+// the state file of a resumable command, the description in the journal and in the remaining steps of a recovery.
+// The plugin hook of a step runs after the step itself, and undo reverts a failed step or a failed hook, e.g. by a
+// rollback of the repository. Without undo, a failed step keeps its changes, so that the cause can be resolved and
+// the command continued. Required steps can neither be skipped nor moved by the definition of a workflow.
+type workflowStep struct {
+	name        string
+	required    bool
+	description string
+	execute     func() error
+	hook        HookType
+	undo        func(cause error) error
+}
+
+// Describe returns the description of the step.
+func (s workflowStep) Describe() string {
+	return s.description
+}
+
+// Execute runs the step and its plugin hook, and undoes the step if either fails.
+func (s workflowStep) Execute(plugin Plugin, repository Repository) error {
+	if s.execute != nil {
+		if err := s.execute(); err != nil {
+			return s.Undo(err)
+		}
+	}
+
+	if len(s.hook) > 0 {
+		if err := GlobalHooks.ExecuteHook(plugin, s.hook, repository); err != nil {
+			return s.Undo(err)
+		}
+	}
+
+	return nil
+}
+
+// Undo reverts the step after it failed with the cause, which is returned as it is for steps without undo.
+func (s workflowStep) Undo(cause error) error {
+	if s.undo == nil {
+		return cause
+	}
+	return s.undo(cause)
+}
+
+// executeSteps runs the steps of a workflow command in order and stops at the first failed step. Each step is
+// journaled in the log before it runs, after the checkpoint, which persists the state of a resumable command.
+func executeSteps(plugin Plugin, repository Repository, workflow string, steps []workflowStep, checkpoint func(step workflowStep) error) error {
+	for _, step := range steps {
+		if checkpoint != nil {
+			if err := checkpoint(step); err != nil {
+				return err
+			}
+		}
+
+		Log(fmt.Sprintf("%v step '%v': %v", workflow, step.name, step.Describe()))
+
+		if err := step.Execute(plugin, repository); err != nil {
+			return err
+		}
+	}
+	return nil
+}
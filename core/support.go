@@ -0,0 +1,159 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+// SupportLine is the maintenance line of the support branch a hotfix is started from and finished into, e.g. '1.2'.
+var SupportLine string
+
+// Maintenance lines consist of a major and minor version.
+var supportLineExpression = regexp.MustCompile(`^\d+\.\d+$`)
+
+// StartSupport creates a support branch named '<prefix>/<major>.<minor>' from the tag of a released version.
+func StartSupport(version, projectPath string) error {
+	// apply suitable settings from the global configuration to the core package
+	if err := applySettings(); err != nil {
+		return err
+	}
+
+	// set path to execute workflow commands
+	ProjectPath = projectPath
+
+	// check if project path exists
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return Error(MsgProjectPathMissing, projectPath)
+	}
+
+	// the version to support must be a plain release version
+	release, err := ParseVersion(version)
+	if err != nil {
+		return err
+	}
+
+	repository := openRepository(projectPath)
+
+	// check if the repository prerequisites are met
+	if err := repository.IsClean(); err != nil {
+		return err
+	}
+
+	// check if the current checkout is a safe starting point
+	if err := checkStartingPoint(repository, Support, versionTag(release.String())); err != nil {
+		return err
+	}
+
+	branchName := supportBranchName(release.Line())
+
+	// format support start command messages
+	called := Message(MsgSupportStartCalled, branchName, repository.Local())
+	completed := Message(MsgSupportStartCompleted, branchName, repository.Local())
+	failed := Message(MsgSupportStartFailed, branchName, repository.Local())
+
+	Progress(called)
+
+	if err := supportStart(repository, release, branchName); err != nil {
+		Failure(failed)
+		return err
+	}
+
+	Success(completed)
+	return nil
+}
+
+func supportStart(repository Repository, release Version, branchName string) error {
+	// only released versions can be supported
+	if found, err := repository.HasTag(versionTag(release.String())); err != nil {
+		return err
+	} else if !found {
+		return Error(MsgVersionNotReleased, release, release)
+	}
+
+	// check if the repository already has a support branch for this line, including one created elsewhere
+	if err := fetchRemotes(repository); err != nil {
+		return err
+	}
+	if found, err := repository.HasRemoteBranch(branchName); err != nil {
+		return err
+	} else if found {
+		return Error(MsgSupportAlreadyExists, branchName)
+	}
+
+	// create support branch at the release tag
+	if err := repository.CreateBranchFrom(branchName, versionTag(release.String())); err != nil {
+		return repository.Rollback(err)
+	}
+
+	// push the support branch to remotes
+	if err := pushIfEnabled(func() error { return repository.PushChanges(branchName) }); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// supportBranchName returns the name of the support branch of a maintenance line, e.g. 'support/1.2'.
+func supportBranchName(line string) string {
+	return Support.String() + "/" + line
+}
+
+// hotfixBase returns the branch that hotfixes of a maintenance line are based on: its support branch or,
+// without line, the production branch.
+func hotfixBase(line string) string {
+	if len(line) == 0 {
+		return Production.String()
+	}
+	return supportBranchName(line)
+}
+
+// checkSupportLine ensures that the maintenance line is well-formed and has a support branch.
+func checkSupportLine(repository Repository, line string) error {
+	if !supportLineExpression.MatchString(line) {
+		return Error(MsgSupportLineInvalid, line)
+	}
+
+	if found, err := repository.HasRemoteBranch(supportBranchName(line)); err != nil {
+		return err
+	} else if !found {
+		return Error(MsgSupportNotFound, supportBranchName(line))
+	}
+
+	return nil
+}
+
+// hotfixBranches returns the hotfix branches of a maintenance line. Without line, these are the hotfix
+// branches of the production branch, i.e. all hotfix branches except those of existing support branches.
+func hotfixBranches(repository Repository, line string) ([]BranchInfo, error) {
+	_, branches, err := repository.HasBranch(Hotfix)
+	if err != nil {
+		return nil, err
+	}
+
+	// collect the maintenance lines which have a support branch
+	supported := make(map[string]bool)
+	if len(line) == 0 {
+		_, supports, err := repository.HasBranch(Support)
+		if err != nil {
+			return nil, err
+		}
+		for _, support := range supports {
+			supported[strings.TrimPrefix(support.Name, Support.String()+"/")] = true
+		}
+	}
+
+	selected := make([]BranchInfo, 0, len(branches))
+	for _, branch := range branches {
+		if branch.Version.Line() == line || (len(line) == 0 && !supported[branch.Version.Line()]) {
+			selected = append(selected, branch)
+		}
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,151 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import "strings"
+
+// Workflow setting of the prefix of release and hotfix tags, e.g. 'v' for 'v1.2.0'.
+const tagPrefixSetting = "tag-prefix"
+
+// Tag settings keys of the core group, e.g. 'core.tag.annotated'.
+const (
+	tagGroup            = "tag"
+	tagAnnotatedSetting = "annotated"
+	tagSignSetting      = "sign"
+	tagMessageSetting   = "message"
+)
+
+// Placeholder in the tag message which is replaced by the tag name.
+const tagPlaceholder = "{tag}"
+
+// The message of annotated tags is the tag name unless configured under 'core.tag.message'.
+const defaultTagMessage = tagPlaceholder
+
+// Annotated and signed tags of the current run, signed tags are annotated as well.
+var tagAnnotated = false
+var tagSigned = false
+var tagMessage = defaultTagMessage
+
+func applyTagSettings(settings map[string]any) {
+	if v, ok := settings[tagAnnotatedSetting].(bool); ok {
+		tagAnnotated = v
+	}
+	if v, ok := settings[tagSignSetting].(bool); ok {
+		tagSigned = v
+	}
+	if v, ok := settings[tagMessageSetting].(string); ok && len(strings.TrimSpace(v)) > 0 {
+		tagMessage = v
+	}
+}
+
+func resetTagSettings() {
+	tagAnnotated = false
+	tagSigned = false
+	tagMessage = defaultTagMessage
+}
+
+// tagMessageOf returns the message of an annotated tag, with the tag name, the released version of a version tag and
+// the variables of the plugin substituted, e.g. 'Release app 1.2.0' for the message 'Release {artifactId} {version}'
+// of the tag 'v1.2.0'.
+func tagMessageOf(tagName string) string {
+	version := tagName
+	if release, ok := releaseTag(tagName); ok {
+		version = release.String()
+	}
+	return expandTemplate(tagMessage, tagPlaceholder, tagName, versionPlaceholder, version)
+}
+
+// The tag prefix is resolved once per command, from the setting or else from the plugin of the project.
+var tagPrefix = ""
+var tagPrefixResolved = false
+
+func applyTagPrefixSetting(settings map[string]any) {
+	if v, ok := settings[tagPrefixSetting].(string); ok {
+		tagPrefix, tagPrefixResolved = v, true
+	}
+}
+
+func resetTagPrefixSetting() {
+	tagPrefix, tagPrefixResolved = "", false
+}
+
+// currentTagPrefix returns the prefix of the release and hotfix tags of the project.
+func currentTagPrefix() string {
+	if !tagPrefixResolved {
+		if plugin, ok := projectPlugin().(TagPrefixPlugin); ok {
+			tagPrefix = plugin.TagPrefix()
+		}
+		tagPrefixResolved = true
+	}
+	return tagPrefix
+}
+
+// versionTag returns the tag of a released version, e.g. '1.2.0', or 'v1.2.0' with the tag prefix 'v'.
+func versionTag(version string) string {
+	return currentTagPrefix() + version
+}
+
+// releaseTag returns the released version of a tag matching the version scheme, e.g. '1.2.0', or 'v1.2.0' with the
+// tag prefix 'v'.
+func releaseTag(tagName string) (Version, bool) {
+	name, found := strings.CutPrefix(tagName, currentTagPrefix())
+	if !found {
+		return NoVersion, false
+	}
+
+	release, err := ParseVersion(name)
+	if err != nil || release.String() != name || !release.plain() {
+		return NoVersion, false
+	}
+	return release, true
+}
+
+// releasedVersions returns the released versions of the tags matching the version scheme and the latest of them,
+// which only takes the names of the tags and no walk of the history.
+func releasedVersions(tagNames []string) (map[Version]bool, Version) {
+	latest := NoVersion
+	released := make(map[Version]bool)
+	for _, tagName := range tagNames {
+		if release, ok := releaseTag(tagName); ok {
+			released[release] = true
+			if latest == NoVersion || latest.Less(release) {
+				latest = release
+			}
+		}
+	}
+	return released, latest
+}
+
+// knownTags returns the names of the local tags and of the tags of the remote, if the repository has one. The
+// remote tags are listed without fetching, so that the latest release is known in shallow clones without tags, e.g.
+// in pipelines, without fetching the history of the repository.
+func knownTags(repository Repository) ([]string, error) {
+	tagNames, err := repository.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := repository.RemoteURL(); err != nil {
+		return tagNames, nil
+	}
+
+	remoteTags, err := repository.RemoteTags()
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(tagNames))
+	for _, tagName := range tagNames {
+		known[tagName] = true
+	}
+
+	for _, tagName := range remoteTags {
+		if !known[tagName] {
+			tagNames = append(tagNames, tagName)
+		}
+	}
+	return tagNames, nil
+}
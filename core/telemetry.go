@@ -0,0 +1,225 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Configuration group and settings of the opt-in telemetry.
+const (
+	telemetryGroup           = "telemetry"
+	telemetryEnabledSetting  = "enabled"
+	telemetryEndpointSetting = "endpoint"
+	telemetryQueueSetting    = "queue"
+)
+
+// Environment variable of the common convention to opt out of telemetry, which overrides 'telemetry.enabled'.
+const doNotTrackEnv = "DO_NOT_TRACK"
+
+// Outcomes of the commands recorded by the telemetry.
+const (
+	telemetrySuccess = "success"
+	telemetryFailure = "failure"
+)
+
+// Failure category of errors which are not user-facing messages of the catalog, e.g. failed git commands.
+const telemetryUnknownCategory = "unknown"
+
+// Maximum number of queued events, the oldest events are dropped while the endpoint cannot be reached.
+const telemetryQueueLimit = 1000
+
+// Time the queued events are sent for at most, so that an unreachable endpoint does not delay the commands.
+var telemetryTimeout = 2 * time.Second
+
+// Telemetry settings of the current run, telemetry is disabled unless it is enabled explicitly.
+var telemetryEnabled = false
+var telemetryEndpoint string
+var telemetryQueuePath string
+
+// Plugin of the current command, recorded by the telemetry.
+var telemetryPlugin string
+
+// TelemetryEvent is the anonymous record of a command run. It holds neither paths, names, versions nor messages of
+// the project, but only the command, the plugin, the duration, and the category of a failure, which is the key of
+// its message in the catalog, e.g. 'error.no-branch-to-finish'.
+type TelemetryEvent struct {
+	Command  string `json:"command"`
+	Plugin   string `json:"plugin,omitempty"`
+	Duration int64  `json:"durationMs"`
+	Outcome  string `json:"outcome"`
+	Category string `json:"category,omitempty"`
+	Tool     string `json:"tool"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Date     string `json:"date"`
+}
+
+func applyTelemetrySettings(settings map[string]any) {
+	if v, ok := settings[telemetryEnabledSetting].(bool); ok {
+		telemetryEnabled = v
+	}
+	if v, ok := settings[telemetryEndpointSetting].(string); ok {
+		telemetryEndpoint = v
+	}
+	if v, ok := settings[telemetryQueueSetting].(string); ok {
+		telemetryQueuePath = v
+	}
+}
+
+func resetTelemetrySettings() {
+	telemetryEnabled = false
+	telemetryEndpoint = ""
+	telemetryQueuePath = ""
+}
+
+// SetUserTelemetrySettings sets the telemetry settings of the user's own configuration, which is the user config in
+// the home directory or the config file of --config. The telemetry of project configs and GITFLOW_* variables is
+// ignored, so that a cloned repository can neither opt its contributors in nor redirect their events.
+func SetUserTelemetrySettings(settings map[string]any) {
+	resetTelemetrySettings()
+	applyTelemetrySettings(settings)
+}
+
+// recordTelemetryPlugin records the plugin of the current command.
+func recordTelemetryPlugin(plugin Plugin) {
+	telemetryPlugin = plugin.String()
+}
+
+// telemetryActive reports whether the telemetry is enabled by 'telemetry.enabled' and not vetoed by DO_NOT_TRACK.
+func telemetryActive() bool {
+	if v := os.Getenv(doNotTrackEnv); len(v) > 0 && v != "0" && v != "false" {
+		return false
+	}
+	return telemetryEnabled
+}
+
+// telemetryQueue returns the file of the queued events, the default is in the user cache directory.
+func telemetryQueue() string {
+	if len(telemetryQueuePath) > 0 {
+		return telemetryQueuePath
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gitflow-cli", "telemetry.jsonl")
+}
+
+// RecordTelemetry queues the event of a finished command, if the telemetry is enabled by the user's configuration,
+// and sends the queued events to the endpoint configured under 'telemetry.endpoint'. Without endpoint, the events
+// stay in the local queue, where they can be inspected. The telemetry never fails a command, its errors are ignored.
+func RecordTelemetry(command, toolVersion string, duration time.Duration, err error) {
+	pluginRegistryLock.Lock()
+	defer pluginRegistryLock.Unlock()
+	defer func() { telemetryPlugin = "" }()
+
+	if !telemetryActive() {
+		return
+	}
+
+	event := TelemetryEvent{
+		Command:  command,
+		Plugin:   telemetryPlugin,
+		Duration: duration.Milliseconds(),
+		Outcome:  telemetrySuccess,
+		Tool:     toolVersion,
+		OS:       runtime.GOOS,
+		Arch:     runtime.GOARCH,
+		Date:     time.Now().UTC().Format(time.DateOnly),
+	}
+	if err != nil {
+		event.Outcome, event.Category = telemetryFailure, errorKey(err)
+		if len(event.Category) == 0 {
+			event.Category = telemetryUnknownCategory
+		}
+	}
+
+	path := telemetryQueue()
+	if len(path) == 0 {
+		return
+	}
+
+	events := append(loadTelemetryQueue(path), event)
+	if len(telemetryEndpoint) > 0 && sendTelemetry(events) {
+		events = nil
+	}
+	saveTelemetryQueue(path, events)
+}
+
+// loadTelemetryQueue reads the queued events, skipping lines which are no events.
+func loadTelemetryQueue(path string) []TelemetryEvent {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = file.Close() }()
+
+	var events []TelemetryEvent
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event TelemetryEvent
+		if json.Unmarshal(scanner.Bytes(), &event) == nil && len(event.Command) > 0 {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// saveTelemetryQueue writes the latest queued events, one JSON document per line.
+func saveTelemetryQueue(path string, events []TelemetryEvent) {
+	if len(events) > telemetryQueueLimit {
+		events = events[len(events)-telemetryQueueLimit:]
+	}
+
+	var content strings.Builder
+	for _, event := range events {
+		if line, err := json.Marshal(event); err == nil {
+			content.Write(line)
+			content.WriteString("\n")
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, []byte(content.String()), 0644)
+}
+
+// sendTelemetry posts the queued events to the endpoint and reports whether it has accepted them.
+func sendTelemetry(events []TelemetryEvent) bool {
+	body, err := json.Marshal(map[string]any{"events": events})
+	if err != nil {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), telemetryTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, telemetryEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return false
+	}
+	_ = response.Body.Close()
+
+	return response.StatusCode >= 200 && response.StatusCode < 300
+}
@@ -0,0 +1,139 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// A version qualifier, e.g. 'SNAPSHOT', 'rc.1' or 'beta-2', which may be empty.
+var qualifierRegex = regexp.MustCompile(`^[0-9A-Za-z]*(?:[.-][0-9A-Za-z]+)*$`)
+
+// checkRefName fails for names which git does not accept for branches and tags, see git-check-ref-format, and for
+// names starting with a hyphen, which git would take for an option when they are passed as an argument.
+func checkRefName(name string) error {
+	if len(name) == 0 || strings.HasPrefix(name, "-") || strings.HasPrefix(name, "/") || strings.HasSuffix(name, "/") ||
+		strings.HasSuffix(name, ".") || name == "@" || strings.Contains(name, "..") || strings.Contains(name, "//") ||
+		strings.Contains(name, "@{") || strings.ContainsAny(name, " ~^:?*[\\\x7f") {
+		return fmt.Errorf("git reference name '%v' is invalid", name)
+	}
+
+	for _, r := range name {
+		if r < 0x20 {
+			return fmt.Errorf("git reference name '%v' is invalid", name)
+		}
+	}
+
+	for _, component := range strings.Split(name, "/") {
+		if strings.HasPrefix(component, ".") || strings.HasSuffix(component, ".lock") {
+			return fmt.Errorf("git reference name '%v' is invalid", name)
+		}
+	}
+
+	return nil
+}
+
+// validateSettings checks the names of the configuration which end up in arguments of git or the build tools, so
+// that a malformed or malicious configuration cannot pass additional options to them: the branch names, the
+// tag prefix, the release head tag, which must hold the version, the environments, the qualifiers of all plugins,
+// the names of the version file and the version locations, and the commands of the hooks, the SBOM, the provenance
+// and the inserted steps, whose executables must be found.
+func validateSettings(all map[string]any) error {
+	for _, branch := range layoutBranches {
+		if err := checkRefName(branchNames[branch]); err != nil {
+			return Error(MsgInvalidRefSetting, branchesGroup+"."+branch.ConfigKey(), branchNames[branch])
+		}
+	}
+
+	// the tag prefix and the tag of the release branch head are checked for a sample version
+	if tagPrefixResolved && len(tagPrefix) > 0 {
+		if err := checkRefName(tagPrefix + "1.0.0"); err != nil {
+			return Error(MsgInvalidRefSetting, workflowGroup+"."+tagPrefixSetting, tagPrefix)
+		}
+	}
+
+	if len(releaseHeadTag) > 0 {
+		if err := checkRefName(releaseHeadTagName("1.0.0")); err != nil || !strings.Contains(releaseHeadTag, versionPlaceholder) {
+			return Error(MsgInvalidRefSetting, workflowGroup+"."+releaseHeadTagSetting, releaseHeadTag)
+		}
+	}
+
+	// malformed patterns of untracked files would never match, which is reported instead
+	for _, pattern := range cleanIgnore {
+		if _, err := path.Match(strings.Trim(pattern, "/"), ""); err != nil {
+			return Error(MsgInvalidPattern, workflowGroup+"."+cleanIgnoreSetting, pattern)
+		}
+	}
+
+	if err := validateCommitSettings(); err != nil {
+		return err
+	}
+
+	if err := validateChangelogSettings(); err != nil {
+		return err
+	}
+
+	if err := validatePresetSettings(); err != nil {
+		return err
+	}
+
+	if err := validateVersionSchemeSetting(); err != nil {
+		return err
+	}
+
+	if err := validateGitOpsSettings(); err != nil {
+		return err
+	}
+
+	if err := validateCommandSettings(all); err != nil {
+		return err
+	}
+
+	for _, environment := range environments {
+		if err := checkRefName(environment); err != nil {
+			return Error(MsgInvalidRefSetting, environmentsKey, environment)
+		}
+	}
+
+	// the qualifiers of every plugin group, in a stable order
+	groups := make([]string, 0, len(all))
+	for group := range all {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	for _, group := range groups {
+		settings, ok := all[group].(map[string]any)
+		if !ok {
+			continue
+		}
+		qualifiers, ok := settings[qualifiersSetting].(map[string]any)
+		if !ok {
+			continue
+		}
+		for key, value := range qualifiers {
+			if qualifier, ok := value.(string); ok && !qualifierRegex.MatchString(qualifier) {
+				return Error(MsgInvalidQualifier, group+"."+qualifiersSetting+"."+key, qualifier)
+			}
+		}
+	}
+
+	if len(versionFileName) > 0 && (filepath.IsAbs(versionFileName) || strings.HasPrefix(versionFileName, "-") ||
+		versionFileName == ".." || strings.HasPrefix(versionFileName, "../")) {
+		return Error(MsgInvalidPathSetting, versionFileGroup+"."+versionFileNameSetting, versionFileName)
+	}
+
+	if _, err := versionLocationSettings(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"os"
+)
+
+// VerifyTags checks that the release tags and the production branch agree: every tag matching the version scheme
+// must be reachable from the production branch, and the version file of the production branch must hold the
+// version of the latest tag. Drift, e.g. from tags or version changes made by hand, is reported and fails the check.
+func VerifyTags(projectPath string) error {
+	pluginRegistryLock.Lock()
+	defer pluginRegistryLock.Unlock()
+
+	// apply suitable settings from the global configuration to the core package
+	if err := applySettings(); err != nil {
+		return err
+	}
+
+	// set path to execute plugin detection and workflow commands
+	ProjectPath = projectPath
+
+	// check if project path exists
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return Error(MsgProjectPathMissing, projectPath)
+	}
+
+	// the plugin of the project reads the version file
+	plugin := projectPlugin()
+	repository := NewRepository(projectPath, Remote)
+
+	// format verify command messages
+	called := Message(MsgVerifyTagsCalled, Production, repository.Local())
+	completed := Message(MsgVerifyTagsCompleted, Production, repository.Local())
+	failed := Message(MsgVerifyTagsFailed, Production, repository.Local())
+
+	Progress(called)
+
+	if err := verifyTags(plugin, repository); err != nil {
+		Failure(failed)
+		return err
+	}
+
+	Success(completed)
+	return nil
+}
+
+func verifyTags(plugin Plugin, repository Repository) error {
+	// tags and branches made by hand elsewhere must be known before they are compared
+	if _, err := repository.RemoteURL(); err == nil {
+		if err := repository.Fetch(); err != nil {
+			return err
+		}
+	}
+
+	// the remote production branch is authoritative, a repository without remote has only the local one
+	production := Production.String()
+	if found, err := repository.HasRemoteBranch(production); err != nil {
+		return err
+	} else if found {
+		production = Remote + "/" + production
+	}
+
+	tags, err := repository.Tags()
+	if err != nil {
+		return err
+	}
+
+	// the tags reachable from the production branch are listed in a single walk of its history
+	merged, err := repository.MergedTags(production)
+	if err != nil {
+		return err
+	}
+
+	reachable := make(map[string]bool, len(merged))
+	for _, tagName := range merged {
+		reachable[tagName] = true
+	}
+
+	drift := 0
+	_, latest := releasedVersions(tags)
+
+	// every release tag must be reachable from the production branch
+	for _, tagName := range tags {
+		if _, ok := releaseTag(tagName); ok && !reachable[tagName] {
+			fmt.Fprintln(os.Stderr, Message(MsgTagUnreachable, tagName, production))
+			drift++
+		}
+	}
+
+	// the version file of the production branch must hold the version of the latest release
+	if latest != NoVersion {
+		current, err := readVersionAt(plugin, repository, production)
+		if err != nil {
+			return err
+		}
+
+		if current != latest {
+			fmt.Fprintln(os.Stderr, Message(MsgTagVersionMismatch, current, plugin.VersionFileName(), production, latest))
+			drift++
+		}
+	}
+
+	if drift > 0 {
+		return Error(MsgTagDrift, drift, production)
+	}
+
+	return nil
+}
+
+// projectPlugin returns the plugin which reads the version file of the project, the fallback plugin if no other
+// plugin detects one.
+func projectPlugin() Plugin {
+	for _, plugin := range pluginRegistry {
+		if CheckVersionFile(plugin) {
+			return plugin
+		}
+	}
+	return fallbackPlugin
+}
@@ -6,10 +6,12 @@ SPDX-License-Identifier: MIT
 package core
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 )
 
 // Version increment types for the workflow automation commands.
@@ -24,13 +26,28 @@ type (
 	// VersionIncrement Type of version increment.
 	VersionIncrement int
 
-	// Version represents a version-stamp with a major, minor, incremental part, and optionally empty qualifier.
+	// Version represents a version-stamp with a major, minor, incremental part, and optionally empty qualifier and
+	// build metadata. The qualifier is the pre-release of Semantic Versioning, e.g. 'rc.1', and the build metadata
+	// follows a plus sign, e.g. 'build.5', which neither the next version nor a changed qualifier keeps.
 	Version struct {
 		VersionIncrement                     VersionIncrement
 		Major, Minor, Incremental, Qualifier string
+		Build                                string
 	}
 )
 
+// Names of the version increment types.
+var versionIncrementNames = map[VersionIncrement]string{
+	Major:       "major",
+	Minor:       "minor",
+	Incremental: "patch",
+}
+
+// String returns the name of the version increment type.
+func (i VersionIncrement) String() string {
+	return versionIncrementNames[i]
+}
+
 // NoVersion is the default version without any parts.
 var NoVersion Version
 
@@ -40,8 +57,42 @@ const versionStamp = "%v.%v.%v"
 // VersionStampWithQualifier is the format for version strings with a qualifier.
 const versionStampWithQualifier = "%v.%v.%v-%v"
 
-// VersionExpression is the regular expression for version strings with optional qualifier.
-const versionExpression = `(\d+)\.(\d+)\.(\d+)(?:-(\w+))?$`
+// VersionStampBuild is the format for the build metadata appended to version strings.
+const versionStampBuild = "%v+%v"
+
+// VersionExpression is the regular expression for version strings of Semantic Versioning 2.0 with optional qualifier
+// of dot-separated identifiers, e.g. 'rc.2' or 'alpha-1.x', and optional build metadata, e.g. '+build.5'.
+const versionExpression = `^(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`
+
+// versionRegex is the compiled regular expression for version strings.
+var versionRegex = regexp.MustCompile(versionExpression)
+
+// LooseVersionExpression is the regular expression for version strings with an optional leading 'v' and an optional
+// incremental part, e.g. 'v1.2.3' or '1.2', which are accepted if loose versions are enabled.
+const looseVersionExpression = `^v?(\d+)\.(\d+)(?:\.(\d+))?(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`
+
+// looseVersionRegex is the compiled regular expression for loose version strings.
+var looseVersionRegex = regexp.MustCompile(looseVersionExpression)
+
+// Workflow setting which accepts version strings with a leading 'v' or without incremental part.
+const looseVersionsSetting = "loose-versions"
+
+// Accept loose version strings in the current run.
+var looseVersions = false
+
+func applyLooseVersionsSetting(settings map[string]any) {
+	if v, ok := settings[looseVersionsSetting].(bool); ok {
+		looseVersions = v
+	}
+}
+
+func resetLooseVersionsSetting() {
+	looseVersions = false
+}
+
+// QualifierNumberExpression is the regular expression for the name, separator, and number of a numbered qualifier,
+// e.g. 'rc.2' or 'beta3'.
+var qualifierNumberExpression = regexp.MustCompile(`^(.*?)(\.?)(\d+)$`)
 
 // NoQualifier is the default empty qualifier for versions.
 var noQualifier = ""
@@ -68,12 +119,17 @@ func NewVersion(major, minor, incremental string, args ...any) Version {
 	return version
 }
 
-// ParseVersion Parse a version string with major, minor, incremental, and optional qualifier.
+// ParseVersion Parse a version string with major, minor, incremental, and optional qualifier and build metadata. If
+// loose versions are enabled by 'workflow.loose-versions', a leading 'v' is dropped and a missing incremental part
+// is normalized to '0', e.g. 'v1.2' is parsed as '1.2.0'.
 func ParseVersion(version string) (Version, error) {
 	var v Version
 
-	// match a version string with optional qualifier
-	matches := regexp.MustCompile(versionExpression).FindStringSubmatch(version)
+	// match a version string with optional qualifier and build metadata
+	matches := versionRegex.FindStringSubmatch(version)
+	if matches == nil && looseVersions {
+		matches = looseVersionRegex.FindStringSubmatch(version)
+	}
 
 	// check if the version string matches the regular expression
 	if matches == nil {
@@ -84,68 +140,201 @@ func ParseVersion(version string) (Version, error) {
 	v.Major = matches[1]
 	v.Minor = matches[2]
 	v.Incremental = matches[3]
-
-	// check if the version string has a qualifier
-	if len(matches) == 5 {
-		v.Qualifier = matches[4]
+	if len(v.Incremental) == 0 {
+		v.Incremental = "0"
 	}
 
+	// set the optionally empty qualifier and build metadata
+	v.Qualifier = matches[4]
+	v.Build = matches[5]
+
 	return v, nil
 }
 
-// Format a version string with major, minor, incremental, and optionally empty qualifier.
+// Format a version string with major, minor, incremental, and optionally empty qualifier and build metadata.
 func (v Version) String() string {
+	stamp := fmt.Sprintf(versionStampWithQualifier, v.Major, v.Minor, v.Incremental, v.Qualifier)
 	if v.Qualifier == noQualifier {
-		return fmt.Sprintf(versionStamp, v.Major, v.Minor, v.Incremental)
+		stamp = fmt.Sprintf(versionStamp, v.Major, v.Minor, v.Incremental)
 	}
 
-	return fmt.Sprintf(versionStampWithQualifier, v.Major, v.Minor, v.Incremental, v.Qualifier)
+	if len(v.Build) == 0 {
+		return stamp
+	}
+	return fmt.Sprintf(versionStampBuild, stamp, v.Build)
 }
 
-// BranchName Create a branch name with a specific version and branch type.
-func (v Version) BranchName(branch Branch) string {
-	return fmt.Sprintf("%v/%v", branch, v)
+// Compare Compare the precedence of two versions by Semantic Versioning 2.0 and return -1, 0, or +1: the major,
+// minor, and incremental parts are compared numerically, a version with qualifier precedes the same version without
+// one, and qualifiers are compared by their dot-separated identifiers, e.g. 'rc.2' precedes 'rc.10'. The build
+// metadata does not take part in the precedence.
+func (v Version) Compare(other Version) int {
+	for _, parts := range [][2]string{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Incremental, other.Incremental}} {
+		if c := compareNumbers(parts[0], parts[1]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case v.Qualifier == other.Qualifier:
+		return 0
+	case v.Qualifier == noQualifier:
+		return 1
+	case other.Qualifier == noQualifier:
+		return -1
+	}
+
+	a, b := strings.Split(v.Qualifier, "."), strings.Split(other.Qualifier, ".")
+	for i := 0; i < min(len(a), len(b)); i++ {
+		if c := compareIdentifiers(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(a), len(b))
 }
 
-// Next Determine the next version based on the current version and the version increment type.
-func (v Version) Next(increment VersionIncrement) (Version, error) {
-	nextMajor, errMajor := strconv.Atoi(v.Major)
-	nextMinor, errMinor := strconv.Atoi(v.Minor)
-	nextIncremental, errIncremental := strconv.Atoi(v.Incremental)
+// Less Report whether the version precedes another version by Semantic Versioning 2.0.
+func (v Version) Less(other Version) bool {
+	return v.Compare(other) < 0
+}
 
-	if errMajor != nil || errMinor != nil || errIncremental != nil {
-		return NoVersion, errors.Join(fmt.Errorf("invalid version parts: %v", v), errMajor, errMinor, errIncremental)
+// compareNumbers (private) Compare two numeric version parts, which may exceed the range of integers.
+func compareNumbers(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if c := cmp.Compare(len(a), len(b)); c != 0 {
+		return c
 	}
+	return strings.Compare(a, b)
+}
 
-	nextMajor++
-	nextMinor++
-	nextIncremental++
-	return v.increment(increment, strconv.Itoa(nextMajor), strconv.Itoa(nextMinor), strconv.Itoa(nextIncremental))
+// compareIdentifiers (private) Compare two identifiers of a qualifier: numeric identifiers are compared numerically
+// and precede alphanumeric identifiers, which are compared in ASCII order.
+func compareIdentifiers(a, b string) int {
+	numericA, numericB := numericIdentifier.MatchString(a), numericIdentifier.MatchString(b)
+	switch {
+	case numericA && numericB:
+		return compareNumbers(a, b)
+	case numericA:
+		return -1
+	case numericB:
+		return 1
+	}
+	return strings.Compare(a, b)
 }
 
-// AddQualifier Add a qualifier to the version.
-func (v Version) AddQualifier(qualifier string) Version {
-	return NewVersion(v.Major, v.Minor, v.Incremental, qualifier, v.VersionIncrement)
+// numericIdentifier is the regular expression for numeric identifiers of a qualifier.
+var numericIdentifier = regexp.MustCompile(`^\d+$`)
+
+// BranchName Create a branch name with a specific version and branch type.
+func (v Version) BranchName(branch Branch) string {
+	return fmt.Sprintf("%v/%v", branch, v)
 }
 
-// RemoveQualifier Remove the qualifier from the version.
-func (v Version) RemoveQualifier() Version {
-	return NewVersion(v.Major, v.Minor, v.Incremental, noQualifier, v.VersionIncrement)
+// EnvironmentTag Create an environment tag name marking the version as promoted to an environment.
+func (v Version) EnvironmentTag(environment string) string {
+	return fmt.Sprintf("%v/%v", environment, v)
+}
+
+// Line Return the maintenance line of the version with major and minor part, e.g. '1.2' for '1.2.3'.
+func (v Version) Line() string {
+	return fmt.Sprintf("%v.%v", v.Major, v.Minor)
 }
 
-// increment (private) Determine next version based on version increment type and next major, minor, and incremental version strings.
-func (v Version) increment(increment VersionIncrement, nextMajor, nextMinor, nextIncremental string) (Version, error) {
+// Next Determine the next version based on the current version and the version increment type, see BumpMajor,
+// BumpMinor, and BumpPatch.
+func (v Version) Next(increment VersionIncrement) (Version, error) {
 	switch increment {
 	case Major:
-		return NewVersion(nextMajor, "0", "0", v.Qualifier, increment), nil
+		return v.BumpMajor()
 
 	case Minor:
-		return NewVersion(v.Major, nextMinor, "0", v.Qualifier, increment), nil
+		return v.BumpMinor()
 
 	case Incremental:
-		return NewVersion(v.Major, v.Minor, nextIncremental, v.Qualifier, increment), nil
+		return v.BumpPatch()
 
 	default:
 		return NoVersion, fmt.Errorf("unsupported version increment type: %v", increment)
 	}
 }
+
+// BumpMajor Return the next major version with minor and incremental part reset, e.g. '2.0.0-dev' for '1.2.3-dev'.
+// Like all bumps, it keeps the qualifier, which the workflows replace when needed, and drops the build metadata.
+func (v Version) BumpMajor() (Version, error) {
+	major, err := bumpPart(v, v.Major)
+	if err != nil {
+		return NoVersion, err
+	}
+	return NewVersion(major, "0", "0", v.Qualifier, Major), nil
+}
+
+// BumpMinor Return the next minor version with incremental part reset, e.g. '1.3.0-dev' for '1.2.3-dev'.
+func (v Version) BumpMinor() (Version, error) {
+	minor, err := bumpPart(v, v.Minor)
+	if err != nil {
+		return NoVersion, err
+	}
+	return NewVersion(v.Major, minor, "0", v.Qualifier, Minor), nil
+}
+
+// BumpPatch Return the next incremental version, e.g. '1.2.4-dev' for '1.2.3-dev'.
+func (v Version) BumpPatch() (Version, error) {
+	incremental, err := bumpPart(v, v.Incremental)
+	if err != nil {
+		return NoVersion, err
+	}
+	return NewVersion(v.Major, v.Minor, incremental, v.Qualifier, Incremental), nil
+}
+
+// bumpPart (private) Increment a numeric part of a version, which only the bumped part needs to be.
+func bumpPart(v Version, part string) (string, error) {
+	number, err := strconv.Atoi(part)
+	if err != nil || number < 0 {
+		return "", errors.Join(fmt.Errorf("invalid version parts: %v", v), err)
+	}
+	return strconv.Itoa(number + 1), nil
+}
+
+// AddQualifier Add a qualifier to the version.
+func (v Version) AddQualifier(qualifier string) Version {
+	return NewVersion(v.Major, v.Minor, v.Incremental, qualifier, v.VersionIncrement)
+}
+
+// NextQualifier Increment the number of the qualifier to bump a prerelease iteration without changing the major,
+// minor, and incremental version, e.g. 'rc.2' to 'rc.3' or 'beta3' to 'beta4'. A qualifier without number becomes the
+// first iteration, e.g. 'dev' to 'dev1'.
+func (v Version) NextQualifier() (Version, error) {
+	if v.Qualifier == noQualifier {
+		return NoVersion, fmt.Errorf("version %v does not have a qualifier to increment", v)
+	}
+
+	matches := qualifierNumberExpression.FindStringSubmatch(v.Qualifier)
+	if matches == nil {
+		return v.AddQualifier(v.Qualifier + "1"), nil
+	}
+
+	number, err := strconv.Atoi(matches[3])
+	if err != nil {
+		return NoVersion, fmt.Errorf("invalid qualifier number of version %v: %v", v, err)
+	}
+
+	return v.AddQualifier(matches[1] + matches[2] + strconv.Itoa(number+1)), nil
+}
+
+// qualifierName (private) Return the qualifier without the number of its iteration, e.g. 'rc' for 'rc.2'.
+func qualifierName(qualifier string) string {
+	if matches := qualifierNumberExpression.FindStringSubmatch(qualifier); matches != nil {
+		return matches[1]
+	}
+	return qualifier
+}
+
+// plain (private) Report whether the version is a plain release version without qualifier and build metadata.
+func (v Version) plain() bool {
+	return v.Qualifier == noQualifier && len(v.Build) == 0
+}
+
+// RemoveQualifier Remove the qualifier from the version.
+func (v Version) RemoveQualifier() Version {
+	return NewVersion(v.Major, v.Minor, v.Incremental, noQualifier, v.VersionIncrement)
+}
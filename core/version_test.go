@@ -0,0 +1,199 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVersionBump tests that bumps reset the lower-order parts, keep the qualifier, and drop the build metadata.
+func TestVersionBump(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		bump    func(Version) (Version, error)
+		want    string
+	}{
+		{"major", "1.2.3", Version.BumpMajor, "2.0.0"},
+		{"minor", "1.2.3", Version.BumpMinor, "1.3.0"},
+		{"patch", "1.2.3", Version.BumpPatch, "1.2.4"},
+		{"major with qualifier", "1.2.3-dev", Version.BumpMajor, "2.0.0-dev"},
+		{"minor with qualifier", "1.2.3-rc.1", Version.BumpMinor, "1.3.0-rc.1"},
+		{"patch with build metadata", "1.2.3-dev+build.5", Version.BumpPatch, "1.2.4-dev"},
+		{"minor of zero version", "0.0.0", Version.BumpMinor, "0.1.0"},
+		{"patch of leading zero", "2025.04.09", Version.BumpPatch, "2025.04.10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := ParseVersion(tt.version)
+			require.NoError(t, err)
+
+			next, err := tt.bump(version)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, next.String())
+		})
+	}
+}
+
+// TestVersionNext tests that Next bumps the part of the increment and records the increment.
+func TestVersionNext(t *testing.T) {
+	version := NewVersion("1", "2", "3", "dev")
+
+	for increment, want := range map[VersionIncrement]string{Major: "2.0.0-dev", Minor: "1.3.0-dev", Incremental: "1.2.4-dev"} {
+		next, err := version.Next(increment)
+		require.NoError(t, err)
+		assert.Equal(t, want, next.String())
+		assert.Equal(t, increment, next.VersionIncrement)
+	}
+
+	_, err := version.Next(None)
+	assert.EqualError(t, err, "unsupported version increment type: ")
+}
+
+// TestVersionBumpInvalidPart tests that only the bumped part needs to be numeric.
+func TestVersionBumpInvalidPart(t *testing.T) {
+	version := NewVersion("1", "x", "3")
+
+	_, err := version.BumpMinor()
+	assert.ErrorContains(t, err, "invalid version parts: 1.x.3")
+
+	next, err := version.BumpMajor()
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", next.String())
+}
+
+// TestParseLooseVersion tests that a leading 'v' and two-part versions are only accepted if loose versions are enabled.
+func TestParseLooseVersion(t *testing.T) {
+	t.Cleanup(resetLooseVersionsSetting)
+
+	for _, version := range []string{"v1.2.3", "1.2", "v1.2-dev"} {
+		_, err := ParseVersion(version)
+		assert.EqualError(t, err, "invalid version string: "+version)
+	}
+
+	applyLooseVersionsSetting(map[string]any{looseVersionsSetting: true})
+
+	tests := map[string]string{
+		"v1.2.3":          "1.2.3",
+		"1.2":             "1.2.0",
+		"v1.2-dev":        "1.2.0-dev",
+		"1.2+build.5":     "1.2.0+build.5",
+		"1.2.3-rc.1":      "1.2.3-rc.1",
+		"v2.0.1-SNAPSHOT": "2.0.1-SNAPSHOT",
+	}
+	for version, want := range tests {
+		parsed, err := ParseVersion(version)
+		require.NoError(t, err)
+		assert.Equal(t, want, parsed.String())
+	}
+
+	for _, version := range []string{"1", "V1.2.3", "vv1.2.3", "1.2.3.4"} {
+		_, err := ParseVersion(version)
+		assert.Error(t, err, version)
+	}
+}
+
+// TestParseVersion tests the qualifier and build metadata of parsed versions and the rejected version strings.
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version   string
+		qualifier string
+		build     string
+	}{
+		{"1.2.3", "", ""},
+		{"1.2.3-rc.1", "rc.1", ""},
+		{"1.2.3+build.5", "", "build.5"},
+		{"1.2.3-rc.1+exp.sha.5114f85", "rc.1", "exp.sha.5114f85"},
+		{"1.0.0-alpha-1.x", "alpha-1.x", ""},
+		{"1.0.0-x.7.z.92", "x.7.z.92", ""},
+		{"1.0.0-0.3.7", "0.3.7", ""},
+		{"1.0.0+20130313144700", "", "20130313144700"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			version, err := ParseVersion(tt.version)
+			require.NoError(t, err)
+			assert.Equal(t, tt.qualifier, version.Qualifier)
+			assert.Equal(t, tt.build, version.Build)
+			assert.Equal(t, tt.version, version.String())
+		})
+	}
+
+	for _, version := range []string{"", "1", "1.2", "1.2.3.4", "a.b.c", "-1.2.3", "1.2.3-", "1.2.3+", "1.2.3-rc..1",
+		"1.2.3-rc_1", "1.2.3+build+5", "1.2.3 ", " 1.2.3"} {
+		_, err := ParseVersion(version)
+		assert.EqualError(t, err, "invalid version string: "+version)
+	}
+}
+
+// TestVersionCompare tests the precedence of versions by Semantic Versioning 2.0.
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{"major", "1.9.9", "2.0.0", -1},
+		{"minor numerically", "1.2.0", "1.10.0", -1},
+		{"incremental numerically", "1.0.10", "1.0.9", 1},
+		{"numbers beyond integers", "1.0.99999999999999999999", "1.0.100000000000000000000", -1},
+		{"equal", "1.2.3", "1.2.3", 0},
+		{"pre-release before its release", "1.0.0-rc.1", "1.0.0", -1},
+		{"release after its pre-release", "1.0.0", "1.0.0-alpha", 1},
+		{"pre-release after the previous release", "1.0.1-alpha", "1.0.0", 1},
+		{"numeric identifiers numerically", "1.0.0-rc.2", "1.0.0-rc.10", -1},
+		{"numeric before alphanumeric identifiers", "1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"alphanumeric identifiers in ASCII order", "1.0.0-alpha", "1.0.0-beta", -1},
+		{"uppercase before lowercase", "1.0.0-RC", "1.0.0-rc", -1},
+		{"fewer identifiers first", "1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"more identifiers after", "1.0.0-beta.2.1", "1.0.0-beta.2", 1},
+		{"hyphenated identifier", "1.0.0-alpha-2", "1.0.0-alpha-10", 1},
+		{"build metadata ignored", "1.0.0+build.1", "1.0.0+build.2", 0},
+		{"build metadata of pre-release ignored", "1.0.0-rc.1+a", "1.0.0-rc.1", 0},
+		{"leading zeros of parts numerically", "2025.04.09", "2025.4.9", 0},
+		{"leading zeros of identifiers numerically", "1.0.0-rc.01", "1.0.0-rc.1", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := ParseVersion(tt.a)
+			require.NoError(t, err)
+			b, err := ParseVersion(tt.b)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, a.Compare(b))
+			assert.Equal(t, -tt.want, b.Compare(a))
+			assert.Equal(t, tt.want < 0, a.Less(b))
+		})
+	}
+}
+
+// TestVersionCompareOrder tests that sorting by precedence orders the versions of the Semantic Versioning 2.0
+// specification.
+func TestVersionCompareOrder(t *testing.T) {
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "2.0.0", "2.1.0", "2.1.1"}
+
+	versions := make([]Version, 0, len(ordered))
+	for i := len(ordered) - 1; i >= 0; i-- {
+		version, err := ParseVersion(ordered[i])
+		require.NoError(t, err)
+		versions = append(versions, version)
+	}
+
+	slices.SortFunc(versions, Version.Compare)
+	sorted := make([]string, 0, len(versions))
+	for _, version := range versions {
+		sorted = append(sorted, version.String())
+	}
+	assert.Equal(t, ordered, sorted)
+}
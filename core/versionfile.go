@@ -0,0 +1,112 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Configuration group and settings of the version file created for projects without one.
+const (
+	versionFileGroup          = "version-file"
+	versionFileCreateSetting  = "create"
+	versionFileNameSetting    = "name"
+	versionFileInitialSetting = "initial-version"
+)
+
+// Default initial version of created version files.
+const defaultInitialVersion = "1.0.0"
+
+// Version file settings of the current run.
+var versionFileCreation = true
+var initialVersion = defaultInitialVersion
+var versionFileName string
+
+// Name of the version file of the fallback plugin before the settings are applied.
+var fallbackVersionFileName string
+
+func applyVersionFileSettings(settings map[string]any) {
+	if v, ok := settings[versionFileCreateSetting].(bool); ok {
+		versionFileCreation = v
+	}
+	if v, ok := settings[versionFileNameSetting].(string); ok && len(v) > 0 {
+		versionFileName = filepath.ToSlash(filepath.Clean(v))
+	}
+	if v, ok := settings[versionFileInitialSetting].(string); ok && len(v) > 0 {
+		initialVersion = v
+	}
+}
+
+func resetVersionFileSettings() {
+	versionFileCreation = true
+	initialVersion = defaultInitialVersion
+	versionFileName = ""
+}
+
+// ConfiguredVersionFileName returns the version file of the fallback plugin configured under 'version-file.name',
+// e.g. 'config/VERSION', relative to the project path, or an empty string if none is configured.
+func ConfiguredVersionFileName() string {
+	return versionFileName
+}
+
+// applyFallbackVersionFileName lets the fallback plugin detect, read, and write the configured version file.
+func applyFallbackVersionFileName() {
+	if fallbackPlugin == nil {
+		return
+	}
+	if len(fallbackVersionFileName) == 0 {
+		fallbackVersionFileName = fallbackPlugin.VersionFileName()
+	}
+	if len(versionFileName) > 0 {
+		fallbackPlugin.SetVersionFileName(versionFileName)
+	} else {
+		fallbackPlugin.SetVersionFileName(fallbackVersionFileName)
+	}
+}
+
+// CheckVersionFileCreation fails if a missing version file must not be created with the initial version.
+func CheckVersionFileCreation(fileName string) error {
+	if !versionFileCreation {
+		return Error(MsgVersionFileMissing, fileName, versionFileGroup, versionFileCreateSetting)
+	}
+	return nil
+}
+
+// InitialVersion returns the configured version of newly created version files, e.g. '1.0.0'.
+func InitialVersion() (Version, error) {
+	version, err := ParseVersion(initialVersion)
+	if err != nil || version.Qualifier != noQualifier {
+		return NoVersion, Error(MsgInitialVersionInvalid, initialVersion, versionFileGroup, versionFileInitialSetting)
+	}
+	return version, nil
+}
+
+// readVersionAt reads the project version at a reference from a copy of its version file, without checking it out.
+func readVersionAt(plugin Plugin, repository Repository, ref string) (Version, error) {
+	content, err := repository.ShowFile(ref, plugin.VersionFileName())
+	if err != nil {
+		return NoVersion, err
+	}
+
+	directory, err := os.MkdirTemp("", "gitflow-cli-version-")
+	if err != nil {
+		return NoVersion, err
+	}
+	defer func() { _ = os.RemoveAll(directory) }()
+
+	// the version file may be located in a subdirectory of the project, e.g. 'internal/version/version.go'
+	copied := filepath.Join(directory, plugin.VersionFileName())
+	if err := os.MkdirAll(filepath.Dir(copied), 0755); err != nil {
+		return NoVersion, err
+	}
+
+	if err := os.WriteFile(copied, content, 0644); err != nil {
+		return NoVersion, err
+	}
+
+	return plugin.ReadVersion(NewRepository(directory, Remote))
+}
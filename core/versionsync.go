@@ -0,0 +1,155 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Configuration key and settings of the additional version locations, which are updated with the version file.
+const (
+	versionSyncKey         = "version-sync"
+	versionSyncFileSetting = "file"
+	versionSyncPattern     = "pattern"
+)
+
+// versionLocation is an additional location of the version in a file of the project, e.g. the appVersion of a
+// Chart.yaml or a README badge. The version is the first group of the pattern, or every occurrence of the version
+// of the project if there is no pattern.
+type versionLocation struct {
+	file    string
+	pattern *regexp.Regexp
+	setting string
+}
+
+// syncPlugin writes the version to the additional version locations along with the version file of the plugin,
+// so that they are part of the same commit.
+type syncPlugin struct {
+	Plugin
+	locations []versionLocation
+}
+
+// versionLocationSettings returns the additional version locations configured under 'version-sync'.
+func versionLocationSettings() ([]versionLocation, error) {
+	entries, ok := viper.AllSettings()[versionSyncKey].([]any)
+	if !ok {
+		return nil, nil
+	}
+
+	locations := make([]versionLocation, 0, len(entries))
+	for _, entry := range entries {
+		settings, ok := entry.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid version location configuration: %v", entry)
+		}
+
+		file, _ := settings[versionSyncFileSetting].(string)
+		if !filepath.IsLocal(file) {
+			return nil, Error(MsgInvalidPathSetting, versionSyncKey+"."+versionSyncFileSetting, file)
+		}
+
+		location := versionLocation{file: filepath.ToSlash(filepath.Clean(file)), setting: versionSyncKey}
+		if pattern, _ := settings[versionSyncPattern].(string); len(pattern) > 0 {
+			expression, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version location pattern '%v': %v", pattern, err)
+			} else if expression.NumSubexp() == 0 {
+				return nil, fmt.Errorf("version location pattern '%v' has no group for the version", pattern)
+			}
+			location.pattern = expression
+		}
+
+		locations = append(locations, location)
+	}
+
+	return locations, nil
+}
+
+// syncVersionLocations wraps the plugin of the project so that it updates the configured version locations.
+func syncVersionLocations(plugin Plugin) Plugin {
+	if locations, err := versionLocationSettings(); err == nil && len(locations) > 0 {
+		return &syncPlugin{Plugin: plugin, locations: locations}
+	}
+	return plugin
+}
+
+// versionFiles returns the version file of a plugin and the files of its additional version locations.
+func versionFiles(plugin Plugin) []string {
+	files := []string{plugin.VersionFileName()}
+	if sync, ok := plugin.(*syncPlugin); ok {
+		for _, location := range sync.locations {
+			files = append(files, location.file)
+		}
+	}
+	return files
+}
+
+// WriteVersion writes the version to the version file and then to all additional version locations. It fails if
+// a location does not hold a version, so that a moved or renamed version is noticed. A version file without a
+// version yet is created without the locations, e.g. by init.
+func (p *syncPlugin) WriteVersion(repository Repository, version Version) error {
+	previous, err := p.Plugin.ReadVersion(repository)
+	if err != nil {
+		return p.Plugin.WriteVersion(repository, version)
+	}
+
+	if err := p.Plugin.WriteVersion(repository, version); err != nil {
+		return err
+	}
+
+	for _, location := range p.locations {
+		if err := location.write(repository, previous, version); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// write replaces the version of the location by the version, which has been the previous version of the project.
+func (l versionLocation) write(repository Repository, previous, version Version) error {
+	fileName := filepath.Join(repository.Local(), filepath.FromSlash(l.file))
+
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return Error(MsgVersionSyncMissing, l.file, l.setting)
+	}
+
+	var updated string
+	if l.pattern == nil {
+		if !strings.Contains(string(content), previous.String()) {
+			return Error(MsgVersionSyncMissing, l.file, l.setting)
+		}
+		updated = strings.ReplaceAll(string(content), previous.String(), version.String())
+	} else {
+		matches := l.pattern.FindAllStringSubmatchIndex(string(content), -1)
+		if len(matches) == 0 {
+			return Error(MsgVersionSyncMissing, l.file, l.setting)
+		}
+
+		var builder strings.Builder
+		last := 0
+		for _, match := range matches {
+			if match[2] < 0 {
+				continue
+			}
+			builder.WriteString(string(content[last:match[2]]))
+			builder.WriteString(version.String())
+			last = match[3]
+		}
+		builder.WriteString(string(content[last:]))
+		updated = builder.String()
+	}
+
+	Log(fmt.Sprintf("Writing to file: %s, version: %s", fileName, version))
+	return os.WriteFile(fileName, []byte(updated), 0644)
+}
@@ -8,8 +8,21 @@ package core
 import (
 	"fmt"
 	"os"
+	"slices"
+	"sort"
+	"strings"
 )
 
+// Messages of the first commits of release and hotfix branches, which mark the start of a release in the history.
+const (
+	releaseStartMessage = "Remove qualifier from project version."
+	hotfixStartMessage  = "Increment patch version for hotfix."
+)
+
+// FastForwardDevelop updates the development branch on release finish by merging the production branch instead of
+// the release branch, so that develop follows the history of production.
+var FastForwardDevelop bool
+
 func pushIfEnabled(fn func() error) error {
 	if !pushChanges {
 		return nil
@@ -17,35 +30,113 @@ func pushIfEnabled(fn func() error) error {
 	return fn()
 }
 
-// Start executes the first plugin that meets the precondition.
-func Start(branch Branch, projectPath string) error {
+// pushBranchDeletion deletes a finished branch on the remote, if pushing is enabled, and tells how collaborators
+// remove its remote-tracking branch, which their clones keep until they fetch with pruning.
+func pushBranchDeletion(repository Repository, branchName string) error {
+	if !pushChanges {
+		return nil
+	}
+
+	if err := repository.PushDeletion(branchName); err != nil {
+		return err
+	}
+
+	if pruneHint && !dryRun {
+		fmt.Fprintln(os.Stderr, Message(MsgPruneHint, branchName, Remote))
+	}
+	return nil
+}
+
+// checkoutBranch checks out a branch and pulls its remote changes with the configured pull strategy.
+func checkoutBranch(repository Repository, branchName string) error {
+	if err := repository.CheckoutBranch(branchName); err != nil {
+		return err
+	}
+	if pullStrategy == 0 {
+		return nil
+	}
+	return repository.PullBranch(branchName, pullStrategy)
+}
+
+// fastForwardBranches fast-forwards the local production and development branches which lag behind their remote
+// branches, e.g. because they were changed on the remote in the meantime, so that the clone ends up to date. The
+// workflow has completed at this point, so problems are reported as warnings instead of failing it.
+func fastForwardBranches(repository Repository) {
+	if err := repository.Fetch(); err != nil {
+		fmt.Fprintln(os.Stderr, Message(MsgFastForwardFailed, Remote, err))
+		return
+	}
+
+	for _, branch := range []Branch{Production, Development} {
+		behind, err := repository.BehindCommits(branch.String())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, Message(MsgFastForwardFailed, branch, err))
+			continue
+		} else if behind == 0 {
+			continue
+		}
+
+		// local commits which are not on the remote must not be lost
+		if unpushed, err := repository.UnpushedCommits(branch.String()); err != nil {
+			fmt.Fprintln(os.Stderr, Message(MsgFastForwardFailed, branch, err))
+			continue
+		} else if unpushed > 0 {
+			fmt.Fprintln(os.Stderr, Message(MsgBranchDiverged, branch, Remote+"/"+branch.String()))
+			continue
+		}
+
+		if err := repository.FastForwardBranch(branch.String()); err != nil {
+			fmt.Fprintln(os.Stderr, Message(MsgFastForwardFailed, branch, err))
+			continue
+		}
+
+		fmt.Fprintln(os.Stderr, Message(MsgBranchFastForwarded, branch, behind, Remote+"/"+branch.String()))
+	}
+}
+
+// Start executes the first plugin that meets the precondition. An optional release version overrides the
+// version derived from the version file, an optional increment bumps the version on the development branch first.
+func Start(branch Branch, version string, increment VersionIncrement, projectPath string) error {
 	pluginRegistryLock.Lock()
 	defer pluginRegistryLock.Unlock()
 
 	// apply suitable settings from the global configuration to the core package
-	applySettings()
+	if err := applySettings(); err != nil {
+		return err
+	}
 
 	// set path to execute plugin detection and workflow commands
 	ProjectPath = projectPath
 
 	// check if project path exists
 	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
-		return fmt.Errorf("project path '%v' does not exist", projectPath)
+		return Error(MsgProjectPathMissing, projectPath)
+	}
+
+	// an explicit version must be a plain release version
+	target := NoVersion
+	if len(version) > 0 {
+		parsed, err := ParseVersion(version)
+		if err != nil || !parsed.plain() {
+			return Error(MsgReleaseVersionInvalid, version)
+		}
+		target = parsed
 	}
 
 	// execute the first plugin that meets the precondition
 	for _, plugin := range pluginRegistry {
 		if CheckVersionFile(plugin) {
-			return executePluginStart(plugin, branch, projectPath)
+			return executePluginStart(plugin, branch, target, increment, projectPath)
 		}
 	}
 	// execute fallback plugin
-	return executePluginStart(fallbackPlugin, branch, projectPath)
+	return executePluginStart(fallbackPlugin, branch, target, increment, projectPath)
 }
 
-func executePluginStart(plugin Plugin, branch Branch, projectPath string) error {
+func executePluginStart(plugin Plugin, branch Branch, target Version, increment VersionIncrement, projectPath string) error {
 	// get access to the local version control system
-	repository := NewRepository(projectPath, Remote)
+	repository := openRepository(projectPath)
+	plugin = openPlugin(plugin, repository)
 
 	// check if required tools are available
 	if err := ValidateToolsAvailability(plugin.RequiredTools()...); err != nil {
@@ -57,51 +148,76 @@ func executePluginStart(plugin Plugin, branch Branch, projectPath string) error
 		return err
 	}
 
-	// ensure production branch exists (must resolve before development)
-	if err := syncBranch(repository, Production); err != nil {
+	// check if workflow commits get the same identity as manual commits in the project path
+	if err := checkIdentity(repository); err != nil {
+		return err
+	}
+
+	// hotfixes of a maintenance line are based on its support branch instead of the production branch
+	base := Development.String()
+	if branch == Hotfix {
+		base = hotfixBase(SupportLine)
+	}
+
+	// check if the current checkout is a safe starting point
+	if err := checkStartingPoint(repository, branch, base); err != nil {
 		return err
 	}
 
-	// ensure development branch exists for release workflows
+	// ensure production branch exists, and the development branch for release workflows
+	synced := []Branch{Production}
 	if branch == Release {
-		if err := syncBranch(repository, Development); err != nil {
+		synced = append(synced, Development)
+	}
+	if err := syncBranches(repository, synced...); err != nil {
+		return err
+	}
+
+	// check if the support branch of the maintenance line exists
+	if branch == Hotfix && len(SupportLine) > 0 {
+		if err := checkSupportLine(repository, SupportLine); err != nil {
 			return err
 		}
 	}
 
+	// look up the issue the branch is started for
+	issue, err := fetchIssue(repository, IssueKey)
+	if err != nil {
+		return err
+	}
+
 	// format start command messages
-	prefix := fmt.Sprintf("%v Plugin Start on branch", plugin.String())
-	called := fmt.Sprintf("%v %v called: %v", prefix, branch.String(), repository.Local())
-	completed := fmt.Sprintf("%v %v completed: %v", prefix, branch, repository.Local())
-	failed := fmt.Sprintf("%v %v failed: %v", prefix, branch, repository.Local())
+	called := Message(MsgStartCalled, plugin, branch, repository.Local())
+	completed := Message(MsgStartCompleted, plugin, branch, repository.Local())
+	failed := Message(MsgStartFailed, plugin, branch, repository.Local())
 
 	switch branch {
 	case Release:
-		fmt.Println(called)
+		Progress(called)
 
 		// run the release start command
-		if err := releaseStart(plugin, repository); err != nil {
-			fmt.Println(failed)
+		if err := releaseStart(plugin, repository, issue, target, increment); err != nil {
+			Failure(failed)
 			return err
 		}
 
-		fmt.Println(completed)
+		Success(completed)
 		return nil
 
 	case Hotfix:
-		fmt.Println(called)
+		Progress(called)
 
 		// run the hotfix start command
-		if err := hotfixStart(plugin, repository); err != nil {
-			fmt.Println(failed)
+		if err := hotfixStart(plugin, repository, issue, SupportLine); err != nil {
+			Failure(failed)
 			return err
 		}
 
-		fmt.Println(completed)
+		Success(completed)
 		return nil
 
 	default:
-		return fmt.Errorf("unsupported branch: %v", branch)
+		return Error(MsgUnsupportedBranch, branch)
 	}
 }
 
@@ -112,14 +228,16 @@ func Finish(branch Branch, projectPath string) error {
 	defer pluginRegistryLock.Unlock()
 
 	// apply suitable settings from the global configuration to the core package
-	applySettings()
+	if err := applySettings(); err != nil {
+		return err
+	}
 
 	// set path to execute plugin detection and workflow commands
 	ProjectPath = projectPath
 
 	// check if project path exists
 	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
-		return fmt.Errorf("project path '%v' does not exist", projectPath)
+		return Error(MsgProjectPathMissing, projectPath)
 	}
 
 	// execute the first plugin that meets the precondition
@@ -134,35 +252,72 @@ func Finish(branch Branch, projectPath string) error {
 
 func executePluginFinish(plugin Plugin, branch Branch, projectPath string) error {
 	// finish the workflow with the selected release business logic
-	repository := NewRepository(projectPath, Remote)
+	repository := openRepository(projectPath)
+	plugin = openPlugin(plugin, repository)
+
+	// an interrupted finish must be continued before another one starts
+	if err := checkInterruptedWorkflow(repository); err != nil {
+		return err
+	}
 
 	// check if required tools are available
 	if err := ValidateToolsAvailability(plugin.RequiredTools()...); err != nil {
 		return err
 	}
 
+	// check if the tools for generating the SBOM are available
+	if branch == Release {
+		if tools, err := sbomTools(); err != nil {
+			return err
+		} else if len(tools) > 0 {
+			if err := ValidateToolsAvailability(tools...); err != nil {
+				return err
+			}
+		}
+	}
+
+	// check if the tool of the pre-tag hook is available
+	if tools := hookTools(); len(tools) > 0 && (branch == Release || branch == Hotfix) {
+		if err := ValidateToolsAvailability(tools...); err != nil {
+			return err
+		}
+	}
+
+	// check if the tools for signing and uploading the provenance statement are available
+	if tools := provenanceTools(); len(tools) > 0 {
+		if err := ValidateToolsAvailability(tools...); err != nil {
+			return err
+		}
+	}
+
 	// check if the repository prerequisites are met
 	if err := repository.IsClean(); err != nil {
 		return err
 	}
 
-	// ensure production branch exists (must resolve before development)
-	if err := syncBranch(repository, Production); err != nil {
+	// check if workflow commits get the same identity as manual commits in the project path
+	if err := checkIdentity(repository); err != nil {
 		return err
 	}
 
-	// ensure development branch exists for finish workflows
-	if err := syncBranch(repository, Development); err != nil {
+	// ensure production and development branches exist for finish workflows
+	if err := syncBranches(repository, Production, Development); err != nil {
 		return err
 	}
 
+	// check if the support branch of the maintenance line exists
+	if branch == Hotfix && len(SupportLine) > 0 {
+		if err := checkSupportLine(repository, SupportLine); err != nil {
+			return err
+		}
+	}
+
 	// format finish command messages
-	prefix := fmt.Sprintf("%v Plugin Finish on branch", plugin.String())
-	called := fmt.Sprintf("%v %v called: %v", prefix, branch.String(), repository.Local())
-	completed := fmt.Sprintf("%v %v completed: %v", prefix, branch, repository.Local())
-	failed := fmt.Sprintf("%v %v failed: %v", prefix, branch, repository.Local())
+	called := Message(MsgFinishCalled, plugin, branch, repository.Local())
+	completed := Message(MsgFinishCompleted, plugin, branch, repository.Local())
+	failed := Message(MsgFinishFailed, plugin, branch, repository.Local())
 
-	fmt.Println(called)
+	Progress(called)
 
 	// select suitable business logic for the branch
 	switch branch {
@@ -170,341 +325,1070 @@ func executePluginFinish(plugin Plugin, branch Branch, projectPath string) error
 
 		// run the release finish command
 		if err := releaseFinish(plugin, repository); err != nil {
-			fmt.Println(failed)
+			Failure(failed)
 			return err
 		}
 
-		fmt.Println(completed)
+		Success(completed)
 		return nil
 
 	case Hotfix:
 
 		// run the hotfix finish command
-		if err := hotfixFinish(plugin, repository); err != nil {
-			fmt.Println(failed)
+		if err := hotfixFinish(plugin, repository, SupportLine); err != nil {
+			Failure(failed)
 			return err
 		}
 
-		fmt.Println(completed)
+		Success(completed)
 		return nil
 
 	default:
-		return fmt.Errorf("unsupported branch: %v", branch)
+		return Error(MsgUnsupportedBranch, branch)
 	}
 }
 
-func releaseStart(plugin Plugin, repository Repository) error {
+func releaseStart(plugin Plugin, repository Repository, issue Issue, target Version, increment VersionIncrement) error {
 
 	// check if the repository already has a release branch
 	if found, _, err := repository.HasBranch(Release); err != nil {
 		return err
 	} else if found {
-		return fmt.Errorf(
-			"repository already has a '%v' branch and only one '%v' branch is allowed at a time",
-			Release, Release)
+		return Error(MsgBranchAlreadyExists, Release, Release)
 	}
 
-	// checkout develop branch
-	if err := repository.CheckoutBranch(Development.String()); err != nil {
-		return err
+	// an explicit version must not have been released before
+	if target != NoVersion {
+		if found, err := repository.HasTag(versionTag(target.String())); err != nil {
+			return err
+		} else if found {
+			return Error(MsgAlreadyReleased, target)
+		}
 	}
 
-	if err := GlobalHooks.ExecuteHook(plugin, ReleaseStartHooks.BeforeReleaseStartHook, repository); err != nil {
-		return repository.Rollback(err)
+	var current, release Version
+	var releaseBranch string
+
+	steps := []workflowStep{
+		{
+			name:        "checkout-development",
+			required:    true,
+			description: "checkout the development branch",
+			execute: func() error {
+				return checkoutBranch(repository, Development.String())
+			},
+		},
+		{
+			name:        "before-start-hook",
+			description: "prepare the development branch for the release",
+			hook:        ReleaseStartHooks.BeforeReleaseStartHook,
+			undo:        repository.Rollback,
+		},
+		{
+			name:        "read-version",
+			required:    true,
+			description: "read the version of the development branch, which an explicit version replaces",
+			execute: func() (err error) {
+				if current, err = plugin.ReadVersion(repository); err == nil && target != NoVersion {
+					current = target
+				}
+				return err
+			},
+		},
+		{
+			name:        "bump-version",
+			description: "bump the major or minor version of the development branch, or derive it from the latest release",
+			execute: func() (err error) {
+				next := current
+				if len(ReleaseBump) > 0 {
+					if next, increment, err = bumpedVersion(repository, current, ReleaseBump); err != nil {
+						return err
+					}
+				} else if increment != None {
+					if next, err = Scheme().Next(current, increment); err != nil {
+						return err
+					}
+				}
+
+				if next.String() == current.String() {
+					return nil
+				}
+
+				current = next
+				if err := plugin.WriteVersion(repository, current); err != nil {
+					return err
+				}
+				return repository.CommitChanges(issue.CommitMessage(fmt.Sprintf("Set next %v project version.", increment)))
+			},
+			undo: repository.Rollback,
+		},
+		{
+			name:        "create-branch",
+			required:    true,
+			description: "create and checkout the branch release/x.y.z[/<issue>] of the release version of the versioning scheme",
+			execute: func() (err error) {
+				// an explicit version is released as is, and a calendar version is dated otherwise
+				release = current.RemoveQualifier()
+				if target == NoVersion {
+					if release, err = Scheme().Release(current); err != nil {
+						return err
+					}
+				}
+				releaseBranch = issue.BranchName(release.BranchName(Release))
+				return repository.CreateBranch(releaseBranch)
+			},
+			undo: repository.Rollback,
+		},
+		{
+			name:        "update-version",
+			required:    true,
+			description: "replace the development qualifier by the qualifier of release versions, which is none by default",
+			execute: func() error {
+				return plugin.WriteVersion(repository, release.AddQualifier(Qualifier(plugin, Release)))
+			},
+			undo: repository.Rollback,
+		},
+		{
+			name:        "commit-version",
+			required:    true,
+			description: "commit the release version",
+			execute: func() error {
+				return repository.CommitChanges(issue.CommitMessage(releaseStartMessage))
+			},
+			hook: ReleaseStartHooks.AfterUpdateProjectVersionHook,
+			undo: repository.Rollback,
+		},
+		{
+			name:        "push-branches",
+			description: "push all branches to the remote",
+			execute: func() error {
+				return pushIfEnabled(repository.PushAllChanges)
+			},
+		},
 	}
 
-	// read out the current project version
-	current, err := plugin.ReadVersion(repository)
+	steps, err := customizeSteps(releaseStartWorkflow, steps, repository, func() (string, string) {
+		if release == NoVersion {
+			return current.RemoveQualifier().String(), releaseBranch
+		}
+		return release.String(), releaseBranch
+	})
 	if err != nil {
 		return err
 	}
 
-	// create branch release/x.y.z based on the current develop branch without qualifier
-	// checkout release/x.y.z branch
-	if err := repository.CreateBranch(current.RemoveQualifier().BranchName(Release)); err != nil {
-		return repository.Rollback(err)
-	}
-
-	// remove qualifier from the project version (change POM file)
-	if err := plugin.WriteVersion(repository, current.RemoveQualifier()); err != nil {
-		return repository.Rollback(err)
-	}
-
-	// perform a git commit with a commit message
-	if err := repository.CommitChanges("Remove qualifier from project version."); err != nil {
-		return repository.Rollback(err)
-	}
-
-	// After update project version hook
-	if err := GlobalHooks.ExecuteHook(plugin, ReleaseStartHooks.AfterUpdateProjectVersionHook, repository); err != nil {
-		return repository.Rollback(err)
-	}
-
-	// push all branches to remotes
-	if err := pushIfEnabled(repository.PushAllChanges); err != nil {
+	if err := executeSteps(plugin, repository, releaseStartWorkflow, steps, nil); err != nil {
 		return err
 	}
 
-	return nil
+	return setOutputs(releaseBranch, release, "")
 }
 
-func hotfixStart(plugin Plugin, repository Repository) error {
-	// check if the repository already has a hotfix branch
-	if found, _, err := repository.HasBranch(Hotfix); err != nil {
+func hotfixStart(plugin Plugin, repository Repository, issue Issue, line string) error {
+	// check if the maintenance line already has a hotfix branch
+	if branches, err := hotfixBranches(repository, line); err != nil {
 		return err
-	} else if found {
-		return fmt.Errorf(
-			"repository already has a '%v' branch and only one '%v' branch is allowed at a time",
-			Hotfix, Hotfix)
+	} else if len(branches) > 0 {
+		return Error(MsgBranchAlreadyExists, Hotfix, Hotfix)
 	}
 
-	// checkout production branch
-	if err := repository.CheckoutBranch(Production.String()); err != nil {
-		return err
+	var next Version
+	var hotfixBranch, baseTag string
+
+	steps := []workflowStep{
+		{
+			name:        "checkout-base",
+			required:    true,
+			description: "checkout the production or support branch",
+			execute: func() error {
+				return checkoutBranch(repository, hotfixBase(line))
+			},
+		},
 	}
 
-	if err := GlobalHooks.ExecuteHook(plugin, HotfixStartHooks.BeforeHotfixStartHook, repository); err != nil {
-		return repository.Rollback(err)
+	// the hooks prepare the production branch, support branches and hotfixes of a mainline are based on released
+	// versions and need no preparation
+	if len(line) == 0 && !mainline() {
+		steps = append(steps, workflowStep{
+			name:        "before-start-hook",
+			description: "prepare the production branch for the hotfix",
+			hook:        HotfixStartHooks.BeforeHotfixStartHook,
+			undo:        repository.Rollback,
+		})
 	}
 
-	// read out the current project version
-	current, err := plugin.ReadVersion(repository)
+	steps = append(steps,
+		workflowStep{
+			name:        "read-version",
+			required:    true,
+			description: "read the version of the base branch, or the latest release on a mainline, and calculate the next incremental version",
+			execute: func() error {
+				// a mainline carries the next development version, so its hotfixes are based on the latest release
+				if mainline() && len(line) == 0 {
+					latest, err := latestRelease(repository)
+					if err != nil {
+						return err
+					}
+					baseTag = versionTag(latest.String())
+					next, err = Scheme().Next(latest, Incremental)
+					return err
+				}
+
+				current, err := plugin.ReadVersion(repository)
+				if err != nil {
+					return err
+				}
+				next, err = Scheme().Next(current, Incremental)
+				return err
+			},
+		},
+		workflowStep{
+			name:        "create-branch",
+			required:    true,
+			description: "create and checkout the branch hotfix/${major}.${minor}.${increment + 1}[/<issue>]",
+			execute: func() error {
+				hotfixBranch = issue.BranchName(next.BranchName(Hotfix))
+				if len(baseTag) > 0 {
+					return repository.CreateBranchFrom(hotfixBranch, baseTag)
+				}
+				return repository.CreateBranch(hotfixBranch)
+			},
+			undo: repository.Rollback,
+		},
+		workflowStep{
+			name:        "update-version",
+			required:    true,
+			description: "update the version to ${major}.${minor}.${increment + 1}[-${qualifier}]",
+			execute: func() error {
+				return plugin.WriteVersion(repository, next.AddQualifier(Qualifier(plugin, Hotfix)))
+			},
+			undo: repository.Rollback,
+		},
+		workflowStep{
+			name:        "commit-version",
+			required:    true,
+			description: "commit the hotfix version",
+			execute: func() error {
+				return repository.CommitChanges(issue.CommitMessage(hotfixStartMessage))
+			},
+			undo: repository.Rollback,
+		},
+		workflowStep{
+			name:        "push-branches",
+			description: "push all branches to the remote",
+			execute: func() error {
+				return pushIfEnabled(repository.PushAllChanges)
+			},
+		},
+	)
+
+	steps, err := customizeSteps(hotfixStartWorkflow, steps, repository, func() (string, string) {
+		return next.String(), hotfixBranch
+	})
 	if err != nil {
 		return err
 	}
 
-	// calculate the next incremental version
-	next, err := current.Next(Incremental)
-	if err != nil {
+	if err := executeSteps(plugin, repository, hotfixStartWorkflow, steps, nil); err != nil {
 		return err
 	}
 
-	// create branch hotfix/${major}.${minor}.${increment + 1} based on the current production branch
-	// checkout hotfix/${major}.${minor}.${increment + 1} branch
-	if err := repository.CreateBranch(next.BranchName(Hotfix)); err != nil {
-		return repository.Rollback(err)
-	}
-
-	// update project version to ${major}.${minor}.${increment + 1}
-	if err := plugin.WriteVersion(repository, next); err != nil {
-		return repository.Rollback(err)
-	}
-
-	// perform a git commit with a commit message
-	if err := repository.CommitChanges("Increment patch version for hotfix."); err != nil {
-		return repository.Rollback(err)
-	}
-
-	// push all branches to remotes
-	if err := pushIfEnabled(repository.PushAllChanges); err != nil {
-		return err
-	}
-
-	return nil
+	return setOutputs(hotfixBranch, next, "")
 }
 
 // Run the release finish command for the standard workflow.
 func releaseFinish(plugin Plugin, repository Repository) error {
-	var releaseVersion Version
+	var releaseBranch BranchInfo
 
 	// check if the repository has a suitable release branch
-	if found, remotes, err := repository.HasBranch(Release); err != nil {
+	if found, branches, err := repository.HasBranch(Release); err != nil {
 		return err
 	} else if !found {
-		return fmt.Errorf("repository does not have a '%v' branch to finish", Release)
-	} else if len(remotes) > 1 {
-		return fmt.Errorf("repository must not have multiple '%v' branches", Release)
-	} else if version, err := ParseVersion(remotes[0]); err != nil {
-		return err
+		return Error(MsgNoBranchToFinish, Release)
+	} else if len(branches) > 1 {
+		return Error(MsgMultipleBranches, Release)
 	} else {
-		releaseVersion = version
+		releaseBranch = branches[0]
 	}
 
-	// checkout release branch
-	if err := repository.CheckoutBranch(releaseVersion.BranchName(Release)); err != nil {
+	// a merge request pipeline finishes the release branch of its merge request only
+	if err := checkMergeRequestBranch(releaseBranch.Name); err != nil {
 		return err
 	}
 
-	// checkout production branch
-	if err := repository.CheckoutBranch(Production.String()); err != nil {
-		return err
+	// protected branches are merged by pull or merge requests, which need the API of the hosting service and the
+	// pushed release branch
+	if ViaPullRequest {
+		if err := checkViaPullRequest(repository); err != nil {
+			return err
+		}
 	}
 
-	// merge release branch into current production branch (with merge commit --no-ff git flag)
-	if err := repository.MergeBranch(releaseVersion.BranchName(Release), NoFastForward); err != nil {
-		if err := handleVersionFileMergeConflict(plugin, repository, Theirs); err != nil {
+	provider := hostingProviderOf(repository)
+	publish, draft := publishing(provider)
+	if publish {
+		if err := checkPublish(repository, provider, draft); err != nil {
 			return err
 		}
 	}
 
-	// tag last commit with the release version number
-	if err := repository.TagCommit(releaseVersion.String()); err != nil {
-		return repository.Rollback(err)
-	}
+	state := newWorkflowState(Release, releaseBranch, "")
+	state.MergeProduction = FastForwardDevelop
+	state.ViaPullRequest = ViaPullRequest
 
-	// checkout develop branch
-	if err := repository.CheckoutBranch(Development.String()); err != nil {
-		return repository.Rollback(err)
+	// branches which accept changes by pull requests only are finished with --via-pr
+	if !ViaPullRequest {
+		if err := checkBranchPermissions(repository, state); err != nil {
+			return err
+		}
 	}
+	state.Publish, state.Draft = publish, draft
+	return runWorkflow(plugin, repository, state, finishSteps(plugin, repository, state))
+}
 
-	// merge release branch into current develop branch (with merge commit --no-ff git flag)
-	if err := repository.MergeBranch(releaseVersion.BranchName(Release), NoFastForward); err != nil {
-		return repository.Rollback(err)
+// Steps of the release finish command, which can be resumed from the state of an interrupted run.
+func releaseFinishSteps(plugin Plugin, repository Repository, state *workflowState) []workflowStep {
+	steps := []workflowStep{
+		{
+			name:        "checkout-release",
+			required:    true,
+			description: "checkout the release branch",
+			execute: func() error {
+				return checkoutBranch(repository, state.Branch)
+			},
+		},
+		updateChangelogStep(plugin, repository, state),
+		{
+			name:        "checkout-production",
+			required:    true,
+			description: "checkout the production branch",
+			execute: func() error {
+				return checkoutBranch(repository, Production.String())
+			},
+		},
+		{
+			name:        "merge-production",
+			required:    true,
+			description: "merge the release branch into the production branch with a merge commit",
+			execute: func() error {
+				if err := repository.MergeBranch(state.Branch, NoFastForward); err != nil {
+					return handleVersionFileMergeConflict(plugin, repository, state.Branch, Theirs)
+				}
+				return nil
+			},
+		},
+		{
+			name:        "remove-qualifier",
+			description: "remove a configured release qualifier or a written candidate qualifier, so that the release tag holds the released version",
+			execute: func() error {
+				return removeQualifier(plugin, repository, Release, state.version())
+			},
+		},
+		{
+			name:        "generate-sbom",
+			description: "generate the SBOM and commit it to the production branch, so that the release tag includes it",
+			execute: func() error {
+				if !sbomEnabled() {
+					return nil
+				}
+				return generateSBOM(repository, state.version())
+			},
+			undo: repository.Rollback,
+		},
+		{
+			name:        "pre-tag-hook",
+			description: "run the configured pre-tag hook on the commit to be tagged, which vetoes the tag if it fails",
+			execute: func() error {
+				return runPreTagHook(repository, state.Version)
+			},
+			undo: repository.Rollback,
+		},
+		{
+			name:        "tag-release",
+			required:    true,
+			description: "tag the merge commit with the release version",
+			execute: func() error {
+				return repository.TagCommit(versionTag(state.Version))
+			},
+			undo: repository.Rollback,
+		},
+		{
+			name:        "tag-release-head",
+			description: "tag the head of the release branch before its deletion, if configured, e.g. '1.2.0-branchpoint'",
+			execute: func() error {
+				if len(releaseHeadTag) == 0 {
+					return nil
+				}
+				return repository.TagRef(releaseHeadTagName(state.Version), state.Branch)
+			},
+			undo: repository.Rollback,
+		},
+		{
+			name:        "checkout-development",
+			required:    true,
+			description: "checkout the development branch",
+			execute: func() error {
+				return checkoutBranch(repository, Development.String())
+			},
+			undo: repository.Rollback,
+		},
+		{
+			// the production branch fast-forwards develop if it has no commits of its own, to keep develop linear
+			// with production
+			name:        "merge-development",
+			description: "merge the release branch, or the production branch, into the development branch",
+			execute: func() error {
+				// a mainline already has the release merged into it
+				if mainline() {
+					return nil
+				}
+
+				if state.MergeProduction {
+					if err := repository.MergeBranch(Production.String(), FastForwardOrCommit); err != nil {
+						return handleVersionFileMergeConflict(plugin, repository, Production.String(), Theirs)
+					}
+					return nil
+				}
+
+				if err := repository.MergeBranch(state.Branch, NoFastForward); err != nil {
+					return repository.Rollback(err)
+				}
+				return nil
+			},
+		},
 	}
 
-	// read the current version from the project
-	current, err := plugin.ReadVersion(repository)
-	if err != nil {
-		return repository.Rollback(err)
-	}
+	steps = append(steps, nextVersionSteps(plugin, repository, state)...)
+	steps = append(steps,
+		workflowStep{
+			name:        "branch-next-version",
+			description: "deliver the next version as pull request instead of pushing it to a protected development branch",
+			execute: func() error {
+				if developmentBump != bumpPullRequest {
+					return nil
+				}
+				return branchNextVersion(repository, state.Next)
+			},
+			undo: repository.Rollback,
+		},
+	)
+
+	steps = append(steps, completionSteps(repository, state)...)
+
+	return append(steps, publishReleaseStep(repository, state), workflowStep{
+		name:        "open-pull-request",
+		description: "open the pull request of the next version once its branch has been pushed",
+		execute: func() error {
+			if developmentBump != bumpPullRequest {
+				return nil
+			}
+			return pushIfEnabled(func() error { return openBumpPullRequest(repository, state.Next) })
+		},
+	}, updateManifestsStep(repository, state))
+}
 
-	// calculate the next minor version
-	next, err := current.Next(Minor)
-	if err != nil {
-		return repository.Rollback(err)
+// Steps of the release finish command via pull requests, which merges the release branch on GitHub or GitLab
+// instead of pushing the merges to protected production and development branches. The finish stops at
+// 'wait-for-merge' until the pull requests are merged, unless it waits for the merge, and then tags the merge into
+// the production branch and delivers the next development version as pull request.
+func releaseFinishViaPullRequestSteps(plugin Plugin, repository Repository, state *workflowState) []workflowStep {
+	steps := []workflowStep{
+		{
+			name:        "checkout-release",
+			required:    true,
+			description: "checkout the release branch",
+			execute: func() error {
+				return checkoutBranch(repository, state.Branch)
+			},
+		},
+		updateChangelogStep(plugin, repository, state),
+		{
+			name:        "remove-qualifier",
+			description: "remove a configured release qualifier or a written candidate qualifier on the release branch, so that the merge holds the released version",
+			execute: func() error {
+				return removeQualifier(plugin, repository, Release, state.version())
+			},
+		},
+		{
+			name:        "push-release",
+			required:    true,
+			description: "push the release branch, which the pull requests merge",
+			execute: func() error {
+				return repository.PushChanges(state.Branch)
+			},
+		},
+		{
+			name:        "open-pull-requests",
+			required:    true,
+			description: "open the pull or merge requests of the release branch into the production and development branches",
+			execute: func() error {
+				return openPullRequests(repository, state)
+			},
+		},
+		{
+			name:        "wait-for-merge",
+			required:    true,
+			description: "wait for the merge of the pull requests, or stop until the finish is continued after the merge",
+			execute: func() error {
+				return waitForPullRequests(repository, state)
+			},
+		},
+		{
+			name:        "checkout-production",
+			required:    true,
+			description: "checkout the production branch and fast-forward it to the merge of the release",
+			execute: func() error {
+				return checkoutMergedBranch(repository, Production.String())
+			},
+		},
+		{
+			name:        "pre-tag-hook",
+			description: "run the configured pre-tag hook on the commit to be tagged, which vetoes the tag if it fails",
+			execute: func() error {
+				return runPreTagHook(repository, state.Version)
+			},
+		},
+		{
+			name:        "tag-release",
+			required:    true,
+			description: "tag the merge commit of the pull request into the production branch with the release version",
+			execute: func() error {
+				return repository.TagRef(versionTag(state.Version), state.MergeCommit)
+			},
+		},
+		{
+			name:        "checkout-development",
+			required:    true,
+			description: "checkout the development branch and fast-forward it to the merge of the release",
+			execute: func() error {
+				return checkoutMergedBranch(repository, Development.String())
+			},
+		},
 	}
 
-	// set project version to the next develop version ${major}.(${minor}+1).0-${qualifier}
-	if err := plugin.WriteVersion(repository, next.AddQualifier(plugin.VersionQualifier())); err != nil {
-		return repository.Rollback(err)
+	steps = append(steps, nextVersionSteps(plugin, repository, state)...)
+	steps = append(steps, workflowStep{
+		name:        "branch-next-version",
+		description: "deliver the next version as pull request, because the development branch is protected",
+		execute: func() error {
+			return branchNextVersion(repository, state.Next)
+		},
+	})
+
+	steps = append(steps, completionSteps(repository, state)...)
+
+	return append(steps, publishReleaseStep(repository, state), workflowStep{
+		name:        "open-pull-request",
+		description: "open the pull request of the next version once its branch has been pushed",
+		execute: func() error {
+			return openBumpPullRequest(repository, state.Next)
+		},
+	}, updateManifestsStep(repository, state))
+}
+
+// updateChangelogStep returns the step which updates the changelog on the release branch.
+func updateChangelogStep(plugin Plugin, repository Repository, state *workflowState) workflowStep {
+	return workflowStep{
+		name:        "update-changelog",
+		description: "update the changelog and commit it to the release branch before it is merged, if enabled",
+		execute: func() error {
+			if !changelogEnabled {
+				return nil
+			}
+			return updateChangelog(plugin, repository, state.Branch, state.version())
+		},
+		undo: repository.Rollback,
 	}
+}
 
-	// perform a git commit with a commit message
-	if err := repository.CommitChanges("Set next minor project version."); err != nil {
-		return repository.Rollback(err)
+// nextVersionSteps returns the steps which calculate and commit the next development version on the checked out
+// development branch.
+func nextVersionSteps(plugin Plugin, repository Repository, state *workflowState) []workflowStep {
+	return []workflowStep{
+		{
+			name:        "next-version",
+			description: "calculate the next minor version from the current version of the project",
+			execute: func() error {
+				current, err := plugin.ReadVersion(repository)
+				if err != nil {
+					return err
+				}
+
+				next, err := Scheme().Next(current, Minor)
+				if err != nil {
+					return err
+				}
+
+				state.Next = next.AddQualifier(Qualifier(plugin, Development)).String()
+				return nil
+			},
+			undo: repository.Rollback,
+		},
+		{
+			// the changes of the hook are part of the commit of the next version
+			name:        "commit-next-version",
+			description: "set and commit the next development version ${major}.(${minor}+1).0-${qualifier}",
+			execute: func() error {
+				next, err := ParseVersion(state.Next)
+				if err != nil {
+					return err
+				}
+
+				if err := plugin.WriteVersion(repository, next); err != nil {
+					return err
+				}
+
+				if err := GlobalHooks.ExecuteHook(plugin, ReleaseFinishHooks.AfterUpdateProjectVersionHook, repository); err != nil {
+					return err
+				}
+
+				return repository.CommitChanges(nextVersionSubject)
+			},
+			undo: repository.Rollback,
+		},
 	}
+}
 
-	// delete the release branch locally
-	if err := repository.DeleteBranch(releaseVersion.BranchName(Release)); err != nil {
-		return repository.Rollback(err)
+// updateManifestsStep returns the step which updates the deployment manifests of the GitOps repository.
+func updateManifestsStep(repository Repository, state *workflowState) workflowStep {
+	return workflowStep{
+		name:        "update-manifests",
+		description: "update the deployment manifests of the GitOps repository to the release, if configured under 'gitops'",
+		execute: func() error {
+			if !gitopsEnabled() {
+				return nil
+			}
+			return pushIfEnabled(func() error { return updateManifests(repository, state.version()) })
+		},
 	}
+}
+
+// Run the hotfix finish command for the standard workflow, or for a maintenance line with a support branch.
+func hotfixFinish(plugin Plugin, repository Repository, line string) error {
+	var hotfixBranch BranchInfo
 
-	// push all branches to remotes
-	if err := pushIfEnabled(repository.PushAllChanges); err != nil {
+	// check if the maintenance line has a suitable hotfix branch
+	if branches, err := hotfixBranches(repository, line); err != nil {
 		return err
+	} else if len(branches) == 0 {
+		return Error(MsgNoBranchToFinish, Hotfix)
+	} else if len(branches) > 1 {
+		return Error(MsgMultipleBranches, Hotfix)
+	} else {
+		hotfixBranch = branches[0]
 	}
 
-	// push all tags to remotes
-	if err := pushIfEnabled(repository.PushAllTags); err != nil {
+	// a merge request pipeline finishes the hotfix branch of its merge request only
+	if err := checkMergeRequestBranch(hotfixBranch.Name); err != nil {
 		return err
 	}
 
-	// delete the release branch remotely
-	if err := pushIfEnabled(func() error { return repository.PushDeletion(releaseVersion.BranchName(Release)) }); err != nil {
-		return err
+	// protected branches are merged by pull or merge requests, which need the API of the hosting service and the
+	// pushed hotfix branch
+	if ViaPullRequest {
+		if err := checkViaPullRequest(repository); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	state := newWorkflowState(Hotfix, hotfixBranch, line)
+	state.ViaPullRequest = ViaPullRequest
+
+	// branches which accept changes by pull requests only are finished with --via-pr
+	if !ViaPullRequest {
+		if err := checkBranchPermissions(repository, state); err != nil {
+			return err
+		}
+	}
+	return runWorkflow(plugin, repository, state, finishSteps(plugin, repository, state))
 }
 
-// Run the release finish command for the standard workflow.
-func hotfixFinish(plugin Plugin, repository Repository) error {
-	var hotfixVersion Version
+// Steps of the hotfix finish command, which can be resumed from the state of an interrupted run.
+func hotfixFinishSteps(plugin Plugin, repository Repository, state *workflowState) []workflowStep {
+	steps := []workflowStep{
+		{
+			name:        "checkout-hotfix",
+			required:    true,
+			description: "checkout the hotfix branch",
+			execute: func() error {
+				return checkoutBranch(repository, state.Branch)
+			},
+		},
+	}
 
-	// check if the repository has a suitable hotfix branch
-	if found, remotes, err := repository.HasBranch(Hotfix); err != nil {
-		return err
-	} else if !found {
-		return fmt.Errorf("repository does not have a '%v' branch to finish", Hotfix)
-	} else if len(remotes) > 1 {
-		return fmt.Errorf("repository must not have multiple '%v' branches", Hotfix)
-	} else if version, err := ParseVersion(remotes[0]); err != nil {
-		return err
-	} else {
-		hotfixVersion = version
+	// hotfixes of a mainline are tagged on the hotfix branch and merged afterwards, because the mainline carries the
+	// next development version
+	if len(state.Line) > 0 || !mainline() {
+		steps = append(steps, hotfixMergeSteps(repository, state)...)
 	}
 
-	// checkout hotfix branch
-	if err := repository.CheckoutBranch(hotfixVersion.BranchName(Hotfix)); err != nil {
-		return err
+	steps = append(steps,
+		workflowStep{
+			name:        "remove-qualifier",
+			description: "remove a configured hotfix qualifier, so that the hotfix tag holds the released version",
+			execute: func() error {
+				return removeQualifier(plugin, repository, Hotfix, state.version())
+			},
+		},
+		workflowStep{
+			name:        "pre-tag-hook",
+			description: "run the configured pre-tag hook on the commit to be tagged, which vetoes the tag if it fails",
+			execute: func() error {
+				return runPreTagHook(repository, state.Version)
+			},
+			undo: repository.Rollback,
+		},
+		workflowStep{
+			name:        "tag-hotfix",
+			required:    true,
+			description: "tag the merge commit, or the hotfix branch on a mainline, with the hotfix version",
+			execute: func() error {
+				return repository.TagCommit(versionTag(state.Version))
+			},
+			undo: repository.Rollback,
+		},
+	)
+
+	// hotfixes of a maintenance line must not touch the release and development branches
+	if len(state.Line) > 0 {
+		return append(steps, completionSteps(repository, state)...)
 	}
 
-	// checkout production branch
-	if err := repository.CheckoutBranch(Production.String()); err != nil {
-		return err
+	// the hooks update the development branch from the production branch, which is the same branch on a mainline
+	afterMergeHook := HotfixFinishHooks.AfterMergeIntoDevelopmentHook
+	if mainline() {
+		afterMergeHook = ""
 	}
 
-	// merge hotfix branch into current production branch (with merge commit --no-ff git flag)
-	if err := repository.MergeBranch(hotfixVersion.BranchName(Hotfix), NoFastForward); err != nil {
-		return repository.Rollback(err)
+	steps = append(steps,
+		workflowStep{
+			name:        "checkout-release",
+			description: "checkout the release branch, if there is one, to merge the hotfix into it",
+			execute: func() error {
+				state.ReleaseBranch = ""
+				if found, branches, err := repository.HasBranch(Release); err != nil {
+					return err
+				} else if !found || len(branches) != 1 {
+					return nil
+				} else {
+					state.ReleaseBranch = branches[0].Name
+				}
+				return checkoutBranch(repository, state.ReleaseBranch)
+			},
+			undo: repository.Rollback,
+		},
+		workflowStep{
+			name:        "merge-release",
+			description: "merge the hotfix branch into the release branch with a merge commit",
+			execute: func() error {
+				if len(state.ReleaseBranch) == 0 {
+					return nil
+				}
+				if err := repository.MergeBranch(state.Branch, NoFastForward); err != nil {
+					return handleVersionFileMergeConflict(plugin, repository, state.Branch, Ours)
+				}
+				return nil
+			},
+		},
+		workflowStep{
+			name:        "checkout-development",
+			required:    true,
+			description: "checkout the development branch",
+			execute: func() error {
+				return checkoutBranch(repository, Development.String())
+			},
+			undo: repository.Rollback,
+		},
+		workflowStep{
+			name:        "merge-development",
+			description: "merge the hotfix branch into the development branch with a merge commit",
+			execute: func() error {
+				if err := repository.MergeBranch(state.Branch, NoFastForward); err != nil {
+					return handleVersionFileMergeConflict(plugin, repository, state.Branch, Ours)
+				}
+				return nil
+			},
+		},
+		workflowStep{
+			name:        "after-merge-hook",
+			description: "update the development branch after the merge of the hotfix",
+			hook:        afterMergeHook,
+			undo:        repository.Rollback,
+		},
+	)
+
+	return append(steps, completionSteps(repository, state)...)
+}
+
+// Steps of the hotfix finish command via pull requests, which merges the hotfix branch on GitHub or GitLab into the
+// production or support branch, and into the release and development branches of the standard workflow, instead of
+// pushing the merges to protected branches. The finish stops at 'wait-for-merge' until the pull requests are merged,
+// unless it waits for the merge, and then tags the merge into the production or support branch.
+func hotfixFinishViaPullRequestSteps(plugin Plugin, repository Repository, state *workflowState) []workflowStep {
+	steps := []workflowStep{
+		{
+			name:        "checkout-hotfix",
+			required:    true,
+			description: "checkout the hotfix branch",
+			execute: func() error {
+				return checkoutBranch(repository, state.Branch)
+			},
+		},
+		{
+			name:        "remove-qualifier",
+			description: "remove a configured hotfix qualifier on the hotfix branch, so that the merge holds the released version",
+			execute: func() error {
+				return removeQualifier(plugin, repository, Hotfix, state.version())
+			},
+		},
+		{
+			name:        "push-hotfix",
+			required:    true,
+			description: "push the hotfix branch, which the pull requests merge",
+			execute: func() error {
+				return repository.PushChanges(state.Branch)
+			},
+		},
+		{
+			name:        "open-pull-requests",
+			required:    true,
+			description: "open the pull or merge requests of the hotfix branch into the production or support branch, and into the release and development branches",
+			execute: func() error {
+				state.ReleaseBranch = ""
+				if found, branches, err := repository.HasBranch(Release); err != nil {
+					return err
+				} else if found && len(branches) == 1 {
+					state.ReleaseBranch = branches[0].Name
+				}
+				return openPullRequests(repository, state)
+			},
+		},
+		{
+			name:        "wait-for-merge",
+			required:    true,
+			description: "wait for the merge of the pull requests, or stop until the finish is continued after the merge",
+			execute: func() error {
+				return waitForPullRequests(repository, state)
+			},
+		},
+		{
+			name:        "checkout-base",
+			required:    true,
+			description: "checkout the production or support branch and fast-forward it to the merge of the hotfix",
+			execute: func() error {
+				return checkoutMergedBranch(repository, hotfixBase(state.Line))
+			},
+		},
+		{
+			name:        "pre-tag-hook",
+			description: "run the configured pre-tag hook on the commit to be tagged, which vetoes the tag if it fails",
+			execute: func() error {
+				return runPreTagHook(repository, state.Version)
+			},
+		},
+		{
+			name:        "tag-hotfix",
+			required:    true,
+			description: "tag the merge commit of the pull request into the production or support branch with the hotfix version",
+			execute: func() error {
+				return repository.TagRef(versionTag(state.Version), state.MergeCommit)
+			},
+		},
 	}
 
-	// tag last commit with the hotfix version number
-	if err := repository.TagCommit(hotfixVersion.String()); err != nil {
-		return repository.Rollback(err)
+	return append(steps, completionSteps(repository, state)...)
+}
+
+// hotfixMergeSteps returns the steps which merge the hotfix branch into the production or support branch before it
+// is tagged.
+func hotfixMergeSteps(repository Repository, state *workflowState) []workflowStep {
+	return []workflowStep{
+		{
+			name:        "checkout-base",
+			required:    true,
+			description: "checkout the production or support branch",
+			execute: func() error {
+				return checkoutBranch(repository, hotfixBase(state.Line))
+			},
+		},
+		{
+			name:        "merge-base",
+			required:    true,
+			description: "merge the hotfix branch into the production or support branch with a merge commit",
+			execute: func() error {
+				return repository.MergeBranch(state.Branch, NoFastForward)
+			},
+			undo: repository.Rollback,
+		},
 	}
+}
 
-	// check if the repository has a release branch and merge hotfix into it
-	if found, remotes, err := repository.HasBranch(Release); err != nil {
-		return repository.Rollback(err)
-	} else if found && len(remotes) == 1 {
-		// checkout release branch
-		if err := repository.CheckoutBranch(remotes[0]); err != nil {
-			return repository.Rollback(err)
+// removeQualifier commits the merged version of a release or hotfix branch without its qualifier, if the type of
+// branch has a qualifier configured under '<plugin>.qualifiers', e.g. '1.2.0-rc', or if release candidates of the
+// release are tagged, whose version may have been written to the release branch, e.g. '1.2.0-rc.2'.
+func removeQualifier(plugin Plugin, repository Repository, branch Branch, release Version) error {
+	if Qualifier(plugin, branch) == noQualifier {
+		if branch != Release {
+			return nil
 		}
-
-		// merge hotfix branch into current release branch (with merge commit --no-ff git flag)
-		if err := repository.MergeBranch(hotfixVersion.BranchName(Hotfix), NoFastForward); err != nil {
-			if err := handleVersionFileMergeConflict(plugin, repository, Ours); err != nil {
-				return err
-			}
+		if numbers, err := candidateNumbers(plugin, repository, release); err != nil {
+			return repository.Rollback(err)
+		} else if len(numbers) == 0 {
+			return nil
 		}
 	}
 
-	// checkout develop branch
-	if err := repository.CheckoutBranch(Development.String()); err != nil {
+	current, err := plugin.ReadVersion(repository)
+	if err != nil {
 		return repository.Rollback(err)
 	}
 
-	// merge hotfix branch into current develop branch
-	if err := repository.MergeBranch(hotfixVersion.BranchName(Hotfix), NoFastForward); err != nil {
-		if err := handleVersionFileMergeConflict(plugin, repository, Ours); err != nil {
-			return err
-		}
+	if current.Qualifier == noQualifier {
+		return nil
 	}
 
-	if err := GlobalHooks.ExecuteHook(plugin, HotfixFinishHooks.AfterMergeIntoDevelopmentHook, repository); err != nil {
+	if err := plugin.WriteVersion(repository, current.RemoveQualifier()); err != nil {
 		return repository.Rollback(err)
 	}
 
-	// delete the release branch locally
-	if err := repository.DeleteBranch(hotfixVersion.BranchName(Hotfix)); err != nil {
+	if err := repository.CommitChanges("Remove qualifier of the released version."); err != nil {
 		return repository.Rollback(err)
 	}
+	return nil
+}
 
-	// push all branches to remotes
-	if err := pushIfEnabled(repository.PushAllChanges); err != nil {
-		return err
+// releaseHeadTagName returns the tag of the release branch head configured under 'workflow.release-head-tag' for
+// a version, e.g. '{version}-branchpoint' for '1.2.0-branchpoint'.
+func releaseHeadTagName(version string) string {
+	return strings.ReplaceAll(releaseHeadTag, versionPlaceholder, version)
+}
+
+// Steps which complete the release and hotfix finish commands: delete the finished branch, push all changes and
+// emit the provenance statement for the tag.
+func completionSteps(repository Repository, state *workflowState) []workflowStep {
+	return []workflowStep{
+		{
+			name:        "delete-branch",
+			description: "delete the finished branch locally",
+			execute: func() error {
+				return repository.DeleteBranch(state.Branch)
+			},
+			undo: repository.Rollback,
+		},
+		{
+			name:        "fast-forward",
+			description: "fast-forward lagging local branches, which would otherwise be rejected when pushing all branches",
+			execute: func() error {
+				fastForwardBranches(repository)
+				return nil
+			},
+		},
+		{
+			name:        "push-branches",
+			description: "push all branches to the remote",
+			execute: func() error {
+				return pushIfEnabled(repository.PushAllChanges)
+			},
+		},
+		{
+			name:        "push-tags",
+			description: "push all tags to the remote",
+			execute: func() error {
+				return pushIfEnabled(repository.PushAllTags)
+			},
+		},
+		{
+			name:        "push-deletion",
+			description: "delete the finished branch remotely",
+			execute: func() error {
+				return pushBranchDeletion(repository, state.Branch)
+			},
+		},
+		{
+			name:        "emit-provenance",
+			description: "emit the provenance statement for the tag",
+			execute: func() error {
+				return emitProvenance(repository, state.version(), state.StartedOn)
+			},
+		},
 	}
+}
 
-	// push all tags to remotes
-	if err := pushIfEnabled(repository.PushAllTags); err != nil {
+// checkStartingPoint ensures that start does not run from a detached HEAD or a release, hotfix or bugfix branch
+// with local work, because the new branch must be based on the base branch (or tag) instead.
+func checkStartingPoint(repository Repository, branch Branch, base string) error {
+	current, err := repository.CurrentBranch()
+	if err != nil {
 		return err
 	}
 
-	// delete the hotfix branch remotely
-	if err := pushIfEnabled(func() error { return repository.PushDeletion(hotfixVersion.BranchName(Hotfix)) }); err != nil {
-		return err
+	// a detached HEAD gives no hint which work the user is about to leave behind
+	if len(current) == 0 {
+		return Error(MsgDetachedHead, branch)
+	}
+
+	// start switches to the base branch itself, which is only safe if nothing gets lost on the way
+	for _, workBranch := range []Branch{Release, Hotfix, Bugfix} {
+		if current != workBranch.String() && !strings.HasPrefix(current, workBranch.String()+"/") {
+			continue
+		}
+
+		if unpushed, err := repository.UnpushedCommits(current); err != nil {
+			return err
+		} else if unpushed > 0 {
+			return Error(MsgUnpushedWorkBranch, current, unpushed, branch)
+		}
+
+		fmt.Fprintln(os.Stderr, Message(MsgSwitchingToBase, current, base, branch))
+	}
+
+	return nil
+}
+
+// versionConflictsOnly reports whether all merge conflicts are single conflicts of the version file or of the files
+// of the additional version locations, which are resolved automatically.
+func versionConflictsOnly(plugin Plugin, mergeConflictsMap map[string][]ConflictMap) bool {
+	if len(mergeConflictsMap) == 0 {
+		return false
 	}
 
+	files := versionFiles(plugin)
+	for file, conflicts := range mergeConflictsMap {
+		if len(conflicts) != 1 || !slices.Contains(files, file) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkIdentity ensures that git can resolve a committer identity in the project path before the workflow
+// creates commits. Git resolves it from the project path itself, so conditional includes and worktree-level
+// configuration apply to workflow commits exactly as to manual commits.
+func checkIdentity(repository Repository) error {
+	if _, err := repository.Identity(); err != nil {
+		return Error(MsgIdentityMissing, repository.Local(), err)
+	}
 	return nil
 }
 
 // handleVersionFileMergeConflict handles merge conflicts when only the version file has conflicts
-// using the specified strategy (Ours or Theirs)
-func handleVersionFileMergeConflict(plugin Plugin, repository Repository, strategy CheckoutStrategy) error {
+// using the specified strategy (Ours or Theirs). All other conflicts, or every conflict if the automatic
+// resolution is disabled, pause the workflow with the merge in progress so that a human can resolve them.
+func handleVersionFileMergeConflict(plugin Plugin, repository Repository, branchName string, strategy CheckoutStrategy) error {
 	mergeConflictsMap, err := repository.GetMergeConflicts()
 	if err != nil {
 		return repository.Rollback(err)
 	}
 
-	if len(mergeConflictsMap) == 1 && len(mergeConflictsMap[plugin.VersionFileName()]) == 1 {
-		if err := repository.CheckoutFile(plugin.VersionFileName(), strategy); err != nil {
-			return repository.Rollback(err)
-		}
+	if resolveVersionConflicts && versionConflictsOnly(plugin, mergeConflictsMap) {
+		for file := range mergeConflictsMap {
+			if err := repository.CheckoutFile(file, strategy); err != nil {
+				return repository.Rollback(err)
+			}
 
-		if err := repository.AddFile(plugin.VersionFileName()); err != nil {
-			return repository.Rollback(err)
+			if err := repository.AddFile(file); err != nil {
+				return repository.Rollback(err)
+			}
 		}
 
 		if err := repository.ContinueMerge(); err != nil {
@@ -514,5 +1398,12 @@ func handleVersionFileMergeConflict(plugin Plugin, repository Repository, strate
 		return nil
 	}
 
-	return err
+	// leave the merge in progress and hand the conflicts over to the user
+	files := make([]string, 0, len(mergeConflictsMap))
+	for file := range mergeConflictsMap {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	return Error(MsgMergeConflictPaused, branchName, strings.Join(files, ", "))
 }
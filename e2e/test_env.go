@@ -32,6 +32,16 @@ type GitTestEnv struct {
 	RemotePath string // Path to simulated remote repository
 	t          *testing.T
 	dockerMode bool
+	notation   func(version string) string
+	remote     string
+	failures   []remoteFailure
+}
+
+// remoteFailure is a rejection of pushes injected into the remote repository.
+type remoteFailure struct {
+	pattern string // shell pattern of the rejected refs
+	message string // error message of the rejection, followed by the rejected ref
+	counter string // file with the number of pushes still to reject
 }
 
 // SetupTestEnvOption configures options for SetupTestEnv
@@ -60,6 +70,18 @@ var (
 			opts.dockerMode = hasImage && mode == "docker-mode"
 		}
 	}
+	WithProjectFiles = func(files map[string]string) SetupTestEnvOption {
+		return func(opts *testEnvOptions) { opts.projectFiles = files }
+	}
+	WithVersionNotation = func(notation func(version string) string) SetupTestEnvOption {
+		return func(opts *testEnvOptions) { opts.versionNotation = notation }
+	}
+	WithRemoteName = func(name string) SetupTestEnvOption {
+		return func(opts *testEnvOptions) { opts.remoteName = name }
+	}
+	WithoutDevelopmentBranch = func() SetupTestEnvOption {
+		return func(opts *testEnvOptions) { opts.developmentBranch = "" }
+	}
 )
 
 // testEnvOptions holds the options for setting up the test environment
@@ -69,6 +91,9 @@ type testEnvOptions struct {
 	releaseBranch     string
 	hotfixBranch      string
 	dockerMode        bool
+	projectFiles      map[string]string
+	versionNotation   func(version string) string
+	remoteName        string
 }
 
 // SetupTestEnv creates test environment with local repo and simulated remote
@@ -79,6 +104,7 @@ func SetupTestEnv(t *testing.T, options ...SetupTestEnvOption) *GitTestEnv {
 	opts := &testEnvOptions{
 		productionBranch:  "main",
 		developmentBranch: "develop",
+		remoteName:        "origin",
 	}
 
 	// Apply user options
@@ -115,34 +141,61 @@ func SetupTestEnv(t *testing.T, options ...SetupTestEnvOption) *GitTestEnv {
 	require.NoError(t, cmd.Run())
 
 	// Add remote to local repository
-	cmd = exec.Command("git", "remote", "add", "origin", remotePath)
+	cmd = exec.Command("git", "remote", "add", opts.remoteName, remotePath)
 	cmd.Dir = localPath
 	require.NoError(t, cmd.Run(), "Failed to add remote to local repository")
 
+	// Assertions check English messages regardless of the locale of the host
+	t.Setenv("GITFLOW_LOCALE", "en")
+
+	// Workflow commands must not publish step outputs of the pipeline running the tests
+	t.Setenv("GITHUB_OUTPUT", "")
+
+	// Hosting API responses are cached per test, not in the cache directory of the user
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	// Prompts and output behave the same in the pipeline running the tests as on a developer machine
+	t.Setenv("GITFLOW_CI_DEFAULTS", "false")
+
 	// Create git testing environment
 	env := &GitTestEnv{
 		LocalPath:  localPath,
 		RemotePath: remotePath,
 		t:          t,
 		dockerMode: opts.dockerMode,
+		notation:   opts.versionNotation,
+		remote:     opts.remoteName,
 	}
 
 	if opts.dockerMode {
 		t.Cleanup(func() { plugin.ExecutorModeOverride = "" })
 	}
 
+	// Add the files identifying the project type to the initial commit
+	for name, content := range opts.projectFiles {
+		path := filepath.Join(localPath, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+		cmd = exec.Command("git", "add", name)
+		cmd.Dir = localPath
+		require.NoError(t, cmd.Run(), "Failed to add project file %s", name)
+	}
+
 	// Create an empty commit to initialize the production branch
 	cmd = exec.Command("git", "commit", "--allow-empty", "-m", "Initial empty commit")
 	cmd.Dir = localPath
 	require.NoError(t, cmd.Run(), "Failed to create initial empty commit")
 
 	// Push the empty production branch to remote
-	cmd = exec.Command("git", "push", "-u", "origin", opts.productionBranch)
+	cmd = exec.Command("git", "push", "-u", opts.remoteName, opts.productionBranch)
 	cmd.Dir = localPath
 	require.NoError(t, cmd.Run(), "Failed to push production branch")
 
-	// Create development branch
-	env.CreateBranch(opts.developmentBranch, opts.productionBranch)
+	// Create development branch, unless the test covers a repository without one
+	if len(opts.developmentBranch) > 0 {
+		env.CreateBranch(opts.developmentBranch, opts.productionBranch)
+	}
 
 	return env
 }
@@ -150,6 +203,12 @@ func SetupTestEnv(t *testing.T, options ...SetupTestEnvOption) *GitTestEnv {
 // SetupTestEnvWithoutDevelop creates a test environment with only the production branch (no develop).
 func SetupTestEnvWithoutDevelop(t *testing.T) *GitTestEnv {
 	t.Helper()
+	return SetupTestEnv(t, WithoutDevelopmentBranch())
+}
+
+// SetupEmptyTestEnv creates a test environment with a local repository without commits and an empty remote.
+func SetupEmptyTestEnv(t *testing.T) *GitTestEnv {
+	t.Helper()
 
 	tmpDir := t.TempDir()
 	localPath := filepath.Join(tmpDir, "local")
@@ -162,7 +221,8 @@ func SetupTestEnvWithoutDevelop(t *testing.T) *GitTestEnv {
 	cmd.Dir = remotePath
 	require.NoError(t, cmd.Run())
 
-	cmd = exec.Command("git", "init", "--initial-branch=main")
+	// the unborn branch deliberately differs from the production branch
+	cmd = exec.Command("git", "init", "--initial-branch=master")
 	cmd.Dir = localPath
 	require.NoError(t, cmd.Run())
 
@@ -178,13 +238,17 @@ func SetupTestEnvWithoutDevelop(t *testing.T) *GitTestEnv {
 	cmd.Dir = localPath
 	require.NoError(t, cmd.Run())
 
-	cmd = exec.Command("git", "commit", "--allow-empty", "-m", "Initial empty commit")
-	cmd.Dir = localPath
-	require.NoError(t, cmd.Run())
+	// Assertions check English messages regardless of the locale of the host
+	t.Setenv("GITFLOW_LOCALE", "en")
 
-	cmd = exec.Command("git", "push", "-u", "origin", "main")
-	cmd.Dir = localPath
-	require.NoError(t, cmd.Run())
+	// Workflow commands must not publish step outputs of the pipeline running the tests
+	t.Setenv("GITHUB_OUTPUT", "")
+
+	// Hosting API responses are cached per test, not in the cache directory of the user
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	// Prompts and output behave the same in the pipeline running the tests as on a developer machine
+	t.Setenv("GITFLOW_CI_DEFAULTS", "false")
 
 	return &GitTestEnv{
 		LocalPath:  localPath,
@@ -200,7 +264,7 @@ func (env *GitTestEnv) CommitTemplateContent(templateContent, fileName, version,
 	tmpl, err := template.New(fileName).Parse(templateContent)
 	require.NoError(env.t, err, "Failed to parse template for %s", fileName)
 
-	data := struct{ Version string }{Version: version}
+	data := struct{ Version string }{Version: env.versionNotation(version)}
 	var buf bytes.Buffer
 	err = tmpl.Execute(&buf, data)
 	require.NoError(env.t, err, "Failed to render template for %s", fileName)
@@ -217,6 +281,7 @@ func (env *GitTestEnv) CommitFile(name string, content []byte, commitRef string)
 
 	// Create file with content
 	path := filepath.Join(env.LocalPath, name)
+	require.NoError(env.t, os.MkdirAll(filepath.Dir(path), 0755))
 	err := os.WriteFile(path, content, 0644)
 	require.NoError(env.t, err, "Failed to create file: %s", path)
 
@@ -225,7 +290,7 @@ func (env *GitTestEnv) CommitFile(name string, content []byte, commitRef string)
 
 	env.ExecuteGit("add", path)
 	env.ExecuteGit("commit", "-m", message)
-	env.ExecuteGit("push", "-u", "origin", commitRef)
+	env.ExecuteGit("push", "-u", env.remoteName(), commitRef)
 }
 
 // CreateBranch creates a new branch from the specified base branch
@@ -246,7 +311,7 @@ func (env *GitTestEnv) CreateBranch(branch string, commitRef ...string) {
 	env.ExecuteGit("checkout", "-b", branch)
 
 	// Push to remote and set up tracking
-	env.ExecuteGit("push", "-u", "origin", branch)
+	env.ExecuteGit("push", "-u", env.remoteName(), branch)
 }
 
 // ExecuteGitflow calls the Gitflow functionality directly via the Go API
@@ -370,6 +435,63 @@ func (env *GitTestEnv) ExecuteGitflowExpectError(args ...string) string {
 	return cmdErr.Error()
 }
 
+// ExecuteGitflowStdout calls the Gitflow CLI and returns its standard output separately from standard error,
+// e.g. the result document of the JSON output mode, and the error of the command if it failed.
+func (env *GitTestEnv) ExecuteGitflowStdout(args ...string) (string, error) {
+	env.t.Helper()
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	baseArgs := []string{"gitflow-cli", "--path", env.LocalPath}
+	if env.dockerMode {
+		baseArgs = append(baseArgs, "--docker-mode")
+	}
+	os.Args = append(baseArgs, args...)
+	env.t.Logf("Executing command (stdout): gitflow-cli %s", strings.Join(os.Args[1:], " "))
+
+	stdoutReader, stdoutWriter, err := os.Pipe()
+	require.NoError(env.t, err)
+	stderrReader, stderrWriter, err := os.Pipe()
+	require.NoError(env.t, err)
+
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = stdoutWriter, stderrWriter
+
+	var stdout, stderr []byte
+	var stdoutErr, stderrErr error
+	done := make(chan struct{}, 2)
+	go func() {
+		stdout, stdoutErr = io.ReadAll(stdoutReader)
+		done <- struct{}{}
+	}()
+	go func() {
+		stderr, stderrErr = io.ReadAll(stderrReader)
+		done <- struct{}{}
+	}()
+
+	var cmdErr error
+	func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				cmdErr = fmt.Errorf("panic: %v", rec)
+			}
+		}()
+		cmdErr = ExecuteFunc()
+	}()
+
+	os.Stdout, os.Stderr = oldStdout, oldStderr
+	stdoutWriter.Close()
+	stderrWriter.Close()
+	<-done
+	<-done
+	require.NoError(env.t, stdoutErr)
+	require.NoError(env.t, stderrErr)
+
+	env.t.Logf("Command stdout:\n%s\nCommand stderr:\n%s", string(stdout), string(stderr))
+	return string(stdout), cmdErr
+}
+
 // WriteConfig writes a temporary config file outside the repo and returns its path.
 func (env *GitTestEnv) WriteConfig(content string) string {
 	env.t.Helper()
@@ -387,7 +509,7 @@ func (env *GitTestEnv) AssertBranchNotOnRemote(branch string) {
 	_, err := env.ExecuteGitAllowError("rev-parse", "--verify", branch)
 	assert.NoError(env.t, err, "Branch %s should exist locally", branch)
 	// Check remote does NOT exist
-	_, err = env.ExecuteGitAllowError("rev-parse", "--verify", "origin/"+branch)
+	_, err = env.ExecuteGitAllowError("rev-parse", "--verify", env.remoteName()+"/"+branch)
 	assert.Error(env.t, err, "Branch %s should NOT exist on remote", branch)
 }
 
@@ -398,10 +520,71 @@ func (env *GitTestEnv) AssertTagNotOnRemote(tag string) {
 	_, err := env.ExecuteGitAllowError("rev-parse", "--verify", tag)
 	assert.NoError(env.t, err, "Tag %s should exist locally", tag)
 	// Check remote tag does NOT exist
-	output, _ := env.ExecuteGitAllowError("ls-remote", "--tags", "origin", tag)
+	output, _ := env.ExecuteGitAllowError("ls-remote", "--tags", env.remoteName(), tag)
 	assert.Empty(env.t, strings.TrimSpace(output), "Tag %s should NOT exist on remote", tag)
 }
 
+// ProtectBranches simulates protected branches of a hosting service with a pre-receive hook in the remote
+// repository, which rejects every push that creates, updates or deletes one of the branches.
+func (env *GitTestEnv) ProtectBranches(branches ...string) {
+	env.t.Helper()
+	for _, branch := range branches {
+		env.injectRemoteFailure("refs/heads/"+branch, "protected branch", 0)
+	}
+}
+
+// RejectPushes injects a failure into the remote repository, which rejects the next pushes of refs matching a shell
+// pattern, e.g. 'refs/heads/develop' or 'refs/tags/*'. A push is rejected as a whole, like by a pre-receive hook of
+// a hosting service. With times greater than zero, only that many pushes are rejected, so that a retried or
+// resumed command succeeds; otherwise every push is rejected.
+func (env *GitTestEnv) RejectPushes(pattern string, times int) {
+	env.t.Helper()
+	env.injectRemoteFailure(pattern, "injected failure: push rejected for", times)
+}
+
+// SetRemoteReadOnly makes the remote repository reject every push, while fetching from it still succeeds.
+func (env *GitTestEnv) SetRemoteReadOnly() {
+	env.t.Helper()
+	env.injectRemoteFailure("*", "read-only remote repository: push rejected for", 0)
+}
+
+// ClearRemoteFailures removes all failures injected into the remote repository.
+func (env *GitTestEnv) ClearRemoteFailures() {
+	env.t.Helper()
+	env.failures = nil
+	require.NoError(env.t, os.RemoveAll(filepath.Join(env.RemotePath, "hooks", "pre-receive")))
+}
+
+// injectRemoteFailure adds a rejection of the refs matching a pattern to the pre-receive hook of the remote
+// repository. The hook keeps the number of pushes still to reject in a file per failure, negative for all.
+func (env *GitTestEnv) injectRemoteFailure(pattern, message string, times int) {
+	env.t.Helper()
+
+	if times <= 0 {
+		times = -1
+	}
+	counter := filepath.Join(env.t.TempDir(), "remaining")
+	require.NoError(env.t, os.WriteFile(counter, []byte(fmt.Sprintf("%d\n", times)), 0644))
+	env.failures = append(env.failures, remoteFailure{pattern: pattern, message: message, counter: counter})
+
+	var hook strings.Builder
+	hook.WriteString("#!/bin/sh\nwhile read old new ref; do\n")
+	for i, failure := range env.failures {
+		fmt.Fprintf(&hook, "  case \"$ref\" in %s) rejected%d=\"$ref\";; esac\n", failure.pattern, i)
+	}
+	hook.WriteString("done\n")
+	for i, failure := range env.failures {
+		fmt.Fprintf(&hook, "remaining=$(cat '%s')\n", failure.counter)
+		fmt.Fprintf(&hook, "if [ -n \"$rejected%d\" ] && [ \"$remaining\" != 0 ]; then\n", i)
+		fmt.Fprintf(&hook, "  [ \"$remaining\" -gt 0 ] && echo $((remaining - 1)) > '%s'\n", failure.counter)
+		fmt.Fprintf(&hook, "  echo \"%s $rejected%d\" >&2\n  exit 1\nfi\n", failure.message, i)
+	}
+
+	hookPath := filepath.Join(env.RemotePath, "hooks", "pre-receive")
+	require.NoError(env.t, os.MkdirAll(filepath.Dir(hookPath), 0755))
+	require.NoError(env.t, os.WriteFile(hookPath, []byte(hook.String()), 0755))
+}
+
 // ExecuteGit runs a git command in the local repository
 func (env *GitTestEnv) ExecuteGit(args ...string) string {
 	env.t.Helper()
@@ -481,6 +664,8 @@ func (env *GitTestEnv) AssertTemplateVersionEquals(templateContent, fileName, ex
 		commitRef = fmt.Sprintf("%s~%d", commitRef, depth[0])
 	}
 
+	expectedVersion = env.versionNotation(expectedVersion)
+
 	// Simple template: content is just "{{.Version}}"
 	if strings.TrimSpace(templateContent) == "{{.Version}}" {
 		actual := strings.TrimSpace(env.ExecuteGit("show", fmt.Sprintf("%s:%s", commitRef, fileName)))
@@ -525,6 +710,23 @@ func (env *GitTestEnv) AssertTemplateVersionEquals(templateContent, fileName, ex
 		"Version in %s at %s should be '%s' but was '%s'", fileName, commitRef, expectedVersion, actualVersion)
 }
 
+// versionNotation returns a version as written to the version file, which differs from the semantic version for
+// plugins with their own notation of qualifiers, e.g. '1.2.0.dev' for Ruby gems.
+// remoteName returns the name of the remote repository, which is 'origin' unless configured with WithRemoteName.
+func (env *GitTestEnv) remoteName() string {
+	if len(env.remote) == 0 {
+		return "origin"
+	}
+	return env.remote
+}
+
+func (env *GitTestEnv) versionNotation(version string) string {
+	if env.notation == nil {
+		return version
+	}
+	return env.notation(version)
+}
+
 // getCommitMessage gets the message of a specific commit
 func (env *GitTestEnv) getCommitMessage(commitRef string, depth ...int) string {
 	env.t.Helper()
@@ -0,0 +1,56 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+)
+
+func RunReleaseActionOutputs(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	// GitHub Actions collects the step outputs in the file of GITHUB_OUTPUT
+	startOutput := filepath.Join(t.TempDir(), "start-output")
+	t.Setenv("GITHUB_OUTPUT", startOutput)
+	env.ExecuteGitflow("release", "start")
+
+	content, err := os.ReadFile(startOutput)
+	assert.NoError(t, err)
+	assert.Equal(t, "branch=release/1.1.0\nversion=1.1.0\ntag=\n", string(content))
+
+	finishOutput := filepath.Join(t.TempDir(), "finish-output")
+	t.Setenv("GITHUB_OUTPUT", finishOutput)
+	env.ExecuteGitflow("release", "finish")
+
+	content, err = os.ReadFile(finishOutput)
+	assert.NoError(t, err)
+	assert.Equal(t, "branch=release/1.1.0\nversion=1.1.0\ntag=1.1.0\n", string(content))
+}
+
+func RunHotfixActionOutputs(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	output := filepath.Join(t.TempDir(), "output")
+	t.Setenv("GITHUB_OUTPUT", output)
+	env.ExecuteGitflow("hotfix", "start")
+
+	content, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Equal(t, "branch=hotfix/1.0.1\nversion=1.0.1\ntag=\n", string(content))
+}
@@ -0,0 +1,160 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+)
+
+// bitbucketServer simulates the pull requests and branch restrictions of the APIs of Bitbucket Cloud, below '/2.0',
+// and of a Bitbucket Server: opened pull requests are numbered in the order they are opened and stay open until they
+// are merged. Like servers before version 7, the server reports no merge commits.
+type bitbucketServer struct {
+	*httptest.Server
+	mutex        sync.Mutex
+	opened       []string
+	merged       map[string]string
+	restrictions string
+	credentials  []string
+}
+
+// newBitbucketServer starts the simulated Bitbucket APIs and configures the token of the API.
+func newBitbucketServer(t *testing.T) *bitbucketServer {
+	t.Helper()
+
+	server := &bitbucketServer{merged: map[string]string{}, restrictions: `{"values":[]}`}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+
+		server.credentials = append(server.credentials, r.Header.Get("Authorization"))
+		cloud := strings.HasPrefix(r.URL.Path, "/2.0/repositories/workspace/repo/")
+
+		switch resource := path.Base(r.URL.Path); {
+		case resource == "branch-restrictions" || resource == "restrictions":
+			_, _ = w.Write([]byte(server.restrictions))
+
+		case r.Method == http.MethodPost && cloud:
+			var pullRequest struct {
+				Source      struct{ Branch struct{ Name string } }
+				Destination struct{ Branch struct{ Name string } }
+			}
+			_ = json.NewDecoder(r.Body).Decode(&pullRequest)
+			server.opened = append(server.opened, pullRequest.Source.Branch.Name+" -> "+pullRequest.Destination.Branch.Name)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"id":%v}`, len(server.opened))
+
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/rest/api/1.0/projects/PROJ/repos/repo/"):
+			var pullRequest struct {
+				FromRef struct{ ID string }
+				ToRef   struct{ ID string }
+			}
+			_ = json.NewDecoder(r.Body).Decode(&pullRequest)
+			server.opened = append(server.opened, strings.TrimPrefix(pullRequest.FromRef.ID, "refs/heads/")+" -> "+
+				strings.TrimPrefix(pullRequest.ToRef.ID, "refs/heads/"))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"id":%v}`, len(server.opened))
+
+		case len(server.merged[resource]) > 0:
+			_, _ = w.Write([]byte(`{"state":"MERGED"}`))
+
+		default:
+			_, _ = w.Write([]byte(`{"state":"OPEN"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	clearGitLabEnv(t)
+	t.Setenv("BITBUCKET_TOKEN", "bitbucket-token")
+	t.Setenv("BITBUCKET_USERNAME", "")
+	t.Setenv("BITBUCKET_REPO_FULL_NAME", "")
+	return server
+}
+
+// config writes the configuration of the API of Bitbucket Cloud or of the Bitbucket Server.
+func (s *bitbucketServer) config(env *e2e.GitTestEnv, cloud bool) string {
+	if cloud {
+		return env.WriteConfig(fmt.Sprintf("bitbucket:\n  api-url: %q\n  repository: workspace/repo\n", s.URL+"/2.0"))
+	}
+	return env.WriteConfig(fmt.Sprintf("bitbucket:\n  api-url: %q\n  repository: PROJ/repo\n", s.URL))
+}
+
+// merge merges a branch into the target branch of a pull request on the remote, as Bitbucket does.
+func (s *bitbucketServer) merge(env *e2e.GitTestEnv, number int, branch, base string) string {
+	commit := mergeOnRemote(env, branch, base, fmt.Sprintf("Merge pull request #%v", number))
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.merged[fmt.Sprint(number)] = commit
+	return commit
+}
+
+// pullRequests returns the source and target branches of the opened pull requests.
+func (s *bitbucketServer) pullRequests() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string{}, s.opened...)
+}
+
+func RunReleaseFinishViaBitbucketPullRequest(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+	server := newBitbucketServer(t)
+	configPath := server.config(env, false)
+
+	errMsg := env.ExecuteGitflowExpectError("release", "finish", "--via-pr", "--config", configPath)
+	assert.Contains(t, errMsg, "pull request(s) #1, #2 of 'release/1.1.0' are not merged yet")
+	assert.Equal(t, []string{"release/1.1.0 -> main", "release/1.1.0 -> develop"}, server.pullRequests())
+	assert.Equal(t, "Bearer bitbucket-token", server.credentials[0])
+
+	production := server.merge(env, 1, "release/1.1.0", "main")
+	server.merge(env, 2, "release/1.1.0", "develop")
+	env.ExecuteGitflow("release", "continue", "--config", configPath)
+
+	// without merge commit of the server, the merged production branch on the remote is tagged
+	assert.Equal(t, production, strings.TrimSpace(env.ExecuteGit("rev-parse", "1.1.0^{commit}")))
+	assert.NotEmpty(t, env.ExecuteGit("ls-remote", "--tags", "origin", "1.1.0"))
+}
+
+func RunReleaseFinishBitbucketRestricted(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+	server := newBitbucketServer(t)
+	server.restrictions = `{"values":[
+		{"kind":"push","branch_match_kind":"glob","pattern":"release/*","users":[{"uuid":"{bot}"}],"groups":[]},
+		{"kind":"push","branch_match_kind":"branching_model","branch_type":"development","users":[],"groups":[]}]}`
+	configPath := server.config(env, true)
+
+	// the restricted development branch fails the finish before anything is merged
+	errMsg := env.ExecuteGitflowExpectError("release", "finish", "--config", configPath)
+	assert.Contains(t, errMsg, "branch 'develop' accepts changes by pull requests only on Bitbucket: finish with --via-pr")
+	assert.Empty(t, env.ExecuteGit("tag", "--list", "1.1.0"))
+	env.AssertCurrentBranchEquals("release/1.1.0")
+
+	env.ExecuteGitflowExpectError("release", "finish", "--via-pr", "--config", configPath)
+	assert.Equal(t, []string{"release/1.1.0 -> main", "release/1.1.0 -> develop"}, server.pullRequests())
+}
+
+func RunReleaseFinishPublishBitbucket(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+	server := newBitbucketServer(t)
+
+	errMsg := env.ExecuteGitflowExpectError("release", "finish", "--publish", "--config", server.config(env, false))
+
+	assert.Contains(t, errMsg, "Bitbucket has no releases: finish the release without --publish")
+	assert.Empty(t, env.ExecuteGit("tag", "--list", "1.1.0"))
+}
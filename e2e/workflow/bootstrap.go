@@ -0,0 +1,67 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+)
+
+func RunBootstrap(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupEmptyTestEnv(t)
+
+	env.ExecuteGitflow("bootstrap", "standard")
+
+	env.AssertCurrentBranchEquals("develop")
+	env.AssertCommitMessageEquals("Bootstrap project with version 0.1.0.", "main")
+	env.AssertTagEquals("0.1.0", "main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "0.1.0", "main")
+	env.AssertCommitMessageEquals("Set next minor project version.", "develop")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "0.2.0-dev", "develop")
+
+	config := env.ExecuteGit("show", "main:.gitflow-cli.yaml")
+	assert.Contains(t, config, "production: main")
+	assert.Contains(t, config, "development: develop")
+
+	output := env.ExecuteGit("ls-remote", "origin")
+	assert.Contains(t, output, "refs/heads/main")
+	assert.Contains(t, output, "refs/heads/develop")
+	assert.Contains(t, output, "refs/tags/0.1.0")
+}
+
+func RunBootstrapInitialVersion(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupEmptyTestEnv(t)
+
+	env.ExecuteGitflow("bootstrap", "standard", "--initial-version", "1.0.0")
+
+	env.AssertTagEquals("1.0.0", "main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+}
+
+func RunBootstrapExistingProject(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	output := env.ExecuteGitflowExpectError("bootstrap", "standard")
+
+	assert.Contains(t, output, "already has commits")
+	env.AssertCommitMessageEquals("Initial empty commit", "main")
+}
+
+func RunBootstrapUnknownTemplate(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupEmptyTestEnv(t)
+
+	output := env.ExecuteGitflowExpectError("bootstrap", "gradle")
+
+	assert.Contains(t, output, "template 'gradle' is not available")
+	_, err := env.ExecuteGitAllowError("rev-parse", "--verify", "HEAD")
+	assert.Error(t, err)
+}
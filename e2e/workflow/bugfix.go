@@ -0,0 +1,116 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+)
+
+func RunBugfixStart(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	env.ExecuteGitflow("bugfix", "start", "login-timeout")
+
+	env.AssertCurrentBranchEquals("bugfix/login-timeout")
+	env.AssertCommitMessageEquals("Set up test precondition for develop branch", "bugfix/login-timeout")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0-dev", "bugfix/login-timeout")
+
+	output := env.ExecuteGit("ls-remote", "--heads", "origin", "bugfix/login-timeout")
+	assert.Contains(t, output, "refs/heads/bugfix/login-timeout")
+}
+
+func RunBugfixStartExistingBranch(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CreateBranch("bugfix/login-timeout", "develop")
+	env.ExecuteGit("checkout", "develop")
+
+	errMsg := env.ExecuteGitflowExpectError("bugfix", "start", "login-timeout")
+
+	assert.Contains(t, errMsg, "already has a bugfix branch 'bugfix/login-timeout'")
+}
+
+func RunBugfixFinish(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	env.CreateBranch("bugfix/login-timeout", "develop")
+	env.CommitFile("fix.txt", []byte("fixed"), "bugfix/login-timeout")
+
+	env.ExecuteGitflow("bugfix", "finish")
+
+	env.AssertCommitMessageEquals("Merge branch 'bugfix/login-timeout' into develop", "develop")
+	assert.Equal(t, "fixed", env.ExecuteGit("show", "develop:fix.txt"))
+	env.AssertCommitMessageEquals("Set up test precondition for main branch", "main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	env.AssertBranchDoesNotExist("bugfix/login-timeout")
+	env.AssertCurrentBranchEquals("develop")
+
+	output := env.ExecuteGit("ls-remote", "--heads", "origin", "bugfix/login-timeout")
+	assert.Empty(t, output)
+}
+
+func RunBugfixFinishNamed(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+
+	env.CreateBranch("bugfix/login-timeout", "develop")
+	env.CommitFile("login.txt", []byte("fixed"), "bugfix/login-timeout")
+	env.CreateBranch("bugfix/typo", "develop")
+	env.CommitFile("typo.txt", []byte("fixed"), "bugfix/typo")
+
+	errMsg := env.ExecuteGitflowExpectError("bugfix", "finish")
+	assert.Contains(t, errMsg, "multiple bugfix branches (bugfix/login-timeout, bugfix/typo)")
+
+	env.ExecuteGitflow("bugfix", "finish", "typo")
+
+	env.AssertCommitMessageEquals("Merge branch 'bugfix/typo' into develop", "develop")
+	env.AssertBranchDoesNotExist("bugfix/typo")
+	env.AssertBranchExists("bugfix/login-timeout")
+}
+
+func RunBugfixFinishUnknownName(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CreateBranch("bugfix/login-timeout", "develop")
+
+	errMsg := env.ExecuteGitflowExpectError("bugfix", "finish", "typo")
+
+	assert.Contains(t, errMsg, "does not have a bugfix branch 'bugfix/typo'")
+}
+
+func RunBugfixStartFetchesOnSync(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	configPath := env.WriteConfig("logging: stderr,cmdline\n")
+	output := env.ExecuteGitflow("bugfix", "start", "login-timeout", "--config", configPath)
+
+	// the remotes are fetched once when the branches are synced, the lookups of the branches read the fetched refs
+	assert.Equal(t, 1, strings.Count(output, "fetch --all"))
+	env.AssertCurrentBranchEquals("bugfix/login-timeout")
+}
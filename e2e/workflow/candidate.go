@@ -0,0 +1,62 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func RunReleaseCandidate(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+
+	env.ExecuteGitflow("release", "rc")
+	env.CommitFile("fix.txt", []byte("fix"), "release/1.1.0")
+	env.ExecuteGitflow("release", "rc")
+
+	// the candidates are numbered in order and tag the head of the release branch without changing it
+	env.AssertTagEquals("1.1.0-rc.1", "release/1.1.0~1")
+	env.AssertTagEquals("1.1.0-rc.2", "release/1.1.0")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+	assert.NotEmpty(t, env.ExecuteGit("ls-remote", "--tags", "origin", "1.1.0-rc.2"))
+
+	// candidates are no releases, the release version is still open
+	env.ExecuteGitflow("release", "finish")
+	env.AssertTagEquals("1.1.0", "main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0", "1.1.0")
+}
+
+func RunReleaseCandidateWrite(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+
+	env.ExecuteGitflow("release", "rc", "--write")
+
+	// the candidate version is committed to the release branch and tagged there
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0-rc.1", "release/1.1.0")
+	env.AssertCommitMessageEquals("Set release candidate version 1.1.0-rc.1.", "release/1.1.0")
+	env.AssertTagEquals("1.1.0-rc.1", "release/1.1.0")
+	env.ExecuteGitflow("check", "release/1.1.0")
+
+	env.ExecuteGitflow("release", "rc", "--write")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0-rc.2", "release/1.1.0")
+
+	// the finish removes the candidate qualifier, so that the release tag holds the released version
+	env.ExecuteGitflow("release", "finish")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0", "1.1.0")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0-dev", "develop")
+}
+
+func RunReleaseCandidateWithoutBranch(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	errMsg := env.ExecuteGitflowExpectError("release", "rc")
+
+	assert.Contains(t, errMsg, "repository does not have a 'release' branch to tag a release candidate of")
+}
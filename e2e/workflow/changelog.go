@@ -0,0 +1,214 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Configuration which updates the changelog on release finish.
+const changelogConfig = `changelog:
+  enabled: true
+`
+
+// Configuration which updates the changelog in the conventional-changelog format.
+const conventionalChangelogConfig = `changelog:
+  enabled: true
+  format: conventional
+`
+
+// setupChangelogRelease sets up the release 1.0.0 and conventional commits on develop for the release 1.1.0.
+func setupChangelogRelease(t *testing.T) *e2e.GitTestEnv {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	commitChanges(env, "develop", "feat(api): add the search endpoint", "chore: update modules",
+		"fix: handle empty input", "refactor!: rename the settings")
+	return env
+}
+
+func RunReleaseFinishWithChangelog(t *testing.T) {
+	t.Helper()
+	env := setupChangelogRelease(t)
+	configPath := env.WriteConfig(changelogConfig)
+
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	// the changelog is committed on the release branch, so that the tag and develop include it
+	env.AssertCommitMessageEquals("Update changelog for release 1.1.0.", "1.1.0^2")
+	changelog := env.ExecuteGit("show", "1.1.0:CHANGELOG.md")
+	assert.Equal(t, changelog, env.ExecuteGit("show", "origin/develop:CHANGELOG.md"))
+
+	assert.Contains(t, changelog, "The format is based on [Keep a Changelog]")
+	assert.Regexp(t, `(?s)## \[1\.1\.0\] - \d{4}-\d{2}-\d{2}\n\n### Added\n\n- \*\*api:\*\* add the search endpoint\n`+
+		`\n### Changed\n\n- \*\*BREAKING:\*\* rename the settings\n- Set up test precondition for develop branch\n`+
+		`\n### Fixed\n\n- handle empty input\n$`, changelog)
+
+	// chores and the commits of the workflow are no notable changes
+	assert.NotContains(t, changelog, "update modules")
+	assert.NotContains(t, changelog, "project version")
+}
+
+func RunReleaseFinishWithExistingChangelog(t *testing.T) {
+	t.Helper()
+	env := setupChangelogRelease(t)
+	configPath := env.WriteConfig(conventionalChangelogConfig)
+
+	env.CommitFile("CHANGELOG.md", []byte("# Changelog\n\n## [Unreleased]\n\n## 1.0.0 (2026-01-05)\n\n* initial release\n"), "develop")
+
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	// the section of the release is inserted below the unreleased changes and above the previous release
+	assert.Regexp(t, `(?s)^# Changelog\n\n## \[Unreleased\]\n\n## 1\.1\.0 \(\d{4}-\d{2}-\d{2}\)\n`+
+		`\n### ⚠ BREAKING CHANGES\n\n\* rename the settings\n`+
+		`\n### Features\n\n\* \*\*api:\*\* add the search endpoint\n`+
+		`\n### Bug Fixes\n\n\* handle empty input\n`+
+		`\n## 1\.0\.0 \(2026-01-05\)\n\n\* initial release\n$`, env.ExecuteGit("show", "1.1.0:CHANGELOG.md"))
+}
+
+func RunReleaseFinishWithMessageTemplates(t *testing.T) {
+	t.Helper()
+	env := setupChangelogRelease(t)
+	configPath := env.WriteConfig(changelogConfig + "  heading: Release {version} - {date}\n" +
+		"core:\n  commit:\n    message: \"chore(release): {message}\"\n")
+
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	// the commits of the workflow are formatted by the template, the changelog section by the heading template
+	env.AssertCommitMessageEquals("chore(release): Update changelog for release 1.1.0.", "1.1.0^2")
+	env.AssertCommitMessageEquals("chore(release): Set next minor project version.", "develop")
+	changelog := env.ExecuteGit("show", "1.1.0:CHANGELOG.md")
+	assert.Regexp(t, `(?s)## Release 1\.1\.0 - \d{4}-\d{2}-\d{2}\n\n### Added\n`, changelog)
+	assert.NotContains(t, changelog, "project version")
+}
+
+func RunInvalidMessageTemplates(t *testing.T) {
+	t.Helper()
+	env := setupChangelogRelease(t)
+
+	errMsg := env.ExecuteGitflowExpectError("release", "start", "--config",
+		env.WriteConfig("core:\n  commit:\n    message: Release\n"))
+	assert.Contains(t, errMsg, "setting 'core.commit.message' holds 'Release', which lacks the placeholder {message}")
+
+	errMsg = env.ExecuteGitflowExpectError("release", "start", "--config",
+		env.WriteConfig(changelogConfig+"  heading: Next release\n"))
+	assert.Contains(t, errMsg, "setting 'changelog.heading' holds 'Next release', which lacks the placeholder {version}")
+	env.AssertBranchDoesNotExist("release/1.1.0")
+}
+
+// commitProjectChanges commits a change of a file with each message on a branch and pushes it.
+func commitProjectChanges(t *testing.T, env *e2e.GitTestEnv, branch, fileName string, messages ...string) {
+	t.Helper()
+	env.ExecuteGit("checkout", branch)
+	for _, message := range messages {
+		path := filepath.Join(env.LocalPath, fileName)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(message), 0644))
+		env.ExecuteGit("add", fileName)
+		env.ExecuteGit("commit", "-m", message)
+	}
+	env.ExecuteGit("push", "origin", branch)
+}
+
+func RunReleaseFinishMonorepoProject(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	// the api and web projects share the branches of the repository
+	env.CommitTemplateContent("{{.Version}}", "api/version.txt", "1.0.0", "main")
+	env.ExecuteGit("tag", "1.0.0", "main")
+	env.ExecuteGit("push", "origin", "1.0.0")
+	env.ExecuteGit("checkout", "develop")
+	env.ExecuteGit("merge", "--no-edit", "main")
+	env.CommitTemplateContent("{{.Version}}", "api/version.txt", "1.1.0-dev", "develop")
+	commitProjectChanges(t, env, "develop", "api/search.txt", "feat(api): add the search endpoint")
+	commitProjectChanges(t, env, "develop", "web/theme.txt", "feat(web): add the dark theme")
+
+	env.LocalPath = filepath.Join(env.LocalPath, "api")
+	configPath := env.WriteConfig(changelogConfig)
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+
+	// the notes and the changelog of the project only hold the commits which change its directory
+	notes := env.ExecuteGitflow("release", "notes", "--config", configPath)
+	assert.Contains(t, notes, "add the search endpoint")
+	assert.NotContains(t, notes, "add the dark theme")
+
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	changelog := env.ExecuteGit("show", "1.1.0:api/CHANGELOG.md")
+	assert.Contains(t, changelog, "- **api:** add the search endpoint\n")
+	assert.NotContains(t, changelog, "dark theme")
+}
+
+func RunReleaseFinishWithoutChangelog(t *testing.T) {
+	t.Helper()
+	env := setupChangelogRelease(t)
+
+	env.ExecuteGitflow("release", "start")
+	env.ExecuteGitflow("release", "finish")
+
+	// the changelog is only updated if enabled
+	_, err := env.ExecuteGitAllowError("show", "1.1.0:CHANGELOG.md")
+	assert.Error(t, err)
+}
+
+func RunReleaseFinishChangelogDryRun(t *testing.T) {
+	t.Helper()
+	env := setupChangelogRelease(t)
+	configPath := env.WriteConfig(changelogConfig)
+
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+	output := env.ExecuteGitflow("release", "finish", "--config", configPath, "--dry-run")
+
+	assert.Contains(t, output, "CHANGELOG.md")
+	assert.Contains(t, output, "Update changelog for release 1.1.0.")
+	env.AssertBranchExists("release/1.1.0")
+	_, err := env.ExecuteGitAllowError("show", "release/1.1.0:CHANGELOG.md")
+	assert.Error(t, err)
+}
+
+func RunChangelog(t *testing.T) {
+	t.Helper()
+	env := setupChangelogRelease(t)
+
+	env.ExecuteGitflow("release", "start")
+	env.ExecuteGitflow("release", "finish")
+	commitChanges(env, "develop", "perf: cache the settings")
+
+	// the changelog lists the releases newest first
+	changelog, err := env.ExecuteGitflowStdout("changelog", "--file", "-")
+	require.NoError(t, err)
+	assert.Regexp(t, `(?s)## \[1\.1\.0\] - .*### Added\n\n- \*\*api:\*\* add the search endpoint\n.*## \[1\.0\.0\] - `, changelog)
+	assert.NotContains(t, changelog, "cache the settings")
+
+	output := env.ExecuteGitflow("changelog", "--format", "conventional")
+	assert.Contains(t, output, "Changelog of 2 release(s) written to 'CHANGELOG.md'")
+
+	content, err := os.ReadFile(filepath.Join(env.LocalPath, "CHANGELOG.md"))
+	require.NoError(t, err)
+	assert.Regexp(t, `(?s)^# Changelog\n\n## 1\.1\.0 \(.*### Bug Fixes\n\n\* handle empty input\n\n## 1\.0\.0 \(`, string(content))
+
+	errMsg := env.ExecuteGitflowExpectError("changelog", "--format", "html")
+	assert.Contains(t, errMsg, "unsupported changelog format: html (keep-a-changelog, conventional)")
+}
+
+func RunChangelogWithoutReleases(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	errMsg := env.ExecuteGitflowExpectError("changelog")
+	assert.Contains(t, errMsg, "has no release tags for a changelog")
+}
@@ -0,0 +1,92 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupReleaseBranch creates a test environment with version 1.0.0 released and a release branch with a version.
+func setupReleaseBranch(t *testing.T, branch, version string) *e2e.GitTestEnv {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch(branch, "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", version, branch)
+
+	return env
+}
+
+func RunCheckReleaseBranch(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+
+	output := env.ExecuteGitflow("check", "release/1.1.0")
+
+	assert.Contains(t, output, "Check of branch release/1.1.0 completed")
+}
+
+func RunCheckReleaseBranchViolations(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.0.0", "1.0.0-dev")
+
+	errMsg := env.ExecuteGitflowExpectError("check", "release/1.0.0")
+
+	// the version was released already and the qualifier was not removed
+	assert.Contains(t, errMsg, "found 2 violation(s) of the workflow rules on branch 'release/1.0.0'")
+}
+
+func RunCheckRemoteTags(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.0.0", "1.0.0")
+
+	// shallow clones of pipelines usually have no tags, the latest release is then known from the remote tags
+	env.ExecuteGit("tag", "--delete", "1.0.0")
+
+	errMsg := env.ExecuteGitflowExpectError("check", "release/1.0.0")
+
+	// the version was released already
+	assert.Contains(t, errMsg, "found 1 violation(s) of the workflow rules on branch 'release/1.0.0'")
+}
+
+func RunCheckInvalidBranchName(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/next", "1.1.0")
+
+	errMsg := env.ExecuteGitflowExpectError("check", "release/next")
+
+	assert.Contains(t, errMsg, "found 1 violation(s) of the workflow rules on branch 'release/next'")
+}
+
+func RunCheckDevelopmentBranch(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	// the qualifier of the development version was removed by hand
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "develop")
+	env.ExecuteGit("checkout", "develop")
+
+	errMsg := env.ExecuteGitflowExpectError("check")
+
+	assert.Contains(t, errMsg, "found 1 violation(s) of the workflow rules on branch 'develop'")
+}
+
+func RunCheckDetachedHead(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+
+	// pipelines of pull requests check out the merge commit as detached HEAD
+	env.ExecuteGit("checkout", "--detach", "release/1.1.0")
+	t.Setenv("GITHUB_HEAD_REF", "release/1.1.0")
+
+	output := env.ExecuteGitflow("check")
+
+	assert.Contains(t, output, "Check of branch release/1.1.0 completed")
+}
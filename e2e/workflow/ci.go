@@ -0,0 +1,118 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+)
+
+// clearGitLabEnv isolates a test from the predefined variables of a GitLab pipeline running the tests.
+func clearGitLabEnv(t *testing.T) {
+	t.Helper()
+	for _, env := range []string{"CI_SERVER_URL", "CI_API_V4_URL", "CI_PROJECT_PATH", "CI_JOB_TOKEN", "GITLAB_TOKEN",
+		"CI_COMMIT_BRANCH", "CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "GITLAB_USER_NAME", "GITLAB_USER_EMAIL"} {
+		t.Setenv(env, "")
+	}
+}
+
+func RunReleaseStartGitLabDetachedHead(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+	clearGitLabEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	// a branch pipeline checks out the commit of its branch as detached HEAD
+	env.ExecuteGit("checkout", "--detach", "develop")
+	t.Setenv("CI_COMMIT_BRANCH", "develop")
+
+	output := env.ExecuteGitflow("--ci", "gitlab", "release", "start")
+
+	assert.Contains(t, output, "checked out branch 'develop' at the commit of the pipeline")
+	assert.Contains(t, output, "section_start:")
+	assert.Contains(t, output, "section_end:")
+	env.AssertBranchExists("origin/release/1.1.0")
+	env.AssertCurrentBranchEquals("release/1.1.0")
+}
+
+func RunReleaseFinishGitLabMergeRequest(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+	clearGitLabEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	// the merge request of another branch must not finish the release
+	t.Setenv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "feature/login")
+	errMsg := env.ExecuteGitflowExpectError("--ci", "gitlab", "release", "finish")
+	assert.Contains(t, errMsg, "the merge request pipeline of 'feature/login' cannot finish 'release/1.1.0'")
+	env.AssertBranchExists("release/1.1.0")
+
+	t.Setenv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "release/1.1.0")
+	env.ExecuteGitflow("--ci", "gitlab", "release", "finish")
+
+	env.AssertBranchDoesNotExist("release/1.1.0")
+	env.AssertTagEquals("1.1.0", "main")
+}
+
+func RunUnsupportedCI(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	errMsg := env.ExecuteGitflowExpectError("--ci", "jenkins", "release", "start")
+
+	assert.Contains(t, errMsg, "unsupported CI system 'jenkins' (supported: gitlab)")
+	env.AssertBranchDoesNotExist("release/1.1.0")
+}
+
+func RunReleaseStartDetectedCI(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() { core.ResetBranchNames() })
+
+	// the repository has 'master' while the configuration expects 'main', which is resolved by a prompt
+	env := e2e.SetupTestEnv(t, e2e.WithProductionBranch("master"))
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "master")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	t.Setenv("GITFLOW_CI_DEFAULTS", "true")
+	t.Setenv("GITHUB_ACTIONS", "true")
+	configPath := env.WriteConfig("")
+	output := env.ExecuteGitflow("release", "start", "--config", configPath)
+
+	// the prompt is confirmed in the detected pipeline without --yes or --ci
+	assert.Contains(t, output, "detected CI system 'github'")
+	assert.Contains(t, output, "main branch 'main' not found, using 'master'")
+	env.AssertBranchExists("release/1.1.0")
+}
+
+func RunReleaseStartCIDefaultsDisabled(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() { core.ResetBranchNames() })
+
+	env := e2e.SetupTestEnv(t, e2e.WithProductionBranch("master"))
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "master")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	t.Setenv("GITFLOW_CI_DEFAULTS", "true")
+	t.Setenv("GITHUB_ACTIONS", "true")
+	configPath := env.WriteConfig("")
+	output := env.ExecuteGitflow("release", "start", "--no-ci-defaults", "--config", configPath)
+
+	// without the defaults of the pipeline, the branch is resolved by the prompt, which takes its default
+	assert.NotContains(t, output, "detected CI system")
+	assert.Contains(t, output, "Enter branch name [master]")
+}
@@ -0,0 +1,160 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Tag prefix combined with qualifiers per type of branch of the standard plugin.
+const prefixedQualifiersConfig = `workflow:
+  tag-prefix: v
+standard:
+  qualifiers:
+    development: SNAPSHOT
+    release: rc
+    hotfix: fix
+`
+
+func RunReleaseAndHotfixWithTagPrefixAndQualifiers(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+	configPath := env.WriteConfig(prefixedQualifiersConfig)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.ExecuteGit("tag", "v1.0.0", "main")
+	env.ExecuteGit("push", "origin", "v1.0.0")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-SNAPSHOT", "develop")
+
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0-rc", "release/1.1.0")
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	env.AssertTagEquals("v1.1.0", "main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0", "main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0-SNAPSHOT", "develop")
+
+	// the next hotfix follows the prefixed tag of the release
+	env.ExecuteGitflow("hotfix", "start", "--config", configPath)
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.1-fix", "hotfix/1.1.1")
+	env.ExecuteGitflow("hotfix", "finish", "--config", configPath)
+
+	env.AssertTagEquals("v1.1.1", "main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.1", "main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0-SNAPSHOT", "develop")
+	assert.NotEmpty(t, strings.TrimSpace(env.ExecuteGit("ls-remote", "--tags", "origin", "v1.1.1")))
+	assert.Empty(t, strings.TrimSpace(env.ExecuteGit("ls-remote", "--tags", "origin", "1.1.1")))
+}
+
+func RunReleaseStartWithoutOriginRemote(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t, e2e.WithRemoteName("upstream"))
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	// the workflow only knows the branches of the remote named origin and does not start anything without them
+	errMsg := env.ExecuteGitflowExpectError("release", "start")
+
+	assert.Contains(t, errMsg, "branch 'main' is required but was not resolved")
+	env.AssertBranchDoesNotExist("release/1.1.0")
+	env.AssertBranchDoesNotExist("upstream/release/1.1.0")
+}
+
+func RunReleaseFinishWithAdditionalRemote(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	// a fork remote next to origin, which holds the same branches
+	forkPath := filepath.Join(t.TempDir(), "fork")
+	require.NoError(t, exec.Command("git", "init", "--bare", forkPath).Run())
+	env.ExecuteGit("remote", "add", "fork", forkPath)
+	env.ExecuteGit("push", "fork", "main", "develop", "release/1.1.0")
+	forkMain := strings.TrimSpace(env.ExecuteGit("ls-remote", "fork", "refs/heads/main"))
+
+	env.ExecuteGitflow("release", "finish")
+
+	// the release is pushed to origin only
+	env.AssertTagEquals("1.1.0", "main")
+	env.AssertBranchDoesNotExist("origin/release/1.1.0")
+	assert.Equal(t, forkMain, strings.TrimSpace(env.ExecuteGit("ls-remote", "fork", "refs/heads/main")))
+	assert.Empty(t, strings.TrimSpace(env.ExecuteGit("ls-remote", "--tags", "fork")))
+	assert.NotEmpty(t, strings.TrimSpace(env.ExecuteGit("ls-remote", "fork", "refs/heads/release/1.1.0")))
+}
+
+func RunHotfixFinishWithoutDevelop(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t, e2e.WithoutDevelopmentBranch())
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CreateBranch("hotfix/1.0.1", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.1", "hotfix/1.0.1")
+
+	// the missing development branch is created from the production branch, so the hotfix reaches it
+	env.ExecuteGitflow("hotfix", "finish", "--yes")
+
+	env.AssertTagEquals("1.0.1", "main")
+	env.AssertBranchExists("origin/develop")
+	env.ExecuteGit("merge-base", "--is-ancestor", "1.0.1^2", "origin/develop")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0-dev", "origin/develop")
+	env.AssertBranchDoesNotExist("origin/hotfix/1.0.1")
+}
+
+// setupProtectedDevelop creates a test environment with a release branch and a remote which rejects pushes to the
+// development branch, like a protected branch of a hosting service.
+func setupProtectedDevelop(t *testing.T) (*e2e.GitTestEnv, string) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+	env.ProtectBranches("develop")
+
+	return env, strings.TrimSpace(env.ExecuteGit("rev-parse", "origin/develop"))
+}
+
+func RunReleaseFinishProtectedDevelop(t *testing.T) {
+	t.Helper()
+	env, developBefore := setupProtectedDevelop(t)
+
+	errMsg := env.ExecuteGitflowExpectError("release", "finish")
+
+	// the rejected push stops the finish before anything reaches the remote, so that it can be continued
+	assert.Contains(t, errMsg, "protected branch refs/heads/develop")
+	assert.Equal(t, developBefore, strings.TrimSpace(env.ExecuteGit("rev-parse", "origin/develop")))
+	env.AssertBranchExists("origin/release/1.1.0")
+	env.AssertTagNotOnRemote("1.1.0")
+}
+
+func RunReleaseFinishProtectedDevelopPullRequest(t *testing.T) {
+	t.Helper()
+	env, developBefore := setupProtectedDevelop(t)
+	clearGitLabEnv(t)
+	t.Setenv("GITHUB_TOKEN", "")
+
+	configPath := env.WriteConfig("workflow:\n  development-bump: pull-request\n")
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	// everything but the protected development branch is pushed
+	env.AssertTagEquals("1.1.0", "main")
+	env.AssertBranchDoesNotExist("origin/release/1.1.0")
+	assert.Equal(t, developBefore, strings.TrimSpace(env.ExecuteGit("rev-parse", "origin/develop")))
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0-dev", "origin/chore/bump-1.2.0-dev")
+}
@@ -0,0 +1,99 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupPausedHotfixFinish runs a hotfix finish which pauses at the merge into develop, because README.md conflicts.
+func setupPausedHotfixFinish(t *testing.T) *e2e.GitTestEnv {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CommitFile("README.md", []byte("develop\n"), "develop")
+	env.CreateBranch("hotfix/1.0.1", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.1", "hotfix/1.0.1")
+	env.CommitFile("README.md", []byte("hotfix\n"), "hotfix/1.0.1")
+
+	errMsg := env.ExecuteGitflowExpectError("hotfix", "finish")
+	assert.Contains(t, errMsg, "paused due to conflicts in: README.md, version.txt")
+
+	return env
+}
+
+func RunHotfixContinueAfterConflict(t *testing.T) {
+	t.Helper()
+	env := setupPausedHotfixFinish(t)
+
+	statePath := filepath.Join(env.LocalPath, ".git", "gitflow-cli", "state.json")
+	assert.FileExists(t, statePath)
+
+	// resolve the conflicts by hand and commit the merge
+	env.ExecuteGit("checkout", "--ours", "version.txt")
+	env.ExecuteGit("checkout", "--theirs", "README.md")
+	env.ExecuteGit("add", "version.txt", "README.md")
+	env.ExecuteGit("commit", "--no-edit")
+
+	output := env.ExecuteGitflow("hotfix", "continue")
+
+	assert.Contains(t, output, "completed")
+	assert.NoFileExists(t, statePath)
+	env.AssertBranchDoesNotExist("hotfix/1.0.1")
+	env.AssertTagEquals("1.0.1", "main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	remoteTags := env.ExecuteGit("ls-remote", "--tags", "origin", "1.0.1")
+	assert.Contains(t, remoteTags, "refs/tags/1.0.1")
+}
+
+func RunReleaseFinishLinkedWorktree(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+
+	// the finish runs in a linked worktree, whose '.git' is a file
+	worktree := filepath.Join(t.TempDir(), "worktree")
+	env.ExecuteGit("checkout", "--detach")
+	env.ExecuteGit("worktree", "add", worktree, "release/1.1.0")
+	env.LocalPath = worktree
+
+	env.ExecuteGitflow("release", "finish")
+
+	env.AssertTagEquals("1.1.0", "main")
+	env.AssertBranchDoesNotExist("release/1.1.0")
+	assert.NoFileExists(t, strings.TrimSpace(env.ExecuteGit("rev-parse", "--git-path", "gitflow-cli/state.json")))
+}
+
+func RunFinishWhileInterrupted(t *testing.T) {
+	t.Helper()
+	env := setupPausedHotfixFinish(t)
+
+	// another finish must not start while the merge is still pending
+	errMsg := env.ExecuteGitflowExpectError("release", "finish")
+	assert.Contains(t, errMsg, "hotfix finish of 'hotfix/1.0.1' was interrupted at step 'merge-development'")
+
+	errMsg = env.ExecuteGitflowExpectError("release", "continue")
+	assert.Contains(t, errMsg, "the interrupted finish is a hotfix finish: run 'hotfix continue'")
+}
+
+func RunReleaseContinueWithoutState(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	errMsg := env.ExecuteGitflowExpectError("release", "continue")
+
+	assert.Contains(t, errMsg, "no interrupted release finish to continue")
+}
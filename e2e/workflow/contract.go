@@ -0,0 +1,142 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseResult parses the standard output of a command in JSON output mode, which must be a single result document.
+func parseResult(t *testing.T, stdout string) core.Result {
+	t.Helper()
+
+	var result core.Result
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result), "stdout must only hold the result document")
+	assert.Equal(t, core.ContractVersion, result.SchemaVersion)
+
+	return result
+}
+
+func RunReleaseJSONOutput(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	stdout, err := env.ExecuteGitflowStdout("release", "start", "--output", "json")
+	require.NoError(t, err)
+
+	result := parseResult(t, stdout)
+	assert.Equal(t, "release start", result.Command)
+	assert.Equal(t, core.StatusSuccess, result.Status)
+	assert.Equal(t, "release/1.1.0", result.Branch)
+	assert.Equal(t, "1.1.0", result.Version)
+	assert.Empty(t, result.Tag)
+
+	stdout, err = env.ExecuteGitflowStdout("release", "finish", "--output", "json")
+	require.NoError(t, err)
+
+	result = parseResult(t, stdout)
+	assert.Equal(t, "release finish", result.Command)
+	assert.Equal(t, core.StatusSuccess, result.Status)
+	assert.Equal(t, "release/1.1.0", result.Branch)
+	assert.Equal(t, "1.1.0", result.Version)
+	assert.Equal(t, "1.1.0", result.Tag)
+
+	stdout, err = env.ExecuteGitflowStdout("release", "notes", "1.1.0", "--output", "json")
+	require.NoError(t, err)
+
+	result = parseResult(t, stdout)
+	require.NotNil(t, result.Notes)
+	assert.Equal(t, "1.1.0", result.Notes.Version)
+}
+
+func RunFailureJSONOutput(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	// there is no release branch to finish
+	stdout, err := env.ExecuteGitflowStdout("release", "finish", "--output", "json")
+	require.Error(t, err)
+
+	result := parseResult(t, stdout)
+	assert.Equal(t, "release finish", result.Command)
+	assert.Equal(t, core.StatusFailure, result.Status)
+	assert.Equal(t, err.Error(), result.Error)
+}
+
+func RunCheckJSONOutput(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.0.0", "1.0.0-dev")
+
+	stdout, err := env.ExecuteGitflowStdout("check", "release/1.0.0", "--output", "json")
+	require.Error(t, err)
+
+	// the version was released already and the qualifier was not removed
+	result := parseResult(t, stdout)
+	assert.Equal(t, "check", result.Command)
+	assert.Equal(t, core.StatusFailure, result.Status)
+	assert.Len(t, result.Violations, 2)
+}
+
+func RunSchema(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	for _, name := range core.SchemaNames() {
+		stdout, err := env.ExecuteGitflowStdout("schema", name)
+		require.NoError(t, err)
+
+		var schema map[string]any
+		require.NoError(t, json.Unmarshal([]byte(stdout), &schema))
+		assert.Contains(t, schema["$id"], "/v1/"+name+".json")
+	}
+
+	_, err := env.ExecuteGitflowStdout("schema", "unknown")
+	assert.ErrorContains(t, err, "unknown schema 'unknown'")
+}
+
+func RunReleaseJSONOutputLinks(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	// the remote points to GitHub, whose ssh connection serves the simulated remote repository instead
+	env.ExecuteGit("remote", "set-url", "origin", "git@github.com:example/project.git")
+	t.Setenv("GIT_SSH_COMMAND", "git-upload-pack '"+env.RemotePath+"' #")
+	configPath := env.WriteConfig("workflow:\n  push: false\n")
+
+	stdout, err := env.ExecuteGitflowStdout("release", "start", "--output", "json", "--config", configPath)
+	require.NoError(t, err)
+
+	result := parseResult(t, stdout)
+	require.NotNil(t, result.Links)
+	assert.Equal(t, "https://github.com/example/project", result.Links.Repository)
+	assert.Equal(t, "https://github.com/example/project/tree/release/1.1.0", result.Links.Branch)
+	assert.Equal(t, "https://github.com/example/project/compare/main...release/1.1.0", result.Links.Compare)
+	assert.Empty(t, result.Links.Tag)
+
+	// the ssh connection only fetches, so the release branch is pushed to the simulated remote repository directly
+	env.ExecuteGit("push", env.RemotePath, "release/1.1.0")
+	stdout, err = env.ExecuteGitflowStdout("release", "finish", "--output", "json", "--config", configPath)
+	require.NoError(t, err)
+
+	result = parseResult(t, stdout)
+	require.NotNil(t, result.Links)
+	assert.Equal(t, "https://github.com/example/project/releases/tag/1.1.0", result.Links.Tag)
+	assert.Equal(t, "https://github.com/example/project/compare/1.0.0...1.1.0", result.Links.Compare)
+	assert.Empty(t, result.Links.Branch)
+}
@@ -0,0 +1,156 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func RunReleaseFinishSkipStep(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+
+	configPath := env.WriteConfig("workflow:\n  steps:\n    release-finish:\n      skip: [merge-development]\n")
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	// the release is tagged on the production branch, but not merged back into the development branch
+	env.AssertTagEquals("1.1.0", "main")
+	_, err := env.ExecuteGitAllowError("merge-base", "--is-ancestor", "1.1.0", "develop")
+	assert.Error(t, err)
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0-dev", "develop")
+	env.AssertBranchDoesNotExist("release/1.1.0")
+}
+
+func RunReleaseFinishInsertStep(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+
+	configPath := env.WriteConfig(`workflow:
+  steps:
+    release-finish:
+      insert:
+        - name: mark-verified
+          after: tag-release
+          run: git tag verified-{version} {branch}
+`)
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	// the inserted step gets the version and the branch of the release
+	env.AssertTagEquals("1.1.0", "main")
+	assert.Equal(t, env.ExecuteGit("rev-parse", "1.1.0^2"), env.ExecuteGit("rev-parse", "verified-1.1.0"))
+}
+
+func RunReleaseFinishInsertStepFailure(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+
+	configPath := env.WriteConfig(`workflow:
+  steps:
+    release-finish:
+      insert:
+        - name: verify-development
+          before: merge-development
+          run: git cat-file -e HEAD:approved.txt
+`)
+	errMsg := env.ExecuteGitflowExpectError("release", "finish", "--config", configPath)
+
+	// the failed step stops the finish after the tag, and the finish continues at the step once the cause is resolved
+	assert.Contains(t, errMsg, "step 'verify-development' of workflow 'release-finish' failed")
+	env.AssertTagEquals("1.1.0", "main")
+	env.AssertBranchExists("release/1.1.0")
+
+	env.CommitFile("approved.txt", []byte("approved"), "develop")
+	env.ExecuteGitflow("release", "continue", "--config", configPath)
+
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0-dev", "develop")
+	env.AssertBranchDoesNotExist("release/1.1.0")
+}
+
+func RunReleaseFinishInvalidDefinition(t *testing.T) {
+	t.Helper()
+
+	cases := map[string]struct {
+		definition string
+		message    string
+	}{
+		"skip required step": {
+			definition: "      skip: [tag-release]\n",
+			message:    "step 'tag-release' of workflow 'release-finish' is required and cannot be skipped or moved",
+		},
+		"skip unknown step": {
+			definition: "      skip: [merge-develop]\n",
+			message:    "workflow 'release-finish' has no step 'merge-develop'",
+		},
+		"move across required step": {
+			definition: "      move:\n        - step: pre-tag-hook\n          after: tag-release\n",
+			message:    "step 'pre-tag-hook' of workflow 'release-finish' cannot be moved across the required step 'tag-release'",
+		},
+		"insert without place": {
+			definition: "      insert:\n        - name: verify\n          run: git status\n",
+			message:    "invalid definition of step 'verify' of workflow 'release-finish'",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+
+			configPath := env.WriteConfig("workflow:\n  steps:\n    release-finish:\n" + c.definition)
+			errMsg := env.ExecuteGitflowExpectError("release", "finish", "--config", configPath)
+
+			// the definition is rejected before any step runs
+			assert.Contains(t, errMsg, c.message)
+			assert.Empty(t, env.ExecuteGit("tag", "--list", "1.1.0"))
+			env.AssertBranchExists("release/1.1.0")
+		})
+	}
+}
+
+func RunReleaseFinishMoveStep(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+
+	configPath := env.WriteConfig(`workflow:
+  steps:
+    release-finish:
+      move:
+        - step: push-tags
+          before: push-branches
+`)
+	output := env.ExecuteGitflow("release", "finish", "--dry-run", "--config", configPath)
+
+	// the tags are pushed before the branches
+	tags := strings.Index(output, "DRY-RUN: git push --tags origin")
+	branches := strings.Index(output, "DRY-RUN: git push --all origin")
+	require.GreaterOrEqual(t, tags, 0, output)
+	assert.Less(t, tags, branches, output)
+}
+
+func RunReleaseStartInsertStep(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	configPath := env.WriteConfig(`workflow:
+  steps:
+    release-start:
+      insert:
+        - name: mark-start
+          after: commit-version
+          run: git tag started-{version} {branch}
+`)
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+
+	env.AssertBranchExists("release/1.1.0")
+	assert.Equal(t, env.ExecuteGit("rev-parse", "release/1.1.0"), env.ExecuteGit("rev-parse", "started-1.1.0"))
+}
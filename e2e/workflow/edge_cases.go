@@ -6,12 +6,17 @@ SPDX-License-Identifier: MIT
 package workflow
 
 import (
+	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/mercedes-benz/gitflow-cli/core"
 	"github.com/mercedes-benz/gitflow-cli/e2e"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // --- Push disabled tests ---
@@ -138,6 +143,76 @@ func RunHotfixFinishNoPushFlag(t *testing.T) {
 	env.AssertCurrentBranchEquals("develop")
 }
 
+// --- Dry-run tests ---
+
+func RunReleaseStartDryRun(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	refs := env.ExecuteGit("for-each-ref")
+	current := env.ExecuteGit("rev-parse", "--abbrev-ref", "HEAD")
+
+	output := env.ExecuteGitflow("release", "start", "--dry-run")
+
+	assert.Contains(t, output, "DRY-RUN: git switch -c release/1.1.0")
+	assert.Contains(t, output, "DRY-RUN: set version 1.1.0 in 'version.txt' (standard plugin)")
+	assert.Contains(t, output, "DRY-RUN: git push --all origin")
+	assert.Equal(t, refs, env.ExecuteGit("for-each-ref"))
+	assert.Equal(t, current, env.ExecuteGit("rev-parse", "--abbrev-ref", "HEAD"))
+	env.AssertBranchDoesNotExist("release/1.1.0")
+}
+
+func RunReleaseFinishDryRun(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+	refs := env.ExecuteGit("for-each-ref")
+
+	output := env.ExecuteGitflow("release", "finish", "--dry-run")
+
+	// the next development version is derived from the version of the release branch
+	assert.Contains(t, output, "DRY-RUN: git merge --no-ff release/1.1.0")
+	assert.Contains(t, output, "DRY-RUN: git tag 1.1.0")
+	assert.Contains(t, output, "DRY-RUN: set version 1.2.0-dev in 'version.txt' (standard plugin)")
+	assert.Contains(t, output, "DRY-RUN: git push --delete origin release/1.1.0")
+	assert.Equal(t, refs, env.ExecuteGit("for-each-ref"))
+	remote := env.ExecuteGit("ls-remote", "origin")
+	assert.Contains(t, remote, "refs/heads/release/1.1.0")
+	assert.NotContains(t, remote, "refs/tags/1.1.0")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+}
+
+func RunHotfixStartDryRunConfig(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	refs := env.ExecuteGit("for-each-ref")
+
+	configPath := env.WriteConfig("workflow:\n  dry-run: true\n")
+	output := env.ExecuteGitflow("hotfix", "start", "--config", configPath)
+
+	assert.Contains(t, output, "DRY-RUN: git switch -c hotfix/1.0.1")
+	assert.Contains(t, output, "DRY-RUN: set version 1.0.1 in 'version.txt' (standard plugin)")
+	assert.Equal(t, refs, env.ExecuteGit("for-each-ref"))
+}
+
+func RunBootstrapDryRun(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupEmptyTestEnv(t)
+
+	errMsg := env.ExecuteGitflowExpectError("bootstrap", "standard", "--dry-run")
+
+	assert.Contains(t, errMsg, "command 'bootstrap' does not support a dry run")
+}
+
 // --- Rollback tests ---
 
 func RunRollbackPreservesExistingBranches(t *testing.T) {
@@ -317,6 +392,49 @@ func RunReleaseStartWithDevBranch(t *testing.T) {
 	env.AssertBranchExists("release/1.1.0")
 }
 
+func RunReleaseDetectsNvieLayout(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() { core.ResetBranchNames() })
+
+	// Repo follows the default nvie layout with 'master', 'develop', and feature branches
+	env := e2e.SetupTestEnv(t, e2e.WithProductionBranch("master"))
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "master")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("feature/login", "develop")
+
+	output := env.ExecuteGitflow("release", "start")
+
+	assert.Contains(t, output, "INFO: branch 'main' not found, using the git-flow layout of the repository (production: master, development: develop)")
+	env.AssertBranchExists("release/1.1.0")
+
+	env.ExecuteGitflow("release", "finish")
+
+	env.AssertTagEquals("1.1.0", "master")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0-dev", "develop")
+	env.AssertBranchDoesNotExist("main")
+}
+
+func RunReleaseStartDetectsNvieConfig(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() { core.ResetBranchNames() })
+
+	// Repo was set up with 'git flow init' and custom names
+	env := e2e.SetupTestEnv(t, e2e.WithProductionBranch("master"), e2e.WithDevelopmentBranch("dev"))
+	env.ExecuteGit("config", "gitflow.branch.master", "master")
+	env.ExecuteGit("config", "gitflow.branch.develop", "dev")
+	env.ExecuteGit("config", "gitflow.prefix.release", "rel/")
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "master")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "dev")
+
+	env.ExecuteGitflow("release", "start")
+
+	env.AssertBranchExists("rel/1.1.0")
+	env.AssertBranchExists("origin/rel/1.1.0")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0", "rel/1.1.0")
+}
+
 // --- Auto-confirm (--yes) tests ---
 
 func RunReleaseStartYesAutoResolvesBranch(t *testing.T) {
@@ -367,6 +485,51 @@ func RunReleaseStartDirtyRepo(t *testing.T) {
 	assert.Contains(t, errMsg, "not clean")
 }
 
+// Configuration ignoring untracked files of IDEs when checking for a clean working tree.
+const cleanIgnoreConfig = `workflow:
+  clean-ignore:
+    - .idea/
+    - "*.iml"
+`
+
+func RunReleaseStartCleanIgnore(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	// untracked files of an IDE
+	env.ExecuteGit("checkout", "develop")
+	require.NoError(t, os.MkdirAll(filepath.Join(env.LocalPath, ".idea"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(env.LocalPath, ".idea", "workspace.xml"), []byte("<project/>"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(env.LocalPath, "project.iml"), []byte("<module/>"), 0644))
+
+	configPath := env.WriteConfig(cleanIgnoreConfig)
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+
+	env.AssertBranchExists("release/1.1.0")
+	assert.FileExists(t, filepath.Join(env.LocalPath, "project.iml"))
+}
+
+func RunReleaseStartCleanIgnoreTracked(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CommitFile("project.iml", []byte("<module/>"), "develop")
+
+	// modifications of tracked files are never ignored
+	env.ExecuteGit("checkout", "develop")
+	require.NoError(t, os.WriteFile(filepath.Join(env.LocalPath, "project.iml"), []byte("<module changed=\"true\"/>"), 0644))
+
+	configPath := env.WriteConfig(cleanIgnoreConfig)
+	errMsg := env.ExecuteGitflowExpectError("release", "start", "--config", configPath)
+
+	assert.Contains(t, errMsg, "not clean")
+}
+
 func RunReleaseStartDuplicateRelease(t *testing.T) {
 	t.Helper()
 	env := e2e.SetupTestEnv(t)
@@ -392,3 +555,399 @@ func RunHotfixStartDuplicateHotfix(t *testing.T) {
 
 	assert.Contains(t, errMsg, "already has")
 }
+
+// --- Merge conflict tests ---
+
+func RunHotfixFinishVersionConflictResolutionDisabled(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("hotfix/1.0.1", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.1", "hotfix/1.0.1")
+
+	configPath := env.WriteConfig("workflow:\n  resolve-version-conflicts: false\n")
+	errMsg := env.ExecuteGitflowExpectError("hotfix", "finish", "--config", configPath)
+
+	assert.Contains(t, errMsg, "merging 'hotfix/1.0.1' paused due to conflicts in: version.txt")
+
+	// the merge into develop is left in progress for manual resolution
+	env.AssertCurrentBranchEquals("develop")
+	_, err := env.ExecuteGitAllowError("rev-parse", "--verify", "--quiet", "MERGE_HEAD")
+	assert.NoError(t, err)
+}
+
+func RunHotfixFinishConflictOutsideVersionFile(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CommitFile("README.md", []byte("develop\n"), "develop")
+	env.CreateBranch("hotfix/1.0.1", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.1", "hotfix/1.0.1")
+	env.CommitFile("README.md", []byte("hotfix\n"), "hotfix/1.0.1")
+
+	errMsg := env.ExecuteGitflowExpectError("hotfix", "finish")
+
+	assert.Contains(t, errMsg, "paused due to conflicts in: README.md, version.txt")
+	env.AssertCurrentBranchEquals("develop")
+}
+
+// --- Starting point tests ---
+
+func RunReleaseStartDetachedHead(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.ExecuteGit("checkout", "--detach", "develop")
+
+	errMsg := env.ExecuteGitflowExpectError("release", "start")
+
+	assert.Contains(t, errMsg, "HEAD is detached")
+	env.AssertBranchDoesNotExist("release/1.1.0")
+}
+
+func RunReleaseStartDuringRebase(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	// a rebase stopped by a failing command leaves the HEAD detached
+	_, err := env.ExecuteGitAllowError("rebase", "--exec", "false", "HEAD~1")
+	require.Error(t, err)
+
+	errMsg := env.ExecuteGitflowExpectError("release", "start")
+
+	assert.Contains(t, errMsg, "a rebase is in progress")
+	assert.Contains(t, errMsg, "git rebase --abort")
+	assert.NotContains(t, errMsg, "HEAD is detached")
+	env.AssertBranchDoesNotExist("release/1.1.0")
+}
+
+func RunReleaseFinishDuringMerge(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	// a manual merge stopped by the conflicting version file is pending
+	env.ExecuteGit("checkout", "main")
+	_, err := env.ExecuteGitAllowError("merge", "--no-ff", "release/1.1.0")
+	require.Error(t, err)
+
+	errMsg := env.ExecuteGitflowExpectError("release", "finish")
+
+	assert.Contains(t, errMsg, "a merge is in progress")
+	assert.Contains(t, errMsg, "git merge --abort")
+	env.AssertBranchExists("release/1.1.0")
+}
+
+func RunHotfixStartDuringBisect(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.ExecuteGit("bisect", "start")
+
+	errMsg := env.ExecuteGitflowExpectError("hotfix", "start")
+
+	assert.Contains(t, errMsg, "a bisect is in progress")
+	assert.Contains(t, errMsg, "git bisect reset")
+	env.AssertBranchDoesNotExist("hotfix/1.0.1")
+}
+
+func RunHotfixStartFromReleaseBranch(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	output := env.ExecuteGitflow("hotfix", "start")
+
+	assert.Contains(t, output, "leaving branch 'release/1.1.0' and switching to 'main'")
+	env.AssertCurrentBranchEquals("hotfix/1.0.1")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.0.1", "hotfix/1.0.1")
+}
+
+func RunHotfixStartFromReleaseBranchWithUnpushedCommits(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.ExecuteGit("commit", "--allow-empty", "-m", "Local release fix")
+
+	errMsg := env.ExecuteGitflowExpectError("hotfix", "start")
+
+	assert.Contains(t, errMsg, "branch 'release/1.1.0' has 1 unpushed commit(s)")
+	env.AssertCurrentBranchEquals("release/1.1.0")
+	env.AssertBranchDoesNotExist("hotfix/1.0.1")
+}
+
+// --- Branch matching tests ---
+
+func RunReleaseFinishIgnoresUnversionedBranches(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/notes", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	env.ExecuteGitflow("release", "finish")
+
+	env.AssertTagEquals("1.1.0", "main")
+	env.AssertBranchDoesNotExist("release/1.1.0")
+	env.AssertBranchExists("release/notes")
+}
+
+func RunHotfixFinishWithDescribedBranch(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("hotfix/1.0.1/PROJ-12-fix-login", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.1", "hotfix/1.0.1/PROJ-12-fix-login")
+
+	env.ExecuteGitflow("hotfix", "finish")
+
+	env.AssertCommitMessageEquals("Merge branch 'hotfix/1.0.1/PROJ-12-fix-login'", "main")
+	env.AssertTagEquals("1.0.1", "main")
+	env.AssertBranchDoesNotExist("hotfix/1.0.1/PROJ-12-fix-login")
+	_, err := env.ExecuteGitAllowError("rev-parse", "--verify", "origin/hotfix/1.0.1/PROJ-12-fix-login")
+	assert.Error(t, err)
+}
+
+// --- Pull strategy tests ---
+
+// setupStaleDevelop creates a local develop branch which lags one commit behind its remote.
+func setupStaleDevelop(t *testing.T) *e2e.GitTestEnv {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CommitFile("feature.txt", []byte("feature\n"), "develop")
+	env.ExecuteGit("reset", "--hard", "HEAD~1")
+
+	return env
+}
+
+func RunReleaseStartPullsDevelop(t *testing.T) {
+	t.Helper()
+	env := setupStaleDevelop(t)
+
+	configPath := env.WriteConfig("workflow:\n  pull-strategy: merge\n")
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+
+	env.AssertCommitMessageEquals("Set up test precondition for develop branch", "release/1.1.0", 1)
+	env.ExecuteGit("cat-file", "-e", "release/1.1.0:feature.txt")
+}
+
+func RunReleaseStartPullDisabled(t *testing.T) {
+	t.Helper()
+	env := setupStaleDevelop(t)
+
+	// checked out branches are not pulled by default, pushing the stale develop branch would be rejected
+	env.ExecuteGitflow("release", "start", "--no-push")
+
+	_, err := env.ExecuteGitAllowError("cat-file", "-e", "release/1.1.0:feature.txt")
+	assert.Error(t, err)
+}
+
+func RunReleaseStartDivergedNotMerged(t *testing.T) {
+	t.Helper()
+	env := setupStaleDevelop(t)
+	env.ExecuteGit("commit", "--allow-empty", "-m", "Local change")
+
+	// the release is cut from the local develop branch, without merging its remote branch
+	env.ExecuteGitflow("release", "start", "--no-push")
+
+	env.AssertCommitMessageEquals("Local change", "release/1.1.0", 1)
+	env.AssertCommitMessageEquals("Local change", "develop")
+}
+
+func RunReleaseStartFetchGitflowBranches(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	// a remote branch outside the gitflow branches and a remote-tracking branch deleted on the remote
+	env.ExecuteGit("push", "origin", "main:refs/heads/experiment")
+	env.ExecuteGit("update-ref", "-d", "refs/remotes/origin/experiment")
+	env.ExecuteGit("update-ref", "refs/remotes/origin/stale", "main")
+
+	configPath := env.WriteConfig("workflow:\n  fetch: gitflow\n")
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+
+	env.AssertBranchExists("origin/release/1.1.0")
+	env.AssertBranchDoesNotExist("origin/experiment")
+	env.AssertBranchExists("origin/stale")
+}
+
+func RunReleaseStartPruneDisabled(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.ExecuteGit("update-ref", "refs/remotes/origin/release/0.9.0", "main")
+
+	configPath := env.WriteConfig("workflow:\n  prune: false\n")
+	env.ExecuteGitflowExpectError("release", "start", "--config", configPath)
+
+	// the remote-tracking branch deleted on the remote is kept and blocks the release
+	env.AssertBranchExists("origin/release/0.9.0")
+	env.AssertBranchDoesNotExist("release/1.1.0")
+
+	env.ExecuteGitflow("release", "start")
+
+	env.AssertBranchDoesNotExist("origin/release/0.9.0")
+	env.AssertBranchExists("release/1.1.0")
+}
+
+func RunReleaseStartDivergedFastForwardOnly(t *testing.T) {
+	t.Helper()
+	env := setupStaleDevelop(t)
+	env.ExecuteGit("commit", "--allow-empty", "-m", "Local change")
+
+	configPath := env.WriteConfig("workflow:\n  pull-strategy: ff-only\n")
+	errMsg := env.ExecuteGitflowExpectError("release", "start", "--config", configPath)
+
+	assert.Contains(t, errMsg, "branch 'develop' has diverged from 'origin/develop'")
+	env.AssertBranchDoesNotExist("release/1.1.0")
+	env.AssertCommitMessageEquals("Local change", "develop")
+}
+
+// --- Fast-forward tests ---
+
+// setupSupportHotfixWithStaleDevelop creates a hotfix of a support branch and a local develop branch which lags
+// one commit behind its remote, because finishing the hotfix leaves develop untouched.
+func setupSupportHotfixWithStaleDevelop(t *testing.T) *e2e.GitTestEnv {
+	t.Helper()
+	env := setupMaintenanceLine(t)
+
+	env.CommitFile("feature.txt", []byte("feature\n"), "develop")
+	env.ExecuteGit("reset", "--hard", "HEAD~1")
+	env.CreateBranch("support/1.2", "1.2.0")
+	env.CreateBranch("hotfix/1.2.1", "support/1.2")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.2.1", "hotfix/1.2.1")
+
+	return env
+}
+
+func RunFinishFastForwardsStaleDevelop(t *testing.T) {
+	t.Helper()
+	env := setupSupportHotfixWithStaleDevelop(t)
+
+	output := env.ExecuteGitflow("hotfix", "finish", "--support", "1.2")
+
+	assert.Contains(t, output, "fast-forwarded local branch 'develop' by 1 commit(s) to 'origin/develop'")
+	assert.Equal(t, env.ExecuteGit("rev-parse", "origin/develop"), env.ExecuteGit("rev-parse", "develop"))
+	env.AssertCurrentBranchEquals("support/1.2")
+}
+
+func RunFinishKeepsDivergedDevelop(t *testing.T) {
+	t.Helper()
+	env := setupSupportHotfixWithStaleDevelop(t)
+	env.ExecuteGit("checkout", "develop")
+	env.ExecuteGit("commit", "--allow-empty", "-m", "Local change")
+	env.ExecuteGit("checkout", "support/1.2")
+
+	// pushing the diverged develop branch would be rejected
+	output := env.ExecuteGitflow("hotfix", "finish", "--support", "1.2", "--no-push")
+
+	assert.Contains(t, output, "local branch 'develop' has diverged from 'origin/develop'")
+	env.AssertCommitMessageEquals("Local change", "develop")
+}
+
+// --- Commit identity tests ---
+
+// setupConditionalConfig moves the git configuration of the test repository into a global configuration
+// file, which includes the given settings only for the repository directory (includeIf "gitdir:").
+func setupConditionalConfig(t *testing.T, env *e2e.GitTestEnv, settings string) {
+	t.Helper()
+	dir := t.TempDir()
+
+	includePath := filepath.Join(dir, "conditional.gitconfig")
+	assert.NoError(t, os.WriteFile(includePath, []byte(settings), 0644))
+
+	globalPath := filepath.Join(dir, "global.gitconfig")
+	global := fmt.Sprintf("[includeIf \"gitdir:%s/\"]\n\tpath = %s\n", env.LocalPath, includePath)
+	assert.NoError(t, os.WriteFile(globalPath, []byte(global), 0644))
+	t.Setenv("GIT_CONFIG_GLOBAL", globalPath)
+
+	env.ExecuteGit("config", "--unset", "user.name")
+	env.ExecuteGit("config", "--unset", "user.email")
+}
+
+func RunReleaseStartWithConditionalIdentity(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	setupConditionalConfig(t, env, "[user]\n\tname = Conditional User\n\temail = conditional@example.com\n")
+	env.ExecuteGitflow("release", "start")
+
+	env.AssertCommitMessageEquals("Remove qualifier from project version.", "release/1.1.0")
+	author := env.ExecuteGit("log", "-1", "--format=%an <%ae>", "release/1.1.0")
+	assert.Equal(t, "Conditional User <conditional@example.com>", strings.TrimSpace(author))
+}
+
+func RunReleaseStartWithoutIdentity(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	setupConditionalConfig(t, env, "[user]\n\tuseConfigOnly = true\n")
+	errMsg := env.ExecuteGitflowExpectError("release", "start")
+
+	assert.Contains(t, errMsg, "git has no commit identity")
+	env.AssertBranchDoesNotExist("release/1.1.0")
+}
+
+func RunHotfixFinishWithConditionalTagSigning(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen is not available")
+	}
+	env := e2e.SetupTestEnv(t)
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("hotfix/1.0.1", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.1", "hotfix/1.0.1")
+
+	keyPath := filepath.Join(t.TempDir(), "signing_key")
+	assert.NoError(t, exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", keyPath).Run())
+
+	setupConditionalConfig(t, env, fmt.Sprintf(
+		"[user]\n\tname = Conditional User\n\temail = conditional@example.com\n\tsigningkey = %s\n"+
+			"[gpg]\n\tformat = ssh\n[tag]\n\tgpgSign = true\n", keyPath))
+	env.ExecuteGitflow("hotfix", "finish")
+
+	assert.Equal(t, "tag", strings.TrimSpace(env.ExecuteGit("cat-file", "-t", "1.0.1")))
+	tag := env.ExecuteGit("cat-file", "tag", "1.0.1")
+	assert.Contains(t, tag, "tagger Conditional User <conditional@example.com>")
+	assert.Contains(t, tag, "-----BEGIN SSH SIGNATURE-----")
+}
@@ -0,0 +1,220 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+)
+
+// pullRequestServer simulates the pull requests of the GitHub API: opened pull requests are numbered in the order
+// they are opened and stay open until they are merged.
+type pullRequestServer struct {
+	*httptest.Server
+	mutex  sync.Mutex
+	opened []map[string]string
+	merged map[string]string
+	closed map[string]bool
+}
+
+func newPullRequestServer(t *testing.T) *pullRequestServer {
+	t.Helper()
+
+	server := &pullRequestServer{merged: map[string]string{}, closed: map[string]bool{}}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+
+		if r.Method == http.MethodPost {
+			var pullRequest map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&pullRequest)
+			server.opened = append(server.opened, pullRequest)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"number":%v}`, len(server.opened))
+			return
+		}
+
+		number := path.Base(r.URL.Path)
+		switch {
+		case len(server.merged[number]) > 0:
+			_, _ = fmt.Fprintf(w, `{"state":"closed","merged":true,"merge_commit_sha":%q}`, server.merged[number])
+		case server.closed[number]:
+			_, _ = w.Write([]byte(`{"state":"closed","merged":false}`))
+		default:
+			_, _ = w.Write([]byte(`{"state":"open","merged":false}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	clearGitLabEnv(t)
+	t.Setenv("GITHUB_TOKEN", "release-token")
+	t.Setenv("GITHUB_API_URL", server.URL)
+	return server
+}
+
+// mergeOnRemote merges a branch into a base branch on the remote with a merge commit, as the hosting services do.
+func mergeOnRemote(env *e2e.GitTestEnv, branch, base, message string) string {
+	commit := strings.TrimSpace(env.ExecuteGit("commit-tree", "origin/"+branch+"^{tree}",
+		"-p", "origin/"+base, "-p", "origin/"+branch, "-m", message))
+	env.ExecuteGit("push", "origin", commit+":refs/heads/"+base)
+	return commit
+}
+
+// merge merges the release branch into the base branch of a pull request on the remote, as GitHub does.
+func (s *pullRequestServer) merge(env *e2e.GitTestEnv, number int, branch, base string) string {
+	commit := mergeOnRemote(env, branch, base, fmt.Sprintf("Merge pull request #%v", number))
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.merged[fmt.Sprint(number)] = commit
+	return commit
+}
+
+// pullRequests returns the head and base branches of the opened pull requests.
+func (s *pullRequestServer) pullRequests() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	pullRequests := make([]string, 0, len(s.opened))
+	for _, pullRequest := range s.opened {
+		pullRequests = append(pullRequests, pullRequest["head"]+" -> "+pullRequest["base"])
+	}
+	return pullRequests
+}
+
+func RunReleaseFinishViaPullRequest(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+	server := newPullRequestServer(t)
+	mainBefore := strings.TrimSpace(env.ExecuteGit("rev-parse", "main"))
+
+	// the finish stops until the pull requests of the release are merged
+	errMsg := env.ExecuteGitflowExpectError("release", "finish", "--via-pr")
+	assert.Contains(t, errMsg, "pull request(s) #1, #2 of 'release/1.1.0' are not merged yet")
+	assert.Equal(t, []string{"release/1.1.0 -> main", "release/1.1.0 -> develop"}, server.pullRequests())
+	assert.Equal(t, mainBefore, strings.TrimSpace(env.ExecuteGit("rev-parse", "origin/main")))
+	assert.Empty(t, env.ExecuteGit("tag", "--list", "1.1.0"))
+
+	// a continued finish neither opens the pull requests again nor tags before the merge
+	env.ExecuteGitflowExpectError("release", "continue")
+	assert.Len(t, server.pullRequests(), 2)
+
+	production := server.merge(env, 1, "release/1.1.0", "main")
+	development := server.merge(env, 2, "release/1.1.0", "develop")
+	env.ExecuteGitflow("release", "continue")
+
+	// the merge of the pull request into the production branch is tagged
+	assert.Equal(t, production, strings.TrimSpace(env.ExecuteGit("rev-parse", "1.1.0^{commit}")))
+	assert.NotEmpty(t, env.ExecuteGit("ls-remote", "--tags", "origin", "1.1.0"))
+	assert.Equal(t, production, strings.TrimSpace(env.ExecuteGit("rev-parse", "main")))
+
+	// the protected development branch is left to the pull request of the next version
+	assert.Equal(t, development, strings.TrimSpace(env.ExecuteGit("rev-parse", "origin/develop")))
+	assert.Equal(t, "chore/bump-1.2.0-dev -> develop", server.pullRequests()[2])
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0-dev", "origin/chore/bump-1.2.0-dev")
+	assert.Empty(t, env.ExecuteGit("ls-remote", "origin", "refs/heads/release/1.1.0"))
+}
+
+func RunReleaseFinishViaPullRequestClosed(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+	server := newPullRequestServer(t)
+	server.closed["2"] = true
+
+	errMsg := env.ExecuteGitflowExpectError("release", "finish", "--via-pr", "--wait")
+
+	assert.Contains(t, errMsg, "pull request #2 of 'release/1.1.0' into 'develop' has been closed without merging it")
+	assert.Empty(t, env.ExecuteGit("tag", "--list", "1.1.0"))
+}
+
+func RunReleaseFinishViaPullRequestWithoutToken(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+	clearGitLabEnv(t)
+	t.Setenv("GITHUB_TOKEN", "")
+
+	errMsg := env.ExecuteGitflowExpectError("release", "finish", "--via-pr")
+
+	// the token is checked before anything is changed
+	assert.Contains(t, errMsg, "finishing via pull requests requires a GitHub token in GITHUB_TOKEN or 'github.token'")
+	env.AssertCurrentBranchEquals("release/1.1.0")
+	assert.Empty(t, env.ExecuteGit("tag", "--list", "1.1.0"))
+}
+
+// releaseServer simulates the releases of the GitHub API and records the created release.
+func releaseServer(t *testing.T, release *map[string]any) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/releases") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(release)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"html_url":"https://github.com/org/repo/releases/tag/1.1.0"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	clearGitLabEnv(t)
+	t.Setenv("GITHUB_TOKEN", "release-token")
+	t.Setenv("GITHUB_API_URL", server.URL)
+}
+
+func RunReleaseFinishPublish(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+	env.ExecuteGit("commit", "--allow-empty", "-m", "feat: add login")
+	env.ExecuteGit("push", "origin", "release/1.1.0")
+
+	var release map[string]any
+	releaseServer(t, &release)
+	output := env.ExecuteGitflow("release", "finish", "--publish")
+
+	// the release of the tag holds the changes since the previous release in the format of the changelog
+	assert.Equal(t, "1.1.0", release["tag_name"])
+	assert.Equal(t, "1.1.0", release["name"])
+	assert.Equal(t, false, release["draft"])
+	assert.Contains(t, release["body"], "### Added\n\n- add login")
+	assert.NotContains(t, release["body"], "## [1.1.0]")
+	assert.Contains(t, output, "published the GitHub release of '1.1.0': https://github.com/org/repo/releases/tag/1.1.0")
+	env.AssertTagEquals("1.1.0", "main")
+}
+
+func RunReleaseFinishPublishDraft(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+
+	var release map[string]any
+	releaseServer(t, &release)
+	configPath := env.WriteConfig("github:\n  draft: true\n")
+	output := env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	assert.Equal(t, true, release["draft"])
+	assert.Contains(t, output, "drafted the GitHub release of '1.1.0'")
+}
+
+func RunReleaseFinishPublishWithoutToken(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+	clearGitLabEnv(t)
+	t.Setenv("GITHUB_TOKEN", "")
+
+	errMsg := env.ExecuteGitflowExpectError("release", "finish", "--publish")
+
+	// the release is not tagged without its GitHub release
+	assert.Contains(t, errMsg, "publishing the GitHub release requires a GitHub token in GITHUB_TOKEN or 'github.token'")
+	assert.Empty(t, env.ExecuteGit("tag", "--list", "1.1.0"))
+}
@@ -0,0 +1,179 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+)
+
+// Project of the simulated GitLab API, whose path is escaped in the URLs of the API.
+const gitlabProject = "group/project"
+
+// gitlabServer simulates the merge requests and releases of the GitLab API of a project: opened merge requests are
+// numbered in the order they are opened and stay open until they are merged.
+type gitlabServer struct {
+	*httptest.Server
+	mutex       sync.Mutex
+	opened      []map[string]string
+	merged      map[string]string
+	release     map[string]any
+	credentials []string
+}
+
+// newGitLabServer starts the simulated GitLab API and configures it as in a pipeline of GitLab CI, without enabling
+// the CI mode.
+func newGitLabServer(t *testing.T) *gitlabServer {
+	t.Helper()
+
+	server := &gitlabServer{merged: map[string]string{}}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+
+		server.credentials = append(server.credentials, r.Header.Get("Authorization")+r.Header.Get("JOB-TOKEN"))
+		project := "/api/v4/projects/group%2Fproject/"
+		if !strings.HasPrefix(r.URL.EscapedPath(), project) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch resource := strings.TrimPrefix(r.URL.EscapedPath(), project); {
+		case r.Method == http.MethodPost && resource == "releases":
+			_ = json.NewDecoder(r.Body).Decode(&server.release)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"_links":{"self":"https://gitlab.example.com/group/project/-/releases/1.1.0"}}`))
+
+		case r.Method == http.MethodPost && resource == "merge_requests":
+			var mergeRequest map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&mergeRequest)
+			server.opened = append(server.opened, mergeRequest)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"iid":%v}`, len(server.opened))
+
+		case len(server.merged[path.Base(resource)]) > 0:
+			_, _ = fmt.Fprintf(w, `{"state":"merged","merge_commit_sha":%q}`, server.merged[path.Base(resource)])
+
+		default:
+			_, _ = w.Write([]byte(`{"state":"opened","merge_commit_sha":null}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	clearGitLabEnv(t)
+	t.Setenv("CI_SERVER_URL", server.URL)
+	t.Setenv("CI_API_V4_URL", server.URL+"/api/v4")
+	t.Setenv("CI_PROJECT_PATH", gitlabProject)
+	t.Setenv("GITLAB_TOKEN", "gitlab-token")
+	return server
+}
+
+// merge merges a branch into the target branch of a merge request on the remote, as GitLab does.
+func (s *gitlabServer) merge(env *e2e.GitTestEnv, number int, branch, base string) string {
+	commit := mergeOnRemote(env, branch, base, fmt.Sprintf("Merge branch '%v' into '%v'", branch, base))
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.merged[fmt.Sprint(number)] = commit
+	return commit
+}
+
+// mergeRequests returns the source and target branches of the opened merge requests.
+func (s *gitlabServer) mergeRequests() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	mergeRequests := make([]string, 0, len(s.opened))
+	for _, mergeRequest := range s.opened {
+		mergeRequests = append(mergeRequests, mergeRequest["source_branch"]+" -> "+mergeRequest["target_branch"])
+	}
+	return mergeRequests
+}
+
+func RunReleaseFinishViaMergeRequest(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+	server := newGitLabServer(t)
+
+	// the finish stops until the merge requests of the release are merged
+	errMsg := env.ExecuteGitflowExpectError("release", "finish", "--via-pr")
+	assert.Contains(t, errMsg, "merge request(s) !1, !2 of 'release/1.1.0' are not merged yet")
+	assert.Equal(t, []string{"release/1.1.0 -> main", "release/1.1.0 -> develop"}, server.mergeRequests())
+	assert.Equal(t, "Bearer gitlab-token", server.credentials[0])
+	assert.Empty(t, env.ExecuteGit("tag", "--list", "1.1.0"))
+
+	production := server.merge(env, 1, "release/1.1.0", "main")
+	server.merge(env, 2, "release/1.1.0", "develop")
+	output := env.ExecuteGitflow("release", "continue")
+
+	// the merge into the production branch is tagged and the next version is delivered as merge request
+	assert.Equal(t, production, strings.TrimSpace(env.ExecuteGit("rev-parse", "1.1.0^{commit}")))
+	assert.NotEmpty(t, env.ExecuteGit("ls-remote", "--tags", "origin", "1.1.0"))
+	assert.Equal(t, "chore/bump-1.2.0-dev -> develop", server.mergeRequests()[2])
+	assert.Contains(t, output, "opened merge request !3 of 'chore/bump-1.2.0-dev' into 'develop'")
+}
+
+func RunHotfixFinishViaMergeRequest(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("hotfix/1.0.1", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.1", "hotfix/1.0.1")
+	server := newGitLabServer(t)
+
+	errMsg := env.ExecuteGitflowExpectError("hotfix", "finish", "--via-pr")
+	assert.Contains(t, errMsg, "merge request(s) !1, !2 of 'hotfix/1.0.1' are not merged yet")
+	assert.Equal(t, []string{"hotfix/1.0.1 -> main", "hotfix/1.0.1 -> develop"}, server.mergeRequests())
+
+	production := server.merge(env, 1, "hotfix/1.0.1", "main")
+	server.merge(env, 2, "hotfix/1.0.1", "develop")
+	env.ExecuteGitflow("hotfix", "continue")
+
+	// the merge into the production branch is tagged, the merged branches are not pushed again
+	assert.Equal(t, production, strings.TrimSpace(env.ExecuteGit("rev-parse", "1.0.1^{commit}")))
+	assert.NotEmpty(t, env.ExecuteGit("ls-remote", "--tags", "origin", "1.0.1"))
+	assert.Equal(t, production, strings.TrimSpace(env.ExecuteGit("rev-parse", "origin/main")))
+	env.AssertBranchDoesNotExist("hotfix/1.0.1")
+}
+
+func RunReleaseFinishPublishGitLab(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+	env.ExecuteGit("commit", "--allow-empty", "-m", "fix: handle empty input")
+	env.ExecuteGit("push", "origin", "release/1.1.0")
+
+	// the job token of the pipeline authenticates without an access token
+	server := newGitLabServer(t)
+	t.Setenv("GITLAB_TOKEN", "")
+	t.Setenv("CI_JOB_TOKEN", "job-token")
+	output := env.ExecuteGitflow("release", "finish", "--publish")
+
+	assert.Equal(t, "1.1.0", server.release["tag_name"])
+	assert.Equal(t, "1.1.0", server.release["name"])
+	assert.Contains(t, server.release["description"], "### Fixed\n\n- handle empty input")
+	assert.Equal(t, []string{"job-token"}, server.credentials)
+	assert.Contains(t, output, "published the GitLab release of '1.1.0': https://gitlab.example.com/group/project/-/releases/1.1.0")
+}
+
+func RunReleaseFinishPublishGitLabDraft(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+	newGitLabServer(t)
+
+	errMsg := env.ExecuteGitflowExpectError("release", "finish", "--draft")
+
+	assert.Contains(t, errMsg, "GitLab has no draft releases: publish the release without --draft")
+	assert.Empty(t, env.ExecuteGit("tag", "--list", "1.1.0"))
+}
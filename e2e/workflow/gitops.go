@@ -0,0 +1,109 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Manifest of the GitOps repository, whose image tag is the deployed version.
+const deploymentManifest = "image:\n  repository: registry.example.com/service\n  tag: \"1.0.0\"\n"
+
+// setupGitOpsRepository creates a bare GitOps repository whose main branch holds the deployment manifest.
+func setupGitOpsRepository(t *testing.T, env *e2e.GitTestEnv) string {
+	t.Helper()
+
+	remote := filepath.Join(t.TempDir(), "deployments.git")
+	env.ExecuteGit("init", "--quiet", "--bare", "--initial-branch", "main", remote)
+
+	clone := filepath.Join(t.TempDir(), "deployments")
+	env.ExecuteGit("clone", "--quiet", remote, clone)
+	require.NoError(t, os.MkdirAll(filepath.Join(clone, "apps"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(clone, "apps", "values.yaml"), []byte(deploymentManifest), 0644))
+	env.ExecuteGit("-C", clone, "add", ".")
+	env.ExecuteGit("-C", clone, "-c", "user.name=Deployer", "-c", "user.email=deployer@example.com", "commit", "--quiet", "-m", "Add deployment manifest")
+	env.ExecuteGit("-C", clone, "push", "--quiet", "origin", "HEAD:main")
+
+	return remote
+}
+
+// gitopsConfig returns the configuration of the GitOps repository and its manifest.
+func gitopsConfig(remote string) string {
+	return fmt.Sprintf("gitops:\n  repository: %v\n  path: apps/values.yaml\n  pattern: 'tag: \"([^\"]+)\"'\n", remote)
+}
+
+func RunReleaseFinishGitOps(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+	remote := setupGitOpsRepository(t, env)
+
+	t.Setenv("GITHUB_TOKEN", "")
+	configPath := env.WriteConfig(gitopsConfig(remote))
+	output := env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	// the manifest update is pushed to a branch of its own, whose pull request is opened by hand without token
+	project := filepath.Base(env.LocalPath)
+	branchName := "deploy/" + project + "/1.1.0"
+	assert.Contains(t, output, fmt.Sprintf("open a pull request of '%v' into 'main' of '%v'", branchName, remote))
+
+	manifest := env.ExecuteGit("--git-dir", remote, "show", branchName+":apps/values.yaml")
+	assert.Contains(t, manifest, "tag: \"1.1.0\"")
+	assert.Contains(t, manifest, "repository: registry.example.com/service")
+	subject := env.ExecuteGit("--git-dir", remote, "log", "-1", "--format=%s", branchName)
+	assert.Equal(t, fmt.Sprintf("Deploy %v version 1.1.0.\n", project), subject)
+
+	// the target branch is left to the review of the pull request
+	assert.Contains(t, env.ExecuteGit("--git-dir", remote, "show", "main:apps/values.yaml"), "tag: \"1.0.0\"")
+	env.AssertTagEquals("1.1.0", "main")
+}
+
+func RunReleaseFinishGitOpsPullRequest(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+	remote := setupGitOpsRepository(t, env)
+
+	var pullRequest map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&pullRequest)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"number":12}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("GITHUB_TOKEN", "gitops-token")
+	t.Setenv("GITHUB_API_URL", server.URL)
+	configPath := env.WriteConfig(gitopsConfig(remote))
+	output := env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	// the pull request of the manifest update targets the configured branch of the GitOps repository
+	project := filepath.Base(env.LocalPath)
+	assert.Equal(t, "deploy/"+project+"/1.1.0", pullRequest["head"])
+	assert.Equal(t, "main", pullRequest["base"])
+	assert.Equal(t, fmt.Sprintf("Deploy %v version 1.1.0.", project), pullRequest["title"])
+	assert.Contains(t, output, "opened pull request #12")
+}
+
+func RunReleaseFinishGitOpsInvalidPattern(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+
+	configPath := env.WriteConfig("gitops:\n  repository: https://example.com/deployments.git\n  path: apps/values.yaml\n  pattern: 'tag: .*'\n")
+	errMsg := env.ExecuteGitflowExpectError("release", "finish", "--config", configPath)
+
+	// the pattern is checked before the release is tagged
+	assert.Contains(t, errMsg, "setting 'gitops.pattern' holds 'tag: .*', which is no regular expression with a group for the version")
+	assert.Empty(t, env.ExecuteGit("tag", "--list", "1.1.0"))
+}
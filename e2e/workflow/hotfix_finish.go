@@ -14,7 +14,8 @@ import (
 
 func RunHotfixFinish(t *testing.T, tc plugin.TestConfig) {
 	t.Helper()
-	env := e2e.SetupTestEnv(t, e2e.WithDockerMode(tc.DockerImage != ""))
+	env := e2e.SetupTestEnv(t, e2e.WithDockerMode(tc.DockerImage != ""), e2e.WithProjectFiles(tc.ProjectFiles),
+		e2e.WithVersionNotation(tc.VersionNotation))
 
 	env.CommitTemplateContent(tc.Template, tc.VersionFileName, "1.0.0", "main")
 	env.CommitTemplateContent(tc.Template, tc.VersionFileName, "1.1.0-"+tc.VersionQualifier, "develop")
@@ -28,7 +29,7 @@ func RunHotfixFinish(t *testing.T, tc plugin.TestConfig) {
 	env.ExecuteGitflow("hotfix", "finish")
 
 	env.AssertCommitMessageEquals("Merge branch 'hotfix/1.0.1'", "main")
-	env.AssertTagEquals("1.0.1", "main")
+	env.AssertTagEquals(tc.TagPrefix+"1.0.1", "main")
 	env.AssertTemplateVersionEquals(tc.Template, tc.VersionFileName, "1.0.1", "main")
 
 	env.AssertCommitMessageEquals("Merge branch 'hotfix/1.0.1' into release/1.1.0", "release/1.1.0", 0)
@@ -14,7 +14,8 @@ import (
 
 func RunHotfixStart(t *testing.T, tc plugin.TestConfig) {
 	t.Helper()
-	env := e2e.SetupTestEnv(t, e2e.WithDockerMode(tc.DockerImage != ""))
+	env := e2e.SetupTestEnv(t, e2e.WithDockerMode(tc.DockerImage != ""), e2e.WithProjectFiles(tc.ProjectFiles),
+		e2e.WithVersionNotation(tc.VersionNotation))
 
 	env.CommitTemplateContent(tc.Template, tc.VersionFileName, "1.0.0", "main")
 	env.CommitTemplateContent(tc.Template, tc.VersionFileName, "1.1.0-"+tc.VersionQualifier, "develop")
@@ -45,7 +46,8 @@ func RunHotfixStartFallback(t *testing.T) {
 
 func RunBeforeHotfixStartHook(t *testing.T, tc plugin.TestConfig) {
 	t.Helper()
-	env := e2e.SetupTestEnv(t, e2e.WithDockerMode(tc.DockerImage != ""))
+	env := e2e.SetupTestEnv(t, e2e.WithDockerMode(tc.DockerImage != ""), e2e.WithProjectFiles(tc.ProjectFiles),
+		e2e.WithVersionNotation(tc.VersionNotation))
 
 	env.CommitFile(tc.VersionFileName, tc.EmptyContent, "main")
 
@@ -0,0 +1,50 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+)
+
+func RunInit(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnvWithoutDevelop(t)
+
+	env.ExecuteGitflow("init", "--version-file")
+
+	env.AssertCurrentBranchEquals("develop")
+	env.AssertCommitMessageEquals("Initialize gitflow-cli configuration.", "develop")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.0.0-dev", "develop")
+
+	config := env.ExecuteGit("show", "develop:.gitflow-cli.yaml")
+	assert.Contains(t, config, "production: main")
+	assert.Contains(t, config, "development: develop")
+
+	output := env.ExecuteGit("ls-remote", "--heads", "origin", "develop")
+	assert.Contains(t, output, "refs/heads/develop")
+	assert.Equal(t, env.ExecuteGit("rev-parse", "develop"), env.ExecuteGit("rev-parse", "origin/develop"))
+}
+
+func RunInitExistingDevelop(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	env.ExecuteGitflow("init", "--version-file")
+
+	env.AssertCommitMessageEquals("Initialize gitflow-cli configuration.", "develop")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.AssertCommitMessageEquals("Set up test precondition for main branch", "main")
+
+	// a second run has nothing left to do
+	env.ExecuteGitflow("init", "--version-file")
+
+	env.AssertCommitMessageEquals("Initialize gitflow-cli configuration.", "develop")
+}
@@ -0,0 +1,121 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// releaseAndHotfix adds a release and a hotfix started by the workflow to a released version.
+func releaseAndHotfix(t *testing.T) *e2e.GitTestEnv {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	commitChanges(env, "develop", "Add export feature")
+	env.ExecuteGitflow("release", "start")
+	env.ExecuteGitflow("release", "finish")
+	env.ExecuteGitflow("hotfix", "start")
+	env.ExecuteGitflow("hotfix", "finish")
+
+	return env
+}
+
+func RunMetrics(t *testing.T) {
+	t.Helper()
+	env := releaseAndHotfix(t)
+
+	stdout, err := env.ExecuteGitflowStdout("metrics")
+	require.NoError(t, err)
+
+	var metrics core.Metrics
+	require.NoError(t, json.Unmarshal([]byte(stdout), &metrics))
+
+	assert.Equal(t, filepath.Base(filepath.Dir(env.RemotePath))+"/remote", metrics.Repository)
+	assert.Equal(t, 2, metrics.Releases)
+	assert.Equal(t, 1, metrics.Hotfixes)
+	assert.Greater(t, metrics.ReleasesPerWeek, 0.0)
+	require.Len(t, metrics.Versions, 3)
+
+	// versions are listed newest first, the initial version was not started by the workflow
+	assert.Equal(t, "1.1.1", metrics.Versions[0].Version)
+	assert.Equal(t, "hotfix", metrics.Versions[0].Kind)
+	assert.NotNil(t, metrics.Versions[0].LeadTimeSeconds)
+	assert.Equal(t, "1.1.0", metrics.Versions[1].Version)
+	assert.Equal(t, "release", metrics.Versions[1].Kind)
+	assert.NotNil(t, metrics.Versions[1].LeadTimeSeconds)
+	assert.Equal(t, "1.0.0", metrics.Versions[2].Version)
+	assert.Nil(t, metrics.Versions[2].LeadTimeSeconds)
+}
+
+func RunMetricsCSV(t *testing.T) {
+	t.Helper()
+	env := releaseAndHotfix(t)
+
+	metricsFile := filepath.Join(t.TempDir(), "metrics.csv")
+	output := env.ExecuteGitflow("metrics", "--format", "csv", "--file", metricsFile)
+	assert.Contains(t, output, "Metrics of 3 released version(s) written to '"+metricsFile+"'")
+
+	content, err := os.ReadFile(metricsFile)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, "version,kind,date,lead_time_seconds,commits", lines[0])
+	assert.Regexp(t, `^1\.1\.1,hotfix,[^,]+,[0-9.]+,\d+$`, lines[1])
+	assert.Regexp(t, `^1\.1\.0,release,[^,]+,[0-9.]+,\d+$`, lines[2])
+	assert.Regexp(t, `^1\.0\.0,release,[^,]+,,\d+$`, lines[3])
+}
+
+func RunMetricsPush(t *testing.T) {
+	t.Helper()
+	env := releaseAndHotfix(t)
+
+	var method, path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+		method, path, body = r.Method, r.URL.EscapedPath(), string(content)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	configPath := env.WriteConfig("metrics:\n  pushgateway: " + server.URL + "/\n  job: delivery\n")
+
+	output := env.ExecuteGitflow("metrics", "--push", "--config", configPath)
+	assert.Contains(t, output, "Metrics of 3 released version(s) pushed to '"+server.URL+"'")
+
+	// the metrics of the repository replace its previous metrics, the slash of its name is encoded
+	repository := base64.RawURLEncoding.EncodeToString([]byte(filepath.Base(filepath.Dir(env.RemotePath)) + "/remote"))
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/metrics/job/delivery/repository@base64/"+repository, path)
+	assert.Contains(t, body, "# TYPE gitflow_released_versions gauge\n")
+	assert.Contains(t, body, "gitflow_released_versions{kind=\"release\"} 2\n")
+	assert.Contains(t, body, "gitflow_released_versions{kind=\"hotfix\"} 1\n")
+	assert.Contains(t, body, "gitflow_lead_time_seconds{kind=\"hotfix\"} ")
+	assert.Contains(t, body, "gitflow_last_release_timestamp_seconds ")
+}
+
+func RunMetricsPushWithoutGateway(t *testing.T) {
+	t.Helper()
+	env := releaseAndHotfix(t)
+
+	errMsg := env.ExecuteGitflowExpectError("metrics", "--push")
+
+	assert.Contains(t, errMsg, "no Prometheus pushgateway is configured under 'metrics.pushgateway'")
+}
@@ -0,0 +1,79 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupPlannedRelease creates a test environment with a development version and writes the execution plan of
+// the release start to a file.
+func setupPlannedRelease(t *testing.T) (*e2e.GitTestEnv, string) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	env.ExecuteGitflow("release", "start", "--plan-out", planPath)
+
+	return env, planPath
+}
+
+func RunReleaseStartPlan(t *testing.T) {
+	t.Helper()
+	env, planPath := setupPlannedRelease(t)
+
+	// the plan is recorded by a dry run, which does not change the repository
+	env.AssertBranchDoesNotExist("release/1.1.0")
+
+	plan, err := core.ReadPlan(planPath)
+	require.NoError(t, err)
+	assert.Equal(t, "release start", plan.Command)
+	assert.Contains(t, plan.Refs, "refs/heads/develop")
+	assert.Contains(t, plan.Refs, "refs/remotes/origin/develop")
+	assert.Contains(t, plan.Steps, core.PlanStep{Git: []string{"switch", "-c", "release/1.1.0"}})
+	assert.Contains(t, plan.Steps, core.PlanStep{Branch: "release/1.1.0", Version: "1.1.0", File: "version.txt"})
+
+	env.ExecuteGitflow("release", "start", "--plan-in", planPath)
+
+	env.AssertBranchExists("release/1.1.0")
+	env.AssertBranchExists("origin/release/1.1.0")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+}
+
+func RunReleaseStartPlanMismatch(t *testing.T) {
+	t.Helper()
+	env, planPath := setupPlannedRelease(t)
+
+	// the development branch moves after the plan was approved
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.2.0-dev", "develop")
+
+	errMsg := env.ExecuteGitflowExpectError("release", "start", "--plan-in", planPath)
+
+	assert.Contains(t, errMsg, "does not match the repository anymore")
+	assert.Contains(t, errMsg, "moved from")
+	env.AssertBranchDoesNotExist("release/1.1.0")
+	env.AssertBranchDoesNotExist("release/1.2.0")
+}
+
+func RunPlanOtherCommand(t *testing.T) {
+	t.Helper()
+	env, planPath := setupPlannedRelease(t)
+
+	// an approved plan only executes the command it was recorded for
+	errMsg := env.ExecuteGitflowExpectError("hotfix", "start", "--plan-in", planPath)
+
+	assert.Contains(t, errMsg, "command 'hotfix start' instead of 'release start'")
+	env.AssertBranchDoesNotExist("hotfix/1.0.1")
+}
@@ -0,0 +1,142 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+)
+
+// Configurations of the OneFlow and GitLab Flow presets.
+const (
+	oneflowConfig    = "workflow:\n  preset: oneflow\n"
+	gitlabFlowConfig = "workflow:\n  preset: gitlab-flow\n"
+)
+
+// setupMainline creates a test environment with version 1.0.0 released on the mainline, which carries the next
+// development version.
+func setupMainline(t *testing.T) *e2e.GitTestEnv {
+	t.Helper()
+	env := e2e.SetupTestEnvWithoutDevelop(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.ExecuteGit("tag", "1.0.0", "main")
+	env.ExecuteGit("push", "origin", "1.0.0")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "main")
+
+	return env
+}
+
+func RunOneFlowRelease(t *testing.T) {
+	t.Helper()
+	env := setupMainline(t)
+
+	configPath := env.WriteConfig(oneflowConfig)
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	// the release is merged into the mainline and tagged there, which then carries the next development version
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0", "1.1.0")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0-dev", "main")
+	env.AssertCommitMessageEquals("Set next minor project version.", "main")
+	_, err := env.ExecuteGitAllowError("merge-base", "--is-ancestor", "1.1.0", "main")
+	assert.NoError(t, err)
+	env.AssertBranchDoesNotExist("release/1.1.0")
+	env.AssertBranchDoesNotExist("develop")
+}
+
+func RunOneFlowHotfix(t *testing.T) {
+	t.Helper()
+	env := setupMainline(t)
+
+	configPath := env.WriteConfig(oneflowConfig)
+	env.ExecuteGitflow("hotfix", "start", "--config", configPath)
+
+	// the hotfix starts from the latest release, not from the development version of the mainline
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.0.1", "hotfix/1.0.1")
+	assert.Equal(t, env.ExecuteGit("rev-parse", "1.0.0"), env.ExecuteGit("rev-parse", "hotfix/1.0.1~1"))
+
+	env.CommitFile("fix.txt", []byte("fix"), "hotfix/1.0.1")
+	env.ExecuteGitflow("hotfix", "finish", "--config", configPath)
+
+	// the hotfix branch is tagged, and merged into the mainline, which keeps its development version
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.0.1", "1.0.1")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0-dev", "main")
+	_, err := env.ExecuteGitAllowError("merge-base", "--is-ancestor", "1.0.1", "main")
+	assert.NoError(t, err)
+	env.AssertBranchDoesNotExist("hotfix/1.0.1")
+}
+
+func RunOneFlowHotfixWithoutRelease(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnvWithoutDevelop(t)
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0-dev", "main")
+
+	configPath := env.WriteConfig(oneflowConfig)
+	errMsg := env.ExecuteGitflowExpectError("hotfix", "start", "--config", configPath)
+
+	assert.Contains(t, errMsg, "hotfixes of the mainline 'main' start from the latest release, but there is no release yet")
+	env.AssertBranchDoesNotExist("hotfix/1.0.1")
+}
+
+func RunGitLabFlowPromote(t *testing.T) {
+	t.Helper()
+	env := setupMainline(t)
+
+	configPath := env.WriteConfig(gitlabFlowConfig)
+
+	// the environment branches are promoted in the order of the preset
+	errMsg := env.ExecuteGitflowExpectError("promote", "1.0.0", "--to", "production", "--config", configPath)
+	assert.Contains(t, errMsg, "version '1.0.0' must be promoted to 'pre-production' before 'production'")
+
+	env.ExecuteGitflow("promote", "1.0.0", "--to", "pre-production", "--config", configPath)
+	env.ExecuteGitflow("promote", "1.0.0", "--to", "production", "--config", configPath)
+
+	// the environment branches hold the release commit, no environment tags are created
+	release := env.ExecuteGit("rev-parse", "1.0.0^{commit}")
+	assert.Equal(t, release, env.ExecuteGit("rev-parse", "origin/pre-production"))
+	assert.Equal(t, release, env.ExecuteGit("rev-parse", "origin/production"))
+	assert.Empty(t, env.ExecuteGit("tag", "--list", "*/1.0.0"))
+
+	errMsg = env.ExecuteGitflowExpectError("promote", "1.0.0", "--to", "production", "--config", configPath)
+	assert.Contains(t, errMsg, "version '1.0.0' has already been promoted to 'production'")
+}
+
+func RunGitLabFlowPromoteDiverged(t *testing.T) {
+	t.Helper()
+	env := setupMainline(t)
+
+	configPath := env.WriteConfig(gitlabFlowConfig)
+	env.ExecuteGit("push", "origin", "1.0.0^{commit}:refs/heads/pre-production")
+	env.CreateBranch("pre-production", "origin/pre-production")
+	env.CommitFile("hotfix.txt", []byte("direct change"), "pre-production")
+	env.ExecuteGit("checkout", "main")
+
+	env.ExecuteGit("tag", "1.1.0", "main")
+	env.ExecuteGit("push", "origin", "1.1.0")
+
+	// an environment branch with commits of its own is not moved
+	errMsg := env.ExecuteGitflowExpectError("promote", "1.1.0", "--to", "pre-production", "--config", configPath)
+	assert.Contains(t, errMsg, "environment branch 'pre-production' has commits which are not part of version '1.1.0'")
+}
+
+func RunPresetInvalid(t *testing.T) {
+	t.Helper()
+	env := setupMainline(t)
+
+	configPath := env.WriteConfig("workflow:\n  preset: trunk\n")
+	errMsg := env.ExecuteGitflowExpectError("release", "start", "--config", configPath)
+	assert.Contains(t, errMsg, "setting 'workflow.preset' holds 'trunk', which is none of gitflow, oneflow, gitlab-flow")
+
+	// a mainline has no development branch of its own to open pull requests against
+	configPath = env.WriteConfig("workflow:\n  preset: oneflow\n  development-bump: pull-request\n")
+	errMsg = env.ExecuteGitflowExpectError("release", "start", "--config", configPath)
+	assert.Contains(t, errMsg, "setting 'workflow.development-bump' holds 'pull-request'")
+}
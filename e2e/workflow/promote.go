@@ -0,0 +1,70 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+)
+
+const environmentsConfig = "environments:\n  - staging\n  - prod\n"
+
+// setupReleasedVersion creates a test environment with version 1.0.0 released on main.
+func setupReleasedVersion(t *testing.T) *e2e.GitTestEnv {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.ExecuteGit("tag", "1.0.0", "main")
+	env.ExecuteGit("push", "origin", "1.0.0")
+
+	return env
+}
+
+func RunPromote(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	configPath := env.WriteConfig(environmentsConfig)
+	env.ExecuteGitflow("promote", "1.0.0", "--to", "staging", "--config", configPath)
+	env.ExecuteGitflow("promote", "1.0.0", "--to", "prod", "--config", configPath)
+
+	env.AssertTagEquals("1.0.0\nprod/1.0.0\nstaging/1.0.0", "main")
+	output := env.ExecuteGit("ls-remote", "--tags", "origin", "prod/1.0.0")
+	assert.Contains(t, output, "refs/tags/prod/1.0.0")
+}
+
+func RunPromoteSkippingEnvironment(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	configPath := env.WriteConfig(environmentsConfig)
+	errMsg := env.ExecuteGitflowExpectError("promote", "1.0.0", "--to", "prod", "--config", configPath)
+
+	assert.Contains(t, errMsg, "must be promoted to 'staging' before 'prod'")
+}
+
+func RunPromoteUnreleasedVersion(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	configPath := env.WriteConfig(environmentsConfig)
+	errMsg := env.ExecuteGitflowExpectError("promote", "1.1.0", "--to", "staging", "--config", configPath)
+
+	assert.Contains(t, errMsg, "has not been released")
+}
+
+func RunPromoteUnknownEnvironment(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	configPath := env.WriteConfig(environmentsConfig)
+	errMsg := env.ExecuteGitflowExpectError("promote", "1.0.0", "--to", "qa", "--config", configPath)
+
+	assert.Contains(t, errMsg, "environment 'qa' is not configured")
+}
@@ -0,0 +1,91 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Qualifiers per type of branch of the standard plugin.
+const qualifiersConfig = `standard:
+  qualifiers:
+    development: SNAPSHOT
+    release: rc
+    hotfix: fix
+`
+
+func RunReleaseQualifier(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+	configPath := env.WriteConfig(qualifiersConfig)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-SNAPSHOT", "develop")
+
+	// the release branch holds a release candidate, which passes the check of the branch
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0-rc", "release/1.1.0")
+	output := env.ExecuteGitflow("check", "release/1.1.0", "--config", configPath)
+	assert.Contains(t, output, "Check of branch release/1.1.0 completed")
+
+	// the qualifier is removed after the release branch is merged into the production branch
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+	env.AssertCommitMessageEquals("Remove qualifier of the released version.", "main")
+	env.AssertCommitMessageEquals("Merge branch 'release/1.1.0'", "main", 1)
+	env.AssertTagEquals("1.1.0", "main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0", "main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0-SNAPSHOT", "develop")
+}
+
+func RunHotfixQualifier(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+	configPath := env.WriteConfig(qualifiersConfig)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-SNAPSHOT", "develop")
+
+	env.ExecuteGitflow("hotfix", "start", "--config", configPath)
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.0.1-fix", "hotfix/1.0.1")
+
+	env.ExecuteGitflow("hotfix", "finish", "--config", configPath)
+	env.AssertCommitMessageEquals("Remove qualifier of the released version.", "main")
+	env.AssertTagEquals("1.0.1", "main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.0.1", "main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0-SNAPSHOT", "develop")
+}
+
+func RunNumberedReleaseQualifier(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+	configPath := env.WriteConfig("standard:\n  qualifiers:\n    release: rc.1\n")
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0-rc.1", "release/1.1.0")
+
+	// further iterations of the release candidate pass the check of the branch
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-rc.2", "release/1.1.0")
+	output := env.ExecuteGitflow("check", "release/1.1.0", "--config", configPath)
+	assert.Contains(t, output, "Check of branch release/1.1.0 completed")
+
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+	env.AssertTagEquals("1.1.0", "main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0", "main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0-dev", "develop")
+}
+
+func RunCheckQualifierViolation(t *testing.T) {
+	t.Helper()
+	env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+	configPath := env.WriteConfig(qualifiersConfig)
+
+	// the release branch lacks the configured qualifier of release candidates
+	errMsg := env.ExecuteGitflowExpectError("check", "release/1.1.0", "--config", configPath)
+
+	assert.Contains(t, errMsg, "found 1 violation(s) of the workflow rules on branch 'release/1.1.0'")
+}
@@ -0,0 +1,105 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupHalfFinishedRelease merges a release branch into main and tags it by hand, but not into develop.
+func setupHalfFinishedRelease(t *testing.T) *e2e.GitTestEnv {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	env.ExecuteGit("checkout", "main")
+	env.ExecuteGit("merge", "--no-ff", "-X", "theirs", "release/1.1.0", "-m", "Merge branch 'release/1.1.0'")
+	env.ExecuteGit("tag", "1.1.0")
+	env.ExecuteGit("push", "origin", "main", "1.1.0")
+
+	return env
+}
+
+func RunRecoverReleaseFromHistory(t *testing.T) {
+	t.Helper()
+	env := setupHalfFinishedRelease(t)
+
+	output := env.ExecuteGitflow("recover")
+
+	assert.Contains(t, output, "release finish of 'release/1.1.0' is half-completed according to the branches and tags, remaining steps from 'checkout-development'")
+	assert.Contains(t, output, "merge-development")
+	assert.NotContains(t, output, "tag-release")
+	env.AssertBranchExists("release/1.1.0")
+
+	output = env.ExecuteGitflow("recover", "--execute")
+
+	assert.Contains(t, output, "completed")
+	env.AssertTagEquals("1.1.0", "main")
+	env.AssertCommitMessageEquals("Merge branch 'release/1.1.0' into develop", "develop", 1)
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0-dev", "develop")
+	env.AssertBranchDoesNotExist("release/1.1.0")
+	assert.Empty(t, env.ExecuteGit("ls-remote", "--heads", "origin", "release/1.1.0"))
+}
+
+func RunRecoverBranchNotDeleted(t *testing.T) {
+	t.Helper()
+	env := setupHalfFinishedRelease(t)
+
+	// the release has been merged into develop as well, but its branch has been deleted locally only
+	env.ExecuteGit("checkout", "develop")
+	env.ExecuteGit("merge", "--no-ff", "-X", "ours", "release/1.1.0", "-m", "Merge branch 'release/1.1.0' into develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.2.0-dev", "develop")
+	env.ExecuteGit("commit", "--amend", "-m", "Set next minor project version.")
+	env.ExecuteGit("push", "--force", "origin", "develop")
+	env.ExecuteGit("branch", "-D", "release/1.1.0")
+
+	output := env.ExecuteGitflow("recover", "--execute")
+
+	assert.Contains(t, output, "remaining steps from 'fast-forward'")
+	assert.Empty(t, env.ExecuteGit("ls-remote", "--heads", "origin", "release/1.1.0"))
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0-dev", "develop")
+}
+
+func RunRecoverFromState(t *testing.T) {
+	t.Helper()
+	env := setupPausedHotfixFinish(t)
+
+	// resolve the conflicts by hand and commit the merge
+	env.ExecuteGit("checkout", "--ours", "version.txt")
+	env.ExecuteGit("checkout", "--theirs", "README.md")
+	env.ExecuteGit("add", "version.txt", "README.md")
+	env.ExecuteGit("commit", "--no-edit")
+
+	output := env.ExecuteGitflow("recover", "--execute")
+
+	assert.Contains(t, output, "hotfix finish of 'hotfix/1.0.1' was interrupted according to its state file, remaining steps from 'merge-development'")
+	assert.NoFileExists(t, filepath.Join(env.LocalPath, ".git", "gitflow-cli", "state.json"))
+	env.AssertBranchDoesNotExist("hotfix/1.0.1")
+	env.AssertTagEquals("1.0.1", "main")
+}
+
+func RunRecoverNothing(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	// an open release branch is no half-completed finish
+	output := env.ExecuteGitflow("recover")
+
+	assert.Contains(t, output, "no half-completed release or hotfix finish found")
+}
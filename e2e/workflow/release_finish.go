@@ -6,15 +6,25 @@ SPDX-License-Identifier: MIT
 package workflow
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/mercedes-benz/gitflow-cli/core/plugin"
 	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func RunReleaseFinish(t *testing.T, tc plugin.TestConfig) {
 	t.Helper()
-	env := e2e.SetupTestEnv(t, e2e.WithDockerMode(tc.DockerImage != ""))
+	env := e2e.SetupTestEnv(t, e2e.WithDockerMode(tc.DockerImage != ""), e2e.WithProjectFiles(tc.ProjectFiles),
+		e2e.WithVersionNotation(tc.VersionNotation))
 
 	env.CommitTemplateContent(tc.Template, tc.VersionFileName, "1.0.0", "main")
 	env.CommitTemplateContent(tc.Template, tc.VersionFileName, "1.1.0-"+tc.VersionQualifier, "develop")
@@ -24,7 +34,7 @@ func RunReleaseFinish(t *testing.T, tc plugin.TestConfig) {
 	env.ExecuteGitflow("release", "finish")
 
 	env.AssertCommitMessageEquals("Merge branch 'release/1.1.0'", "main")
-	env.AssertTagEquals("1.1.0", "main")
+	env.AssertTagEquals(tc.TagPrefix+"1.1.0", "main")
 	env.AssertTemplateVersionEquals(tc.Template, tc.VersionFileName, "1.1.0", "main")
 
 	env.AssertCommitMessageEquals("Merge branch 'release/1.1.0' into develop", "develop", 1)
@@ -56,3 +66,471 @@ func RunReleaseFinishFallback(t *testing.T) {
 	env.AssertBranchDoesNotExist("release/1.0.0")
 	env.AssertCurrentBranchEquals("develop")
 }
+
+func RunReleaseFinishWithSBOM(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0-dev", "develop")
+	env.CreateBranch("release/1.0.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "release/1.0.0")
+
+	configPath := env.WriteConfig("sbom:\n  command: 'echo {\"bomFormat\":\"CycloneDX\"}'\n  path: sbom.json\n")
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	env.AssertCommitMessageEquals("Add SBOM for release 1.0.0.", "main")
+	env.AssertCommitMessageEquals("Merge branch 'release/1.0.0'", "main", 1)
+	env.AssertTagEquals("1.0.0", "main")
+	assert.Equal(t, `{"bomFormat":"CycloneDX"}`, strings.TrimSpace(env.ExecuteGit("show", "main:sbom.json")))
+
+	env.AssertBranchDoesNotExist("release/1.0.0")
+	env.AssertCurrentBranchEquals("develop")
+}
+
+func RunReleaseFinishWithUnknownSBOMGenerator(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0-dev", "develop")
+	env.CreateBranch("release/1.0.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "release/1.0.0")
+
+	configPath := env.WriteConfig("sbom:\n  generator: unknown\n")
+	errMsg := env.ExecuteGitflowExpectError("release", "finish", "--config", configPath)
+
+	assert.Contains(t, errMsg, "unsupported SBOM generator 'unknown'")
+	env.AssertBranchExists("release/1.0.0")
+}
+
+func RunReleaseFinishWithProvenance(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0-dev", "develop")
+	env.CreateBranch("release/1.0.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "release/1.0.0")
+
+	configPath := env.WriteConfig("provenance:\n  enabled: true\n  sign: cp {file} {file}.sig\n")
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	env.AssertTagEquals("1.0.0", "main")
+	commitHash := strings.TrimSpace(env.ExecuteGit("rev-parse", "1.0.0^{commit}"))
+
+	statementPath := filepath.Join(env.LocalPath, ".git", "gitflow-cli", "provenance", "1.0.0.intoto.json")
+	content, err := os.ReadFile(statementPath)
+	require.NoError(t, err)
+
+	var statement struct {
+		Type          string `json:"_type"`
+		PredicateType string `json:"predicateType"`
+		Subject       []struct {
+			Name   string            `json:"name"`
+			Digest map[string]string `json:"digest"`
+		} `json:"subject"`
+	}
+	require.NoError(t, json.Unmarshal(content, &statement))
+	assert.Equal(t, "https://in-toto.io/Statement/v1", statement.Type)
+	assert.Equal(t, "https://slsa.dev/provenance/v1", statement.PredicateType)
+	require.Len(t, statement.Subject, 1)
+	assert.Equal(t, "1.0.0", statement.Subject[0].Name)
+	assert.Equal(t, commitHash, statement.Subject[0].Digest["gitCommit"])
+
+	assert.FileExists(t, statementPath+".sig")
+}
+
+func RunReleaseFinishWithProvenanceInWorktree(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0-dev", "develop")
+	env.CreateBranch("release/1.0.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "release/1.0.0")
+
+	// the statement is written to the git directory of the linked worktree, whose '.git' is a file
+	worktree := filepath.Join(t.TempDir(), "worktree")
+	env.ExecuteGit("checkout", "--detach")
+	env.ExecuteGit("worktree", "add", worktree, "release/1.0.0")
+	env.LocalPath = worktree
+
+	configPath := env.WriteConfig("provenance:\n  enabled: true\n")
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	statementPath := strings.TrimSpace(env.ExecuteGit("rev-parse", "--git-path", "gitflow-cli/provenance/1.0.0.intoto.json"))
+	assert.FileExists(t, statementPath)
+}
+
+func RunReleaseFinishFastForwardDevelop(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	env.ExecuteGitflow("release", "finish", "--fast-forward-develop")
+
+	env.AssertCommitMessageEquals("Merge branch 'release/1.1.0'", "main")
+	env.AssertTagEquals("1.1.0", "main")
+
+	// develop without commits of its own is fast-forwarded to main before the next version is committed
+	assert.Equal(t, env.ExecuteGit("rev-parse", "main"), env.ExecuteGit("rev-parse", "develop~1"))
+	env.AssertCommitMessageEquals("Set next minor project version.", "develop", 0)
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0-dev", "develop")
+
+	env.AssertBranchDoesNotExist("release/1.1.0")
+	env.AssertCurrentBranchEquals("develop")
+}
+
+func RunReleaseFinishFastForwardDevelopDiverged(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+	commitChanges(env, "develop", "Add feature after release start")
+
+	env.ExecuteGitflow("release", "finish", "--fast-forward-develop")
+
+	// develop with commits of its own gets main merged instead of the release branch
+	env.AssertCommitMessageEquals("Merge branch 'main' into develop", "develop", 1)
+	env.AssertCommitMessageEquals("Set next minor project version.", "develop", 0)
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0-dev", "develop")
+	assert.Empty(t, env.ExecuteGit("rev-list", "develop..main"))
+}
+
+func RunBugfixFinishWithoutPruneHint(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("bugfix/login-timeout", "develop")
+	env.CommitFile("fix.txt", []byte("fixed"), "bugfix/login-timeout")
+
+	configPath := env.WriteConfig("workflow:\n  prune-hint: false\n")
+	output := env.ExecuteGitflow("bugfix", "finish", "--config", configPath)
+
+	assert.Empty(t, env.ExecuteGit("ls-remote", "--heads", "origin", "bugfix/login-timeout"))
+	assert.NotContains(t, output, "git fetch --prune")
+}
+
+func RunReleaseFinishWithHeadTag(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+	releaseHead := env.ExecuteGit("rev-parse", "release/1.1.0")
+
+	configPath := env.WriteConfig("workflow:\n  release-head-tag: \"{version}-branchpoint\"\n")
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	// the head tag keeps the release branch tip, which is the second parent of the production merge
+	env.AssertTagEquals("1.1.0", "main")
+	assert.Equal(t, releaseHead, env.ExecuteGit("rev-parse", "1.1.0-branchpoint^{commit}"))
+	assert.Equal(t, releaseHead, env.ExecuteGit("rev-parse", "main^2"))
+	assert.NotEmpty(t, env.ExecuteGit("ls-remote", "--tags", "origin", "1.1.0-branchpoint"))
+
+	env.AssertBranchDoesNotExist("release/1.1.0")
+}
+
+func RunReleaseFinishWithPreTagHook(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	// the hook marks the commit it gets, so that the test sees which commit has been verified
+	configPath := env.WriteConfig("hooks:\n  pre-tag: git tag verified-{version} {commit}\n")
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	assert.Equal(t, env.ExecuteGit("rev-parse", "1.1.0^{commit}"), env.ExecuteGit("rev-parse", "verified-1.1.0^{commit}"))
+	assert.Equal(t, env.ExecuteGit("rev-parse", "main"), env.ExecuteGit("rev-parse", "1.1.0^{commit}"))
+	env.AssertBranchDoesNotExist("release/1.1.0")
+}
+
+func RunReleaseFinishPreTagHookVeto(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	configPath := env.WriteConfig("hooks:\n  pre-tag: git cat-file -e {commit}:missing-scan-report.json\n")
+	errMsg := env.ExecuteGitflowExpectError("release", "finish", "--config", configPath)
+
+	// the failing hook vetoes the tag, the release branch is kept to fix the cause and continue the release
+	assert.Contains(t, errMsg, "pre-tag hook vetoed tag '1.1.0'")
+	assert.Empty(t, env.ExecuteGit("tag", "--list", "1.1.0"))
+	env.AssertBranchExists("release/1.1.0")
+}
+
+func RunReleaseFinishInvalidCommands(t *testing.T) {
+	t.Helper()
+
+	cases := map[string]struct {
+		config   string
+		variable string
+		message  string
+	}{
+		"missing executable": {
+			config:  "hooks:\n  pre-tag: gitflow-missing-verifier {commit}\n",
+			message: "setting 'hooks.pre-tag' of {config} holds 'gitflow-missing-verifier {commit}', whose executable cannot be found",
+		},
+		"missing script": {
+			config:  "sbom:\n  command: ./generate-sbom.sh\n",
+			message: "setting 'sbom.command' of {config} holds './generate-sbom.sh', whose executable cannot be found",
+		},
+		"blank command": {
+			config:  "hooks:\n  pre-tag: ' '\n",
+			message: "setting 'hooks.pre-tag' of {config} holds no command",
+		},
+		"inserted step without command": {
+			config:  "workflow:\n  steps:\n    release-finish:\n      insert:\n        - name: verify\n          after: tag-release\n",
+			message: "setting 'workflow.steps.release-finish.insert.verify' of {config} holds no command",
+		},
+		"environment variable": {
+			config:   "provenance:\n  enabled: true\n",
+			variable: "GITFLOW_PROVENANCE__SIGN",
+			message:  "setting 'provenance.sign' of GITFLOW_PROVENANCE__SIGN holds 'gitflow-missing-signer {file}', whose executable cannot be found",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			env := setupReleaseBranch(t, "release/1.1.0", "1.1.0")
+			if len(c.variable) > 0 {
+				t.Setenv(c.variable, "gitflow-missing-signer {file}")
+			}
+
+			configPath := env.WriteConfig(c.config)
+			errMsg := env.ExecuteGitflowExpectError("release", "finish", "--config", configPath)
+
+			// the command is rejected along with its config file or variable before any step runs
+			assert.Contains(t, errMsg, strings.ReplaceAll(c.message, "{config}", configPath))
+			assert.Empty(t, env.ExecuteGit("tag", "--list", "1.1.0"))
+			env.AssertBranchExists("release/1.1.0")
+		})
+	}
+}
+
+func RunReleaseFinishWithTagPrefix(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	configPath := env.WriteConfig("workflow:\n  tag-prefix: v\n")
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	// the version file holds the version, the tag has the prefix
+	env.AssertTagEquals("v1.1.0", "main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0", "main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0-dev", "develop")
+
+	// an explicit version is checked against the prefixed tags
+	errMsg := env.ExecuteGitflowExpectError("release", "start", "1.1.0", "--config", configPath)
+	assert.Contains(t, errMsg, "version '1.1.0' has already been released")
+}
+
+// setupDevelopmentBumpPullRequest creates a test environment with a release branch whose next development version
+// is delivered as pull request.
+func setupDevelopmentBumpPullRequest(t *testing.T) (*e2e.GitTestEnv, string) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	return env, env.WriteConfig("workflow:\n  development-bump: pull-request\n")
+}
+
+func RunReleaseFinishDevelopmentBumpPullRequest(t *testing.T) {
+	t.Helper()
+	env, configPath := setupDevelopmentBumpPullRequest(t)
+	clearGitLabEnv(t)
+	t.Setenv("GITHUB_TOKEN", "")
+
+	developBefore := strings.TrimSpace(env.ExecuteGit("rev-parse", "origin/develop"))
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	// the production branch is released as usual
+	env.AssertTagEquals("1.1.0", "main")
+	env.AssertBranchDoesNotExist("origin/release/1.1.0")
+
+	// the development branch is left untouched, the next version is pushed to the branch of the pull request
+	assert.Equal(t, developBefore, strings.TrimSpace(env.ExecuteGit("rev-parse", "origin/develop")))
+	assert.Equal(t, developBefore, strings.TrimSpace(env.ExecuteGit("rev-parse", "develop")))
+	env.AssertCurrentBranchEquals("develop")
+	env.AssertBranchExists("origin/chore/bump-1.2.0-dev")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0-dev", "origin/chore/bump-1.2.0-dev")
+	env.AssertCommitMessageEquals("Set next minor project version.", "origin/chore/bump-1.2.0-dev")
+
+	// the branch of the pull request holds the merge of the release
+	env.ExecuteGit("merge-base", "--is-ancestor", "main^2", "origin/chore/bump-1.2.0-dev")
+}
+
+func RunReleaseFinishDevelopmentBumpGitHub(t *testing.T) {
+	t.Helper()
+	env, configPath := setupDevelopmentBumpPullRequest(t)
+
+	var requestPath, authorization string
+	var pullRequest map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath, authorization = r.URL.Path, r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&pullRequest)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"number":7}`))
+	}))
+	defer server.Close()
+
+	clearGitLabEnv(t)
+	t.Setenv("GITHUB_TOKEN", "bump-token")
+	t.Setenv("GITHUB_API_URL", server.URL)
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	assert.True(t, strings.HasSuffix(requestPath, "/pulls"), requestPath)
+	assert.Equal(t, "Bearer bump-token", authorization)
+	assert.Equal(t, "chore/bump-1.2.0-dev", pullRequest["head"])
+	assert.Equal(t, "develop", pullRequest["base"])
+	env.AssertBranchExists("origin/chore/bump-1.2.0-dev")
+}
+
+// Identity of the commits created by the tool, which differs from the identity of the repository.
+const commitIdentityConfig = `core:
+  commit:
+    name: Release Bot
+    email: bot@example.com
+    author: Jane Doe <jane@example.com>
+`
+
+func RunReleaseFinishWithCommitIdentity(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	configPath := env.WriteConfig(commitIdentityConfig)
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	// merge commits and version bumps get the configured author and committer
+	env.AssertCommitMessageEquals("Merge branch 'release/1.1.0'", "main")
+	env.AssertCommitMessageEquals("Set next minor project version.", "develop")
+	for _, ref := range []string{"main", "develop", "develop^"} {
+		identity := strings.TrimSpace(env.ExecuteGit("log", "-1", "--format=%an <%ae>|%cn <%ce>", ref))
+		assert.Equal(t, "Jane Doe <jane@example.com>|Release Bot <bot@example.com>", identity, ref)
+	}
+
+	// commits of the repository keep their identity
+	identity := strings.TrimSpace(env.ExecuteGit("log", "-1", "--format=%an", "main^2"))
+	assert.Equal(t, "Test User", identity)
+}
+
+func RunReleaseFinishWithInvalidCommitAuthor(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	configPath := env.WriteConfig("core:\n  commit:\n    author: jane@example.com\n")
+	errMsg := env.ExecuteGitflowExpectError("release", "finish", "--config", configPath)
+
+	assert.Contains(t, errMsg, "setting 'core.commit.author' holds 'jane@example.com', which is no identity")
+	env.AssertBranchExists("release/1.1.0")
+}
+
+func RunReleaseFinishWithSignedCommits(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen is not available")
+	}
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	// the signing key is configured, but git does not request signed commits (commit.gpgSign)
+	keyPath := filepath.Join(t.TempDir(), "signing_key")
+	require.NoError(t, exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", keyPath).Run())
+	env.ExecuteGit("config", "gpg.format", "ssh")
+	env.ExecuteGit("config", "user.signingkey", keyPath)
+
+	configPath := env.WriteConfig("core:\n  commit:\n    sign: true\n")
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	// merge commits and version bumps are signed, the commits of the repository are not
+	for _, ref := range []string{"main", "develop", "develop^"} {
+		assert.Contains(t, env.ExecuteGit("cat-file", "commit", ref), "-----BEGIN SSH SIGNATURE-----", ref)
+	}
+	assert.NotContains(t, env.ExecuteGit("cat-file", "commit", "main^2"), "-----BEGIN SSH SIGNATURE-----")
+}
+
+func RunReleaseFinishWithAnnotatedTag(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	configPath := env.WriteConfig("workflow:\n  tag-prefix: v\ncore:\n  tag:\n    annotated: true\n" +
+		"    message: Release {version} ({tag})\n")
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	env.AssertTagEquals("v1.1.0", "main")
+	assert.Equal(t, "tag", strings.TrimSpace(env.ExecuteGit("cat-file", "-t", "v1.1.0")))
+	tag := env.ExecuteGit("cat-file", "tag", "v1.1.0")
+	assert.Contains(t, tag, "Release 1.1.0 (v1.1.0)")
+	assert.NotContains(t, tag, "-----BEGIN")
+}
+
+func RunReleaseFinishWithSignedTag(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen is not available")
+	}
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	// the signing key is configured, but git does not request signed tags (tag.gpgSign)
+	keyPath := filepath.Join(t.TempDir(), "signing_key")
+	require.NoError(t, exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", keyPath).Run())
+	env.ExecuteGit("config", "gpg.format", "ssh")
+	env.ExecuteGit("config", "user.signingkey", keyPath)
+
+	configPath := env.WriteConfig("core:\n  tag:\n    sign: true\n")
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	env.AssertTagEquals("1.1.0", "main")
+	tag := env.ExecuteGit("cat-file", "tag", "1.1.0")
+	assert.Contains(t, tag, "\n1.1.0\n")
+	assert.Contains(t, tag, "-----BEGIN SSH SIGNATURE-----")
+}
@@ -0,0 +1,232 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+)
+
+const issuesConfig = `notes:
+  issues:
+    - pattern: '\b[A-Z][A-Z0-9]+-\d+\b'
+      link: https://jira.example.com/browse/{id}
+    - pattern: '#(\d+)\b'
+      link: https://github.com/example/project/issues/{id}
+`
+
+// commitChanges creates empty commits with the given messages on a branch and pushes them.
+func commitChanges(env *e2e.GitTestEnv, branch string, messages ...string) {
+	env.ExecuteGit("checkout", branch)
+	for _, message := range messages {
+		env.ExecuteGit("commit", "--allow-empty", "-m", message)
+	}
+	env.ExecuteGit("push", "origin", branch)
+}
+
+func RunReleaseNotes(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	commitChanges(env, "develop", "PROJ-1 Add login", "Fix typo (#12)", "Update docs")
+	env.CreateBranch("release/1.1.0", "develop")
+	commitChanges(env, "release/1.1.0", "PROJ-1 Polish login")
+
+	configPath := env.WriteConfig(issuesConfig)
+	output := env.ExecuteGitflow("release", "notes", "--config", configPath)
+
+	assert.Contains(t, output, "# Release 1.1.0\n")
+	assert.Contains(t, output, "- [PROJ-1](https://jira.example.com/browse/PROJ-1): PROJ-1 Add login; PROJ-1 Polish login\n")
+	assert.Contains(t, output, "- [#12](https://github.com/example/project/issues/12): Fix typo (#12)\n")
+	assert.Contains(t, output, "## Other Changes\n\n- Set up test precondition for develop branch\n- Update docs\n")
+}
+
+func RunReleaseNotesForVersion(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	commitChanges(env, "main", "PROJ-7 Add export")
+	env.ExecuteGit("tag", "1.1.0", "main")
+	env.ExecuteGit("push", "origin", "1.1.0")
+
+	output := env.ExecuteGitflow("release", "notes", "1.1.0", "--format", "json")
+
+	assert.Contains(t, output, `"version": "1.1.0"`)
+	assert.Contains(t, output, `"id": "PROJ-7"`)
+	assert.Contains(t, output, `"PROJ-7 Add export"`)
+	assert.NotContains(t, output, "Set up test precondition")
+}
+
+func RunReleaseNotesUnreleasedVersion(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	errMsg := env.ExecuteGitflowExpectError("release", "notes", "2.0.0")
+
+	assert.Contains(t, errMsg, "has not been released")
+}
+
+func RunReleaseNotesContributors(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	env.ExecuteGit("checkout", "main")
+	env.ExecuteGit("commit", "--allow-empty", "-m", "Add export", "--author", "Jane Doe <jane@example.com>")
+	env.ExecuteGit("commit", "--allow-empty", "-m", "Fix export", "--author", "Jane Doe <JANE@example.com>")
+	env.ExecuteGit("commit", "--allow-empty", "-m", "Add import", "--author", "Octo Cat <123+octocat@users.noreply.github.com>")
+	env.ExecuteGit("tag", "1.1.0", "main")
+	env.ExecuteGit("push", "origin", "main", "1.1.0")
+
+	output := env.ExecuteGitflow("release", "notes", "1.1.0")
+
+	assert.Contains(t, output, "## Contributors\n\n- Jane Doe <jane@example.com>\n- Octo Cat (@octocat)\n")
+
+	output = env.ExecuteGitflow("release", "notes", "1.1.0", "--format", "json")
+
+	assert.Contains(t, output, `"handle": "octocat"`)
+	assert.Equal(t, 1, strings.Count(output, `"name": "Jane Doe"`))
+}
+
+func RunReleaseNotesGitHubHandles(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	env.ExecuteGit("checkout", "main")
+	env.ExecuteGit("commit", "--allow-empty", "-m", "Add export", "--author", "Jane Doe <jane@example.com>")
+	env.ExecuteGit("tag", "1.1.0", "main")
+	env.ExecuteGit("push", "origin", "main", "1.1.0")
+	commitHash := strings.TrimSpace(env.ExecuteGit("rev-parse", "main"))
+
+	var requestPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		_, _ = w.Write([]byte(`{"author":{"login":"janedoe"}}`))
+	}))
+	defer server.Close()
+
+	configPath := env.WriteConfig("notes:\n  github-handles: true\n  github-api: " + server.URL + "\n")
+	output := env.ExecuteGitflow("release", "notes", "1.1.0", "--config", configPath)
+
+	assert.Contains(t, output, "- Jane Doe (@janedoe)\n")
+	assert.True(t, strings.HasSuffix(requestPath, "/commits/"+commitHash), requestPath)
+}
+
+func RunReleaseNotesCachedHandles(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	env.ExecuteGit("checkout", "main")
+	env.ExecuteGit("commit", "--allow-empty", "-m", "Add export", "--author", "Jane Doe <jane@example.com>")
+	env.ExecuteGit("tag", "1.1.0", "main")
+	env.ExecuteGit("push", "origin", "main", "1.1.0")
+
+	// the API answers conditional requests of unchanged commits without body, like GitHub
+	var requests, revalidations int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"commit-v1"` {
+			revalidations++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"commit-v1"`)
+		_, _ = w.Write([]byte(`{"author":{"login":"janedoe"}}`))
+	}))
+	defer server.Close()
+
+	configPath := env.WriteConfig("notes:\n  github-handles: true\n  github-api: " + server.URL + "\n")
+	for range 2 {
+		output := env.ExecuteGitflow("release", "notes", "1.1.0", "--config", configPath)
+		assert.Contains(t, output, "- Jane Doe (@janedoe)\n")
+	}
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, 1, revalidations)
+
+	// without cache, every lookup fetches the full response
+	configPath = env.WriteConfig("notes:\n  github-handles: true\n  github-api: " + server.URL + "\napi-cache:\n  enabled: false\n")
+	output := env.ExecuteGitflow("release", "notes", "1.1.0", "--config", configPath)
+	assert.Contains(t, output, "- Jane Doe (@janedoe)\n")
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, 1, revalidations)
+}
+
+func RunReleaseNotesRateLimited(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	env.ExecuteGit("checkout", "main")
+	env.ExecuteGit("commit", "--allow-empty", "-m", "Add export", "--author", "Jane Doe <jane@example.com>")
+	env.ExecuteGit("tag", "1.1.0", "main")
+	env.ExecuteGit("push", "origin", "main", "1.1.0")
+
+	// the first request exceeds the secondary rate limit of GitHub, which asks to retry after a second
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"author":{"login":"janedoe"}}`))
+	}))
+	defer server.Close()
+
+	configPath := env.WriteConfig("notes:\n  github-handles: true\n  github-api: " + server.URL + "\n")
+	output := env.ExecuteGitflow("release", "notes", "1.1.0", "--config", configPath)
+
+	assert.Contains(t, output, "responded with 403 Forbidden, retrying in 1s")
+	assert.Contains(t, output, "- Jane Doe (@janedoe)\n")
+	assert.Equal(t, 2, requests)
+}
+
+func RunReleaseNotesRateLimitTimeout(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	env.ExecuteGit("checkout", "main")
+	env.ExecuteGit("commit", "--allow-empty", "-m", "Add export", "--author", "Jane Doe <jane@example.com>")
+	env.ExecuteGit("tag", "1.1.0", "main")
+	env.ExecuteGit("push", "origin", "main", "1.1.0")
+
+	// the rate limit is only reset after the configured timeout
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	configPath := env.WriteConfig("notes:\n  github-handles: true\n  github-api: " + server.URL + "\napi-retry:\n  timeout: 10s\n")
+	errMsg := env.ExecuteGitflowExpectError("release", "notes", "1.1.0", "--config", configPath)
+
+	assert.Contains(t, errMsg, "did not succeed within 10s (rate limited or unavailable), the limit is configured under 'api-retry.timeout'")
+}
+
+func RunReleaseNotesCompareLink(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	commitChanges(env, "main", "Add export")
+	env.ExecuteGit("tag", "1.1.0", "main")
+	env.ExecuteGit("push", "origin", "1.1.0")
+
+	// the remote points to the hosting service, whose ssh connection serves the simulated remote repository instead
+	env.ExecuteGit("remote", "set-url", "origin", "git@gitlab.example.com:group/project.git")
+	t.Setenv("GIT_SSH_COMMAND", "git-upload-pack '"+env.RemotePath+"' #")
+	output := env.ExecuteGitflow("release", "notes", "1.1.0")
+
+	assert.Contains(t, output, "\n**Full Changelog**: https://gitlab.example.com/group/project/-/compare/1.0.0...1.1.0\n")
+
+	output = env.ExecuteGitflow("release", "notes", "1.1.0", "--format", "json")
+
+	assert.Contains(t, output, `"compare": "https://gitlab.example.com/group/project/-/compare/1.0.0...1.1.0"`)
+}
@@ -10,11 +10,13 @@ import (
 
 	"github.com/mercedes-benz/gitflow-cli/core/plugin"
 	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
 )
 
 func RunReleaseStart(t *testing.T, tc plugin.TestConfig) {
 	t.Helper()
-	env := e2e.SetupTestEnv(t, e2e.WithDockerMode(tc.DockerImage != ""))
+	env := e2e.SetupTestEnv(t, e2e.WithDockerMode(tc.DockerImage != ""), e2e.WithProjectFiles(tc.ProjectFiles),
+		e2e.WithVersionNotation(tc.VersionNotation))
 
 	env.CommitTemplateContent(tc.Template, tc.VersionFileName, "1.0.0", "main")
 	env.CommitTemplateContent(tc.Template, tc.VersionFileName, "1.1.0-"+tc.VersionQualifier, "develop")
@@ -45,7 +47,8 @@ func RunReleaseStartFallback(t *testing.T) {
 
 func RunBeforeReleaseStartHook(t *testing.T, tc plugin.TestConfig) {
 	t.Helper()
-	env := e2e.SetupTestEnv(t, e2e.WithDockerMode(tc.DockerImage != ""))
+	env := e2e.SetupTestEnv(t, e2e.WithDockerMode(tc.DockerImage != ""), e2e.WithProjectFiles(tc.ProjectFiles),
+		e2e.WithVersionNotation(tc.VersionNotation))
 
 	env.CommitFile(tc.VersionFileName, tc.EmptyContent, "develop")
 
@@ -55,3 +58,284 @@ func RunBeforeReleaseStartHook(t *testing.T, tc plugin.TestConfig) {
 	env.AssertBranchExists("release/1.0.0")
 	env.AssertBranchExists("origin/release/1.0.0")
 }
+
+func RunReleaseStartExplicitVersion(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	env.ExecuteGitflow("release", "start", "2.5.0")
+
+	env.AssertBranchExists("release/2.5.0")
+	env.AssertBranchExists("origin/release/2.5.0")
+	env.AssertBranchDoesNotExist("release/1.1.0")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "2.5.0", "release/2.5.0")
+
+	// the next development version follows the explicit version
+	env.ExecuteGitflow("release", "finish")
+
+	env.AssertTagEquals("2.5.0", "main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "2.6.0-dev", "develop")
+}
+
+func RunReleaseStartVersionFlag(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	env.ExecuteGitflow("release", "start", "--version", "2.0.0")
+
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "2.0.0", "release/2.0.0")
+}
+
+func RunReleaseStartInvalidVersion(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	output := env.ExecuteGitflowExpectError("release", "start", "2.5")
+	assert.Contains(t, output, "release version '2.5' must be a version without qualifier")
+
+	output = env.ExecuteGitflowExpectError("release", "start", "2.5.0-rc")
+	assert.Contains(t, output, "release version '2.5.0-rc' must be a version without qualifier")
+
+	env.AssertBranchDoesNotExist("release/2.5.0")
+}
+
+func RunReleaseStartReleasedVersion(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.ExecuteGit("tag", "1.0.0", "main")
+	env.ExecuteGit("push", "origin", "1.0.0")
+
+	output := env.ExecuteGitflowExpectError("release", "start", "1.0.0")
+
+	assert.Contains(t, output, "version '1.0.0' has already been released")
+	env.AssertBranchDoesNotExist("release/1.0.0")
+}
+
+func RunReleaseStartFallbackSettings(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	configPath := env.WriteConfig("version-file:\n  name: VERSION\n  initial-version: 0.1.0\n")
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+
+	env.AssertTemplateVersionEquals("{{.Version}}", "VERSION", "0.1.0-dev", "develop")
+	env.AssertCommitMessageEquals("Create versions file", "develop")
+	env.AssertTemplateVersionEquals("{{.Version}}", "VERSION", "0.1.0", "release/0.1.0")
+	_, err := env.ExecuteGitAllowError("cat-file", "-e", "develop:version.txt")
+	assert.Error(t, err)
+}
+
+func RunReleaseStartFallbackNestedPath(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	configPath := env.WriteConfig("version-file:\n  name: ./config/VERSION\n")
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+
+	env.AssertTemplateVersionEquals("{{.Version}}", "config/VERSION", "1.0.0-dev", "develop")
+	env.AssertTemplateVersionEquals("{{.Version}}", "config/VERSION", "1.0.0", "release/1.0.0")
+	_, err := env.ExecuteGitAllowError("cat-file", "-e", "develop:version.txt")
+	assert.Error(t, err)
+}
+
+func RunReleaseStartUpperVersionFile(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitFile("VERSION", []byte("1.0.0\n"), "main")
+	env.CommitFile("VERSION", []byte("1.1.0-dev\n"), "develop")
+
+	env.ExecuteGitflow("release", "start")
+
+	env.AssertTemplateVersionEquals("{{.Version}}", "VERSION", "1.1.0", "release/1.1.0")
+	_, err := env.ExecuteGitAllowError("cat-file", "-e", "release/1.1.0:version.txt")
+	assert.Error(t, err)
+}
+
+func RunReleaseStartFallbackCreationDisabled(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	configPath := env.WriteConfig("version-file:\n  create: false\n")
+	output := env.ExecuteGitflowExpectError("release", "start", "--config", configPath)
+
+	assert.Contains(t, output, "project has no version file 'version.txt' and creating it is disabled by setting 'version-file.create'")
+	env.AssertCommitMessageEquals("Initial empty commit", "develop")
+	env.AssertBranchDoesNotExist("release/1.0.0")
+}
+
+func RunReleaseStartMajor(t *testing.T, tc plugin.TestConfig) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t, e2e.WithDockerMode(tc.DockerImage != ""), e2e.WithProjectFiles(tc.ProjectFiles),
+		e2e.WithVersionNotation(tc.VersionNotation))
+
+	env.CommitTemplateContent(tc.Template, tc.VersionFileName, "1.0.0", "main")
+	env.CommitTemplateContent(tc.Template, tc.VersionFileName, "1.1.0-"+tc.VersionQualifier, "develop")
+
+	env.ExecuteGitflow("release", "start", "--major")
+
+	env.AssertCommitMessageEquals("Set next major project version.", "develop")
+	env.AssertTemplateVersionEquals(tc.Template, tc.VersionFileName, "2.0.0-"+tc.VersionQualifier, "develop")
+	env.AssertTemplateVersionEquals(tc.Template, tc.VersionFileName, "2.0.0-"+tc.VersionQualifier, "origin/develop")
+	env.AssertBranchExists("release/2.0.0")
+	env.AssertBranchExists("origin/release/2.0.0")
+	env.AssertTemplateVersionEquals(tc.Template, tc.VersionFileName, "2.0.0", "release/2.0.0")
+	env.AssertCurrentBranchEquals("release/2.0.0")
+}
+
+func RunReleaseStartMinor(t *testing.T, tc plugin.TestConfig) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t, e2e.WithDockerMode(tc.DockerImage != ""), e2e.WithProjectFiles(tc.ProjectFiles),
+		e2e.WithVersionNotation(tc.VersionNotation))
+
+	env.CommitTemplateContent(tc.Template, tc.VersionFileName, "1.0.0", "main")
+	env.CommitTemplateContent(tc.Template, tc.VersionFileName, "1.1.0-"+tc.VersionQualifier, "develop")
+
+	env.ExecuteGitflow("release", "start", "--minor")
+
+	env.AssertCommitMessageEquals("Set next minor project version.", "develop")
+	env.AssertTemplateVersionEquals(tc.Template, tc.VersionFileName, "1.2.0-"+tc.VersionQualifier, "develop")
+	env.AssertBranchExists("release/1.2.0")
+	env.AssertTemplateVersionEquals(tc.Template, tc.VersionFileName, "1.2.0", "release/1.2.0")
+}
+
+func RunReleaseStartMajorAndMinor(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	output := env.ExecuteGitflowExpectError("release", "start", "--major", "--minor")
+	assert.Contains(t, output, "[major minor] were all set")
+
+	output = env.ExecuteGitflowExpectError("release", "start", "2.5.0", "--major")
+	assert.Contains(t, output, "release version 2.5.0 cannot be combined with --major")
+
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.AssertBranchDoesNotExist("release/1.1.0")
+}
+
+// setupConventionalCommits sets up the release 1.0.0 and commits with the given messages on develop since then.
+func setupConventionalCommits(env *e2e.GitTestEnv, messages ...string) {
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.ExecuteGit("tag", "1.0.0", "main")
+	env.ExecuteGit("push", "origin", "1.0.0")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	for _, message := range messages {
+		env.ExecuteGit("commit", "--allow-empty", "-m", message)
+	}
+	env.ExecuteGit("push", "origin", "develop")
+}
+
+func RunReleaseStartBumpAutoPatch(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+	setupConventionalCommits(env, "fix: handle empty input", "docs: describe the input", "chore(deps): update modules")
+
+	output := env.ExecuteGitflow("release", "start", "--bump=auto")
+	assert.Contains(t, output, "patch bump of release 1.0.0 to 1.0.1")
+
+	env.AssertCommitMessageEquals("Set next patch project version.", "develop")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.0.1-dev", "origin/develop")
+	env.AssertBranchExists("origin/release/1.0.1")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.0.1", "release/1.0.1")
+}
+
+func RunReleaseStartBumpAutoMinor(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+	setupConventionalCommits(env, "fix: handle empty input", "feat(api): add the search endpoint")
+
+	env.ExecuteGitflow("release", "start", "--bump=auto")
+
+	// the develop version already is the next minor version
+	env.AssertCommitMessageEquals("feat(api): add the search endpoint", "develop")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+}
+
+func RunReleaseStartBumpAutoMajor(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+	setupConventionalCommits(env, "feat!: remove the v1 endpoints", "fix: handle empty input")
+
+	env.ExecuteGitflow("release", "start", "--bump=auto")
+	env.AssertCommitMessageEquals("Set next major project version.", "develop")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "2.0.0", "release/2.0.0")
+}
+
+func RunReleaseStartBumpAutoBreakingFooter(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+	setupConventionalCommits(env, "refactor: rename the settings\n\nBREAKING CHANGE: 'timeout' is now 'request-timeout'")
+
+	env.ExecuteGitflow("release", "start", "--bump=auto")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "2.0.0-dev", "develop")
+	env.AssertBranchExists("release/2.0.0")
+}
+
+func RunReleaseStartBumpAutoNoChanges(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+	setupConventionalCommits(env, "docs: describe the input", "update the build")
+
+	output := env.ExecuteGitflowExpectError("release", "start", "--bump=auto")
+	assert.Contains(t, output, "none of the 3 commit(s) since '1.0.0' on 'develop' is a feature, fix or breaking change")
+
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.AssertBranchDoesNotExist("release/1.1.0")
+}
+
+func RunReleaseStartBumpExplicit(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+	setupConventionalCommits(env, "feat: add the search endpoint")
+
+	// the explicit bump overrides the conventional commits
+	env.ExecuteGitflow("release", "start", "--bump=patch")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.0.1-dev", "develop")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.0.1", "release/1.0.1")
+}
+
+func RunReleaseStartBumpWithoutRelease(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	// without release, the develop version is released
+	env.ExecuteGitflow("release", "start", "--bump=auto")
+	env.AssertCommitMessageEquals("Set up test precondition for develop branch", "develop")
+	env.AssertBranchExists("release/1.1.0")
+}
+
+func RunReleaseStartBumpInvalid(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+	setupConventionalCommits(env, "fix: handle empty input")
+
+	output := env.ExecuteGitflowExpectError("release", "start", "--bump=huge")
+	assert.Contains(t, output, "unsupported version bump: huge (auto, major, minor, patch)")
+
+	output = env.ExecuteGitflowExpectError("release", "start", "--bump=auto", "--major")
+	assert.Contains(t, output, "[bump major] were all set")
+
+	output = env.ExecuteGitflowExpectError("release", "start", "2.5.0", "--bump=patch")
+	assert.Contains(t, output, "release version 2.5.0 cannot be combined with --bump")
+
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+}
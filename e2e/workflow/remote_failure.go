@@ -0,0 +1,116 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupFinishableRelease creates a test environment with a release branch ready to be finished.
+func setupFinishableRelease(t *testing.T) *e2e.GitTestEnv {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	return env
+}
+
+// assertReleaseFinished checks that the finished release reached the remote repository.
+func assertReleaseFinished(t *testing.T, env *e2e.GitTestEnv) {
+	t.Helper()
+	assert.NoFileExists(t, filepath.Join(env.LocalPath, ".git", "gitflow-cli", "state.json"))
+	assert.Contains(t, env.ExecuteGit("ls-remote", "--tags", "origin", "1.1.0"), "refs/tags/1.1.0")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0", "origin/main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0-dev", "origin/develop")
+	env.AssertBranchDoesNotExist("origin/release/1.1.0")
+}
+
+func RunReleaseFinishResumeAfterRejectedPush(t *testing.T) {
+	t.Helper()
+	env := setupFinishableRelease(t)
+	env.RejectPushes("refs/heads/develop", 1)
+
+	// the rejected push stops the finish with its state, nothing reaches the remote
+	errMsg := env.ExecuteGitflowExpectError("release", "finish")
+	assert.Contains(t, errMsg, "injected failure: push rejected for refs/heads/develop")
+	assert.FileExists(t, filepath.Join(env.LocalPath, ".git", "gitflow-cli", "state.json"))
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0-dev", "origin/develop")
+	assert.Empty(t, strings.TrimSpace(env.ExecuteGit("ls-remote", "--tags", "origin")))
+
+	// the remote accepts the next push, so that the finish resumes at the push
+	output := env.ExecuteGitflow("release", "continue")
+
+	assert.Contains(t, output, "completed")
+	assertReleaseFinished(t, env)
+}
+
+func RunReleaseFinishRetryRejectedTags(t *testing.T) {
+	t.Helper()
+	env := setupFinishableRelease(t)
+	env.RejectPushes("refs/tags/*", 2)
+
+	errMsg := env.ExecuteGitflowExpectError("release", "finish")
+	assert.Contains(t, errMsg, "injected failure: push rejected for refs/tags/1.1.0")
+
+	// a continue which fails again keeps the state for the next attempt
+	errMsg = env.ExecuteGitflowExpectError("release", "continue")
+	assert.Contains(t, errMsg, "injected failure: push rejected for refs/tags/1.1.0")
+	assert.FileExists(t, filepath.Join(env.LocalPath, ".git", "gitflow-cli", "state.json"))
+
+	env.ExecuteGitflow("release", "continue")
+
+	assertReleaseFinished(t, env)
+}
+
+func RunReleaseFinishBranchDeletedOnRemote(t *testing.T) {
+	t.Helper()
+	env := setupFinishableRelease(t)
+	env.RejectPushes("refs/tags/*", 1)
+
+	errMsg := env.ExecuteGitflowExpectError("release", "finish")
+	assert.Contains(t, errMsg, "injected failure: push rejected for refs/tags/1.1.0")
+
+	// the hosting service deletes the branch in the meantime, while the clone still tracks it
+	head := strings.TrimSpace(env.ExecuteGit("rev-parse", "origin/release/1.1.0"))
+	env.ExecuteGit("push", "origin", "--delete", "release/1.1.0")
+	env.ExecuteGit("update-ref", "refs/remotes/origin/release/1.1.0", head)
+
+	output := env.ExecuteGitflow("release", "continue")
+
+	assertReleaseFinished(t, env)
+	assert.Empty(t, env.ExecuteGit("branch", "--remotes", "--list", "origin/release/*"))
+	assert.Contains(t, output, "collaborators remove its remote-tracking branch with 'git fetch --prune origin'")
+}
+
+func RunReleaseStartReadOnlyRemote(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.SetRemoteReadOnly()
+
+	configPath := env.WriteConfig("workflow:\n  rollback: true\n")
+	errMsg := env.ExecuteGitflowExpectError("release", "start", "--config", configPath)
+
+	// the push is not rolled back: the started branch is kept, so that it can be pushed once the remote accepts it
+	assert.Contains(t, errMsg, "read-only remote repository: push rejected for refs/heads/release/1.1.0")
+	env.AssertBranchNotOnRemote("release/1.1.0")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	env.ClearRemoteFailures()
+	env.ExecuteGit("push", "-u", "origin", "release/1.1.0")
+	env.AssertBranchExists("origin/release/1.1.0")
+}
@@ -0,0 +1,63 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func RunReport(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	commitChanges(env, "develop", "Add <export> feature")
+	env.ExecuteGitflow("release", "start")
+	env.ExecuteGitflow("release", "finish")
+	env.ExecuteGitflow("hotfix", "start")
+	env.ExecuteGitflow("hotfix", "finish")
+
+	reportFile := filepath.Join(t.TempDir(), "report.html")
+	output := env.ExecuteGitflow("report", "--file", reportFile)
+	assert.Contains(t, output, "Report of 3 release(s) written to '"+reportFile+"'")
+
+	content, err := os.ReadFile(reportFile)
+	require.NoError(t, err)
+	report := string(content)
+
+	// the summary lists the versions newest first with their type
+	assert.Regexp(t, `(?s)<td><a href="#1.1.1">1.1.1</a></td><td class="hotfix">hotfix</td>.*<td><a href="#1.1.0">1.1.0</a></td><td class="release">release</td>.*<td><a href="#1.0.0">1.0.0</a></td>`, report)
+
+	// versions started by the workflow have a duration, the initial version has none
+	assert.Contains(t, report, "<td>&lt;1m</td>")
+	assert.Regexp(t, `<a href="#1.0.0">1.0.0</a></td><td class="release">release</td><td>[^<]+</td><td>–</td>`, report)
+
+	// the commits of each version are listed with escaped subjects
+	assert.Contains(t, report, "Add &lt;export&gt; feature")
+	assert.Contains(t, report, "Increment patch version for hotfix.")
+
+	// the limit selects the latest versions
+	env.ExecuteGitflow("report", "--file", reportFile, "--limit", "1")
+	content, err = os.ReadFile(reportFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `id="1.1.1"`)
+	assert.NotContains(t, string(content), `id="1.1.0"`)
+}
+
+func RunReportWithoutReleases(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	errMsg := env.ExecuteGitflowExpectError("report", "--file", filepath.Join(t.TempDir(), "report.html"))
+
+	assert.Contains(t, errMsg, "has no release tags to report")
+}
@@ -0,0 +1,81 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const calverConfig = "workflow:\n  version-scheme: calver\n"
+
+// calendarVersion returns the calendar version of the current month with a micro version, e.g. '2025.04.1'.
+func calendarVersion(micro int) string {
+	now := time.Now()
+	return fmt.Sprintf("%d.%02d.%d", now.Year(), int(now.Month()), micro)
+}
+
+func RunReleaseCalendarVersion(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	// the development version of an earlier month is dated when the release is started
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "2020.01.4-dev", "develop")
+	configPath := env.WriteConfig(calverConfig)
+	release := calendarVersion(0)
+
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+	env.AssertBranchExists("release/" + release)
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", release, "release/"+release)
+
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+	env.AssertTagEquals(release, "main")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", release, "main")
+
+	// further releases of the month are numbered by the micro version
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", calendarVersion(1)+"-dev", "develop")
+}
+
+func RunReleaseCalendarVersionOfMonth(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", calendarVersion(2)+"-dev", "develop")
+	configPath := env.WriteConfig(calverConfig)
+
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+	env.AssertBranchExists("release/" + calendarVersion(2))
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", calendarVersion(2), "release/"+calendarVersion(2))
+}
+
+func RunReleaseStartInvalidVersionScheme(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	configPath := env.WriteConfig("workflow:\n  version-scheme: romver\n")
+	errMsg := env.ExecuteGitflowExpectError("release", "start", "--config", configPath)
+
+	assert.Contains(t, errMsg, "setting 'workflow.version-scheme' holds 'romver', which is none of semver, calver")
+}
+
+func RunReleaseStartLooseVersion(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "v1.1-dev", "develop")
+
+	// loose versions are rejected unless enabled
+	errMsg := env.ExecuteGitflowExpectError("release", "start")
+	assert.Contains(t, errMsg, "invalid version string: v1.1-dev")
+
+	configPath := env.WriteConfig("workflow:\n  loose-versions: true\n")
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+	env.AssertBranchExists("release/1.1.0")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+}
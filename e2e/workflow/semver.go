@@ -0,0 +1,38 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func RunReleaseStartBuildMetadata(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev.2+build.7", "develop")
+	env.ExecuteGitflow("release", "start")
+
+	// the release version has neither the qualifier nor the build metadata of the development version
+	env.AssertBranchExists("release/1.1.0")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+	output := env.ExecuteGitflow("check", "release/1.1.0")
+	assert.Contains(t, output, "Check of branch release/1.1.0 completed")
+}
+
+func RunReleaseStartFourPartVersion(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	// a version with four parts is rejected instead of being read as its last three parts
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "11.1.0.4", "develop")
+	errMsg := env.ExecuteGitflowExpectError("release", "start")
+
+	assert.Contains(t, errMsg, "invalid version string: 11.1.0.4")
+	env.AssertBranchDoesNotExist("release/1.0.4")
+}
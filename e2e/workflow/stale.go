@@ -0,0 +1,61 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+)
+
+func RunStaleReleaseAge(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+
+	// the release branch has been started 45 days ago
+	started := time.Now().AddDate(0, 0, -45).Format(time.RFC3339)
+	t.Setenv("GIT_AUTHOR_DATE", started)
+	t.Setenv("GIT_COMMITTER_DATE", started)
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+	_ = os.Unsetenv("GIT_AUTHOR_DATE")
+	_ = os.Unsetenv("GIT_COMMITTER_DATE")
+
+	output := env.ExecuteGitflow("check")
+	assert.Contains(t, output, "WARN: branch 'release/1.1.0' has been open for 45 days (limit 30): finish or delete it")
+
+	// the warning is disabled with a limit of zero
+	configPath := env.WriteConfig("stale-branches:\n  max-age-days: 0\n")
+	output = env.ExecuteGitflow("check", "--config", configPath)
+	assert.NotContains(t, output, "has been open for")
+}
+
+func RunStaleReleaseBehind(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+	env.CreateBranch("release/1.1.0", "develop")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+
+	configPath := env.WriteConfig("stale-branches:\n  max-behind: 2\n")
+
+	// a fresh release branch is not stale
+	output := env.ExecuteGitflow("check", "--config", configPath)
+	assert.NotContains(t, output, "WARN: branch 'release/1.1.0'")
+
+	commitChanges(env, "develop", "Add feature A", "Add feature B", "Add feature C")
+
+	output = env.ExecuteGitflow("check", "--config", configPath)
+	assert.Contains(t, output, "WARN: branch 'release/1.1.0' is 3 commits behind 'origin/develop' (limit 2): finish or delete it")
+}
@@ -0,0 +1,96 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupMaintenanceLine creates a test environment with version 1.2.0 released and 1.3.0 on main.
+func setupMaintenanceLine(t *testing.T) *e2e.GitTestEnv {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.2.0", "main")
+	env.ExecuteGit("tag", "1.2.0", "main")
+	env.ExecuteGit("push", "origin", "1.2.0")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.3.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.4.0-dev", "develop")
+
+	return env
+}
+
+func RunSupportStart(t *testing.T) {
+	t.Helper()
+	env := setupMaintenanceLine(t)
+
+	env.ExecuteGitflow("support", "start", "1.2.0")
+
+	env.AssertCurrentBranchEquals("support/1.2")
+	env.AssertTagEquals("1.2.0", "support/1.2")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0", "support/1.2")
+
+	output := env.ExecuteGit("ls-remote", "--heads", "origin", "support/1.2")
+	assert.Contains(t, output, "refs/heads/support/1.2")
+}
+
+func RunSupportStartUnreleasedVersion(t *testing.T) {
+	t.Helper()
+	env := setupMaintenanceLine(t)
+
+	errMsg := env.ExecuteGitflowExpectError("support", "start", "1.1.0")
+
+	assert.Contains(t, errMsg, "has not been released")
+}
+
+func RunHotfixStartOnSupport(t *testing.T) {
+	t.Helper()
+	env := setupMaintenanceLine(t)
+	env.CreateBranch("support/1.2", "1.2.0")
+
+	env.ExecuteGitflow("hotfix", "start", "--support", "1.2")
+
+	env.AssertCurrentBranchEquals("hotfix/1.2.1")
+	env.AssertCommitMessageEquals("Increment patch version for hotfix.", "hotfix/1.2.1")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.1", "hotfix/1.2.1")
+
+	// the production line can be hotfixed at the same time
+	env.ExecuteGitflow("hotfix", "start")
+
+	env.AssertCurrentBranchEquals("hotfix/1.3.1")
+}
+
+func RunHotfixStartOnMissingSupport(t *testing.T) {
+	t.Helper()
+	env := setupMaintenanceLine(t)
+
+	errMsg := env.ExecuteGitflowExpectError("hotfix", "start", "--support", "1.2")
+
+	assert.Contains(t, errMsg, "does not have a support branch 'support/1.2'")
+}
+
+func RunHotfixFinishOnSupport(t *testing.T) {
+	t.Helper()
+	env := setupMaintenanceLine(t)
+	env.CreateBranch("support/1.2", "1.2.0")
+	env.CreateBranch("hotfix/1.2.1", "support/1.2")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.2.1", "hotfix/1.2.1")
+
+	env.ExecuteGitflow("hotfix", "finish", "--support", "1.2")
+
+	env.AssertCommitMessageEquals("Merge branch 'hotfix/1.2.1' into support/1.2", "support/1.2")
+	env.AssertTagEquals("1.2.1", "support/1.2")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.1", "support/1.2")
+
+	env.AssertCommitMessageEquals("Set up test precondition for main branch", "main")
+	env.AssertCommitMessageEquals("Set up test precondition for develop branch", "develop")
+
+	env.AssertBranchDoesNotExist("hotfix/1.2.1")
+	env.AssertCurrentBranchEquals("support/1.2")
+}
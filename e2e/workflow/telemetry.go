@@ -0,0 +1,137 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTelemetry creates a test environment with a development version and the configuration of the telemetry,
+// whose events are queued in a temporary file.
+func setupTelemetry(t *testing.T, endpoint string) (*e2e.GitTestEnv, string, string) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+	t.Setenv("DO_NOT_TRACK", "")
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	queue := filepath.Join(t.TempDir(), "telemetry.jsonl")
+	configPath := env.WriteConfig(fmt.Sprintf("telemetry:\n  enabled: true\n  endpoint: %q\n  queue: %q\n", endpoint, queue))
+	return env, configPath, queue
+}
+
+// queuedEvents reads the events of the telemetry queue.
+func queuedEvents(t *testing.T, queue string) []map[string]any {
+	t.Helper()
+
+	content, err := os.ReadFile(queue)
+	require.NoError(t, err)
+
+	events := make([]map[string]any, 0)
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		if len(line) > 0 {
+			var event map[string]any
+			require.NoError(t, json.Unmarshal([]byte(line), &event))
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func RunTelemetryQueue(t *testing.T) {
+	t.Helper()
+	env, configPath, queue := setupTelemetry(t, "")
+
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+	env.ExecuteGitflowExpectError("hotfix", "finish", "--config", configPath)
+
+	// without endpoint, the events stay in the local queue
+	events := queuedEvents(t, queue)
+	require.Len(t, events, 2)
+	assert.Equal(t, "release start", events[0]["command"])
+	assert.Equal(t, "standard", events[0]["plugin"])
+	assert.Equal(t, "success", events[0]["outcome"])
+	assert.NotContains(t, events[0], "category")
+	assert.Equal(t, "hotfix finish", events[1]["command"])
+	assert.Equal(t, "failure", events[1]["outcome"])
+	assert.Equal(t, "error.no-branch-to-finish", events[1]["category"])
+
+	// the events are anonymous
+	content, err := os.ReadFile(queue)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), env.LocalPath)
+	assert.NotContains(t, string(content), "release/1.1.0")
+}
+
+func RunTelemetryEndpoint(t *testing.T) {
+	t.Helper()
+
+	var received []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch struct {
+			Events []map[string]any `json:"events"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&batch)
+		received = append(received, batch.Events...)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	env, configPath, queue := setupTelemetry(t, server.URL)
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+
+	// the sent events are removed from the queue
+	require.Len(t, received, 1)
+	assert.Equal(t, "release start", received[0]["command"])
+	assert.Empty(t, queuedEvents(t, queue))
+}
+
+func RunTelemetryDoNotTrack(t *testing.T) {
+	t.Helper()
+	env, configPath, queue := setupTelemetry(t, "")
+	t.Setenv("DO_NOT_TRACK", "1")
+
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+
+	_, err := os.Stat(queue)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func RunTelemetryProjectConfigIgnored(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+	t.Setenv("DO_NOT_TRACK", "")
+	t.Setenv("HOME", t.TempDir())
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	// neither the versioned project config nor the environment opts the user in
+	queue := filepath.Join(t.TempDir(), "telemetry.jsonl")
+	projectConfig := fmt.Sprintf("telemetry:\n  enabled: true\n  queue: %q\n", queue)
+	require.NoError(t, os.WriteFile(filepath.Join(env.LocalPath, ".gitflow-cli.yaml"), []byte(projectConfig), 0644))
+	env.ExecuteGit("add", ".gitflow-cli.yaml")
+	env.ExecuteGit("commit", "-m", "Add project config")
+	t.Setenv("GITFLOW_TELEMETRY__ENABLED", "true")
+	t.Setenv("GITFLOW_TELEMETRY__QUEUE", queue)
+
+	env.ExecuteGitflow("release", "start")
+
+	_, err := os.Stat(queue)
+	assert.True(t, os.IsNotExist(err))
+}
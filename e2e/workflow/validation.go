@@ -0,0 +1,79 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+)
+
+func RunReleaseStartOptionBranchSetting(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	// a branch prefix starting with a hyphen would be passed to git as an option
+	configPath := env.WriteConfig("branches:\n  release: \"--upload-pack=touch pwned\"\n")
+	errMsg := env.ExecuteGitflowExpectError("release", "start", "--config", configPath)
+
+	assert.Contains(t, errMsg, "setting 'branches.release' holds '--upload-pack=touch pwned', which is no valid git branch or tag name")
+	assert.NoFileExists(t, filepath.Join(env.LocalPath, "pwned"))
+	env.AssertBranchDoesNotExist("release/1.1.0")
+}
+
+func RunReleaseStartInvalidBranchSetting(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	configPath := env.WriteConfig("branches:\n  development: \"dev..elop\"\n")
+	errMsg := env.ExecuteGitflowExpectError("release", "start", "--config", configPath)
+
+	assert.Contains(t, errMsg, "setting 'branches.development' holds 'dev..elop', which is no valid git branch or tag name")
+}
+
+func RunReleaseStartInvalidQualifierSetting(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	configPath := env.WriteConfig("standard:\n  qualifiers:\n    release: \"rc --force\"\n")
+	errMsg := env.ExecuteGitflowExpectError("release", "start", "--config", configPath)
+
+	assert.Contains(t, errMsg, "setting 'standard.qualifiers.release' holds 'rc --force', which is no valid version qualifier")
+}
+
+func RunPromoteInvalidEnvironmentSetting(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	configPath := env.WriteConfig("environments:\n  - \"-f\"\n")
+	errMsg := env.ExecuteGitflowExpectError("promote", "1.0.0", "--to", "-f", "--config", configPath)
+
+	assert.Contains(t, errMsg, "setting 'environments' holds '-f', which is no valid git branch or tag name")
+}
+
+func RunVersionFileOutsideProject(t *testing.T) {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.0", "main")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	configPath := env.WriteConfig("version-file:\n  name: ../version.txt\n")
+	errMsg := env.ExecuteGitflowExpectError("release", "start", "--config", configPath)
+
+	assert.Contains(t, errMsg, "setting 'version-file.name' holds '../version.txt', which is no relative path inside the project")
+}
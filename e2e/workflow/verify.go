@@ -0,0 +1,52 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func RunVerifyTags(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	// environment tags and development commits are no drift
+	env.ExecuteGit("tag", "staging/1.0.0", "1.0.0")
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0-dev", "develop")
+
+	output := env.ExecuteGitflow("verify", "tags")
+
+	assert.Contains(t, output, "Verify tags against main branch completed")
+	assert.NotContains(t, output, "WARN")
+}
+
+func RunVerifyTagsUnreachable(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	// a tag created by hand on the development branch was never released
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.1.0", "develop")
+	env.ExecuteGit("tag", "1.1.0", "develop")
+
+	errMsg := env.ExecuteGitflowExpectError("verify", "tags")
+
+	// the production branch also lags behind the latest tag
+	assert.Contains(t, errMsg, "found 2 drift(s) between the version tags and 'origin/main'")
+}
+
+func RunVerifyTagsVersionDrift(t *testing.T) {
+	t.Helper()
+	env := setupReleasedVersion(t)
+
+	// a version changed by hand on the production branch was never tagged
+	env.CommitTemplateContent("{{.Version}}", "version.txt", "1.0.1", "main")
+
+	errMsg := env.ExecuteGitflowExpectError("verify", "tags")
+
+	assert.Contains(t, errMsg, "found 1 drift(s) between the version tags and 'origin/main'")
+}
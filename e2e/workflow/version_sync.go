@@ -0,0 +1,90 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/stretchr/testify/assert"
+)
+
+// Additional version locations: a pattern with the version as its group, and a README holding the plain version.
+const (
+	valuesTemplate  = "image:\n  repository: app\n  tag: {{.Version}}\n"
+	readmeTemplate  = "# App\n\nCurrent version: {{.Version}}\n"
+	versionSyncYAML = "version-sync:\n  - file: deploy/values.yaml\n    pattern: 'tag: (\\S+)'\n  - file: README.md\n"
+)
+
+// setupVersionSync commits the version file and the additional version locations on both branches.
+func setupVersionSync(t *testing.T) *e2e.GitTestEnv {
+	t.Helper()
+	env := e2e.SetupTestEnv(t)
+
+	for branch, version := range map[string]string{"main": "1.0.0", "develop": "1.1.0-dev"} {
+		env.CommitTemplateContent("{{.Version}}", "version.txt", version, branch)
+		env.CommitTemplateContent(valuesTemplate, "deploy/values.yaml", version, branch)
+		env.CommitTemplateContent(readmeTemplate, "README.md", version, branch)
+	}
+
+	return env
+}
+
+func RunVersionSyncReleaseStart(t *testing.T) {
+	t.Helper()
+	env := setupVersionSync(t)
+
+	configPath := env.WriteConfig(versionSyncYAML)
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+
+	// the locations are part of the commit of the release version
+	env.AssertCommitMessageEquals("Remove qualifier from project version.", "release/1.1.0")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.1.0", "release/1.1.0")
+	env.AssertTemplateVersionEquals(valuesTemplate, "deploy/values.yaml", "1.1.0", "release/1.1.0")
+	env.AssertTemplateVersionEquals(readmeTemplate, "README.md", "1.1.0", "release/1.1.0")
+	assert.Empty(t, env.ExecuteGit("status", "--porcelain"))
+}
+
+func RunVersionSyncReleaseFinish(t *testing.T) {
+	t.Helper()
+	env := setupVersionSync(t)
+
+	configPath := env.WriteConfig(versionSyncYAML)
+	env.ExecuteGitflow("release", "start", "--config", configPath)
+	env.ExecuteGitflow("release", "finish", "--config", configPath)
+
+	// the conflicts of the locations are resolved like the conflict of the version file
+	env.AssertTagEquals("1.1.0", "main")
+	env.AssertTemplateVersionEquals(valuesTemplate, "deploy/values.yaml", "1.1.0", "main")
+	env.AssertTemplateVersionEquals(readmeTemplate, "README.md", "1.1.0", "main")
+
+	env.AssertCommitMessageEquals("Set next minor project version.", "develop")
+	env.AssertTemplateVersionEquals("{{.Version}}", "version.txt", "1.2.0-dev", "develop")
+	env.AssertTemplateVersionEquals(valuesTemplate, "deploy/values.yaml", "1.2.0-dev", "develop")
+	env.AssertTemplateVersionEquals(readmeTemplate, "README.md", "1.2.0-dev", "develop")
+}
+
+func RunVersionSyncMissingVersion(t *testing.T) {
+	t.Helper()
+	env := setupVersionSync(t)
+	env.CommitFile("README.md", []byte("# App\n"), "develop")
+
+	configPath := env.WriteConfig(versionSyncYAML)
+	errMsg := env.ExecuteGitflowExpectError("release", "start", "--config", configPath)
+
+	assert.Contains(t, errMsg, "file 'README.md' of setting 'version-sync' does not hold the version of the project")
+}
+
+func RunVersionSyncInvalidPath(t *testing.T) {
+	t.Helper()
+	env := setupVersionSync(t)
+
+	configPath := env.WriteConfig("version-sync:\n  - file: ../README.md\n")
+	errMsg := env.ExecuteGitflowExpectError("release", "start", "--config", configPath)
+
+	assert.Contains(t, errMsg, "setting 'version-sync.file' holds '../README.md', which is no relative path inside the project")
+	env.AssertBranchDoesNotExist("release/1.1.0")
+}
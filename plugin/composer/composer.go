@@ -9,17 +9,31 @@ import (
 	"fmt"
 	"github.com/mercedes-benz/gitflow-cli/core"
 	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+	"os/exec"
+	"path/filepath"
 	"strings"
 )
 
 // composer-specific command constant
 const composer = "composer"
 
+// lookPath finds the executable of a tool, replaceable in tests.
+var lookPath = exec.LookPath
+
+// Version file template for new projects created with the bootstrap command.
+const versionFileTemplate = `{
+    "name": "{{.Name}}/{{.Name}}",
+    "type": "project",
+    "version": "{{.Version}}"
+}
+`
+
 // Fixed configuration for the Composer plugin
 var pluginConfig = plugin.Config{
 	Name:             "composer",
 	VersionFileName:  "composer.json",
 	VersionQualifier: "dev",
+	Template:         versionFileTemplate,
 	RequiredTools:    []string{composer},
 	DockerImage:      "composer:2",
 }
@@ -44,8 +58,34 @@ func init() {
 	core.RegisterPlugin(composerPlugin)
 }
 
-// ReadVersion reads the version from composer.json using composer.
+// RequiredTools requires no tools if composer is not available, because the version of composer.json is then edited
+// in-process instead of falling back to docker.
+func (p *composerPlugin) RequiredTools() []string {
+	if !p.composerAvailable() {
+		return []string{}
+	}
+	return p.Plugin.RequiredTools()
+}
+
+// composerAvailable reports whether composer can be run, either in the docker image of the plugin or natively.
+func (p *composerPlugin) composerAvailable() bool {
+	if plugin.ExecutorModeOverride == plugin.ModeDocker {
+		return true
+	}
+	_, err := lookPath(composer)
+	return err == nil
+}
+
+// ReadVersion reads the version from composer.json using composer, or directly if composer is not available.
 func (p *composerPlugin) ReadVersion(repository core.Repository) (core.Version, error) {
+	if !p.composerAvailable() {
+		versionString, err := plugin.ReadJSONVersion(filepath.Join(repository.Local(), p.VersionFileName()))
+		if err != nil {
+			return core.Version{}, fmt.Errorf("failed to read version from composer.json: %v", err)
+		}
+		return core.ParseVersion(versionString)
+	}
+
 	var logs = make([]any, 0)
 	// Execute composer command to read the version from composer.json
 	cmd := p.Executor.Command(repository.Local(), composer, "config", "version", "--no-ansi")
@@ -72,8 +112,18 @@ func (p *composerPlugin) ReadVersion(repository core.Repository) (core.Version,
 	return version, nil
 }
 
-// WriteVersion writes the version to composer.json using composer.
+// WriteVersion writes the version to composer.json using composer, or directly if composer is not available.
 func (p *composerPlugin) WriteVersion(repository core.Repository, version core.Version) error {
+	if !p.composerAvailable() {
+		fileName := filepath.Join(repository.Local(), p.VersionFileName())
+		err := plugin.WriteJSONVersion(fileName, version.String())
+		core.Log(fmt.Sprintf("Writing to file: %s, version: %s", fileName, version), err)
+		if err != nil {
+			return fmt.Errorf("failed to write version to composer.json: %v", err)
+		}
+		return nil
+	}
+
 	var err error
 	var output []byte
 
@@ -107,17 +157,11 @@ func (p *composerPlugin) beforeReleaseStart(repository core.Repository) error {
 	// Version doesn't exist, set it to 1.0.0 with qualifier
 	initVersion := core.NewVersion("1", "0", "0", p.Config.VersionQualifier)
 
-	// Set the version using composer CLI
-	cmd := p.Executor.Command(repository.Local(), composer, "config", "version", initVersion.String(), "--no-ansi")
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		core.Log(cmd, output, err)
+	// Set the version using composer CLI, or directly if composer is not available
+	if err := p.WriteVersion(repository, initVersion); err != nil {
 		return repository.Rollback(fmt.Errorf("failed to set initial version: %v", err))
 	}
 
-	core.Log(cmd, output)
-
 	if err := repository.CommitChanges("Set initial project version."); err != nil {
 		return repository.Rollback(err)
 	}
@@ -141,17 +185,11 @@ func (p *composerPlugin) beforeHotfixStart(repository core.Repository) error {
 	// Version doesn't exist, set it to 1.0.0 (no qualifier for production)
 	initVersion := core.NewVersion("1", "0", "0")
 
-	// Set the version using composer CLI
-	cmd := p.Executor.Command(repository.Local(), composer, "config", "version", initVersion.String(), "--no-ansi")
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		core.Log(cmd, output, err)
+	// Set the version using composer CLI, or directly if composer is not available
+	if err := p.WriteVersion(repository, initVersion); err != nil {
 		return repository.Rollback(fmt.Errorf("failed to set initial version: %v", err))
 	}
 
-	core.Log(cmd, output)
-
 	if err := repository.CommitChanges("Set initial project version."); err != nil {
 		return repository.Rollback(err)
 	}
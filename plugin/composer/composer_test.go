@@ -7,10 +7,16 @@ package composer
 
 import (
 	_ "embed"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/mercedes-benz/gitflow-cli/core"
 	"github.com/mercedes-benz/gitflow-cli/core/plugin"
 	"github.com/mercedes-benz/gitflow-cli/e2e/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 //go:embed testdata/e2e/composer.json.tpl
@@ -29,6 +35,14 @@ func TestReleaseStart(t *testing.T) {
 	workflow.RunReleaseStart(t, testConfig)
 }
 
+func TestReleaseStartMajor(t *testing.T) {
+	workflow.RunReleaseStartMajor(t, testConfig)
+}
+
+func TestReleaseStartMinor(t *testing.T) {
+	workflow.RunReleaseStartMinor(t, testConfig)
+}
+
 func TestReleaseStart_BeforeHook(t *testing.T) {
 	workflow.RunBeforeReleaseStartHook(t, testConfig)
 }
@@ -48,3 +62,30 @@ func TestHotfixStart_BeforeHook(t *testing.T) {
 func TestHotfixFinish(t *testing.T) {
 	workflow.RunHotfixFinish(t, testConfig)
 }
+
+func TestWithoutComposer(t *testing.T) {
+	original := lookPath
+	lookPath = func(file string) (string, error) { return "", errors.New("not found") }
+	t.Cleanup(func() { lookPath = original })
+
+	tempDir := t.TempDir()
+	fileName := filepath.Join(tempDir, "composer.json")
+	require.NoError(t, os.WriteFile(fileName, []byte("{\n    \"name\": \"vendor/app\",\n    \"type\": \"project\"\n}\n"), 0644))
+	repository := core.NewRepository(tempDir, "")
+	p := &composerPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+
+	assert.Empty(t, p.RequiredTools())
+
+	_, err := p.ReadVersion(repository)
+	assert.Error(t, err, "composer.json without version")
+
+	require.NoError(t, p.WriteVersion(repository, core.NewVersion("1", "0", "0", "dev")))
+
+	version, err := p.ReadVersion(repository)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0-dev", version.String())
+
+	content, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n    \"version\": \"1.0.0-dev\",\n    \"name\": \"vendor/app\",\n    \"type\": \"project\"\n}\n", string(content))
+}
@@ -0,0 +1,237 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package custom
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+)
+
+// Settings of the custom plugin group, e.g. 'custom.file'.
+const (
+	fileSetting     = "file"
+	regexSetting    = "regex"
+	pathSetting     = "path"
+	templateSetting = "template"
+)
+
+// Fixed configuration for the custom plugin, whose version file is configured under 'custom.file'.
+var pluginConfig = plugin.Config{
+	Name:             "custom",
+	VersionQualifier: "dev",
+	RequiredTools:    []string{},
+	DockerImage:      "alpine:3",
+}
+
+// customPlugin is the plugin for projects with a proprietary version file, which is read and written with an
+// expression or a path from the configuration instead of code.
+type customPlugin struct {
+	plugin.Plugin
+}
+
+// Register the custom plugin
+func init() {
+	pluginFactory := plugin.NewFactory()
+
+	// Create plugin with pluginFactory to get hooks and other dependencies
+	customPlugin := &customPlugin{
+		Plugin: pluginFactory.NewPlugin(pluginConfig),
+	}
+
+	// Register plugin directly in core, before the built-in plugins because it is configured explicitly
+	core.RegisterPreferredPlugin(customPlugin)
+}
+
+// VersionFileName returns the version file configured under 'custom.file', which is empty if the plugin is not set
+// up, so that it never applies to a project then.
+func (p *customPlugin) VersionFileName() string {
+	return p.setting(fileSetting)
+}
+
+// VersionFileNames returns no alternatives, the version file is the configured one.
+func (p *customPlugin) VersionFileNames() []string {
+	return []string{}
+}
+
+// ReadVersion reads the version from the configured version file
+func (p *customPlugin) ReadVersion(repository core.Repository) (core.Version, error) {
+	versionFile, err := p.versionFile(repository)
+	if err != nil {
+		return core.NoVersion, err
+	}
+
+	var value string
+	if path := p.setting(pathSetting); len(path) > 0 {
+		value, err = readPath(versionFile, pathKeys(path))
+	} else {
+		value, err = p.readRegex(versionFile)
+	}
+	if err != nil {
+		return core.NoVersion, err
+	}
+
+	return core.ParseVersion(strings.TrimSpace(value))
+}
+
+// WriteVersion writes the version to the configured version file
+func (p *customPlugin) WriteVersion(repository core.Repository, version core.Version) error {
+	versionFile, err := p.versionFile(repository)
+	if err != nil {
+		return err
+	}
+
+	if path := p.setting(pathSetting); len(path) > 0 {
+		err = writePath(versionFile, pathKeys(path), version.String())
+	} else {
+		err = p.writeRegex(versionFile, version)
+	}
+
+	core.Log(fmt.Sprintf("Writing to file: %s, version: %s", versionFile, version), err)
+	return err
+}
+
+// setting returns a string setting of the custom plugin group.
+func (p *customPlugin) setting(key string) string {
+	value, _ := core.PluginSettings(p)[key].(string)
+	return value
+}
+
+// versionFile returns the path of the configured version file, which must be a relative path inside the project
+// and have either an expression or a path of its version configured.
+func (p *customPlugin) versionFile(repository core.Repository) (string, error) {
+	fileName := p.setting(fileSetting)
+	if !filepath.IsLocal(fileName) {
+		return "", fmt.Errorf("custom.file '%v' is no relative path inside the project", fileName)
+	}
+
+	regex, path := p.setting(regexSetting), p.setting(pathSetting)
+	if len(regex) == 0 && len(path) == 0 {
+		return "", fmt.Errorf("custom plugin requires custom.regex or custom.path for %v", fileName)
+	} else if len(regex) > 0 && len(path) > 0 {
+		return "", fmt.Errorf("custom plugin accepts either custom.regex or custom.path for %v, not both", fileName)
+	}
+
+	return filepath.Join(repository.Local(), fileName), nil
+}
+
+// expression returns the configured expression, whose first group is the version.
+func (p *customPlugin) expression() (*regexp.Regexp, error) {
+	expression, err := regexp.Compile(p.setting(regexSetting))
+	if err != nil {
+		return nil, fmt.Errorf("invalid custom.regex: %v", err)
+	} else if expression.NumSubexp() == 0 {
+		return nil, fmt.Errorf("custom.regex '%v' has no group for the version", expression)
+	}
+	return expression, nil
+}
+
+// readRegex reads the version of all matches of the configured expression, which must be the same.
+func (p *customPlugin) readRegex(versionFile string) (string, error) {
+	expression, err := p.expression()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(versionFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read custom version file: %v", err)
+	}
+
+	matches := expression.FindAllSubmatch(data, -1)
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no version matching custom.regex found in %v file", filepath.Base(versionFile))
+	}
+	for _, match := range matches[1:] {
+		if !bytes.Equal(match[1], matches[0][1]) {
+			return "", fmt.Errorf("different version entries found in %v file: %s, %s", filepath.Base(versionFile), matches[0][1], match[1])
+		}
+	}
+
+	return string(matches[0][1]), nil
+}
+
+// writeRegex writes the version to all matches of the configured expression: the first group is replaced by the
+// version, or the whole match by the template configured under 'custom.template', e.g. 'APP_VERSION={{.Version}}'.
+func (p *customPlugin) writeRegex(versionFile string, version core.Version) error {
+	expression, err := p.expression()
+	if err != nil {
+		return err
+	}
+
+	replacement := version.String()
+	wholeMatch := false
+	if text := p.setting(templateSetting); len(text) > 0 {
+		parsed, err := template.New(templateSetting).Parse(text)
+		if err != nil {
+			return fmt.Errorf("invalid custom.template: %v", err)
+		}
+
+		var content strings.Builder
+		if err := parsed.Execute(&content, struct{ Version string }{version.String()}); err != nil {
+			return fmt.Errorf("invalid custom.template: %v", err)
+		}
+		replacement, wholeMatch = content.String(), true
+	}
+
+	data, err := os.ReadFile(versionFile)
+	if err != nil {
+		return fmt.Errorf("custom version update failed: %v", err)
+	}
+
+	matches := expression.FindAllSubmatchIndex(data, -1)
+	if len(matches) == 0 {
+		return fmt.Errorf("no version matching custom.regex found in %v file", filepath.Base(versionFile))
+	}
+
+	var updated bytes.Buffer
+	last := 0
+	for _, match := range matches {
+		start, end := match[2], match[3]
+		if wholeMatch {
+			start, end = match[0], match[1]
+		} else if start < 0 {
+			continue
+		}
+
+		updated.Write(data[last:start])
+		updated.WriteString(replacement)
+		last = end
+	}
+	updated.Write(data[last:])
+
+	return os.WriteFile(versionFile, updated.Bytes(), 0644)
+}
+
+// pathKeys splits a path of the version into its keys, e.g. '$.app.version' or 'app.version' into 'app' and
+// 'version'.
+func pathKeys(path string) []string {
+	return strings.Split(strings.TrimPrefix(strings.TrimPrefix(path, "$"), "."), ".")
+}
+
+// readPath reads the version at a path of keys of a JSON file, or of a YAML file for all other extensions.
+func readPath(versionFile string, keys []string) (string, error) {
+	if strings.EqualFold(filepath.Ext(versionFile), ".json") {
+		return plugin.ReadJSONValue(versionFile, keys)
+	}
+	return plugin.ReadYAMLValue(versionFile, keys)
+}
+
+// writePath writes the version at a path of keys of a JSON file, or of a YAML file for all other extensions, in
+// place, which keeps the formatting and the comments of the file.
+func writePath(versionFile string, keys []string, version string) error {
+	if strings.EqualFold(filepath.Ext(versionFile), ".json") {
+		return plugin.WriteJSONValue(versionFile, keys, version)
+	}
+	return plugin.WriteYAMLValue(versionFile, keys, version)
+}
@@ -0,0 +1,187 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package custom
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+	"github.com/mercedes-benz/gitflow-cli/e2e/workflow"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The e2e tests configure the plugin with the project-local configuration file of the test repository.
+var testConfigs = []plugin.TestConfig{
+	{
+		Name:             "custom_regex",
+		PluginName:       "custom",
+		DockerImage:      pluginConfig.DockerImage,
+		VersionQualifier: "dev",
+		VersionFileName:  "app.properties",
+		Template:         "name=app\napp.version={{.Version}}\n",
+		ProjectFiles: map[string]string{
+			".gitflow-cli.yaml": "custom:\n  file: app.properties\n  regex: '(?m)^app\\.version=(\\S+)$'\n",
+		},
+	},
+	{
+		Name:             "custom_yaml",
+		PluginName:       "custom",
+		DockerImage:      pluginConfig.DockerImage,
+		VersionQualifier: "dev",
+		VersionFileName:  "app.yaml",
+		Template:         "app:\n  name: app\n  version: \"{{.Version}}\" # released version\n",
+		ProjectFiles: map[string]string{
+			".gitflow-cli.yaml": "custom:\n  file: app.yaml\n  path: app.version\n",
+		},
+	},
+	{
+		Name:             "custom_json",
+		PluginName:       "custom",
+		DockerImage:      pluginConfig.DockerImage,
+		VersionQualifier: "dev",
+		VersionFileName:  "app.json",
+		Template:         "{\n  \"app\": {\n    \"name\": \"app\",\n    \"version\": \"{{.Version}}\"\n  }\n}\n",
+		ProjectFiles: map[string]string{
+			".gitflow-cli.yaml": "custom:\n  file: app.json\n  path: $.app.version\n",
+		},
+	},
+}
+
+func TestE2E_ReleaseStart(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStart(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseFinish(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseFinish(t, tc)
+		})
+	}
+}
+
+func TestE2E_HotfixStart(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunHotfixStart(t, tc)
+		})
+	}
+}
+
+func TestE2E_HotfixFinish(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunHotfixFinish(t, tc)
+		})
+	}
+}
+
+// setupTest writes a version file into a temp dir and configures the custom plugin with the settings.
+func setupTest(t *testing.T, fileName, content string, settings map[string]string) (string, core.Repository, *customPlugin) {
+	t.Helper()
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set("custom.file", fileName)
+	for key, value := range settings {
+		viper.Set("custom."+key, value)
+	}
+
+	tempDir := t.TempDir()
+	testFilePath := filepath.Join(tempDir, fileName)
+	require.NoError(t, os.WriteFile(testFilePath, []byte(content), 0644))
+
+	p := &customPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+	return testFilePath, core.NewRepository(tempDir, ""), p
+}
+
+func TestVersionReadWrite(t *testing.T) {
+	testCases := []struct {
+		name           string
+		fileName       string
+		settings       map[string]string
+		initialContent string
+		expectedResult string
+	}{
+		{"Regex", "build.cfg", map[string]string{"regex": `VERSION\s*:=\s*(\S+)`},
+			"NAME := app\nVERSION := 1.2.3\n", "NAME := app\nVERSION := 1.2.3-dev\n"},
+		{"RegexAllMatches", "build.cfg", map[string]string{"regex": `version "([^"]+)"`},
+			"version \"1.2.3\"\nlabel version \"1.2.3\"\n", "version \"1.2.3-dev\"\nlabel version \"1.2.3-dev\"\n"},
+		{"RegexTemplate", "build.cfg", map[string]string{"regex": `(?m)^VERSION=(\S+)$`, "template": "VERSION={{.Version}}"},
+			"VERSION=1.2.3\n", "VERSION=1.2.3-dev\n"},
+		{"YAMLPath", "app.yml", map[string]string{"path": "metadata.app.version"},
+			"metadata:\n  app:\n    version: '1.2.3' # kept\n  version: 0.1.0\n", "metadata:\n  app:\n    version: '1.2.3-dev' # kept\n  version: 0.1.0\n"},
+		{"JSONPath", "app.json", map[string]string{"path": "$.metadata.version"},
+			"{\"version\": \"0.1.0\", \"metadata\": {\"version\": \"1.2.3\"}}", "{\"version\": \"0.1.0\", \"metadata\": {\"version\": \"1.2.3-dev\"}}"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(test *testing.T) {
+			testFilePath, repository, p := setupTest(test, testCase.fileName, testCase.initialContent, testCase.settings)
+
+			version, err := p.ReadVersion(repository)
+			require.NoError(test, err)
+			assert.Equal(test, "1.2.3", version.String())
+
+			version.Qualifier = "dev"
+			require.NoError(test, p.WriteVersion(repository, version))
+
+			result, err := os.ReadFile(testFilePath)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expectedResult, string(result))
+		})
+	}
+}
+
+func TestVersionInvalidSettings(t *testing.T) {
+	testCases := []struct {
+		name           string
+		fileName       string
+		settings       map[string]string
+		initialContent string
+	}{
+		{"NoRegexOrPath", "app.cfg", map[string]string{}, "version=1.2.3\n"},
+		{"RegexAndPath", "app.cfg", map[string]string{"regex": `version=(\S+)`, "path": "version"}, "version=1.2.3\n"},
+		{"RegexWithoutGroup", "app.cfg", map[string]string{"regex": `version=\S+`}, "version=1.2.3\n"},
+		{"RegexNoMatch", "app.cfg", map[string]string{"regex": `release=(\S+)`}, "version=1.2.3\n"},
+		{"RegexDifferentVersions", "app.cfg", map[string]string{"regex": `version=(\S+)`}, "version=1.2.3\nversion=1.2.4\n"},
+		{"PathNoMatch", "app.yaml", map[string]string{"path": "app.version"}, "version: 1.2.3\n"},
+		{"PathNoScalar", "app.yaml", map[string]string{"path": "app"}, "app:\n  version: 1.2.3\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(test *testing.T) {
+			_, repository, p := setupTest(test, testCase.fileName, testCase.initialContent, testCase.settings)
+
+			_, err := p.ReadVersion(repository)
+			assert.Error(test, err)
+		})
+	}
+
+	t.Run("OutsideProject", func(test *testing.T) {
+		_, repository, p := setupTest(test, "app.cfg", "version=1.2.3\n", map[string]string{"regex": `version=(\S+)`})
+		viper.Set("custom.file", "../app.cfg")
+
+		_, err := p.ReadVersion(repository)
+		assert.ErrorContains(test, err, "no relative path inside the project")
+	})
+}
+
+func TestNotConfigured(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	p := &customPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+	assert.Empty(t, p.VersionFileName())
+	assert.False(t, core.CheckVersionFile(p))
+}
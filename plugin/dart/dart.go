@@ -0,0 +1,145 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package dart
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+)
+
+// The top-level version of pubspec.yaml with an optional build number, e.g. 'version: 1.2.0+5', not the versions
+// of the dependencies.
+var versionRegex = regexp.MustCompile(`(?m)^(version[ \t]*:[ \t]*)(['"]?)([^'"\s#+]+)(?:\+(\d+))?(['"]?)`)
+
+// Version file template for new projects created with the bootstrap command.
+const versionFileTemplate = `name: {{.Name}}
+description: A new Dart project.
+version: {{.Version}}
+
+environment:
+  sdk: ^3.0.0
+`
+
+// Fixed configuration for the dart plugin
+var pluginConfig = plugin.Config{
+	Name:             "dart",
+	VersionFileName:  "pubspec.yaml",
+	VersionQualifier: "dev",
+	Template:         versionFileTemplate,
+	RequiredTools:    []string{},
+	DockerImage:      "alpine:3",
+}
+
+// dartPlugin is the plugin for Dart packages and Flutter apps.
+type dartPlugin struct {
+	plugin.Plugin
+}
+
+// Register the dart plugin
+func init() {
+	pluginFactory := plugin.NewFactory()
+
+	// Create plugin with pluginFactory to get hooks and other dependencies
+	dartPlugin := &dartPlugin{
+		Plugin: pluginFactory.NewPlugin(pluginConfig),
+	}
+
+	// Register hooks
+	dartPlugin.RegisterHook(core.ReleaseFinishHooks.AfterUpdateProjectVersionHook, dartPlugin.afterReleaseFinishUpdate)
+
+	// Register plugin directly in core
+	core.RegisterPlugin(dartPlugin)
+}
+
+// ReadVersion reads the version from pubspec.yaml, without its build number
+func (p *dartPlugin) ReadVersion(repository core.Repository) (core.Version, error) {
+	matches, err := p.readVersion(repository)
+	if err != nil {
+		return core.NoVersion, err
+	}
+
+	return core.ParseVersion(matches[3])
+}
+
+// WriteVersion writes the version to pubspec.yaml and keeps its build number, e.g. 'version: 1.2.0+5'
+func (p *dartPlugin) WriteVersion(repository core.Repository, version core.Version) error {
+	versionFile := filepath.Join(repository.Local(), p.VersionFileName())
+
+	data, err := os.ReadFile(versionFile)
+	if err != nil {
+		return fmt.Errorf("dart version update failed: %v", err)
+	}
+
+	if !versionRegex.Match(data) {
+		return fmt.Errorf("version key not found in %v file", p.VersionFileName())
+	}
+
+	// keep the quotation marks, build number and comments of the version
+	newContent := versionRegex.ReplaceAllStringFunc(string(data), func(match string) string {
+		groups := versionRegex.FindStringSubmatch(match)
+		return groups[1] + groups[2] + buildVersion(version.String(), groups[4]) + groups[5]
+	})
+
+	return os.WriteFile(versionFile, []byte(newContent), 0644)
+}
+
+// readVersion returns the groups of the single version entry of pubspec.yaml.
+func (p *dartPlugin) readVersion(repository core.Repository) ([]string, error) {
+	versionFile := filepath.Join(repository.Local(), p.VersionFileName())
+
+	data, err := os.ReadFile(versionFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dart version file: %v", err)
+	}
+
+	// Check for multiple version entries
+	allMatches := versionRegex.FindAllStringSubmatch(string(data), -1)
+	if len(allMatches) > 1 {
+		return nil, fmt.Errorf("multiple version entries found in %v file", p.VersionFileName())
+	} else if len(allMatches) == 0 {
+		return nil, fmt.Errorf("no version found in %v file", p.VersionFileName())
+	}
+
+	return allMatches[0], nil
+}
+
+// afterReleaseFinishUpdate increments the build number of the next development version, so that every release of a
+// Flutter app is uploaded to the app stores with a higher build number. Versions without build number are kept.
+func (p *dartPlugin) afterReleaseFinishUpdate(repository core.Repository) error {
+	matches, err := p.readVersion(repository)
+	if err != nil || len(matches[4]) == 0 {
+		return err
+	}
+
+	build, err := strconv.Atoi(matches[4])
+	if err != nil {
+		return fmt.Errorf("invalid build number %v in %v file: %v", matches[4], p.VersionFileName(), err)
+	}
+
+	versionFile := filepath.Join(repository.Local(), p.VersionFileName())
+	data, err := os.ReadFile(versionFile)
+	if err != nil {
+		return fmt.Errorf("dart version update failed: %v", err)
+	}
+
+	newContent := versionRegex.ReplaceAllString(string(data), "${1}${2}"+buildVersion(matches[3], strconv.Itoa(build+1))+"${5}")
+
+	return os.WriteFile(versionFile, []byte(newContent), 0644)
+}
+
+// buildVersion appends a build number to a version, if there is one.
+func buildVersion(version, build string) string {
+	if len(build) == 0 {
+		return version
+	}
+	return version + "+" + build
+}
@@ -0,0 +1,183 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package dart
+
+import (
+	_ "embed"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+	"github.com/mercedes-benz/gitflow-cli/e2e"
+	"github.com/mercedes-benz/gitflow-cli/e2e/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/e2e/pubspec.yaml.tpl
+var pubspecTemplate string
+
+var testConfigs = []plugin.TestConfig{
+	{
+		Name:             "dart_pubspec",
+		PluginName:       "dart",
+		DockerImage:      pluginConfig.DockerImage,
+		VersionQualifier: "dev",
+		VersionFileName:  "pubspec.yaml",
+		Template:         pubspecTemplate,
+	},
+}
+
+func TestE2E_ReleaseStart(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStart(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseStartMajor(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStartMajor(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseStartMinor(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStartMinor(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseFinish(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseFinish(t, tc)
+		})
+	}
+}
+
+func TestE2E_HotfixStart(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunHotfixStart(t, tc)
+		})
+	}
+}
+
+func TestE2E_HotfixFinish(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunHotfixFinish(t, tc)
+		})
+	}
+}
+
+// The build number is kept by release start and incremented for the next development version by release finish.
+func TestE2E_ReleaseBuildNumber(t *testing.T) {
+	env := e2e.SetupTestEnv(t, e2e.WithDockerMode(pluginConfig.DockerImage != ""))
+
+	env.CommitTemplateContent(pubspecTemplate, "pubspec.yaml", "1.0.0+4", "main")
+	env.CommitTemplateContent(pubspecTemplate, "pubspec.yaml", "1.1.0-dev+4", "develop")
+
+	env.ExecuteGitflow("release", "start")
+	env.AssertTemplateVersionEquals(pubspecTemplate, "pubspec.yaml", "1.1.0+4", "release/1.1.0")
+
+	env.ExecuteGitflow("release", "finish")
+	env.AssertTagEquals("1.1.0", "main")
+	env.AssertTemplateVersionEquals(pubspecTemplate, "pubspec.yaml", "1.1.0+4", "main")
+	env.AssertCommitMessageEquals("Set next minor project version.", "develop")
+	env.AssertTemplateVersionEquals(pubspecTemplate, "pubspec.yaml", "1.2.0-dev+5", "develop")
+}
+
+func setupTest(t *testing.T, content string) (string, core.Repository, *dartPlugin) {
+	t.Helper()
+	tempDir := t.TempDir()
+	testFilePath := filepath.Join(tempDir, "pubspec.yaml")
+	require.NoError(t, os.WriteFile(testFilePath, []byte(content), 0644))
+
+	p := &dartPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+	return testFilePath, core.NewRepository(tempDir, ""), p
+}
+
+func TestVersionReadWrite(t *testing.T) {
+	testCases := []struct {
+		name           string
+		initialContent string
+		expectedResult string
+	}{
+		{"Plain", "name: app\nversion: 1.2.3\n", "name: app\nversion: 1.2.3-dev\n"},
+		{"BuildNumber", "name: app\nversion: 1.2.3+42\n", "name: app\nversion: 1.2.3-dev+42\n"},
+		{"Quoted", "version: '1.2.3+7' # app version\n", "version: '1.2.3-dev+7' # app version\n"},
+		{"DependenciesIgnored", "version: 1.2.3\ndependencies:\n  foo:\n    version: 1.0.0+1\n", "version: 1.2.3-dev\ndependencies:\n  foo:\n    version: 1.0.0+1\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(test *testing.T) {
+			testFilePath, repository, p := setupTest(test, testCase.initialContent)
+
+			version, err := p.ReadVersion(repository)
+			require.NoError(test, err)
+			assert.Equal(test, "1.2.3", version.String())
+
+			version.Qualifier = "dev"
+			require.NoError(test, p.WriteVersion(repository, version))
+
+			result, err := os.ReadFile(testFilePath)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expectedResult, string(result))
+		})
+	}
+}
+
+func TestVersionNoMatch(t *testing.T) {
+	testCases := []struct {
+		name           string
+		initialContent string
+	}{
+		{"NoVersion", "name: app\n"},
+		{"MultipleVersions", "version: 1.2.3\nversion: 3.4.5\n"},
+		{"NestedVersionOnly", "dependencies:\n  foo:\n    version: 1.0.0\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(test *testing.T) {
+			_, repository, p := setupTest(test, testCase.initialContent)
+
+			_, err := p.ReadVersion(repository)
+			require.Error(test, err)
+		})
+	}
+}
+
+func TestIncrementBuildNumber(t *testing.T) {
+	testCases := []struct {
+		name           string
+		initialContent string
+		expectedResult string
+	}{
+		{"BuildNumber", "version: 1.3.0-dev+9\n", "version: 1.3.0-dev+10\n"},
+		{"QuotedBuildNumber", "version: \"1.3.0-dev+9\"\n", "version: \"1.3.0-dev+10\"\n"},
+		{"NoBuildNumber", "version: 1.3.0-dev\n", "version: 1.3.0-dev\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(test *testing.T) {
+			testFilePath, repository, p := setupTest(test, testCase.initialContent)
+
+			require.NoError(test, p.afterReleaseFinishUpdate(repository))
+
+			result, err := os.ReadFile(testFilePath)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expectedResult, string(result))
+		})
+	}
+}
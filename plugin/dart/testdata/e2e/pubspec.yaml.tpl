@@ -0,0 +1,12 @@
+name: example
+description: An example Flutter app.
+publish_to: none
+version: {{.Version}}
+
+environment:
+  sdk: ^3.5.0
+
+dependencies:
+  flutter:
+    sdk: flutter
+  http: ^1.2.0
@@ -0,0 +1,207 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package golang
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+)
+
+// go-specific constants
+const (
+	goMod              = "go.mod"
+	versionFileSetting = "version-file"
+)
+
+// The declaration of the version constant or variable of a Go source file, e.g. const Version = "1.2.0", also
+// inside a const block.
+var declarationRegex = regexp.MustCompile(`(?m)^([ \t]*(?:(?:const|var)[ \t]+)?Version(?:[ \t]+string)?[ \t]*=[ \t]*)"([^"\n]*)"`)
+
+// Go source file holding the version constant, created if the project has no version file yet.
+const versionSourceTemplate = `// Package %v holds the version of the module, which is managed by gitflow-cli.
+package %v
+
+// Version of the module.
+const Version = "%v"
+`
+
+// Fixed configuration for the go plugin
+var pluginConfig = plugin.Config{
+	Name:             "go",
+	VersionFileName:  "internal/version/version.go",
+	VersionQualifier: "dev",
+	RequiredTools:    []string{},
+	DockerImage:      "alpine:3",
+}
+
+// goPlugin is the plugin for Go modules.
+type goPlugin struct {
+	plugin.Plugin
+}
+
+// Register the go plugin
+func init() {
+	pluginFactory := plugin.NewFactory()
+
+	// Create plugin with pluginFactory to get hooks and other dependencies
+	goPlugin := &goPlugin{
+		Plugin: pluginFactory.NewPlugin(pluginConfig),
+	}
+
+	// Register hooks
+	goPlugin.RegisterHook(core.ReleaseStartHooks.BeforeReleaseStartHook, goPlugin.beforeReleaseStart)
+	goPlugin.RegisterHook(core.HotfixStartHooks.BeforeHotfixStartHook, goPlugin.beforeHotfixStart)
+
+	// Register plugin directly in core
+	core.RegisterPlugin(goPlugin)
+}
+
+// RequiredFile identifies Go modules by their go.mod file, the version file is created if it is missing.
+func (p *goPlugin) RequiredFile() string {
+	return goMod
+}
+
+// VersionFileName returns the version file configured under 'go.version-file', or internal/version/version.go.
+func (p *goPlugin) VersionFileName() string {
+	if v, ok := core.PluginSettings(p)[versionFileSetting].(string); ok && len(v) > 0 {
+		return filepath.ToSlash(filepath.Clean(v))
+	}
+	return p.Config.VersionFileName
+}
+
+// ReadVersion reads the version constant of a Go source file, or the content of a plain version file
+func (p *goPlugin) ReadVersion(repository core.Repository) (core.Version, error) {
+	var logs = make([]any, 0)
+	versionFilePath := filepath.Join(repository.Local(), p.VersionFileName())
+
+	// log human-readable description of commands
+	defer func() { core.Log(logs...) }()
+
+	data, err := os.ReadFile(versionFilePath)
+	if err != nil {
+		logs = append(logs, fmt.Sprintf("Reading file: %s", versionFilePath), err)
+		return core.NoVersion, fmt.Errorf("go version evaluation failed with %v: %v", err, p.VersionFileName())
+	}
+
+	logs = append(logs, fmt.Sprintf("Reading file: %s", versionFilePath), string(data))
+
+	if !p.isSource() {
+		return core.ParseVersion(strings.TrimSpace(string(data)))
+	}
+
+	// Check for multiple version declarations
+	allMatches := declarationRegex.FindAllSubmatch(data, -1)
+	if len(allMatches) > 1 {
+		return core.NoVersion, fmt.Errorf("multiple Version declarations found in %v", p.VersionFileName())
+	} else if len(allMatches) == 0 {
+		return core.NoVersion, fmt.Errorf("no Version declaration found in %v", p.VersionFileName())
+	}
+
+	return core.ParseVersion(string(allMatches[0][2]))
+}
+
+// WriteVersion writes the version constant of a Go source file, or the content of a plain version file
+func (p *goPlugin) WriteVersion(repository core.Repository, version core.Version) error {
+	versionFilePath := filepath.Join(repository.Local(), p.VersionFileName())
+
+	var err error
+	operation := fmt.Sprintf("Writing to file: %s, content: %s", versionFilePath, version.String())
+
+	// log operation description
+	defer func() { core.Log(operation, err) }()
+
+	content := version.String()
+	if p.isSource() {
+		if content, err = p.sourceContent(versionFilePath, version); err != nil {
+			return err
+		}
+	}
+
+	if err = os.MkdirAll(filepath.Dir(versionFilePath), 0755); err != nil {
+		return fmt.Errorf("go version update failed with %v: %v", err, p.VersionFileName())
+	}
+
+	if err = os.WriteFile(versionFilePath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("go version update failed with %v: %v", err, p.VersionFileName())
+	}
+
+	return nil
+}
+
+// sourceContent replaces the version of the declaration in a Go source file, or renders a new source file.
+func (p *goPlugin) sourceContent(versionFilePath string, version core.Version) (string, error) {
+	data, err := os.ReadFile(versionFilePath)
+	if os.IsNotExist(err) {
+		packageName := filepath.Base(filepath.Dir(versionFilePath))
+		return fmt.Sprintf(versionSourceTemplate, packageName, packageName, version), nil
+	} else if err != nil {
+		return "", fmt.Errorf("go version update failed with %v: %v", err, p.VersionFileName())
+	}
+
+	if !declarationRegex.Match(data) {
+		return "", fmt.Errorf("no Version declaration found in %v", p.VersionFileName())
+	}
+
+	return declarationRegex.ReplaceAllString(string(data), `${1}"`+version.String()+`"`), nil
+}
+
+// isSource reports whether the version file is a Go source file, otherwise it only holds the version.
+func (p *goPlugin) isSource() bool {
+	return strings.HasSuffix(p.VersionFileName(), ".go")
+}
+
+func (p *goPlugin) beforeReleaseStart(repository core.Repository) error {
+	if err := repository.CheckoutBranch(core.Development.String()); err != nil {
+		return repository.Rollback(err)
+	}
+
+	return p.createVersionFile(repository, core.Qualifier(p, core.Development))
+}
+
+func (p *goPlugin) beforeHotfixStart(repository core.Repository) error {
+	if err := repository.CheckoutBranch(core.Production.String()); err != nil {
+		return repository.Rollback(err)
+	}
+
+	return p.createVersionFile(repository, "")
+}
+
+// createVersionFile creates a missing version file with the configured initial version and commits it.
+func (p *goPlugin) createVersionFile(repository core.Repository, qualifier string) error {
+	if _, err := os.Stat(filepath.Join(repository.Local(), p.VersionFileName())); err == nil {
+		return nil
+	}
+
+	// create the version file with the configured initial version unless this is disabled
+	if err := core.CheckVersionFileCreation(p.VersionFileName()); err != nil {
+		return err
+	}
+
+	initVersion, err := core.InitialVersion()
+	if err != nil {
+		return err
+	}
+
+	if err := p.WriteVersion(repository, initVersion.AddQualifier(qualifier)); err != nil {
+		return repository.Rollback(err)
+	}
+
+	if err := repository.AddFile(p.VersionFileName()); err != nil {
+		return repository.Rollback(err)
+	}
+
+	if err := repository.CommitChanges("Create versions file"); err != nil {
+		return repository.Rollback(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package golang
+
+import (
+	_ "embed"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+	"github.com/mercedes-benz/gitflow-cli/e2e/workflow"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/e2e/version.go.tpl
+var sourceTemplate string
+
+//go:embed testdata/e2e/VERSION.tpl
+var plainTemplate string
+
+const goModContent = "module example.com/app\n\ngo 1.25\n"
+
+var testConfigs = []plugin.TestConfig{
+	{
+		Name:             "go_source",
+		PluginName:       "go",
+		DockerImage:      pluginConfig.DockerImage,
+		VersionQualifier: "dev",
+		VersionFileName:  "internal/version/version.go",
+		Template:         sourceTemplate,
+		ProjectFiles:     map[string]string{goMod: goModContent},
+	},
+	{
+		Name:             "go_version_file",
+		PluginName:       "go",
+		DockerImage:      pluginConfig.DockerImage,
+		VersionQualifier: "dev",
+		VersionFileName:  "VERSION",
+		Template:         plainTemplate,
+		ProjectFiles: map[string]string{
+			goMod:                      goModContent,
+			core.ProjectConfigFileName: "go:\n  version-file: VERSION\n",
+		},
+	},
+}
+
+func TestE2E_ReleaseStart(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStart(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseStartMajor(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStartMajor(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseStartMinor(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStartMinor(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseFinish(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseFinish(t, tc)
+		})
+	}
+}
+
+func TestE2E_HotfixStart(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunHotfixStart(t, tc)
+		})
+	}
+}
+
+func TestE2E_HotfixFinish(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunHotfixFinish(t, tc)
+		})
+	}
+}
+
+// setupTest writes a version file into a temp dir and selects it as the version file of the plugin. The settings
+// of previous e2e tests are reset, so that no 'go.version-file' setting overrides the selected file.
+func setupTest(t *testing.T, fileName, content string) (string, core.Repository, *goPlugin) {
+	t.Helper()
+	viper.Reset()
+	tempDir := t.TempDir()
+
+	testFilePath := filepath.Join(tempDir, fileName)
+	require.NoError(t, os.MkdirAll(filepath.Dir(testFilePath), 0755))
+	require.NoError(t, os.WriteFile(testFilePath, []byte(content), 0644))
+
+	p := &goPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+	p.Config.VersionFileName = fileName
+
+	return testFilePath, core.NewRepository(tempDir, ""), p
+}
+
+func TestVersionReadWrite(t *testing.T) {
+	testCases := []struct {
+		name           string
+		fileName       string
+		initialContent string
+		expectedResult string
+	}{
+		{"Const", "version.go", "package version\n\nconst Version = \"1.2.3\"\n", "package version\n\nconst Version = \"1.2.3-dev\"\n"},
+		{"ConstBlock", "version.go", "package version\n\nconst (\n\tName    = \"app\"\n\tVersion = \"1.2.3\"\n)\n", "package version\n\nconst (\n\tName    = \"app\"\n\tVersion = \"1.2.3-dev\"\n)\n"},
+		{"Var", "version.go", "package main\n\nvar Version = \"1.2.3\"\n", "package main\n\nvar Version = \"1.2.3-dev\"\n"},
+		{"Typed", "version.go", "package main\n\nconst Version string = \"1.2.3\"\n", "package main\n\nconst Version string = \"1.2.3-dev\"\n"},
+		{"VersionFile", "VERSION", "1.2.3\n", "1.2.3-dev"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(test *testing.T) {
+			testFilePath, repository, p := setupTest(test, testCase.fileName, testCase.initialContent)
+
+			version, err := p.ReadVersion(repository)
+			require.NoError(test, err)
+			assert.Equal(test, "1.2.3", version.String())
+
+			version.Qualifier = "dev"
+			require.NoError(test, p.WriteVersion(repository, version))
+
+			result, err := os.ReadFile(testFilePath)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expectedResult, string(result))
+		})
+	}
+}
+
+func TestVersionNoMatch(t *testing.T) {
+	testCases := []struct {
+		name           string
+		initialContent string
+	}{
+		{"NoVersionDeclaration", "package version\n\nconst Name = \"app\"\n"},
+		{"MultipleVersionDeclarations", "package version\n\nconst Version = \"1.2.3\"\n\nvar Version = \"3.4.5\"\n"},
+		{"OtherDeclaration", "package version\n\nconst MinVersion = \"1.2.3\"\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(test *testing.T) {
+			_, repository, p := setupTest(test, "version.go", testCase.initialContent)
+
+			_, err := p.ReadVersion(repository)
+			require.Error(test, err)
+		})
+	}
+}
+
+func TestWriteVersionCreatesSource(t *testing.T) {
+	viper.Reset()
+	tempDir := t.TempDir()
+	repository := core.NewRepository(tempDir, "")
+	p := &goPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+
+	require.NoError(t, p.WriteVersion(repository, core.NewVersion("1", "0", "0", "dev")))
+
+	result, err := os.ReadFile(filepath.Join(tempDir, "internal", "version", "version.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(result), "package version\n")
+	assert.Contains(t, string(result), "const Version = \"1.0.0-dev\"\n")
+
+	version, err := p.ReadVersion(repository)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0-dev", version.String())
+}
+
+func TestDetectionByGoMod(t *testing.T) {
+	tempDir := t.TempDir()
+
+	projectPath := core.ProjectPath
+	core.ProjectPath = tempDir
+	t.Cleanup(func() { core.ProjectPath = projectPath })
+
+	p := &goPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+	assert.False(t, core.CheckVersionFile(p))
+
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, goMod), []byte(goModContent), 0644))
+	assert.True(t, core.CheckVersionFile(p))
+}
+
+func TestVersionFileSetting(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	p := &goPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+
+	assert.Equal(t, "internal/version/version.go", p.VersionFileName())
+
+	viper.Set("go.version-file", "./pkg/build/../build/version.go")
+	assert.Equal(t, "pkg/build/version.go", p.VersionFileName())
+
+	viper.Set("go.version-file", "VERSION")
+	assert.Equal(t, "VERSION", p.VersionFileName())
+	assert.False(t, p.isSource())
+}
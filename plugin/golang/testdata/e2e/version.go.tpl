@@ -0,0 +1,19 @@
+// Package version holds the version of the application.
+package version
+
+import "runtime/debug"
+
+// Version of the application, set by the release workflow.
+const Version = "{{.Version}}"
+
+// Commit returns the VCS revision the binary was built from.
+func Commit() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				return setting.Value
+			}
+		}
+	}
+	return "unknown"
+}
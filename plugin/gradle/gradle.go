@@ -0,0 +1,147 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package gradle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+)
+
+// gradle-specific file names
+const (
+	gradleProperties = "gradle.properties"
+	buildGradleKts   = "build.gradle.kts"
+	buildGradle      = "build.gradle"
+)
+
+// The version property of gradle.properties, e.g. 'version=1.2.0' or 'version: 1.2.0'.
+var propertyRegex = regexp.MustCompile(`(?m)^(version[ \t]*[=:][ \t]*)(\S+)[ \t]*$`)
+
+// The version assignment of a top-level build script statement, e.g. version = '1.2.0' (Groovy) or
+// version = "1.2.0" (Kotlin).
+var assignmentRegex = regexp.MustCompile(`(?m)^(version[ \t]*=?[ \t]*)(['"])([^'"\n]+)(['"])`)
+
+// Version file template for new projects created with the bootstrap command.
+const versionFileTemplate = `group={{.Name}}
+version={{.Version}}
+`
+
+// Fixed configuration for the gradle plugin
+var pluginConfig = plugin.Config{
+	Name: "gradle",
+	VersionFileNames: []string{
+		gradleProperties,
+		buildGradleKts,
+		buildGradle,
+	},
+	VersionQualifier: "SNAPSHOT",
+	Template:         versionFileTemplate,
+	RequiredTools:    []string{},
+	DockerImage:      "alpine:3",
+}
+
+// gradlePlugin is the plugin for the gradle build tool.
+type gradlePlugin struct {
+	plugin.Plugin
+}
+
+// Register the gradle plugin
+func init() {
+	pluginFactory := plugin.NewFactory()
+
+	// Create plugin with pluginFactory to get hooks and other dependencies
+	gradlePlugin := &gradlePlugin{
+		Plugin: pluginFactory.NewPlugin(pluginConfig),
+	}
+
+	// Register plugin directly in core
+	core.RegisterPlugin(gradlePlugin)
+}
+
+// VersionFileNames returns the files which can hold the version in order of priority. The gradle.properties file
+// is skipped if it has no version property and a build script exists, which then sets the version instead.
+func (p *gradlePlugin) VersionFileNames() []string {
+	content, err := os.ReadFile(filepath.Join(core.ProjectPath, gradleProperties))
+	if err != nil || propertyRegex.Match(content) {
+		return p.Config.VersionFileNames
+	}
+
+	for _, buildScript := range []string{buildGradleKts, buildGradle} {
+		if _, err := os.Stat(filepath.Join(core.ProjectPath, buildScript)); err == nil {
+			return []string{buildGradleKts, buildGradle}
+		}
+	}
+
+	return p.Config.VersionFileNames
+}
+
+// ReadVersion reads the version from gradle.properties or the build script
+func (p *gradlePlugin) ReadVersion(repository core.Repository) (core.Version, error) {
+	versionFile := filepath.Join(repository.Local(), p.VersionFileName())
+
+	data, err := os.ReadFile(versionFile)
+	if err != nil {
+		return core.NoVersion, fmt.Errorf("failed to read gradle version file: %v", err)
+	}
+
+	// Check for multiple version entries
+	allMatches := p.versionRegex().FindAllSubmatch(data, -1)
+	if len(allMatches) > 1 {
+		return core.NoVersion, fmt.Errorf("multiple version entries found in %v file", p.VersionFileName())
+	} else if len(allMatches) == 0 {
+		return core.NoVersion, fmt.Errorf("no version found in %v file", p.VersionFileName())
+	}
+
+	// The version is the second group of a property and the third group of an assignment
+	if p.VersionFileName() == gradleProperties {
+		return core.ParseVersion(string(allMatches[0][2]))
+	}
+	return core.ParseVersion(string(allMatches[0][3]))
+}
+
+// WriteVersion writes the version to gradle.properties or the build script
+func (p *gradlePlugin) WriteVersion(repository core.Repository, version core.Version) error {
+	versionFile := filepath.Join(repository.Local(), p.VersionFileName())
+
+	data, err := os.ReadFile(versionFile)
+	if err != nil {
+		return fmt.Errorf("gradle version update failed: %v", err)
+	}
+
+	// keep the separator of a property and the quotation marks of an assignment
+	var newContent string
+	if p.VersionFileName() == gradleProperties {
+		newContent = propertyRegex.ReplaceAllString(string(data), "${1}"+version.String())
+	} else {
+		newContent = assignmentRegex.ReplaceAllString(string(data), "${1}${2}"+version.String()+"${4}")
+	}
+
+	// gradle.properties without version property gets one, build scripts must set the version themselves
+	if !p.versionRegex().MatchString(string(data)) {
+		if p.VersionFileName() != gradleProperties {
+			return fmt.Errorf("version assignment not found in %v file", p.VersionFileName())
+		}
+		if len(data) > 0 && data[len(data)-1] != '\n' {
+			newContent += "\n"
+		}
+		newContent += "version=" + version.String() + "\n"
+	}
+
+	return os.WriteFile(versionFile, []byte(newContent), 0644)
+}
+
+// versionRegex returns the expression matching the version of the selected version file.
+func (p *gradlePlugin) versionRegex() *regexp.Regexp {
+	if p.VersionFileName() == gradleProperties {
+		return propertyRegex
+	}
+	return assignmentRegex
+}
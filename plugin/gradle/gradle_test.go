@@ -0,0 +1,221 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package gradle
+
+import (
+	_ "embed"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+	"github.com/mercedes-benz/gitflow-cli/e2e/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/e2e/gradle.properties.tpl
+var propertiesTemplate string
+
+//go:embed testdata/e2e/build.gradle.tpl
+var groovyTemplate string
+
+//go:embed testdata/e2e/build.gradle.kts.tpl
+var kotlinTemplate string
+
+var testConfigs = []plugin.TestConfig{
+	{
+		Name:             "gradle_properties",
+		PluginName:       "gradle",
+		DockerImage:      pluginConfig.DockerImage,
+		VersionQualifier: "SNAPSHOT",
+		VersionFileName:  gradleProperties,
+		Template:         propertiesTemplate,
+	},
+	{
+		Name:             "gradle_groovy",
+		PluginName:       "gradle",
+		DockerImage:      pluginConfig.DockerImage,
+		VersionQualifier: "SNAPSHOT",
+		VersionFileName:  buildGradle,
+		Template:         groovyTemplate,
+	},
+	{
+		Name:             "gradle_kotlin",
+		PluginName:       "gradle",
+		DockerImage:      pluginConfig.DockerImage,
+		VersionQualifier: "SNAPSHOT",
+		VersionFileName:  buildGradleKts,
+		Template:         kotlinTemplate,
+	},
+}
+
+func TestE2E_ReleaseStart(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStart(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseStartMajor(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStartMajor(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseStartMinor(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStartMinor(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseFinish(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseFinish(t, tc)
+		})
+	}
+}
+
+func TestE2E_HotfixStart(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunHotfixStart(t, tc)
+		})
+	}
+}
+
+func TestE2E_HotfixFinish(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunHotfixFinish(t, tc)
+		})
+	}
+}
+
+// setupTest writes a version file into a temp dir and selects it as the version file of the plugin.
+func setupTest(t *testing.T, fileName, content string) (string, core.Repository, *gradlePlugin) {
+	t.Helper()
+	tempDir := t.TempDir()
+
+	testFilePath := filepath.Join(tempDir, fileName)
+	require.NoError(t, os.WriteFile(testFilePath, []byte(content), 0644))
+
+	p := &gradlePlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+	p.Config.VersionFileName = fileName
+
+	return testFilePath, core.NewRepository(tempDir, ""), p
+}
+
+func TestVersionReadWrite(t *testing.T) {
+	testCases := []struct {
+		name           string
+		fileName       string
+		initialContent string
+		expectedResult string
+	}{
+		{"Property", gradleProperties, "version=1.2.3\n", "version=1.2.3-SNAPSHOT\n"},
+		{"PropertyWithSpaces", gradleProperties, "version = 1.2.3  \n", "version = 1.2.3-SNAPSHOT\n"},
+		{"PropertyWithColon", gradleProperties, "version: 1.2.3\n", "version: 1.2.3-SNAPSHOT\n"},
+		{"GroovyAssignment", buildGradle, "version = '1.2.3'\n", "version = '1.2.3-SNAPSHOT'\n"},
+		{"GroovyMethodCall", buildGradle, "version '1.2.3'\n", "version '1.2.3-SNAPSHOT'\n"},
+		{"KotlinAssignment", buildGradleKts, "version = \"1.2.3\"\n", "version = \"1.2.3-SNAPSHOT\"\n"},
+		{"KotlinPluginVersionIgnored", buildGradleKts, "plugins {\n    kotlin(\"jvm\") version \"2.0.0\"\n}\nversion = \"1.2.3\"\n", "plugins {\n    kotlin(\"jvm\") version \"2.0.0\"\n}\nversion = \"1.2.3-SNAPSHOT\"\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(test *testing.T) {
+			testFilePath, repository, p := setupTest(test, testCase.fileName, testCase.initialContent)
+
+			version, err := p.ReadVersion(repository)
+			require.NoError(test, err)
+			assert.Equal(test, "1.2.3", version.String())
+
+			version.Qualifier = "SNAPSHOT"
+			require.NoError(test, p.WriteVersion(repository, version))
+
+			result, err := os.ReadFile(testFilePath)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expectedResult, string(result))
+		})
+	}
+}
+
+func TestVersionNoMatch(t *testing.T) {
+	testCases := []struct {
+		name           string
+		fileName       string
+		initialContent string
+	}{
+		{"NoVersionProperty", gradleProperties, "group=com.example\n"},
+		{"MultipleVersionProperties", gradleProperties, "version=1.2.3\nversion=3.4.5\n"},
+		{"OtherProperty", gradleProperties, "versionCode=3\n"},
+		{"NoVersionAssignment", buildGradle, "group = 'com.example'\n"},
+		{"NestedVersionAssignment", buildGradleKts, "allprojects {\n    version = \"1.2.3\"\n}\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(test *testing.T) {
+			_, repository, p := setupTest(test, testCase.fileName, testCase.initialContent)
+
+			_, err := p.ReadVersion(repository)
+			require.Error(test, err)
+		})
+	}
+}
+
+func TestWriteVersionAddsProperty(t *testing.T) {
+	testFilePath, repository, p := setupTest(t, gradleProperties, "org.gradle.parallel=true")
+
+	require.NoError(t, p.WriteVersion(repository, core.NewVersion("1", "0", "0", "SNAPSHOT")))
+
+	result, err := os.ReadFile(testFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, "org.gradle.parallel=true\nversion=1.0.0-SNAPSHOT\n", string(result))
+
+	// build scripts are not extended, because the place of the version assignment is up to the project
+	_, repository, p = setupTest(t, buildGradle, "group = 'com.example'\n")
+	require.Error(t, p.WriteVersion(repository, core.NewVersion("1", "0", "0")))
+}
+
+func TestVersionFileSelection(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected string
+	}{
+		{"OnlyGradleProperties", map[string]string{gradleProperties: "version=1.0.0\n"}, gradleProperties},
+		{"OnlyBuildGradle", map[string]string{buildGradle: "version = '1.0.0'\n"}, buildGradle},
+		{"OnlyBuildGradleKts", map[string]string{buildGradleKts: "version = \"1.0.0\"\n"}, buildGradleKts},
+		{"GradlePropertiesHasHighestPriority", map[string]string{gradleProperties: "version=1.0.0\n", buildGradle: "plugins {}\n"}, gradleProperties},
+		{"BuildScriptWithoutVersionProperty", map[string]string{gradleProperties: "org.gradle.parallel=true\n", buildGradle: "version = '1.0.0'\n"}, buildGradle},
+		{"KotlinBeforeGroovy", map[string]string{gradleProperties: "org.gradle.parallel=true\n", buildGradleKts: "version = \"1.0.0\"\n", buildGradle: "\n"}, buildGradleKts},
+		{"GradlePropertiesWithoutBuildScript", map[string]string{gradleProperties: "org.gradle.parallel=true\n"}, gradleProperties},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			for name, content := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644))
+			}
+
+			projectPath := core.ProjectPath
+			core.ProjectPath = tempDir
+			t.Cleanup(func() { core.ProjectPath = projectPath })
+
+			p := &gradlePlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+			require.True(t, core.CheckVersionFile(p))
+			assert.Equal(t, tt.expected, p.VersionFileName())
+		})
+	}
+}
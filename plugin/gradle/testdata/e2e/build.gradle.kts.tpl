@@ -0,0 +1,14 @@
+plugins {
+    kotlin("jvm") version "2.0.0"
+}
+
+group = "com.example"
+version = "{{.Version}}"
+
+repositories {
+    mavenCentral()
+}
+
+dependencies {
+    testImplementation(kotlin("test"))
+}
@@ -0,0 +1,14 @@
+plugins {
+    id 'java'
+}
+
+group = 'com.example'
+version = '{{.Version}}'
+
+repositories {
+    mavenCentral()
+}
+
+dependencies {
+    testImplementation 'org.junit.jupiter:junit-jupiter:5.10.2'
+}
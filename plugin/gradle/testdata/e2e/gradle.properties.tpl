@@ -0,0 +1,4 @@
+group=com.example
+version={{.Version}}
+org.gradle.jvmargs=-Xmx2g
+org.gradle.parallel=true
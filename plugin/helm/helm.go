@@ -0,0 +1,134 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package helm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+)
+
+// helm-specific constants
+const (
+	appVersionSetting = "app-version"
+)
+
+// The top-level version of Chart.yaml, e.g. 'version: 1.2.0', not the versions of the chart dependencies.
+var versionRegex = regexp.MustCompile(`(?m)^(version[ \t]*:[ \t]*)(['"]?)([^'"\s#]+)(['"]?)`)
+
+// The top-level version of the application deployed by the chart, e.g. 'appVersion: "1.2.0"'.
+var appVersionRegex = regexp.MustCompile(`(?m)^(appVersion[ \t]*:[ \t]*)(['"]?)([^'"\s#]+)(['"]?)`)
+
+// Version file template for new projects created with the bootstrap command.
+const versionFileTemplate = `apiVersion: v2
+name: {{.Name}}
+description: A Helm chart for Kubernetes
+type: application
+version: {{.Version}}
+appVersion: "{{.Version}}"
+`
+
+// Fixed configuration for the helm plugin
+var pluginConfig = plugin.Config{
+	Name:             "helm",
+	VersionFileName:  "Chart.yaml",
+	VersionQualifier: "dev",
+	Template:         versionFileTemplate,
+	RequiredTools:    []string{},
+	DockerImage:      "alpine:3",
+}
+
+// helmPlugin is the plugin for Helm charts.
+type helmPlugin struct {
+	plugin.Plugin
+}
+
+// Register the helm plugin
+func init() {
+	pluginFactory := plugin.NewFactory()
+
+	// Create plugin with pluginFactory to get hooks and other dependencies
+	helmPlugin := &helmPlugin{
+		Plugin: pluginFactory.NewPlugin(pluginConfig),
+	}
+
+	// Register plugin directly in core
+	core.RegisterPlugin(helmPlugin)
+}
+
+// ReadVersion reads the chart version from Chart.yaml
+func (p *helmPlugin) ReadVersion(repository core.Repository) (core.Version, error) {
+	versionFile := filepath.Join(repository.Local(), p.VersionFileName())
+
+	data, err := os.ReadFile(versionFile)
+	if err != nil {
+		return core.NoVersion, fmt.Errorf("failed to read helm version file: %v", err)
+	}
+
+	// Check for multiple version entries
+	allMatches := versionRegex.FindAllSubmatch(data, -1)
+	if len(allMatches) > 1 {
+		return core.NoVersion, fmt.Errorf("multiple version entries found in %v file", p.VersionFileName())
+	} else if len(allMatches) == 0 {
+		return core.NoVersion, fmt.Errorf("no version found in %v file", p.VersionFileName())
+	}
+
+	return core.ParseVersion(string(allMatches[0][3]))
+}
+
+// WriteVersion writes the chart version to Chart.yaml, and the appVersion if configured under 'helm.app-version'
+func (p *helmPlugin) WriteVersion(repository core.Repository, version core.Version) error {
+	versionFile := filepath.Join(repository.Local(), p.VersionFileName())
+
+	data, err := os.ReadFile(versionFile)
+	if err != nil {
+		return fmt.Errorf("helm version update failed: %v", err)
+	}
+
+	if !versionRegex.Match(data) {
+		return fmt.Errorf("version key not found in %v file", p.VersionFileName())
+	}
+
+	// keep the quotation marks and comments of the version
+	newContent := versionRegex.ReplaceAllString(string(data), "${1}${2}"+version.String()+"${4}")
+
+	if p.updateAppVersion() {
+		newContent = writeAppVersion(newContent, version)
+	}
+
+	return os.WriteFile(versionFile, []byte(newContent), 0644)
+}
+
+// updateAppVersion reports whether the appVersion follows the chart version, which is disabled by default because
+// many charts deploy an application released independently.
+func (p *helmPlugin) updateAppVersion() bool {
+	enabled, _ := core.PluginSettings(p)[appVersionSetting].(bool)
+	return enabled
+}
+
+// writeAppVersion sets the appVersion of a chart, which is added below the version if the chart has none.
+func writeAppVersion(content string, version core.Version) string {
+	if appVersionRegex.MatchString(content) {
+		return appVersionRegex.ReplaceAllString(content, "${1}${2}"+version.String()+"${4}")
+	}
+
+	// the appVersion is quoted, as recommended by Helm, since it is not required to be a semantic version
+	location := versionRegex.FindStringIndex(content)
+	end := location[1]
+	for end < len(content) && content[end] != '\n' {
+		end++
+	}
+
+	appVersion := fmt.Sprintf("appVersion: %q", version.String())
+	if end == len(content) {
+		return content + "\n" + appVersion + "\n"
+	}
+	return content[:end+1] + appVersion + "\n" + content[end+1:]
+}
@@ -0,0 +1,154 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package helm
+
+import (
+	_ "embed"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+	"github.com/mercedes-benz/gitflow-cli/e2e/workflow"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/e2e/Chart.yaml.tpl
+var chartTemplate string
+
+var testConfigs = []plugin.TestConfig{
+	{
+		Name:             "helm_chart",
+		PluginName:       "helm",
+		DockerImage:      pluginConfig.DockerImage,
+		VersionQualifier: "dev",
+		VersionFileName:  "Chart.yaml",
+		Template:         chartTemplate,
+	},
+}
+
+func TestE2E_ReleaseStart(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStart(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseStartMajor(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStartMajor(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseStartMinor(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStartMinor(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseFinish(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseFinish(t, tc)
+		})
+	}
+}
+
+func TestE2E_HotfixStart(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunHotfixStart(t, tc)
+		})
+	}
+}
+
+func TestE2E_HotfixFinish(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunHotfixFinish(t, tc)
+		})
+	}
+}
+
+// setupTest writes a Chart.yaml into a temp dir and selects whether the appVersion follows the chart version.
+func setupTest(t *testing.T, content string, appVersion bool) (string, core.Repository, *helmPlugin) {
+	t.Helper()
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	if appVersion {
+		viper.Set("helm.app-version", true)
+	}
+
+	tempDir := t.TempDir()
+	testFilePath := filepath.Join(tempDir, "Chart.yaml")
+	require.NoError(t, os.WriteFile(testFilePath, []byte(content), 0644))
+
+	p := &helmPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+	return testFilePath, core.NewRepository(tempDir, ""), p
+}
+
+func TestVersionReadWrite(t *testing.T) {
+	testCases := []struct {
+		name           string
+		initialContent string
+		appVersion     bool
+		expectedResult string
+	}{
+		{"Plain", "name: app\nversion: 1.2.3\nappVersion: \"4.5.6\"\n", false, "name: app\nversion: 1.2.3-dev\nappVersion: \"4.5.6\"\n"},
+		{"Quoted", "name: app\nversion: '1.2.3'\n", false, "name: app\nversion: '1.2.3-dev'\n"},
+		{"Comment", "version: 1.2.3 # chart version\n", false, "version: 1.2.3-dev # chart version\n"},
+		{"DependenciesIgnored", "version: 1.2.3\ndependencies:\n  - name: redis\n    version: 17.0.0\n", false, "version: 1.2.3-dev\ndependencies:\n  - name: redis\n    version: 17.0.0\n"},
+		{"AppVersion", "version: 1.2.3\nappVersion: \"4.5.6\"\n", true, "version: 1.2.3-dev\nappVersion: \"1.2.3-dev\"\n"},
+		{"AppVersionUnquoted", "appVersion: 4.5.6\nversion: 1.2.3\n", true, "appVersion: 1.2.3-dev\nversion: 1.2.3-dev\n"},
+		{"AppVersionAdded", "name: app\nversion: 1.2.3\ntype: application\n", true, "name: app\nversion: 1.2.3-dev\nappVersion: \"1.2.3-dev\"\ntype: application\n"},
+		{"AppVersionAddedAtEnd", "name: app\nversion: 1.2.3", true, "name: app\nversion: 1.2.3-dev\nappVersion: \"1.2.3-dev\"\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(test *testing.T) {
+			testFilePath, repository, p := setupTest(test, testCase.initialContent, testCase.appVersion)
+
+			version, err := p.ReadVersion(repository)
+			require.NoError(test, err)
+			assert.Equal(test, "1.2.3", version.String())
+
+			version.Qualifier = "dev"
+			require.NoError(test, p.WriteVersion(repository, version))
+
+			result, err := os.ReadFile(testFilePath)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expectedResult, string(result))
+		})
+	}
+}
+
+func TestVersionNoMatch(t *testing.T) {
+	testCases := []struct {
+		name           string
+		initialContent string
+	}{
+		{"NoVersion", "name: app\nappVersion: 1.2.3\n"},
+		{"MultipleVersions", "version: 1.2.3\nversion: 3.4.5\n"},
+		{"NestedVersionOnly", "dependencies:\n  - name: redis\n    version: 17.0.0\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(test *testing.T) {
+			_, repository, p := setupTest(test, testCase.initialContent, false)
+
+			_, err := p.ReadVersion(repository)
+			require.Error(test, err)
+		})
+	}
+}
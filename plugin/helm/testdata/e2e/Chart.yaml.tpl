@@ -0,0 +1,11 @@
+apiVersion: v2
+name: example-chart
+description: A Helm chart for Kubernetes
+type: application
+# chart version, managed by gitflow-cli
+version: {{.Version}}
+appVersion: "1.16.0"
+dependencies:
+  - name: postgresql
+    version: 15.5.0
+    repository: oci://registry-1.docker.io/bitnamicharts
@@ -9,6 +9,9 @@ import (
 	"fmt"
 	"github.com/mercedes-benz/gitflow-cli/core"
 	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 )
 
@@ -24,14 +27,40 @@ const (
 	releases        = "versions:use-releases"
 	failNotReplaced = "-DfailIfNotReplaced=true"
 	newVersion      = "-DnewVersion=%s"
+	allModules      = "-DprocessAllModules=true"
+)
+
+// Maven wrapper of a project, see https://maven.apache.org/wrapper/
+const mvnw = "mvnw"
 
+// Setting of the mvn plugin group with the policy for SNAPSHOT dependencies of a release, e.g. 'mvn.snapshot-dependencies'.
+const snapshotDependenciesSetting = "snapshot-dependencies"
+
+// Policies for SNAPSHOT dependencies without a released counterpart when a release is started.
+const (
+	failPolicy = "fail"
+	warnPolicy = "warn"
+	skipPolicy = "skip"
 )
 
+// Version file template for new projects created with the bootstrap command.
+const versionFileTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+         xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 https://maven.apache.org/xsd/maven-4.0.0.xsd">
+    <modelVersion>4.0.0</modelVersion>
+
+    <groupId>{{.Name}}</groupId>
+    <artifactId>{{.Name}}</artifactId>
+    <version>{{.Version}}</version>
+</project>
+`
+
 // Fixed configuration for the mvn plugin
 var pluginConfig = plugin.Config{
 	Name:             "mvn",
 	VersionFileName:  "pom.xml",
 	VersionQualifier: "SNAPSHOT",
+	Template:         versionFileTemplate,
 	RequiredTools:    []string{mvn},
 	DockerImage:      "maven:3.9-eclipse-temurin-17",
 }
@@ -52,14 +81,67 @@ func init() {
 	mavenPlugin := &mavenPlugin{
 		Plugin:      pluginFactory.NewPlugin(pluginConfig),
 		getVersion:  []string{evaluate, versionProperty, quiet, stdout},
-		setVersion:  []string{versions, noBackups},
-		useReleases: []string{releases, noBackups, failNotReplaced},
+		setVersion:  []string{versions, noBackups, allModules},
+		useReleases: []string{releases, noBackups},
 	}
 
+	// Register hook to replace SNAPSHOT dependencies of a release, depending on 'mvn.snapshot-dependencies'
+	mavenPlugin.RegisterHook(core.ReleaseStartHooks.AfterUpdateProjectVersionHook, mavenPlugin.afterUpdateProjectVersion)
+
+	// Register hook to check the versions of all modules before the release branch is created
+	mavenPlugin.RegisterHook(core.ReleaseStartHooks.BeforeReleaseStartHook, mavenPlugin.beforeReleaseStart)
+
 	// Register plugin directly in core, bypassing the pluginFactory
 	core.RegisterPlugin(mavenPlugin)
 }
 
+// RequiredTools requires the maven wrapper of the project instead of mvn if the project has one, unless the plugin runs
+// in docker mode, which uses the mvn of the docker image.
+func (p *mavenPlugin) RequiredTools() []string {
+	if wrapper, ok := p.wrapper(core.ProjectPath); ok {
+		return []string{wrapper}
+	}
+	return p.Plugin.RequiredTools()
+}
+
+// Metadata returns the artifactId and the groupId of the project as variables of the message templates, with the group
+// inherited from its parent if it is not declared, or no variables if the project object model cannot be read.
+func (p *mavenPlugin) Metadata(projectPath string) map[string]string {
+	project, err := readPom(projectPath)
+	if err != nil {
+		return nil
+	}
+
+	groupID, artifactID, _ := strings.Cut(project.key(), ":")
+	return map[string]string{"groupId": groupID, "artifactId": artifactID}
+}
+
+// wrapper returns the path of the maven wrapper in a project directory, if the project has one and the plugin does
+// not run in docker mode.
+func (p *mavenPlugin) wrapper(projectPath string) (string, bool) {
+	if plugin.ExecutorModeOverride == plugin.ModeDocker {
+		return "", false
+	}
+
+	wrapper, err := filepath.Abs(filepath.Join(projectPath, mvnw))
+	if err != nil {
+		return "", false
+	}
+
+	if info, err := os.Stat(wrapper); err != nil || info.IsDir() {
+		return "", false
+	}
+	return wrapper, true
+}
+
+// command returns the mvn command of a project, which prefers the maven wrapper of the project over the system mvn.
+func (p *mavenPlugin) command(projectPath string) string {
+	if wrapper, ok := p.wrapper(projectPath); ok {
+		return wrapper
+	}
+	return mvn
+}
+
 // ReadVersion reads the current version from the project
 func (p *mavenPlugin) ReadVersion(repository core.Repository) (core.Version, error) {
 	var logs = make([]any, 0)
@@ -69,7 +151,7 @@ func (p *mavenPlugin) ReadVersion(repository core.Repository) (core.Version, err
 	defer func() { core.Log(logs...) }()
 
 	// evaluate the version of the mvn project
-	versionCommand := p.Executor.Command(projectPath, mvn, p.getVersion...)
+	versionCommand := p.Executor.Command(projectPath, p.command(projectPath), p.getVersion...)
 
 	// run mvn to evaluate the version of the mvn project
 	output, err := versionCommand.CombinedOutput()
@@ -85,14 +167,20 @@ func (p *mavenPlugin) ReadVersion(repository core.Repository) (core.Version, err
 	return core.ParseVersion(versionStr)
 }
 
-// WriteVersion writes a new version to the project
+// WriteVersion writes a new version to the project and all of its modules, or to the properties of a project with CI
+// friendly versions
 func (p *mavenPlugin) WriteVersion(repository core.Repository, version core.Version) error {
 	var err error
 	var output []byte
 	projectPath := repository.Local()
 
+	// CI friendly versions are set in the properties, because versions:set would replace the property references
+	if root, err := readPom(projectPath); err == nil && root.ciFriendly() {
+		return writeVersionProperties(projectPath, version)
+	}
+
 	// update version information
-	versionCommand := p.Executor.Command(projectPath, mvn, append(p.setVersion, fmt.Sprintf(newVersion, version))...)
+	versionCommand := p.Executor.Command(projectPath, p.command(projectPath), append(p.setVersion, fmt.Sprintf(newVersion, version))...)
 
 	// log human-readable description of the mvn command
 	defer func() { core.Log(versionCommand, output, err) }()
@@ -105,22 +193,48 @@ func (p *mavenPlugin) WriteVersion(repository core.Repository, version core.Vers
 	return nil
 }
 
-// afterUpdateProjectVersion is executed after updating the project version
-func (p *mavenPlugin) afterUpdateProjectVersion(repository core.Repository) error {
-	fmt.Println("After Update Project Version Hook")
+// beforeReleaseStart checks that all modules of a multi-module project have the version of the project, so that no
+// release is created with mismatched module versions
+func (p *mavenPlugin) beforeReleaseStart(repository core.Repository) error {
+	return checkModuleVersions(repository.Local())
+}
 
-	var err error
-	var output []byte
+// snapshotPolicy returns the policy for SNAPSHOT dependencies of a release configured under
+// 'mvn.snapshot-dependencies', which is skip by default
+func (p *mavenPlugin) snapshotPolicy() (string, error) {
+	policy, ok := core.PluginSettings(p)[snapshotDependenciesSetting].(string)
+	if !ok || len(policy) == 0 {
+		return skipPolicy, nil
+	}
 
-	// replace -SNAPSHOT versions and fail if not replaced (i.e. if the version has not been released)
-	releasesCommand := p.Executor.Command(repository.Local(), mvn, p.useReleases...)
+	switch policy = strings.ToLower(policy); policy {
+	case failPolicy, warnPolicy, skipPolicy:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("mvn setting '%v.%v' must be %v, %v, or %v: %v",
+			p, snapshotDependenciesSetting, failPolicy, warnPolicy, skipPolicy, policy)
+	}
+}
 
-	// log human-readable description of the mvn command
-	defer func() { core.Log(releasesCommand, output, err) }()
+// afterUpdateProjectVersion replaces the SNAPSHOT dependencies of a release by their released versions after updating
+// the project version, and fails or warns if a dependency has not been released, depending on the policy
+func (p *mavenPlugin) afterUpdateProjectVersion(repository core.Repository) error {
+	policy, err := p.snapshotPolicy()
+	if err != nil || policy == skipPolicy {
+		return err
+	}
 
-	// run mvn to replace -SNAPSHOT versions with releases in the mvn project
-	if output, err = releasesCommand.CombinedOutput(); err != nil {
-		return fmt.Errorf("mvn releases update failed with %v: %s", err, output)
+	// replace -SNAPSHOT versions and fail if not replaced (i.e. if the version has not been released)
+	if err := p.useReleaseVersions(repository, failNotReplaced); err != nil {
+		if policy == failPolicy {
+			return err
+		}
+
+		// replace the -SNAPSHOT versions that have been released and keep the others
+		fmt.Fprintln(os.Stderr, core.Message(core.MsgSnapshotDependencies, p, snapshotDependenciesSetting, failPolicy))
+		if err := p.useReleaseVersions(repository); err != nil {
+			return err
+		}
 	}
 
 	// if not clean: perform a git commit with a commit message because the previous step changed the POM file
@@ -131,3 +245,19 @@ func (p *mavenPlugin) afterUpdateProjectVersion(repository core.Repository) erro
 	}
 	return nil
 }
+
+// useReleaseVersions runs mvn to replace -SNAPSHOT versions with releases in the mvn project
+func (p *mavenPlugin) useReleaseVersions(repository core.Repository, args ...string) error {
+	var err error
+	var output []byte
+
+	releasesCommand := p.Executor.Command(repository.Local(), p.command(repository.Local()), append(slices.Clone(p.useReleases), args...)...)
+
+	// log human-readable description of the mvn command
+	defer func() { core.Log(releasesCommand, output, err) }()
+
+	if output, err = releasesCommand.CombinedOutput(); err != nil {
+		return fmt.Errorf("mvn releases update failed with %v: %s", err, output)
+	}
+	return nil
+}
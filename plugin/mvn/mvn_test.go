@@ -7,10 +7,17 @@ package mvn
 
 import (
 	_ "embed"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/mercedes-benz/gitflow-cli/core"
 	"github.com/mercedes-benz/gitflow-cli/core/plugin"
 	"github.com/mercedes-benz/gitflow-cli/e2e/workflow"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 //go:embed testdata/e2e/pom.xml.tpl
@@ -28,6 +35,14 @@ func TestReleaseStart(t *testing.T) {
 	workflow.RunReleaseStart(t, testConfig)
 }
 
+func TestReleaseStartMajor(t *testing.T) {
+	workflow.RunReleaseStartMajor(t, testConfig)
+}
+
+func TestReleaseStartMinor(t *testing.T) {
+	workflow.RunReleaseStartMinor(t, testConfig)
+}
+
 func TestReleaseFinish(t *testing.T) {
 	workflow.RunReleaseFinish(t, testConfig)
 }
@@ -39,3 +54,144 @@ func TestHotfixStart(t *testing.T) {
 func TestHotfixFinish(t *testing.T) {
 	workflow.RunHotfixFinish(t, testConfig)
 }
+
+func TestCheckModuleVersions(t *testing.T) {
+	t.Run("SingleModule", func(t *testing.T) {
+		assert.NoError(t, checkModuleVersions(filepath.Join("testdata", "unit", "consistent", "core")))
+	})
+
+	t.Run("Consistent", func(t *testing.T) {
+		assert.NoError(t, checkModuleVersions(filepath.Join("testdata", "unit", "consistent")))
+	})
+
+	t.Run("Revision", func(t *testing.T) {
+		assert.NoError(t, checkModuleVersions(filepath.Join("testdata", "unit", "revision")))
+	})
+
+	t.Run("Mismatched", func(t *testing.T) {
+		err := checkModuleVersions(filepath.Join("testdata", "unit", "mismatched"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "app (1.1.0-SNAPSHOT)")
+		assert.NotContains(t, err.Error(), "core")
+	})
+
+	t.Run("NestedModulesAndExternalParent", func(t *testing.T) {
+		err := checkModuleVersions(filepath.Join("testdata", "unit", "external-parent"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "core/api (1.0.0)")
+		assert.NotContains(t, err.Error(), "tools")
+	})
+}
+
+// copyPom copies the POM of a fixture into a temp dir
+func copyPom(t *testing.T, fixture string) string {
+	t.Helper()
+	tempDir := t.TempDir()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "unit", fixture, "pom.xml"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "pom.xml"), data, 0644))
+
+	return tempDir
+}
+
+func TestWriteVersionProperties(t *testing.T) {
+	t.Run("Revision", func(t *testing.T) {
+		tempDir := copyPom(t, "revision")
+		require.NoError(t, writeVersionProperties(tempDir, core.NewVersion("1", "3", "0", "SNAPSHOT")))
+
+		data, err := os.ReadFile(filepath.Join(tempDir, "pom.xml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "<version>${revision}</version>")
+		assert.Contains(t, string(data), "<revision>1.3.0-SNAPSHOT</revision>")
+	})
+
+	t.Run("RevisionAndChangelist", func(t *testing.T) {
+		tempDir := copyPom(t, "changelist")
+		require.NoError(t, writeVersionProperties(tempDir, core.NewVersion("1", "3", "0", "SNAPSHOT")))
+
+		data, err := os.ReadFile(filepath.Join(tempDir, "pom.xml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "<version>${revision}${sha1}${changelist}</version>")
+		assert.Contains(t, string(data), "<revision>1.3.0</revision>\n        <changelist>-SNAPSHOT</changelist>")
+		assert.Contains(t, string(data), "<flattenMode>resolveCiFriendliesOnly</flattenMode>")
+
+		require.NoError(t, writeVersionProperties(tempDir, core.NewVersion("1", "3", "0")))
+
+		data, err = os.ReadFile(filepath.Join(tempDir, "pom.xml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "<revision>1.3.0</revision>\n        <changelist></changelist>")
+	})
+
+	t.Run("MissingProperty", func(t *testing.T) {
+		tempDir := copyPom(t, "consistent")
+		assert.Error(t, writeVersionProperties(tempDir, core.NewVersion("1", "3", "0")))
+	})
+}
+
+func TestCIFriendly(t *testing.T) {
+	for fixture, expected := range map[string]bool{"consistent": false, "revision": true, "changelist": true} {
+		t.Run(fixture, func(t *testing.T) {
+			project, err := readPom(filepath.Join("testdata", "unit", fixture))
+			require.NoError(t, err)
+			assert.Equal(t, expected, project.ciFriendly())
+		})
+	}
+}
+
+func TestSnapshotPolicy(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	p := &mavenPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+
+	policy, err := p.snapshotPolicy()
+	require.NoError(t, err)
+	assert.Equal(t, skipPolicy, policy)
+
+	for _, value := range []string{"fail", "Warn", "skip"} {
+		viper.Set("mvn.snapshot-dependencies", value)
+		policy, err = p.snapshotPolicy()
+		require.NoError(t, err)
+		assert.Equal(t, strings.ToLower(value), policy)
+	}
+
+	viper.Set("mvn.snapshot-dependencies", "ignore")
+	_, err = p.snapshotPolicy()
+	assert.Error(t, err)
+}
+
+func TestWrapper(t *testing.T) {
+	p := &mavenPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+	tempDir := t.TempDir()
+
+	t.Run("WithoutWrapper", func(t *testing.T) {
+		assert.Equal(t, mvn, p.command(tempDir))
+	})
+
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, mvnw), []byte("#!/bin/sh\n"), 0755))
+
+	t.Run("WithWrapper", func(t *testing.T) {
+		assert.Equal(t, filepath.Join(tempDir, mvnw), p.command(tempDir))
+
+		projectPath := core.ProjectPath
+		core.ProjectPath = tempDir
+		t.Cleanup(func() { core.ProjectPath = projectPath })
+		assert.Equal(t, []string{filepath.Join(tempDir, mvnw)}, p.RequiredTools())
+	})
+
+	t.Run("DockerMode", func(t *testing.T) {
+		plugin.ExecutorModeOverride = plugin.ModeDocker
+		t.Cleanup(func() { plugin.ExecutorModeOverride = "" })
+		assert.Equal(t, mvn, p.command(tempDir))
+	})
+}
+
+func TestMetadata(t *testing.T) {
+	p := &mavenPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+
+	assert.Equal(t, map[string]string{"groupId": "com.mercedes-benz", "artifactId": "aggregator"},
+		p.Metadata(filepath.Join("testdata", "unit", "consistent")))
+	assert.Equal(t, map[string]string{"groupId": "com.mercedes-benz", "artifactId": "core"},
+		p.Metadata(filepath.Join("testdata", "unit", "consistent", "core")))
+	assert.Empty(t, p.Metadata(t.TempDir()))
+}
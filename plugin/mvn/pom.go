@@ -0,0 +1,212 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package mvn
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+)
+
+// Properties of CI friendly versions, see https://maven.apache.org/maven-ci-friendly.html
+const (
+	revisionProperty   = "revision"
+	changelistProperty = "changelist"
+)
+
+// pom is the part of a project object model needed to check the versions of a multi-module project.
+type pom struct {
+	GroupID    string   `xml:"groupId"`
+	ArtifactID string   `xml:"artifactId"`
+	Version    string   `xml:"version"`
+	Modules    []string `xml:"modules>module"`
+	Parent     struct {
+		GroupID    string `xml:"groupId"`
+		ArtifactID string `xml:"artifactId"`
+		Version    string `xml:"version"`
+	} `xml:"parent"`
+}
+
+// module is a project of the reactor with the path of its directory relative to the root project.
+type module struct {
+	path string
+	pom  pom
+}
+
+// readPom reads the project object model of a directory.
+func readPom(directory string) (pom, error) {
+	var project pom
+
+	data, err := os.ReadFile(filepath.Join(directory, "pom.xml"))
+	if err != nil {
+		return project, err
+	}
+
+	if err := xml.Unmarshal(data, &project); err != nil {
+		return project, fmt.Errorf("mvn project object model %v is invalid: %v", filepath.Join(directory, "pom.xml"), err)
+	}
+
+	return project, nil
+}
+
+// version returns the version of the project, which is inherited from its parent if it is not declared.
+func (p pom) version() string {
+	if len(p.Version) > 0 {
+		return strings.TrimSpace(p.Version)
+	}
+	return strings.TrimSpace(p.Parent.Version)
+}
+
+// key returns the coordinates of the project without its version, e.g. 'com.example:app', with the group inherited
+// from its parent if it is not declared.
+func (p pom) key() string {
+	groupID := p.GroupID
+	if len(groupID) == 0 {
+		groupID = p.Parent.GroupID
+	}
+	return strings.TrimSpace(groupID) + ":" + strings.TrimSpace(p.ArtifactID)
+}
+
+// ciFriendly reports whether the version of the project refers to the revision property.
+func (p pom) ciFriendly() bool {
+	return p.refersTo(revisionProperty)
+}
+
+// refersTo reports whether the version of the project refers to a property.
+func (p pom) refersTo(property string) bool {
+	return strings.Contains(p.version(), "${"+property+"}")
+}
+
+// propertyRegex returns the expression of a property of the project, e.g. <revision>1.2.0</revision>.
+func propertyRegex(property string) *regexp.Regexp {
+	return regexp.MustCompile(`(<properties>[\s\S]*?<` + property + `>)([^<]*)(</` + property + `>)`)
+}
+
+// writeVersionProperties writes a CI friendly version to the properties of the project in place, which keeps the
+// formatting of the POM and the flatten-maven-plugin setup of the project. If the version of the project refers to
+// the changelist property as well, e.g. ${revision}${changelist}, the revision is the version without qualifier and
+// the changelist the qualifier with a leading hyphen, e.g. -SNAPSHOT, or empty for a release.
+func writeVersionProperties(directory string, version core.Version) error {
+	fileName := filepath.Join(directory, "pom.xml")
+
+	var err error
+	operation := fmt.Sprintf("Writing to file: %s, properties of version: %s", fileName, version)
+
+	// log operation description
+	defer func() { core.Log(operation, err) }()
+
+	var project pom
+	if project, err = readPom(directory); err != nil {
+		return err
+	}
+
+	var data []byte
+	if data, err = os.ReadFile(fileName); err != nil {
+		return fmt.Errorf("mvn version update failed with %v", err)
+	}
+
+	properties := map[string]string{revisionProperty: version.String()}
+	if project.refersTo(changelistProperty) {
+		properties[revisionProperty] = version.RemoveQualifier().String()
+		properties[changelistProperty] = ""
+		if len(version.Qualifier) > 0 {
+			properties[changelistProperty] = "-" + version.Qualifier
+		}
+	}
+
+	for _, property := range []string{revisionProperty, changelistProperty} {
+		value, ok := properties[property]
+		if !ok {
+			continue
+		}
+
+		expression := propertyRegex(property)
+		if !expression.Match(data) {
+			err = fmt.Errorf("mvn property %v of CI friendly version not found in %v", property, fileName)
+			return err
+		}
+		data = expression.ReplaceAll(data, []byte("${1}"+value+"${3}"))
+	}
+
+	if err = os.WriteFile(fileName, data, 0644); err != nil {
+		return fmt.Errorf("mvn version update failed with %v", err)
+	}
+
+	return nil
+}
+
+// readModules reads the modules of the reactor below a project directory, including the modules of its modules.
+func readModules(directory, path string, project pom) ([]module, error) {
+	modules := make([]module, 0)
+
+	for _, name := range project.Modules {
+		modulePath := filepath.ToSlash(filepath.Join(path, strings.TrimSpace(name)))
+		moduleDirectory := filepath.Join(directory, filepath.FromSlash(modulePath))
+
+		// a module may also refer to the model file itself instead of its directory
+		if strings.HasSuffix(modulePath, ".xml") {
+			moduleDirectory = filepath.Dir(moduleDirectory)
+		}
+
+		child, err := readPom(moduleDirectory)
+		if err != nil {
+			return nil, err
+		}
+
+		children, err := readModules(directory, modulePath, child)
+		if err != nil {
+			return nil, err
+		}
+
+		modules = append(modules, module{path: modulePath, pom: child})
+		modules = append(modules, children...)
+	}
+
+	return modules, nil
+}
+
+// checkModuleVersions checks that all modules of an aggregator project have the version of the project, either
+// inherited from their parent or declared. Modules which inherit their version from a parent outside the reactor,
+// e.g. a company parent, are not checked. Projects without modules are always consistent.
+func checkModuleVersions(directory string) error {
+	root, err := readPom(directory)
+	if err != nil {
+		return err
+	}
+
+	modules, err := readModules(directory, "", root)
+	if err != nil {
+		return err
+	}
+
+	reactor := map[string]bool{root.key(): true}
+	for _, m := range modules {
+		reactor[m.pom.key()] = true
+	}
+
+	mismatched := make([]string, 0)
+	for _, m := range modules {
+		parent := strings.TrimSpace(m.pom.Parent.GroupID) + ":" + strings.TrimSpace(m.pom.Parent.ArtifactID)
+		if len(m.pom.Version) == 0 && !reactor[parent] {
+			continue
+		}
+
+		if m.pom.version() != root.version() {
+			mismatched = append(mismatched, fmt.Sprintf("%v (%v)", m.path, m.pom.version()))
+		}
+	}
+
+	if len(mismatched) > 0 {
+		return fmt.Errorf("mvn modules do not have the project version %v: %v", root.version(), strings.Join(mismatched, ", "))
+	}
+
+	return nil
+}
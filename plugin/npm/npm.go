@@ -6,20 +6,50 @@ SPDX-License-Identifier: MIT
 package npm
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/mercedes-benz/gitflow-cli/core"
 	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
 	"strings"
 )
 
 // npm-specific command constant
 const npm = "npm"
 
+// lookPath finds the executable of a tool, replaceable in tests.
+var lookPath = exec.LookPath
+
+// Lockfiles of a project, which hold the version of the project as well: a published npm-shrinkwrap.json takes
+// precedence over package-lock.json, but both are kept in sync if both exist.
+var lockFileNames = []string{"npm-shrinkwrap.json", "package-lock.json"}
+
+var (
+	// The top-level version of a lockfile, which is indented by two spaces like all top-level keys.
+	lockVersionRegex = regexp.MustCompile(`(?m)^(  "version":\s*")([^"]*)(")`)
+
+	// The version of the root package of a lockfile, i.e. the entry "" of its packages, which precedes the entries
+	// of the workspaces and dependencies.
+	lockRootPackageRegex = regexp.MustCompile(`("packages":\s*\{\s*"":\s*\{[^{}]*?"version":\s*")([^"]*)(")`)
+)
+
+// Version file template for new projects created with the bootstrap command.
+const versionFileTemplate = `{
+  "name": "{{.Name}}",
+  "version": "{{.Version}}",
+  "private": true
+}
+`
+
 // Fixed configuration for the NPM plugin
 var pluginConfig = plugin.Config{
 	Name:             "npm",
 	VersionFileName:  "package.json",
 	VersionQualifier: "dev",
+	Template:         versionFileTemplate,
 	RequiredTools:    []string{npm},
 	DockerImage:      "node:20-slim",
 }
@@ -46,8 +76,51 @@ func init() {
 	core.RegisterPlugin(npmPlugin)
 }
 
-// ReadVersion reads the version from package.json using npm.
+// RequiredTools requires no tools if npm is not available, because the version of package.json is then edited
+// in-process instead of falling back to docker.
+func (p *npmPlugin) RequiredTools() []string {
+	if !p.npmAvailable() {
+		return []string{}
+	}
+	return p.Plugin.RequiredTools()
+}
+
+// Metadata returns the name of the package as variable of the message templates, or no variables if package.json
+// cannot be read.
+func (p *npmPlugin) Metadata(projectPath string) map[string]string {
+	content, err := os.ReadFile(filepath.Join(projectPath, p.VersionFileName()))
+	if err != nil {
+		return nil
+	}
+
+	var pkg struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil
+	}
+	return map[string]string{"name": pkg.Name}
+}
+
+// npmAvailable reports whether npm can be run, either in the docker image of the plugin or natively.
+func (p *npmPlugin) npmAvailable() bool {
+	if plugin.ExecutorModeOverride == plugin.ModeDocker {
+		return true
+	}
+	_, err := lookPath(npm)
+	return err == nil
+}
+
+// ReadVersion reads the version from package.json using npm, or directly if npm is not available.
 func (p *npmPlugin) ReadVersion(repository core.Repository) (core.Version, error) {
+	if !p.npmAvailable() {
+		versionString, err := plugin.ReadJSONVersion(filepath.Join(repository.Local(), p.VersionFileName()))
+		if err != nil {
+			return core.Version{}, fmt.Errorf("failed to read version: %v", err)
+		}
+		return core.ParseVersion(versionString)
+	}
+
 	var logs = make([]any, 0)
 	// Execute npm command to read the version from package.json
 	cmd := p.Executor.Command(repository.Local(), npm, "pkg", "get", "version")
@@ -76,8 +149,35 @@ func (p *npmPlugin) ReadVersion(repository core.Repository) (core.Version, error
 	return version, nil
 }
 
-// WriteVersion writes the version to package.json using npm.
+// WriteVersion writes the version to package.json using npm, or directly if npm is not available.
 func (p *npmPlugin) WriteVersion(repository core.Repository, version core.Version) error {
+	if err := p.writePackageVersion(repository, version); err != nil {
+		return err
+	}
+
+	// propagate the version to the workspaces of a monorepo, if configured under 'npm.workspaces'
+	if p.syncWorkspaces() {
+		if err := writeWorkspaceVersions(repository.Local(), version); err != nil {
+			return err
+		}
+	}
+
+	// the lockfiles are part of the version commit, because all changes of tracked files are committed
+	return syncLockFiles(repository.Local(), version)
+}
+
+// writePackageVersion writes the version to package.json using npm, or directly if npm is not available.
+func (p *npmPlugin) writePackageVersion(repository core.Repository, version core.Version) error {
+	if !p.npmAvailable() {
+		fileName := filepath.Join(repository.Local(), p.VersionFileName())
+		err := plugin.WriteJSONVersion(fileName, version.String())
+		core.Log(fmt.Sprintf("Writing to file: %s, version: %s", fileName, version), err)
+		if err != nil {
+			return fmt.Errorf("failed to write version: %v", err)
+		}
+		return nil
+	}
+
 	var err error
 	var output []byte
 
@@ -95,6 +195,37 @@ func (p *npmPlugin) WriteVersion(repository core.Repository, version core.Versio
 	return nil
 }
 
+// syncLockFiles sets the version of the root package in the lockfiles of a project, if npm version has not done so,
+// e.g. for an npm-shrinkwrap.json or a lockfile of workspaces. The versions of the workspaces are kept.
+func syncLockFiles(projectPath string, version core.Version) error {
+	for _, fileName := range lockFileNames {
+		lockFile := filepath.Join(projectPath, fileName)
+
+		content, err := os.ReadFile(lockFile)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to read lockfile: %v", err)
+		}
+
+		updated := content
+		for _, expression := range []*regexp.Regexp{lockVersionRegex, lockRootPackageRegex} {
+			updated = expression.ReplaceAll(updated, []byte("${1}"+version.String()+"${3}"))
+		}
+
+		if string(updated) == string(content) {
+			continue
+		}
+
+		if err := os.WriteFile(lockFile, updated, 0644); err != nil {
+			return fmt.Errorf("failed to write lockfile: %v", err)
+		}
+		core.Log(fmt.Sprintf("Writing to file: %s, version: %s", lockFile, version))
+	}
+
+	return nil
+}
+
 // beforeReleaseStart ensures a version is set in the package.json file on the development branch
 func (p *npmPlugin) beforeReleaseStart(repository core.Repository) error {
 	if err := repository.CheckoutBranch(core.Development.String()); err != nil {
@@ -111,17 +242,11 @@ func (p *npmPlugin) beforeReleaseStart(repository core.Repository) error {
 	// Version doesn't exist, set it to 1.0.0 with qualifier
 	initVersion := core.NewVersion("1", "0", "0", p.Config.VersionQualifier)
 
-	// Set the version using npm CLI
-	cmd := p.Executor.Command(repository.Local(), npm, "version", initVersion.String(), "--no-git-tag-version")
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		core.Log(cmd, output, err)
+	// Set the version using npm CLI, or directly if npm is not available
+	if err := p.writePackageVersion(repository, initVersion); err != nil {
 		return repository.Rollback(fmt.Errorf("failed to set initial version: %v", err))
 	}
 
-	core.Log(cmd, output)
-
 	if err := repository.CommitChanges("Set initial project version."); err != nil {
 		return repository.Rollback(err)
 	}
@@ -145,17 +270,11 @@ func (p *npmPlugin) beforeHotfixStart(repository core.Repository) error {
 	// Version doesn't exist, set it to 1.0.0 (no qualifier for production)
 	initVersion := core.NewVersion("1", "0", "0")
 
-	// Set the version using npm CLI
-	cmd := p.Executor.Command(repository.Local(), npm, "version", initVersion.String(), "--no-git-tag-version")
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		core.Log(cmd, output, err)
+	// Set the version using npm CLI, or directly if npm is not available
+	if err := p.writePackageVersion(repository, initVersion); err != nil {
 		return repository.Rollback(fmt.Errorf("failed to set initial version: %v", err))
 	}
 
-	core.Log(cmd, output)
-
 	if err := repository.CommitChanges("Set initial project version."); err != nil {
 		return repository.Rollback(err)
 	}
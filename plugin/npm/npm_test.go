@@ -7,10 +7,19 @@ package npm
 
 import (
 	_ "embed"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/mercedes-benz/gitflow-cli/core"
 	"github.com/mercedes-benz/gitflow-cli/core/plugin"
 	"github.com/mercedes-benz/gitflow-cli/e2e/workflow"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 //go:embed testdata/e2e/package.json.tpl
@@ -29,6 +38,14 @@ func TestReleaseStart(t *testing.T) {
 	workflow.RunReleaseStart(t, testConfig)
 }
 
+func TestReleaseStartMajor(t *testing.T) {
+	workflow.RunReleaseStartMajor(t, testConfig)
+}
+
+func TestReleaseStartMinor(t *testing.T) {
+	workflow.RunReleaseStartMinor(t, testConfig)
+}
+
 func TestReleaseStart_BeforeHook(t *testing.T) {
 	workflow.RunBeforeReleaseStartHook(t, testConfig)
 }
@@ -48,3 +65,147 @@ func TestHotfixStart_BeforeHook(t *testing.T) {
 func TestHotfixFinish(t *testing.T) {
 	workflow.RunHotfixFinish(t, testConfig)
 }
+
+func TestSyncLockFiles(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "unit", "package-lock.json"))
+	require.NoError(t, err)
+
+	tempDir := t.TempDir()
+	for _, fileName := range lockFileNames {
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, fileName), content, 0644))
+	}
+
+	require.NoError(t, syncLockFiles(tempDir, core.NewVersion("1", "1", "0", "dev")))
+
+	for _, fileName := range lockFileNames {
+		t.Run(fileName, func(t *testing.T) {
+			updated, err := os.ReadFile(filepath.Join(tempDir, fileName))
+			require.NoError(t, err)
+
+			var lock struct {
+				Version  string `json:"version"`
+				Packages map[string]struct {
+					Version string `json:"version"`
+				} `json:"packages"`
+			}
+			require.NoError(t, json.Unmarshal(updated, &lock))
+
+			assert.Equal(t, "1.1.0-dev", lock.Version)
+			assert.Equal(t, "1.1.0-dev", lock.Packages[""].Version)
+			assert.Equal(t, "1.0.0", lock.Packages["packages/a"].Version, "workspace version must be kept")
+			assert.Equal(t, strings.Count(string(content), "\n"), strings.Count(string(updated), "\n"))
+		})
+	}
+
+	t.Run("WithoutLockFile", func(t *testing.T) {
+		assert.NoError(t, syncLockFiles(t.TempDir(), core.NewVersion("1", "1", "0")))
+	})
+}
+
+func TestReadWorkspaces(t *testing.T) {
+	workspaces, err := readWorkspaces(filepath.Join("testdata", "unit", "workspaces"))
+	require.NoError(t, err)
+	assert.Equal(t, []workspace{
+		{path: "packages/a", name: "example-a", version: "1.0.0"},
+		{path: "packages/b", name: "example-b", version: "0.9.0"},
+	}, workspaces)
+
+	t.Run("PackagesObject", func(t *testing.T) {
+		tempDir := t.TempDir()
+		require.NoError(t, os.CopyFS(tempDir, os.DirFS(filepath.Join("testdata", "unit", "workspaces"))))
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, "package.json"),
+			[]byte(`{"name": "example", "version": "1.0.0", "workspaces": {"packages": ["packages/b"]}}`), 0644))
+
+		workspaces, err := readWorkspaces(tempDir)
+		require.NoError(t, err)
+		assert.Equal(t, []workspace{{path: "packages/b", name: "example-b", version: "0.9.0"}}, workspaces)
+	})
+}
+
+func TestWriteWorkspaceVersions(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.CopyFS(tempDir, os.DirFS(filepath.Join("testdata", "unit", "workspaces"))))
+
+	require.NoError(t, writeWorkspaceVersions(tempDir, core.NewVersion("1", "1", "0", "dev")))
+
+	type dependencies map[string]string
+	type pkg struct {
+		Version          string       `json:"version"`
+		Dependencies     dependencies `json:"dependencies"`
+		DevDependencies  dependencies `json:"devDependencies"`
+		PeerDependencies dependencies `json:"peerDependencies"`
+	}
+	read := func(fileName string, v any) {
+		content, err := os.ReadFile(filepath.Join(tempDir, fileName))
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(content, v))
+	}
+
+	var root, a, b pkg
+	read("package.json", &root)
+	read(filepath.Join("packages", "a", "package.json"), &a)
+	read(filepath.Join("packages", "b", "package.json"), &b)
+
+	// the version of the root package is written by npm version
+	assert.Equal(t, "1.0.0", root.Version)
+	assert.Equal(t, "^1.1.0-dev", root.DevDependencies["example-a"])
+	assert.Equal(t, "1.1.0-dev", a.Version)
+	assert.Equal(t, "1.1.0-dev", b.Version)
+	assert.Equal(t, "~1.1.0-dev", b.Dependencies["example-a"])
+	assert.Equal(t, "^1.0.0", b.Dependencies["left-pad"], "external dependency must be kept")
+	assert.Equal(t, "*", b.PeerDependencies["example-a"], "wildcard range must be kept")
+
+	var lock struct {
+		Packages map[string]pkg `json:"packages"`
+	}
+	read("package-lock.json", &lock)
+
+	assert.Equal(t, "^1.1.0-dev", lock.Packages[""].DevDependencies["example-a"])
+	assert.Equal(t, "1.1.0-dev", lock.Packages["packages/a"].Version)
+	assert.Equal(t, "1.1.0-dev", lock.Packages["packages/b"].Version)
+	assert.Equal(t, "~1.1.0-dev", lock.Packages["packages/b"].Dependencies["example-a"])
+	assert.Equal(t, "1.0.0", lock.Packages["node_modules/left-pad"].Version)
+}
+
+// withoutNpm pretends that npm is not installed.
+func withoutNpm(t *testing.T) {
+	t.Helper()
+	original := lookPath
+	lookPath = func(file string) (string, error) { return "", errors.New("not found") }
+	t.Cleanup(func() { lookPath = original })
+}
+
+func TestWithoutNpm(t *testing.T) {
+	withoutNpm(t)
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	tempDir := t.TempDir()
+	require.NoError(t, os.CopyFS(tempDir, os.DirFS(filepath.Join("testdata", "unit", "workspaces"))))
+	repository := core.NewRepository(tempDir, "")
+	p := &npmPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+
+	assert.Empty(t, p.RequiredTools())
+
+	version, err := p.ReadVersion(repository)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", version.String())
+
+	require.NoError(t, p.WriteVersion(repository, core.NewVersion("1", "1", "0", "dev")))
+
+	version, err = p.ReadVersion(repository)
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0-dev", version.String())
+
+	// the lockfile is kept in sync without npm as well
+	content, err := os.ReadFile(filepath.Join(tempDir, "package-lock.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "\"name\": \"example\",\n  \"version\": \"1.1.0-dev\",")
+}
+
+func TestMetadata(t *testing.T) {
+	p := &npmPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+
+	assert.Equal(t, map[string]string{"name": "example"}, p.Metadata(filepath.Join("testdata", "unit", "workspaces")))
+	assert.Empty(t, p.Metadata(t.TempDir()))
+}
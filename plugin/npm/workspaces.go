@@ -0,0 +1,179 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package npm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+)
+
+// Setting of the npm plugin group which propagates the version to all workspaces, e.g. 'npm.workspaces'.
+const workspacesSetting = "workspaces"
+
+// workspace is a package of a project with workspaces, with the path of its directory relative to the project.
+type workspace struct {
+	path    string
+	name    string
+	version string
+}
+
+// syncWorkspaces reports whether the version is propagated to the workspaces of the project, which is disabled by
+// default because the packages of many monorepos are released independently.
+func (p *npmPlugin) syncWorkspaces() bool {
+	enabled, _ := core.PluginSettings(p)[workspacesSetting].(bool)
+	return enabled
+}
+
+// readWorkspaces reads the workspaces of a project from the 'workspaces' of its package.json, either a list of
+// patterns or an object with the patterns as 'packages'. Patterns are matched against directories with a package.json.
+func readWorkspaces(projectPath string) ([]workspace, error) {
+	var project struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+
+	content, err := os.ReadFile(filepath.Join(projectPath, "package.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package.json: %v", err)
+	}
+	if err := json.Unmarshal(content, &project); err != nil {
+		return nil, fmt.Errorf("failed to parse package.json: %v", err)
+	}
+
+	var patterns []string
+	if len(project.Workspaces) > 0 && json.Unmarshal(project.Workspaces, &patterns) != nil {
+		var object struct {
+			Packages []string `json:"packages"`
+		}
+		if err := json.Unmarshal(project.Workspaces, &object); err != nil {
+			return nil, fmt.Errorf("failed to parse workspaces of package.json: %v", err)
+		}
+		patterns = object.Packages
+	}
+
+	found := make(map[string]bool)
+	workspaces := make([]workspace, 0)
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(projectPath, filepath.FromSlash(pattern), "package.json"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid workspace pattern '%v': %v", pattern, err)
+		}
+		sort.Strings(matches)
+
+		for _, match := range matches {
+			path, err := filepath.Rel(projectPath, filepath.Dir(match))
+			if err != nil || found[path] {
+				continue
+			}
+			found[path] = true
+
+			var pkg struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+			}
+			content, err := os.ReadFile(match)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read workspace package.json: %v", err)
+			}
+			if err := json.Unmarshal(content, &pkg); err != nil {
+				return nil, fmt.Errorf("failed to parse %v: %v", filepath.ToSlash(filepath.Join(path, "package.json")), err)
+			}
+
+			workspaces = append(workspaces, workspace{path: filepath.ToSlash(path), name: pkg.Name, version: pkg.Version})
+		}
+	}
+
+	return workspaces, nil
+}
+
+// writeWorkspaceVersions sets the version of all workspaces of a project, and the version ranges of the dependencies
+// between them in the root package, the workspaces and the lockfiles, so that a monorepo is released with one version.
+// Dependencies with a range of another form than a version with an optional ^, ~, or = prefix, e.g. 'workspace:*' or
+// a file path, are kept.
+func writeWorkspaceVersions(projectPath string, version core.Version) error {
+	workspaces, err := readWorkspaces(projectPath)
+	if err != nil {
+		return err
+	}
+
+	files := []string{"package.json"}
+	for _, w := range workspaces {
+		fileName := filepath.ToSlash(filepath.Join(w.path, "package.json"))
+		files = append(files, fileName)
+
+		if len(w.version) > 0 {
+			if err := replaceInFile(projectPath, fileName, packageVersionRegex(w.version), version); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, w := range workspaces {
+		if len(w.name) == 0 || len(w.version) == 0 {
+			continue
+		}
+
+		// the version ranges of the dependencies on the workspace in all packages and lockfiles
+		for _, fileName := range append(files, lockFileNames...) {
+			if err := replaceInFile(projectPath, fileName, dependencyRegex(w.name, w.version), version); err != nil {
+				return err
+			}
+		}
+
+		// the version of the workspace in the lockfiles
+		for _, fileName := range lockFileNames {
+			if err := replaceInFile(projectPath, fileName, lockPackageRegex(w.path), version); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// packageVersionRegex returns the expression of the first version property of a package.json with a version.
+func packageVersionRegex(current string) *regexp.Regexp {
+	return regexp.MustCompile(`^([\s\S]*?"version"\s*:\s*")(` + regexp.QuoteMeta(current) + `)(")`)
+}
+
+// dependencyRegex returns the expression of a dependency on a package with a version range of its version.
+func dependencyRegex(name, current string) *regexp.Regexp {
+	return regexp.MustCompile(`("` + regexp.QuoteMeta(name) + `"\s*:\s*"[\^~=]?)(` + regexp.QuoteMeta(current) + `)(")`)
+}
+
+// lockPackageRegex returns the expression of the version of the entry of a workspace in a lockfile.
+func lockPackageRegex(path string) *regexp.Regexp {
+	return regexp.MustCompile(`("` + regexp.QuoteMeta(path) + `"\s*:\s*\{[^{}]*?"version"\s*:\s*")([^"]*)(")`)
+}
+
+// replaceInFile replaces the second group of all matches of an expression in a file of the project by the version,
+// which keeps the formatting of the file. Missing files are skipped.
+func replaceInFile(projectPath, fileName string, expression *regexp.Regexp, version core.Version) error {
+	file := filepath.Join(projectPath, filepath.FromSlash(fileName))
+
+	content, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read %v: %v", fileName, err)
+	}
+
+	updated := expression.ReplaceAll(content, []byte("${1}"+version.String()+"${3}"))
+	if string(updated) == string(content) {
+		return nil
+	}
+
+	if err := os.WriteFile(file, updated, 0644); err != nil {
+		return fmt.Errorf("failed to write %v: %v", fileName, err)
+	}
+	core.Log(fmt.Sprintf("Writing to file: %s, version: %s", file, version))
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package openapi
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+)
+
+// The version of the API described by an OpenAPI or AsyncAPI document.
+var versionKeys = []string{"info", "version"}
+
+// Version file template for new API contracts created with the bootstrap command.
+const versionFileTemplate = `openapi: 3.1.0
+info:
+  title: {{.Name}}
+  version: {{.Version}}
+paths: {}
+`
+
+// Fixed configuration for the openapi plugin
+var pluginConfig = plugin.Config{
+	Name: "openapi",
+	VersionFileNames: []string{
+		"openapi.yaml",
+		"openapi.yml",
+		"openapi.json",
+		"asyncapi.yaml",
+		"asyncapi.yml",
+		"asyncapi.json",
+	},
+	VersionQualifier: "dev",
+	Template:         versionFileTemplate,
+	RequiredTools:    []string{},
+	DockerImage:      "alpine:3",
+}
+
+// openAPIPlugin is the plugin for API contract repositories with an OpenAPI or AsyncAPI document and no build tool.
+type openAPIPlugin struct {
+	plugin.Plugin
+}
+
+// Register the openapi plugin
+func init() {
+	pluginFactory := plugin.NewFactory()
+
+	// Create plugin with pluginFactory to get hooks and other dependencies
+	openAPIPlugin := &openAPIPlugin{
+		Plugin: pluginFactory.NewPlugin(pluginConfig),
+	}
+
+	// Register plugin after the plugins of build tools, whose projects may hold API contracts as well
+	core.RegisterGenericPlugin(openAPIPlugin)
+}
+
+// ReadVersion reads the info.version of the OpenAPI or AsyncAPI document
+func (p *openAPIPlugin) ReadVersion(repository core.Repository) (core.Version, error) {
+	versionFile := filepath.Join(repository.Local(), p.VersionFileName())
+
+	var value string
+	var err error
+	if p.isJSON() {
+		value, err = plugin.ReadJSONValue(versionFile, versionKeys)
+	} else {
+		value, err = plugin.ReadYAMLValue(versionFile, versionKeys)
+	}
+	if err != nil {
+		return core.NoVersion, fmt.Errorf("failed to read openapi version file: %v", err)
+	}
+
+	return core.ParseVersion(strings.TrimSpace(value))
+}
+
+// WriteVersion writes the info.version of the OpenAPI or AsyncAPI document in place, which keeps the formatting and
+// the comments of the document.
+func (p *openAPIPlugin) WriteVersion(repository core.Repository, version core.Version) error {
+	versionFile := filepath.Join(repository.Local(), p.VersionFileName())
+
+	var err error
+	if p.isJSON() {
+		err = plugin.WriteJSONValue(versionFile, versionKeys, version.String())
+	} else {
+		err = plugin.WriteYAMLValue(versionFile, versionKeys, version.String())
+	}
+
+	core.Log(fmt.Sprintf("Writing to file: %s, version: %s", versionFile, version), err)
+	if err != nil {
+		return fmt.Errorf("openapi version update failed: %v", err)
+	}
+	return nil
+}
+
+// isJSON reports whether the document is written in JSON, otherwise it is written in YAML.
+func (p *openAPIPlugin) isJSON() bool {
+	return strings.EqualFold(filepath.Ext(p.VersionFileName()), ".json")
+}
@@ -0,0 +1,158 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package openapi
+
+import (
+	_ "embed"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+	"github.com/mercedes-benz/gitflow-cli/e2e/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/e2e/openapi.yaml.tpl
+var openAPITemplate string
+
+//go:embed testdata/e2e/asyncapi.json.tpl
+var asyncAPITemplate string
+
+var testConfigs = []plugin.TestConfig{
+	{
+		Name:             "openapi_yaml",
+		PluginName:       "openapi",
+		DockerImage:      pluginConfig.DockerImage,
+		VersionQualifier: "dev",
+		VersionFileName:  "openapi.yaml",
+		Template:         openAPITemplate,
+	},
+	{
+		Name:             "asyncapi_json",
+		PluginName:       "openapi",
+		DockerImage:      pluginConfig.DockerImage,
+		VersionQualifier: "dev",
+		VersionFileName:  "asyncapi.json",
+		Template:         asyncAPITemplate,
+	},
+}
+
+func TestE2E_ReleaseStart(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStart(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseFinish(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseFinish(t, tc)
+		})
+	}
+}
+
+func TestE2E_HotfixStart(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunHotfixStart(t, tc)
+		})
+	}
+}
+
+func TestE2E_HotfixFinish(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunHotfixFinish(t, tc)
+		})
+	}
+}
+
+// setupTest writes a document into a temp dir and selects it as the version file of the plugin.
+func setupTest(t *testing.T, fileName, content string) (string, core.Repository, *openAPIPlugin) {
+	t.Helper()
+	tempDir := t.TempDir()
+
+	testFilePath := filepath.Join(tempDir, fileName)
+	require.NoError(t, os.WriteFile(testFilePath, []byte(content), 0644))
+
+	p := &openAPIPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+	p.SetVersionFileName(fileName)
+
+	return testFilePath, core.NewRepository(tempDir, ""), p
+}
+
+func TestVersionReadWrite(t *testing.T) {
+	testCases := []struct {
+		name           string
+		fileName       string
+		initialContent string
+		expectedResult string
+	}{
+		{"OpenAPIYAML", "openapi.yaml", "openapi: 3.1.0\ninfo:\n  title: API\n  version: 1.2.3\npaths: {}\n", "openapi: 3.1.0\ninfo:\n  title: API\n  version: 1.2.3-dev\npaths: {}\n"},
+		{"QuotedYAML", "asyncapi.yml", "asyncapi: 3.0.0\ninfo:\n  version: '1.2.3' # contract\n", "asyncapi: 3.0.0\ninfo:\n  version: '1.2.3-dev' # contract\n"},
+		{"OtherVersionKeys", "openapi.yaml", "openapi: 3.1.0\nx-version: 0.1.0\ninfo:\n  version: 1.2.3\ncomponents:\n  schemas:\n    Version:\n      version: 9.9.9\n", "openapi: 3.1.0\nx-version: 0.1.0\ninfo:\n  version: 1.2.3-dev\ncomponents:\n  schemas:\n    Version:\n      version: 9.9.9\n"},
+		{"OpenAPIJSON", "openapi.json", "{\n  \"openapi\": \"3.1.0\",\n  \"info\": {\"title\": \"API\", \"version\": \"1.2.3\"}\n}\n", "{\n  \"openapi\": \"3.1.0\",\n  \"info\": {\"title\": \"API\", \"version\": \"1.2.3-dev\"}\n}\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(test *testing.T) {
+			testFilePath, repository, p := setupTest(test, testCase.fileName, testCase.initialContent)
+
+			version, err := p.ReadVersion(repository)
+			require.NoError(test, err)
+			assert.Equal(test, "1.2.3", version.String())
+
+			version.Qualifier = "dev"
+			require.NoError(test, p.WriteVersion(repository, version))
+
+			result, err := os.ReadFile(testFilePath)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expectedResult, string(result))
+		})
+	}
+}
+
+func TestVersionNoMatch(t *testing.T) {
+	testCases := []struct {
+		name           string
+		fileName       string
+		initialContent string
+	}{
+		{"NoInfo", "openapi.yaml", "openapi: 3.1.0\npaths: {}\n"},
+		{"NoVersion", "openapi.yaml", "openapi: 3.1.0\ninfo:\n  title: API\n"},
+		{"NoJSONVersion", "asyncapi.json", "{\"asyncapi\": \"3.0.0\", \"info\": {}}\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(test *testing.T) {
+			_, repository, p := setupTest(test, testCase.fileName, testCase.initialContent)
+
+			_, err := p.ReadVersion(repository)
+			require.Error(test, err)
+			assert.Error(test, p.WriteVersion(repository, core.NewVersion("1", "0", "0", "")))
+		})
+	}
+}
+
+func TestDetection(t *testing.T) {
+	tempDir := t.TempDir()
+
+	projectPath := core.ProjectPath
+	core.ProjectPath = tempDir
+	t.Cleanup(func() { core.ProjectPath = projectPath })
+
+	p := &openAPIPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+	assert.False(t, core.CheckVersionFile(p))
+
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "asyncapi.yaml"), []byte("asyncapi: 3.0.0\n"), 0644))
+	assert.True(t, core.CheckVersionFile(p))
+	assert.Equal(t, "asyncapi.yaml", p.VersionFileName())
+}
@@ -0,0 +1,8 @@
+{
+  "asyncapi": "3.0.0",
+  "info": {
+    "title": "Order events",
+    "version": "{{.Version}}"
+  },
+  "channels": {}
+}
@@ -0,0 +1,8 @@
+openapi: 3.1.0
+info:
+  title: Orders API
+  description: Orders of the shop
+  version: "{{.Version}}" # version of the contract
+servers:
+  - url: https://api.example.com/v1
+paths: {}
@@ -8,9 +8,17 @@ package plugin
 import (
 	// import all plugins here to make them available to the plugin registry
 	_ "github.com/mercedes-benz/gitflow-cli/plugin/composer"
+	_ "github.com/mercedes-benz/gitflow-cli/plugin/custom"
+	_ "github.com/mercedes-benz/gitflow-cli/plugin/dart"
+	_ "github.com/mercedes-benz/gitflow-cli/plugin/golang"
+	_ "github.com/mercedes-benz/gitflow-cli/plugin/gradle"
+	_ "github.com/mercedes-benz/gitflow-cli/plugin/helm"
 	_ "github.com/mercedes-benz/gitflow-cli/plugin/mvn"
 	_ "github.com/mercedes-benz/gitflow-cli/plugin/npm"
+	_ "github.com/mercedes-benz/gitflow-cli/plugin/openapi"
 	_ "github.com/mercedes-benz/gitflow-cli/plugin/python"
 	_ "github.com/mercedes-benz/gitflow-cli/plugin/road"
+	_ "github.com/mercedes-benz/gitflow-cli/plugin/ruby"
 	_ "github.com/mercedes-benz/gitflow-cli/plugin/standard"
+	_ "github.com/mercedes-benz/gitflow-cli/plugin/terraform"
 )
@@ -0,0 +1,417 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package python
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// lookPath finds the executable of a tool, replaceable in tests.
+var lookPath = exec.LookPath
+
+// Lock files of the uv and PDM project managers.
+const (
+	uvLock  = "uv.lock"
+	pdmLock = "pdm.lock"
+)
+
+var (
+	// uvSectionRegex matches the tool table of uv in pyproject.toml, e.g. '[tool.uv]' or '[tool.uv.sources]'.
+	uvSectionRegex = regexp.MustCompile(`(?m)^\[tool\.uv[.\]]`)
+
+	// pdmSectionRegex matches the tool table of PDM in pyproject.toml, e.g. '[tool.pdm]' or '[tool.pdm.version]'.
+	pdmSectionRegex = regexp.MustCompile(`(?m)^\[tool\.pdm[.\]]`)
+
+	// uvLockVersionRegex matches the version of a package entry in uv.lock.
+	uvLockVersionRegex = regexp.MustCompile(`(?m)^(version = ")[^"]*(")`)
+
+	// uvLockSourceRegex matches the source of the project itself in a package entry of uv.lock.
+	uvLockSourceRegex = regexp.MustCompile(`(?m)^source = \{ (?:editable|virtual) = "\." \}`)
+
+	// projectTableRegex matches the header of the PEP 621 table in pyproject.toml.
+	projectTableRegex = regexp.MustCompile(`(?m)^\[project\][ \t]*(?:#.*)?\n`)
+
+	// dunderVersionRegex matches the version assignment of a python module, e.g. '__version__ = "1.2.3"'.
+	dunderVersionRegex = regexp.MustCompile(`(?m)^(__version__[ \t]*=[ \t]*)(['"])([^'"]*)(['"])`)
+)
+
+// versionManager reads and writes the version of a kind of python project in one of the version files.
+type versionManager interface {
+	// Name of the project kind for the logs, e.g. 'uv'.
+	name() string
+
+	// Version file of the project kind.
+	fileName() string
+
+	// Reports whether the project in the path is of this kind.
+	detect(projectPath string) bool
+
+	read(projectPath string) (string, error)
+	write(projectPath, version string) error
+}
+
+// versionManagers returns the managers of all project kinds in the order of their priority. Without toml-cli, the
+// version of pyproject.toml is edited in-process. Otherwise the managers of uv and PDM come before the generic
+// pyproject.toml manager, which covers PEP 621 and Poetry projects.
+func (p *pythonPlugin) versionManagers() []versionManager {
+	return []versionManager{
+		&pyprojectTomlManager{p},
+		&uvManager{p},
+		&pdmManager{p},
+		&pyprojectManager{p},
+		&setupCfgManager{p},
+		&setupPyManager{p},
+	}
+}
+
+// versionManager returns the manager with the highest priority for the version file of the project.
+func (p *pythonPlugin) versionManager(projectPath string) (versionManager, error) {
+	for _, manager := range p.versionManagers() {
+		if manager.fileName() == p.VersionFileName() && manager.detect(projectPath) {
+			return manager, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported version file: %s", p.VersionFileName())
+}
+
+// pyprojectContains reports whether the pyproject.toml of the project matches a regular expression.
+func pyprojectContains(projectPath string, expression *regexp.Regexp) bool {
+	content, err := os.ReadFile(filepath.Join(projectPath, "pyproject.toml"))
+	return err == nil && expression.Match(content)
+}
+
+// fileExists reports whether a file exists in the project.
+func fileExists(projectPath, fileName string) bool {
+	_, err := os.Stat(filepath.Join(projectPath, fileName))
+	return err == nil
+}
+
+// pyprojectManager manages the version of PEP 621 and Poetry projects in pyproject.toml.
+type pyprojectManager struct {
+	p *pythonPlugin
+}
+
+func (m *pyprojectManager) name() string                   { return "pyproject" }
+func (m *pyprojectManager) fileName() string               { return "pyproject.toml" }
+func (m *pyprojectManager) detect(projectPath string) bool { return true }
+
+func (m *pyprojectManager) read(projectPath string) (string, error) {
+	return m.p.readPyprojectVersion(projectPath)
+}
+
+func (m *pyprojectManager) write(projectPath, version string) error {
+	return m.p.writePyprojectVersion(projectPath, version)
+}
+
+// uvManager manages the version of uv projects in the PEP 621 table of pyproject.toml. uv records the version of the
+// project itself in uv.lock, which is updated as well, so that 'uv sync --locked' keeps passing after a version bump.
+type uvManager struct {
+	p *pythonPlugin
+}
+
+func (m *uvManager) name() string     { return "uv" }
+func (m *uvManager) fileName() string { return "pyproject.toml" }
+
+func (m *uvManager) detect(projectPath string) bool {
+	return fileExists(projectPath, uvLock) || pyprojectContains(projectPath, uvSectionRegex)
+}
+
+func (m *uvManager) read(projectPath string) (string, error) {
+	return m.p.readProjectVersion(projectPath)
+}
+
+func (m *uvManager) write(projectPath, version string) error {
+	if err := m.p.writeProjectVersion(projectPath, version); err != nil {
+		return err
+	}
+
+	if !fileExists(projectPath, uvLock) {
+		return nil
+	}
+	return writeUvLockVersion(filepath.Join(projectPath, uvLock), version)
+}
+
+// writeUvLockVersion sets the version of the project itself in uv.lock, which is the package with the project
+// directory as its source. Lock files without such a package, e.g. of workspaces, are left unchanged.
+func writeUvLockVersion(fileName, version string) error {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	// the first block is the header of the lock file, every further block is a package entry
+	blocks := strings.SplitAfter(string(content), "\n[[package]]")
+	for i := 1; i < len(blocks); i++ {
+		if uvLockSourceRegex.MatchString(blocks[i]) {
+			if match := uvLockVersionRegex.FindStringSubmatchIndex(blocks[i]); match != nil {
+				blocks[i] = blocks[i][:match[3]] + version + blocks[i][match[4]:]
+				return os.WriteFile(fileName, []byte(strings.Join(blocks, "")), 0644)
+			}
+		}
+	}
+
+	return nil
+}
+
+// pdmManager manages the version of PDM projects, either in the PEP 621 table of pyproject.toml or, for a dynamic
+// version with the 'file' source, in the '__version__' assignment of the module configured under
+// 'tool.pdm.version.path'. PDM does not record the version of the project itself in pdm.lock.
+type pdmManager struct {
+	p *pythonPlugin
+}
+
+func (m *pdmManager) name() string     { return "pdm" }
+func (m *pdmManager) fileName() string { return "pyproject.toml" }
+
+func (m *pdmManager) detect(projectPath string) bool {
+	return fileExists(projectPath, pdmLock) || pyprojectContains(projectPath, pdmSectionRegex)
+}
+
+func (m *pdmManager) read(projectPath string) (string, error) {
+	if path, ok := m.versionSource(projectPath); ok {
+		return readDunderVersion(projectPath, path)
+	}
+
+	return m.p.readProjectVersion(projectPath)
+}
+
+func (m *pdmManager) write(projectPath, version string) error {
+	if path, ok := m.versionSource(projectPath); ok {
+		return writeDunderVersion(projectPath, path, version)
+	}
+
+	return m.p.writeProjectVersion(projectPath, version)
+}
+
+// versionSource returns the module of a dynamic version with the 'file' source of PDM.
+func (m *pdmManager) versionSource(projectPath string) (string, bool) {
+	source, err := m.p.getToml(projectPath, "tool.pdm.version.source")
+	if err != nil || source != "file" {
+		return "", false
+	}
+
+	path, err := m.p.getToml(projectPath, "tool.pdm.version.path")
+	if err != nil || len(path) == 0 {
+		return "", false
+	}
+	return path, true
+}
+
+// readDunderVersion reads the '__version__' assignment of a python module.
+func readDunderVersion(projectPath, path string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(projectPath, path))
+	if err != nil {
+		return "", err
+	}
+
+	match := dunderVersionRegex.FindSubmatch(content)
+	if match == nil {
+		return "", fmt.Errorf("no __version__ found in %s", path)
+	}
+	return string(match[3]), nil
+}
+
+// writeDunderVersion writes the '__version__' assignment of a python module.
+func writeDunderVersion(projectPath, path, version string) error {
+	fileName := filepath.Join(projectPath, path)
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	if !dunderVersionRegex.Match(content) {
+		return fmt.Errorf("no __version__ found in %s", path)
+	}
+	return os.WriteFile(fileName, dunderVersionRegex.ReplaceAll(content, []byte("${1}${2}"+version+"${4}")), 0644)
+}
+
+// pyprojectTomlManager manages the version of pyproject.toml in-process if toml-cli is not available, e.g. in CI
+// containers without python tooling. It edits the version of the PEP 621 or the Poetry table in place, so that the
+// formatting and comments of the file are kept, and covers the lock file of uv and the dynamic version of PDM as well.
+type pyprojectTomlManager struct {
+	p *pythonPlugin
+}
+
+func (m *pyprojectTomlManager) name() string     { return "pyproject.toml" }
+func (m *pyprojectTomlManager) fileName() string { return "pyproject.toml" }
+
+func (m *pyprojectTomlManager) detect(projectPath string) bool {
+	return !m.p.tomlAvailable()
+}
+
+func (m *pyprojectTomlManager) read(projectPath string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(projectPath, m.fileName()))
+	if err != nil {
+		return "", err
+	}
+
+	if path, ok := pdmVersionSource(content); ok {
+		return readDunderVersion(projectPath, path)
+	}
+
+	for _, key := range []string{"project.version", "tool.poetry.version"} {
+		value, _, err := findTomlString(content, key)
+		if err != nil {
+			return "", err
+		} else if value != nil {
+			return *value, nil
+		}
+	}
+	return "", fmt.Errorf("no version found in pyproject.toml")
+}
+
+func (m *pyprojectTomlManager) write(projectPath, version string) error {
+	fileName := filepath.Join(projectPath, m.fileName())
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	if path, ok := pdmVersionSource(content); ok {
+		return writeDunderVersion(projectPath, path, version)
+	}
+
+	if content, err = setPyprojectVersion(content, version); err != nil {
+		return err
+	}
+	if err = os.WriteFile(fileName, content, 0644); err != nil {
+		return err
+	}
+
+	if !fileExists(projectPath, uvLock) {
+		return nil
+	}
+	return writeUvLockVersion(filepath.Join(projectPath, uvLock), version)
+}
+
+// setPyprojectVersion replaces the version of the PEP 621 or the Poetry table, or adds it to the PEP 621 table, which
+// is added as well if it is missing.
+func setPyprojectVersion(content []byte, version string) ([]byte, error) {
+	quoted := strconv.Quote(version)
+
+	for _, key := range []string{"project.version", "tool.poetry.version"} {
+		value, raw, err := findTomlString(content, key)
+		if err != nil {
+			return nil, err
+		} else if value != nil {
+			updated := append([]byte{}, content[:raw.Offset]...)
+			updated = append(updated, quoted...)
+			return append(updated, content[raw.Offset+raw.Length:]...), nil
+		}
+	}
+
+	if location := projectTableRegex.FindIndex(content); location != nil {
+		updated := append([]byte{}, content[:location[1]]...)
+		updated = append(updated, "version = "+quoted+"\n"...)
+		return append(updated, content[location[1]:]...), nil
+	}
+
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		content = append(content, '\n')
+	}
+	return append(content, "[project]\nversion = "+quoted+"\n"...), nil
+}
+
+// findTomlString returns the string value of a dotted key, e.g. 'project.version', and the location of the value
+// including its quotes, or no value if the key is missing.
+func findTomlString(content []byte, key string) (*string, unstable.Range, error) {
+	parser := unstable.Parser{}
+	parser.Reset(content)
+
+	table := ""
+	for parser.NextExpression() {
+		expression := parser.Expression()
+
+		keys := make([]string, 0)
+		for iterator := expression.Key(); iterator.Next(); {
+			keys = append(keys, string(iterator.Node().Data))
+		}
+
+		switch expression.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			table = strings.Join(keys, ".")
+		case unstable.KeyValue:
+			if strings.TrimPrefix(table+"."+strings.Join(keys, "."), ".") != key {
+				continue
+			}
+			if value := expression.Value(); value.Kind == unstable.String {
+				data := string(value.Data)
+				return &data, value.Raw, nil
+			}
+			return nil, unstable.Range{}, fmt.Errorf("%s of pyproject.toml is not a string", key)
+		}
+	}
+
+	return nil, unstable.Range{}, parser.Error()
+}
+
+// pdmVersionSource returns the module of a dynamic version with the 'file' source of PDM.
+func pdmVersionSource(content []byte) (string, bool) {
+	source, _, err := findTomlString(content, "tool.pdm.version.source")
+	if err != nil || source == nil || *source != "file" {
+		return "", false
+	}
+
+	path, _, err := findTomlString(content, "tool.pdm.version.path")
+	if err != nil || path == nil || len(*path) == 0 {
+		return "", false
+	}
+	return *path, true
+}
+
+// tomlAvailable reports whether toml-cli can be run, either in the docker image of the plugin or natively.
+func (p *pythonPlugin) tomlAvailable() bool {
+	if plugin.ExecutorModeOverride == plugin.ModeDocker {
+		return true
+	}
+	_, err := lookPath(toml)
+	return err == nil
+}
+
+// setupCfgManager manages the version of setuptools projects in setup.cfg.
+type setupCfgManager struct {
+	p *pythonPlugin
+}
+
+func (m *setupCfgManager) name() string                   { return "setup.cfg" }
+func (m *setupCfgManager) fileName() string               { return "setup.cfg" }
+func (m *setupCfgManager) detect(projectPath string) bool { return true }
+
+func (m *setupCfgManager) read(projectPath string) (string, error) {
+	return m.p.runPython(projectPath, readSetupCfgScript, m.fileName())
+}
+
+func (m *setupCfgManager) write(projectPath, version string) error {
+	_, err := m.p.runPython(projectPath, writeSetupCfgScript, m.fileName(), version)
+	return err
+}
+
+// setupPyManager manages the version of setuptools projects in setup.py.
+type setupPyManager struct {
+	p *pythonPlugin
+}
+
+func (m *setupPyManager) name() string                   { return "setup.py" }
+func (m *setupPyManager) fileName() string               { return "setup.py" }
+func (m *setupPyManager) detect(projectPath string) bool { return true }
+
+func (m *setupPyManager) read(projectPath string) (string, error) {
+	return m.p.runPython(projectPath, readSetupPyScript, m.fileName())
+}
+
+func (m *setupPyManager) write(projectPath, version string) error {
+	_, err := m.p.runPython(projectPath, writeSetupPyScript, m.fileName(), version)
+	return err
+}
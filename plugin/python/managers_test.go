@@ -0,0 +1,305 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package python
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupProject copies fixture files into a temp dir, keyed by their target name, with pyproject.toml as version file.
+func setupProject(t *testing.T, fixtures map[string]string) (core.Repository, *pythonPlugin) {
+	t.Helper()
+	tmpDir := t.TempDir()
+
+	for targetFileName, fixture := range fixtures {
+		content := []byte{}
+		if len(fixture) > 0 {
+			var err error
+			content, err = os.ReadFile(filepath.Join("testdata", "unit", fixture))
+			require.NoError(t, err)
+		}
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, targetFileName), content, 0644))
+	}
+
+	p := &pythonPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+	p.Config.VersionFileName = "pyproject.toml"
+
+	return core.NewRepository(tmpDir, ""), p
+}
+
+// withToml pretends that toml-cli is available or not.
+func withToml(t *testing.T, available bool) {
+	t.Helper()
+	original := lookPath
+	lookPath = func(file string) (string, error) {
+		if available {
+			return file, nil
+		}
+		return "", errors.New("not found")
+	}
+	t.Cleanup(func() { lookPath = original })
+}
+
+// TestVersionManagerSelection tests correct priority: uv > pdm > pyproject
+func TestVersionManagerSelection(t *testing.T) {
+	withToml(t, true)
+
+	tests := []struct {
+		name     string
+		fixtures map[string]string
+		expected string
+	}{
+		{"PEP621", map[string]string{"pyproject.toml": "pyproject_pep621.toml"}, "pyproject"},
+		{"Poetry", map[string]string{"pyproject.toml": "pyproject_poetry.toml"}, "pyproject"},
+		{"UvToolTable", map[string]string{"pyproject.toml": "pyproject_uv.toml"}, "uv"},
+		{"UvLockFile", map[string]string{"pyproject.toml": "pyproject_pep621.toml", "uv.lock": "uv.lock"}, "uv"},
+		{"PdmToolTable", map[string]string{"pyproject.toml": "pyproject_pdm.toml"}, "pdm"},
+		{"PdmLockFile", map[string]string{"pyproject.toml": "pyproject_pep621.toml", "pdm.lock": ""}, "pdm"},
+		{"UvBeforePdm", map[string]string{"pyproject.toml": "pyproject_pdm.toml", "uv.lock": "uv.lock"}, "uv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, p := setupProject(t, tt.fixtures)
+
+			manager, err := p.versionManager(repo.Local())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, manager.name())
+		})
+	}
+
+	t.Run("PyprojectWithoutToml", func(t *testing.T) {
+		withToml(t, false)
+		repo, p := setupProject(t, map[string]string{"pyproject.toml": "pyproject_uv.toml", "uv.lock": "uv.lock"})
+
+		manager, err := p.versionManager(repo.Local())
+		require.NoError(t, err)
+		assert.Equal(t, "pyproject.toml", manager.name())
+		assert.Empty(t, p.RequiredTools())
+	})
+
+	t.Run("SetupFilesIgnoreProjectManagers", func(t *testing.T) {
+		repo, p := setupProject(t, map[string]string{"setup.cfg": "setup.cfg", "uv.lock": "uv.lock"})
+		p.Config.VersionFileName = "setup.cfg"
+
+		manager, err := p.versionManager(repo.Local())
+		require.NoError(t, err)
+		assert.Equal(t, "setup.cfg", manager.name())
+	})
+}
+
+// TestWriteUvLockVersion tests updating the version of the project itself in uv.lock
+func TestWriteUvLockVersion(t *testing.T) {
+	t.Run("UpdatesProjectPackage", func(t *testing.T) {
+		repo, _ := setupProject(t, map[string]string{"uv.lock": "uv.lock"})
+		fileName := filepath.Join(repo.Local(), "uv.lock")
+		require.NoError(t, writeUvLockVersion(fileName, "2.0.0.dev0"))
+
+		content, err := os.ReadFile(fileName)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "name = \"my-app\"\nversion = \"2.0.0.dev0\"\nsource = { editable = \".\" }")
+		assert.Contains(t, string(content), "name = \"certifi\"\nversion = \"2024.8.30\"")
+		assert.Contains(t, string(content), "name = \"requests\"\nversion = \"2.32.3\"")
+		assert.Contains(t, string(content), "version = 1\n")
+	})
+
+	t.Run("LockWithoutProjectPackageIsUnchanged", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		fileName := filepath.Join(tmpDir, "uv.lock")
+		lock := "version = 1\n\n[[package]]\nname = \"certifi\"\nversion = \"2024.8.30\"\nsource = { registry = \"https://pypi.org/simple\" }\n"
+		require.NoError(t, os.WriteFile(fileName, []byte(lock), 0644))
+
+		require.NoError(t, writeUvLockVersion(fileName, "2.0.0"))
+
+		content, err := os.ReadFile(fileName)
+		require.NoError(t, err)
+		assert.Equal(t, lock, string(content))
+	})
+}
+
+// TestUvManager tests reading and writing the version of uv projects
+func TestUvManager(t *testing.T) {
+	useDockerMode(t)
+	t.Run("ReadVersion", func(t *testing.T) {
+		repo, p := setupProject(t, map[string]string{"pyproject.toml": "pyproject_uv.toml", "uv.lock": "uv.lock"})
+		v, err := p.ReadVersion(repo)
+		require.NoError(t, err)
+		assert.Equal(t, "1.2.3", v.String())
+	})
+
+	t.Run("WriteVersionUpdatesLockFile", func(t *testing.T) {
+		repo, p := setupProject(t, map[string]string{"pyproject.toml": "pyproject_uv.toml", "uv.lock": "uv.lock"})
+		require.NoError(t, p.WriteVersion(repo, core.NewVersion("2", "0", "0")))
+
+		v, err := p.ReadVersion(repo)
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0", v.String())
+
+		content, err := os.ReadFile(filepath.Join(repo.Local(), "uv.lock"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "name = \"my-app\"\nversion = \"2.0.0\"")
+	})
+
+	t.Run("WriteVersionWithoutLockFile", func(t *testing.T) {
+		repo, p := setupProject(t, map[string]string{"pyproject.toml": "pyproject_uv.toml"})
+		require.NoError(t, p.WriteVersion(repo, core.NewVersion("2", "0", "0", "dev")))
+
+		v, err := p.ReadVersion(repo)
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0-dev", v.String())
+	})
+}
+
+// TestPdmManager tests reading and writing the version of PDM projects
+func TestPdmManager(t *testing.T) {
+	useDockerMode(t)
+	t.Run("ReadStaticVersion", func(t *testing.T) {
+		repo, p := setupProject(t, map[string]string{"pyproject.toml": "pyproject_pdm.toml"})
+		v, err := p.ReadVersion(repo)
+		require.NoError(t, err)
+		assert.Equal(t, "1.2.3", v.String())
+	})
+
+	t.Run("WriteStaticVersion", func(t *testing.T) {
+		repo, p := setupProject(t, map[string]string{"pyproject.toml": "pyproject_pdm.toml"})
+		require.NoError(t, p.WriteVersion(repo, core.NewVersion("2", "0", "0")))
+
+		v, err := p.ReadVersion(repo)
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0", v.String())
+	})
+
+	t.Run("ReadDynamicVersion", func(t *testing.T) {
+		repo, p := setupProject(t, map[string]string{"pyproject.toml": "pyproject_pdm_dynamic.toml", "version.py": "pdm_version.py"})
+		v, err := p.ReadVersion(repo)
+		require.NoError(t, err)
+		assert.Equal(t, "1.2.3", v.String())
+	})
+
+	t.Run("WriteDynamicVersion", func(t *testing.T) {
+		repo, p := setupProject(t, map[string]string{"pyproject.toml": "pyproject_pdm_dynamic.toml", "version.py": "pdm_version.py"})
+		require.NoError(t, p.WriteVersion(repo, core.NewVersion("2", "0", "0", "dev")))
+
+		v, err := p.ReadVersion(repo)
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0-dev", v.String())
+
+		content, err := os.ReadFile(filepath.Join(repo.Local(), "version.py"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `"""Version of my-app."""`)
+
+		pyproject, err := os.ReadFile(filepath.Join(repo.Local(), "pyproject.toml"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(pyproject), "version = ")
+	})
+
+	t.Run("DynamicVersionWithoutAssignment_ReturnsError", func(t *testing.T) {
+		repo, p := setupProject(t, map[string]string{"pyproject.toml": "pyproject_pdm_dynamic.toml", "version.py": ""})
+		_, err := p.ReadVersion(repo)
+		assert.Error(t, err)
+	})
+}
+
+// TestPyprojectTomlManager tests editing pyproject.toml in-process without toml-cli
+func TestPyprojectTomlManager(t *testing.T) {
+	withToml(t, false)
+	t.Run("ReadPEP621Version", func(t *testing.T) {
+		repo, p := setupProject(t, map[string]string{"pyproject.toml": "pyproject_pep621.toml"})
+		v, err := p.ReadVersion(repo)
+		require.NoError(t, err)
+		assert.Equal(t, "1.2.3", v.String())
+	})
+
+	t.Run("ReadPoetryVersion", func(t *testing.T) {
+		repo, p := setupProject(t, map[string]string{"pyproject.toml": "pyproject_poetry.toml"})
+		v, err := p.ReadVersion(repo)
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", v.String())
+	})
+
+	t.Run("WriteVersionPreservesFormatting", func(t *testing.T) {
+		repo, p := setupProject(t, map[string]string{"pyproject.toml": ""})
+		fileName := filepath.Join(repo.Local(), "pyproject.toml")
+		original := "# my app\n[project]\nname = \"my-app\"\nversion = '1.2.3'  # bumped by gitflow-cli\n\n[tool.poetry]\nversion = \"0.0.0\"\n"
+		require.NoError(t, os.WriteFile(fileName, []byte(original), 0644))
+
+		require.NoError(t, p.WriteVersion(repo, core.NewVersion("2", "0", "0", "dev")))
+
+		content, err := os.ReadFile(fileName)
+		require.NoError(t, err)
+		assert.Equal(t, "# my app\n[project]\nname = \"my-app\"\nversion = \"2.0.0.dev0\"  # bumped by gitflow-cli\n\n[tool.poetry]\nversion = \"0.0.0\"\n", string(content))
+	})
+
+	t.Run("WritePoetryVersion", func(t *testing.T) {
+		repo, p := setupProject(t, map[string]string{"pyproject.toml": "pyproject_poetry.toml"})
+		require.NoError(t, p.WriteVersion(repo, core.NewVersion("3", "0", "0")))
+
+		v, err := p.ReadVersion(repo)
+		require.NoError(t, err)
+		assert.Equal(t, "3.0.0", v.String())
+
+		content, err := os.ReadFile(filepath.Join(repo.Local(), "pyproject.toml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `[tool.poetry.dependencies]`)
+	})
+
+	t.Run("AddVersionToFileWithoutVersionField", func(t *testing.T) {
+		repo, p := setupProject(t, map[string]string{"pyproject.toml": "pyproject_pep621_no_version.toml"})
+		require.NoError(t, p.WriteVersion(repo, core.NewVersion("1", "0", "0")))
+
+		v, err := p.ReadVersion(repo)
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", v.String())
+	})
+
+	t.Run("AddVersionToEmptyFile", func(t *testing.T) {
+		repo, p := setupProject(t, map[string]string{"pyproject.toml": ""})
+		require.NoError(t, p.WriteVersion(repo, core.NewVersion("1", "0", "0", "dev")))
+
+		v, err := p.ReadVersion(repo)
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0-dev", v.String())
+	})
+
+	t.Run("WriteVersionUpdatesUvLockFile", func(t *testing.T) {
+		repo, p := setupProject(t, map[string]string{"pyproject.toml": "pyproject_uv.toml", "uv.lock": "uv.lock"})
+		require.NoError(t, p.WriteVersion(repo, core.NewVersion("2", "0", "0")))
+
+		content, err := os.ReadFile(filepath.Join(repo.Local(), "uv.lock"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "name = \"my-app\"\nversion = \"2.0.0\"")
+	})
+
+	t.Run("WritePdmDynamicVersion", func(t *testing.T) {
+		repo, p := setupProject(t, map[string]string{"pyproject.toml": "pyproject_pdm_dynamic.toml", "version.py": "pdm_version.py"})
+		require.NoError(t, p.WriteVersion(repo, core.NewVersion("2", "0", "0")))
+
+		v, err := p.ReadVersion(repo)
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0", v.String())
+	})
+
+	t.Run("NoVersionField_ReturnsError", func(t *testing.T) {
+		repo, p := setupProject(t, map[string]string{"pyproject.toml": "pyproject_pep621_no_version.toml"})
+		_, err := p.ReadVersion(repo)
+		assert.Error(t, err)
+	})
+
+	t.Run("InvalidToml_ReturnsError", func(t *testing.T) {
+		repo, p := setupProject(t, map[string]string{"pyproject.toml": ""})
+		require.NoError(t, os.WriteFile(filepath.Join(repo.Local(), "pyproject.toml"), []byte("[project\nversion = 1"), 0644))
+		_, err := p.ReadVersion(repo)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,60 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package python
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+)
+
+var (
+	// A qualifier with a counterpart in PEP 440 and its optional number, e.g. 'dev', 'rc.2' or 'b1'.
+	pep440QualifierRegex = regexp.MustCompile(`^(dev|post|a|b|rc)\.?(\d*)$`)
+
+	// A version in the notation of PEP 440 with a development, post, or pre-release segment, e.g. 1.2.0.dev0 or 1.2.0rc1.
+	pep440VersionRegex = regexp.MustCompile(`^(\d+\.\d+\.\d+)(?:\.(dev|post)|(a|b|rc))(\d+)$`)
+)
+
+// formatVersion returns a version in the notation of PEP 440, because a hyphen before the qualifier is not valid in
+// python package versions: development and post releases are separated by a dot, pre-releases are appended, and every
+// segment is numbered, e.g. 1.2.0.dev0 for 1.2.0-dev or 1.2.0rc2 for 1.2.0-rc.2. Qualifiers without a counterpart in
+// PEP 440, e.g. SNAPSHOT, are kept in the notation of the core.
+func formatVersion(version core.Version) string {
+	matches := pep440QualifierRegex.FindStringSubmatch(version.Qualifier)
+	if matches == nil {
+		return version.String()
+	}
+
+	name, number := matches[1], matches[2]
+	if len(number) == 0 {
+		number = "0"
+	}
+
+	separator := ""
+	if name == "dev" || name == "post" {
+		separator = "."
+	}
+
+	return fmt.Sprintf("%v.%v.%v%v%v%v", version.Major, version.Minor, version.Incremental, separator, name, number)
+}
+
+// parseVersion parses a version in the notation of PEP 440, or of the core. Segments numbered zero become the bare
+// qualifier, e.g. 1.2.0.dev0 is parsed as 1.2.0-dev.
+func parseVersion(version string) (core.Version, error) {
+	matches := pep440VersionRegex.FindStringSubmatch(version)
+	if matches == nil {
+		return core.ParseVersion(version)
+	}
+
+	name, number := matches[2]+matches[3], matches[4]
+	if number == "0" {
+		number = ""
+	}
+
+	return core.ParseVersion(matches[1] + "-" + name + number)
+}
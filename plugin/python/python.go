@@ -35,6 +35,12 @@ type pythonPlugin struct {
 	plugin.Plugin
 }
 
+// Version file template for new projects created with the bootstrap command.
+const versionFileTemplate = `[project]
+name = "{{.Name}}"
+version = "{{.Version}}"
+`
+
 var pluginConfig = plugin.Config{
 	Name: "python",
 	VersionFileNames: []string{
@@ -43,6 +49,7 @@ var pluginConfig = plugin.Config{
 		"setup.py",
 	},
 	VersionQualifier: "dev",
+	Template:         versionFileTemplate,
 	RequiredTools:    []string{python3, toml},
 	DockerImage:      "python:3.12-slim",
 	DockerSetup:      []string{"pip install -q toml-cli"},
@@ -61,6 +68,15 @@ func init() {
 	core.RegisterPlugin(p)
 }
 
+// RequiredTools requires no tools for pyproject.toml if toml-cli is not available, because its version is then edited
+// in-process instead of falling back to docker.
+func (p *pythonPlugin) RequiredTools() []string {
+	if p.VersionFileName() == "pyproject.toml" && !p.tomlAvailable() {
+		return []string{}
+	}
+	return p.Plugin.RequiredTools()
+}
+
 func (p *pythonPlugin) ReadVersion(repository core.Repository) (core.Version, error) {
 	var logs = make([]any, 0)
 	defer func() { core.Log(logs...) }()
@@ -75,7 +91,7 @@ func (p *pythonPlugin) ReadVersion(repository core.Repository) (core.Version, er
 
 	logs = append(logs, fmt.Sprintf("Read version from %s: %s", p.VersionFileName(), versionString))
 
-	version, err := core.ParseVersion(versionString)
+	version, err := parseVersion(versionString)
 	if err != nil {
 		return core.Version{}, fmt.Errorf("failed to parse version: %v", err)
 	}
@@ -89,41 +105,29 @@ func (p *pythonPlugin) WriteVersion(repository core.Repository, version core.Ver
 
 	projectPath := repository.Local()
 
-	if err := p.writeVersion(projectPath, version.String()); err != nil {
+	if err := p.writeVersion(projectPath, formatVersion(version)); err != nil {
 		logs = append(logs, err)
 		return err
 	}
 
-	logs = append(logs, fmt.Sprintf("Wrote version %s to %s", version.String(), p.VersionFileName()))
+	logs = append(logs, fmt.Sprintf("Wrote version %s to %s", formatVersion(version), p.VersionFileName()))
 	return nil
 }
 
 func (p *pythonPlugin) readVersion(projectPath string) (string, error) {
-	switch p.VersionFileName() {
-	case "pyproject.toml":
-		return p.readPyprojectVersion(projectPath)
-	case "setup.cfg":
-		return p.runPython(projectPath, readSetupCfgScript, p.VersionFileName())
-	case "setup.py":
-		return p.runPython(projectPath, readSetupPyScript, p.VersionFileName())
-	default:
-		return "", fmt.Errorf("unsupported version file: %s", p.VersionFileName())
+	manager, err := p.versionManager(projectPath)
+	if err != nil {
+		return "", err
 	}
+	return manager.read(projectPath)
 }
 
 func (p *pythonPlugin) writeVersion(projectPath, version string) error {
-	switch p.VersionFileName() {
-	case "pyproject.toml":
-		return p.writePyprojectVersion(projectPath, version)
-	case "setup.cfg":
-		_, err := p.runPython(projectPath, writeSetupCfgScript, p.VersionFileName(), version)
-		return err
-	case "setup.py":
-		_, err := p.runPython(projectPath, writeSetupPyScript, p.VersionFileName(), version)
+	manager, err := p.versionManager(projectPath)
+	if err != nil {
 		return err
-	default:
-		return fmt.Errorf("unsupported version file: %s", p.VersionFileName())
 	}
+	return manager.write(projectPath, version)
 }
 
 func (p *pythonPlugin) readPyprojectVersion(projectPath string) (string, error) {
@@ -152,6 +156,28 @@ func (p *pythonPlugin) writePyprojectVersion(projectPath, version string) error
 	return p.runToml(projectPath, "set", "--toml-path", p.VersionFileName(), "project.version", version)
 }
 
+// readProjectVersion reads the version from the PEP 621 table of pyproject.toml.
+func (p *pythonPlugin) readProjectVersion(projectPath string) (string, error) {
+	if version, err := p.getToml(projectPath, "project.version"); err == nil {
+		return version, nil
+	}
+	return "", fmt.Errorf("no version found in pyproject.toml")
+}
+
+// writeProjectVersion writes the version to the PEP 621 table of pyproject.toml.
+func (p *pythonPlugin) writeProjectVersion(projectPath, version string) error {
+	return p.runToml(projectPath, "set", "--toml-path", p.VersionFileName(), "project.version", version)
+}
+
+func (p *pythonPlugin) getToml(projectPath, key string) (string, error) {
+	cmd := p.Executor.Command(projectPath, toml, "get", "--toml-path", p.VersionFileName(), key)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 func (p *pythonPlugin) runToml(projectPath string, args ...string) error {
 	cmd := p.Executor.Command(projectPath, toml, args...)
 	if output, err := cmd.CombinedOutput(); err != nil {
@@ -31,7 +31,6 @@ func useDockerMode(t *testing.T) {
 	t.Cleanup(func() { plugin.ExecutorModeOverride = "" })
 }
 
-
 //go:embed testdata/e2e/pyproject_pep621.toml.tpl
 var pyprojectTemplate string
 
@@ -44,6 +43,15 @@ var setupCfgTemplate string
 //go:embed testdata/e2e/setup.py.tpl
 var setupPyTemplate string
 
+// pythonNotation converts the versions of the e2e assertions to the notation of PEP 440.
+func pythonNotation(version string) string {
+	v, err := core.ParseVersion(version)
+	if err != nil {
+		return version
+	}
+	return formatVersion(v)
+}
+
 var testConfigs = []plugin.TestConfig{
 	{
 		Name:             "python_pyproject",
@@ -53,6 +61,7 @@ var testConfigs = []plugin.TestConfig{
 		VersionFileName:  "pyproject.toml",
 		Template:         pyprojectTemplate,
 		EmptyContent:     []byte{},
+		VersionNotation:  pythonNotation,
 	},
 	{
 		Name:             "python_poetry",
@@ -61,6 +70,7 @@ var testConfigs = []plugin.TestConfig{
 		VersionQualifier: "dev",
 		VersionFileName:  "pyproject.toml",
 		Template:         poetryTemplate,
+		VersionNotation:  pythonNotation,
 	},
 	{
 		Name:             "python_setup_cfg",
@@ -70,6 +80,7 @@ var testConfigs = []plugin.TestConfig{
 		VersionFileName:  "setup.cfg",
 		Template:         setupCfgTemplate,
 		EmptyContent:     []byte{},
+		VersionNotation:  pythonNotation,
 	},
 	{
 		Name:             "python_setup_py",
@@ -79,6 +90,7 @@ var testConfigs = []plugin.TestConfig{
 		VersionFileName:  "setup.py",
 		Template:         setupPyTemplate,
 		EmptyContent:     []byte{},
+		VersionNotation:  pythonNotation,
 	},
 }
 
@@ -90,6 +102,22 @@ func TestE2E_ReleaseStart(t *testing.T) {
 	}
 }
 
+func TestE2E_ReleaseStartMajor(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStartMajor(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseStartMinor(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStartMinor(t, tc)
+		})
+	}
+}
+
 func TestE2E_ReleaseStart_BeforeHook(t *testing.T) {
 	for _, tc := range testConfigs {
 		if tc.EmptyContent == nil {
@@ -503,3 +531,59 @@ func TestReadWriteRoundtrip(t *testing.T) {
 		})
 	}
 }
+
+// TestFormatVersion tests the notation of PEP 440 for written versions
+func TestFormatVersion(t *testing.T) {
+	tests := []struct {
+		version  core.Version
+		expected string
+	}{
+		{core.NewVersion("1", "1", "0"), "1.1.0"},
+		{core.NewVersion("1", "1", "0", "dev"), "1.1.0.dev0"},
+		{core.NewVersion("1", "1", "0", "dev3"), "1.1.0.dev3"},
+		{core.NewVersion("1", "1", "0", "dev.3"), "1.1.0.dev3"},
+		{core.NewVersion("1", "1", "0", "rc"), "1.1.0rc0"},
+		{core.NewVersion("1", "1", "0", "rc.2"), "1.1.0rc2"},
+		{core.NewVersion("1", "1", "0", "a1"), "1.1.0a1"},
+		{core.NewVersion("1", "1", "0", "b"), "1.1.0b0"},
+		{core.NewVersion("1", "1", "0", "post1"), "1.1.0.post1"},
+		{core.NewVersion("1", "1", "0", "SNAPSHOT"), "1.1.0-SNAPSHOT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version.String(), func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatVersion(tt.version))
+		})
+	}
+}
+
+// TestParseVersion tests reading versions in the notation of PEP 440 and of the core
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected string
+	}{
+		{"1.1.0", "1.1.0"},
+		{"1.1.0.dev0", "1.1.0-dev"},
+		{"1.1.0.dev3", "1.1.0-dev3"},
+		{"1.1.0rc0", "1.1.0-rc"},
+		{"1.1.0rc2", "1.1.0-rc2"},
+		{"1.1.0a1", "1.1.0-a1"},
+		{"1.1.0.post1", "1.1.0-post1"},
+		{"1.1.0-dev", "1.1.0-dev"},
+		{"1.1.0-SNAPSHOT", "1.1.0-SNAPSHOT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := parseVersion(tt.version)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, v.String())
+		})
+	}
+
+	t.Run("Invalid_ReturnsError", func(t *testing.T) {
+		_, err := parseVersion("1.1.0rc1.dev0")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,3 @@
+"""Version of my-app."""
+
+__version__ = "1.2.3"
@@ -6,27 +6,37 @@ SPDX-License-Identifier: MIT
 package road
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"github.com/mercedes-benz/gitflow-cli/core"
 	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+	"gopkg.in/yaml.v3"
+	"io"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 )
 
 // road-specific constants
 const (
-	versionKey = "versionNumber"
+	versionKey         = "versionNumber"
+	versionPathSetting = "version-path"
 )
 
-var versionRegex = regexp.MustCompile(`(?m)^(` + versionKey + `\s*:)(\s*)(['"]?)(.+?)(['"]?)\s*$`)
+// Version file template for new projects created with the bootstrap command.
+const versionFileTemplate = `configFormat: 1.0.0
+versionNumber: {{.Version}}
+app:
+  name: {{.Name}}
+`
 
 // Fixed configuration for the Road plugin
 var pluginConfig = plugin.Config{
 	Name:             "road",
 	VersionFileName:  "road.yaml",
 	VersionQualifier: "dev",
+	Template:         versionFileTemplate,
 	RequiredTools:    []string{},
 	DockerImage:      "alpine:3",
 }
@@ -53,7 +63,7 @@ func init() {
 	core.RegisterPlugin(roadPlugin)
 }
 
-// ReadVersion reads the version from road.yaml file
+// ReadVersion reads the version from road.yaml file. All documents of the file that have a version must have the same.
 func (p *roadPlugin) ReadVersion(repository core.Repository) (core.Version, error) {
 	versionFile := filepath.Join(repository.Local(), p.Config.VersionFileName)
 
@@ -63,26 +73,27 @@ func (p *roadPlugin) ReadVersion(repository core.Repository) (core.Version, erro
 		return core.Version{}, fmt.Errorf("failed to read road version file: %v", err)
 	}
 
-	// Check for multiple version entries
-	allMatches := versionRegex.FindAllSubmatch(data, -1)
-	if len(allMatches) > 1 {
-		return core.Version{}, fmt.Errorf("multiple version entries found in road.yaml file")
+	nodes, err := findVersionNodes(data, p.versionPaths())
+	if err != nil {
+		return core.Version{}, err
 	}
 
-	// Get the first (and should be only) match
-	matches := versionRegex.FindSubmatch(data)
+	// No version found in file
+	if len(nodes) == 0 {
+		return core.Version{}, fmt.Errorf("no version found in road.yaml file")
+	}
 
-	// The version is in the fourth group (index 4)
-	if len(matches) >= 5 {
-		versionStr := strings.TrimSpace(string(matches[4]))
-		return core.ParseVersion(versionStr)
+	// Check for different versions in multiple documents
+	for _, node := range nodes[1:] {
+		if node.Value != nodes[0].Value {
+			return core.Version{}, fmt.Errorf("different version entries found in road.yaml file: %v, %v", nodes[0].Value, node.Value)
+		}
 	}
 
-	// No version found in file
-	return core.Version{}, fmt.Errorf("no version found in road.yaml file")
+	return core.ParseVersion(strings.TrimSpace(nodes[0].Value))
 }
 
-// WriteVersion writes the version to the road.yaml file
+// WriteVersion writes the version to all documents of the road.yaml file that have a version
 func (p *roadPlugin) WriteVersion(repository core.Repository, version core.Version) error {
 	versionFile := filepath.Join(repository.Local(), p.Config.VersionFileName)
 
@@ -92,14 +103,129 @@ func (p *roadPlugin) WriteVersion(repository core.Repository, version core.Versi
 		return fmt.Errorf("road version update failed: %v", err)
 	}
 
-	// When replacing, we use exactly one space after the colon and keep the original quotation marks (groups 3 and 5)
-	newContent := versionRegex.ReplaceAllString(string(data), "${1} ${3}"+version.String()+"${5}")
+	nodes, err := findVersionNodes(data, p.versionPaths())
+	if err != nil {
+		return fmt.Errorf("road version update failed: %v", err)
+	}
 
-	// If no replacement occurred, return an error
-	if newContent == string(data) {
+	// If no version is found, return an error
+	if len(nodes) == 0 {
 		return fmt.Errorf("version key not found in road.yaml file")
 	}
 
+	lines := strings.Split(string(data), "\n")
+	for _, node := range nodes {
+		if node.Line > len(lines) {
+			return fmt.Errorf("road version update failed: version at line %v not found", node.Line)
+		}
+		lines[node.Line-1] = replaceScalar(lines[node.Line-1], node, version.String())
+	}
+
 	// Write back to the file
-	return os.WriteFile(versionFile, []byte(newContent), 0644)
+	return os.WriteFile(versionFile, []byte(strings.Join(lines, "\n")), 0644)
+}
+
+// versionPaths returns the dotted paths of the version configured under 'road.version-path', which is a path or a
+// list of paths, e.g. 'metadata.versionNumber', or the top-level versionNumber key.
+func (p *roadPlugin) versionPaths() []string {
+	switch v := core.PluginSettings(p)[versionPathSetting].(type) {
+	case string:
+		if len(v) > 0 {
+			return []string{v}
+		}
+	case []any:
+		paths := make([]string, 0, len(v))
+		for _, path := range v {
+			if s, ok := path.(string); ok && len(s) > 0 {
+				paths = append(paths, s)
+			}
+		}
+		if len(paths) > 0 {
+			return paths
+		}
+	}
+	return []string{versionKey}
+}
+
+// findVersionNodes returns the version of every document of a YAML stream, found at the first path of a document
+// that exists. Documents without a version are skipped.
+func findVersionNodes(data []byte, paths []string) ([]*yaml.Node, error) {
+	nodes := make([]*yaml.Node, 0)
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		var document yaml.Node
+		if err := decoder.Decode(&document); errors.Is(err, io.EOF) {
+			return nodes, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("invalid road.yaml file: %v", err)
+		}
+
+		for _, path := range paths {
+			node, err := lookupPath(&document, strings.Split(path, "."))
+			if err != nil {
+				return nil, err
+			}
+
+			if node != nil {
+				if node.Kind != yaml.ScalarNode {
+					return nil, fmt.Errorf("version at %v of road.yaml file is not a scalar", path)
+				}
+				nodes = append(nodes, node)
+				break
+			}
+		}
+	}
+}
+
+// lookupPath returns the node at a path of mapping keys, or nil if it does not exist. A key of the path must not be
+// defined twice.
+func lookupPath(node *yaml.Node, keys []string) (*yaml.Node, error) {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		return lookupPath(node.Content[0], keys)
+	}
+
+	if len(keys) == 0 {
+		return node, nil
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	var value *yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != keys[0] {
+			continue
+		}
+		if value != nil {
+			return nil, fmt.Errorf("multiple %v entries found in road.yaml file", keys[0])
+		}
+		value = node.Content[i+1]
+	}
+
+	if value == nil {
+		return nil, nil
+	}
+	return lookupPath(value, keys[1:])
+}
+
+// replaceScalar replaces the scalar of a node in its line with a value, using exactly one space after the colon, the
+// original quotation marks, and no trailing whitespace, while keeping a trailing comment.
+func replaceScalar(line string, node *yaml.Node, value string) string {
+	start := node.Column - 1
+	if start > len(line) {
+		return line
+	}
+
+	quote := ""
+	switch node.Style {
+	case yaml.SingleQuotedStyle:
+		quote = "'"
+	case yaml.DoubleQuotedStyle:
+		quote = "\""
+	}
+
+	end := min(start+len(node.Value)+2*len(quote), len(line))
+	return strings.TrimRight(line[:start], " \t") + " " + quote + value + quote + strings.TrimRight(line[end:], " \t")
 }
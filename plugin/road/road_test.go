@@ -14,6 +14,7 @@ import (
 	"github.com/mercedes-benz/gitflow-cli/core"
 	"github.com/mercedes-benz/gitflow-cli/core/plugin"
 	"github.com/mercedes-benz/gitflow-cli/e2e/workflow"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -33,6 +34,14 @@ func TestReleaseStart(t *testing.T) {
 	workflow.RunReleaseStart(t, testConfig)
 }
 
+func TestReleaseStartMajor(t *testing.T) {
+	workflow.RunReleaseStartMajor(t, testConfig)
+}
+
+func TestReleaseStartMinor(t *testing.T) {
+	workflow.RunReleaseStartMinor(t, testConfig)
+}
+
 func TestReleaseFinish(t *testing.T) {
 	workflow.RunReleaseFinish(t, testConfig)
 }
@@ -93,6 +102,21 @@ func TestVersionReadWrite(t *testing.T) {
 			initialContent: "versionNumber:    1.2.3   ",
 			expectedResult: "versionNumber: 1.2.3-dev",
 		},
+		{
+			name:           "WithComment",
+			initialContent: "versionNumber: 1.2.3 # version of the app\napp:\n  name: example\n",
+			expectedResult: "versionNumber: 1.2.3-dev # version of the app\napp:\n  name: example\n",
+		},
+		{
+			name:           "IndentedDocument",
+			initialContent: " versionNumber: 1.2.3",
+			expectedResult: " versionNumber: 1.2.3-dev",
+		},
+		{
+			name:           "MultipleDocuments",
+			initialContent: "versionNumber: 1.2.3\napp:\n  name: example\n---\nversionNumber: '1.2.3'\n---\nkind: other\n",
+			expectedResult: "versionNumber: 1.2.3-dev\napp:\n  name: example\n---\nversionNumber: '1.2.3-dev'\n---\nkind: other\n",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -136,13 +160,21 @@ func TestVersionNoMatch(t *testing.T) {
 			initialContent: "otherKey: 1.2.3",
 		},
 		{
-			name:           "VersionNodeWithLeadingSpaces",
-			initialContent: " versionNumber: 1.2.3",
+			name:           "NestedVersionNode",
+			initialContent: "metadata:\n  versionNumber: 1.2.3",
 		},
 		{
 			name:           "MultipleVersionNodes",
 			initialContent: "versionNumber: 1.2.3\nversionNumber: 3.4.5",
 		},
+		{
+			name:           "DifferentVersionsInDocuments",
+			initialContent: "versionNumber: 1.2.3\n---\nversionNumber: 3.4.5",
+		},
+		{
+			name:           "VersionNodeNotScalar",
+			initialContent: "versionNumber:\n  major: 1",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -159,3 +191,47 @@ func TestVersionNoMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestVersionPath(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	content := "metadata:\n  name: example\n  versionNumber: \"1.2.3\"\nversionNumber: 9.9.9\n---\nspec:\n  release:\n    versionNumber: 1.2.3\n"
+
+	t.Run("NestedPath", func(t *testing.T) {
+		viper.Set("road.version-path", "metadata.versionNumber")
+		testFilePath, repository, roadPlugin := setupTest(t, "metadata:\n  versionNumber: \"1.2.3\"\nversionNumber: 9.9.9\n")
+
+		version, err := roadPlugin.ReadVersion(repository)
+		require.NoError(t, err)
+		assert.Equal(t, "1.2.3", version.String())
+
+		require.NoError(t, roadPlugin.WriteVersion(repository, core.NewVersion("1", "3", "0", "dev")))
+		result, err := os.ReadFile(testFilePath)
+		require.NoError(t, err)
+		assert.Equal(t, "metadata:\n  versionNumber: \"1.3.0-dev\"\nversionNumber: 9.9.9\n", string(result))
+	})
+
+	t.Run("PathsPerDocument", func(t *testing.T) {
+		viper.Set("road.version-path", []any{"metadata.versionNumber", "spec.release.versionNumber"})
+		testFilePath, repository, roadPlugin := setupTest(t, content)
+
+		version, err := roadPlugin.ReadVersion(repository)
+		require.NoError(t, err)
+		assert.Equal(t, "1.2.3", version.String())
+
+		require.NoError(t, roadPlugin.WriteVersion(repository, core.NewVersion("2", "0", "0")))
+		result, err := os.ReadFile(testFilePath)
+		require.NoError(t, err)
+		assert.Equal(t, "metadata:\n  name: example\n  versionNumber: \"2.0.0\"\nversionNumber: 9.9.9\n---\nspec:\n  release:\n    versionNumber: 2.0.0\n", string(result))
+	})
+
+	t.Run("MissingPath", func(t *testing.T) {
+		viper.Set("road.version-path", "metadata.versionNumber")
+		_, repository, roadPlugin := setupTest(t, "versionNumber: 1.2.3\n")
+
+		_, err := roadPlugin.ReadVersion(repository)
+		assert.Error(t, err)
+		assert.Error(t, roadPlugin.WriteVersion(repository, core.NewVersion("2", "0", "0")))
+	})
+}
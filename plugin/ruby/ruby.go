@@ -0,0 +1,175 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package ruby
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+)
+
+// ruby-specific constants
+const (
+	libDirectory       = "lib"
+	versionSource      = "version.rb"
+	gemspecPattern     = "*.gemspec"
+	versionFileSetting = "version-file"
+)
+
+// The VERSION constant of a version.rb file, e.g. VERSION = "1.2.0", also with a trailing .freeze.
+var constantRegex = regexp.MustCompile(`(?m)^([ \t]*VERSION[ \t]*=[ \t]*)(['"])([^'"\n]*)(['"])`)
+
+// The version attribute of a gem specification, e.g. spec.version = "1.2.0".
+var gemspecRegex = regexp.MustCompile(`(?m)^([ \t]*\w+\.version[ \t]*=[ \t]*)(['"])([^'"\n]*)(['"])`)
+
+// A prerelease version in Ruby notation, e.g. 1.2.0.dev or 1.2.0.rc.2, whose qualifier is separated by a dot instead
+// of a hyphen.
+var prereleaseRegex = regexp.MustCompile(`^(\d+\.\d+\.\d+)\.([A-Za-z]\w*(?:\.\w+)*)$`)
+
+// Fixed configuration for the ruby plugin
+var pluginConfig = plugin.Config{
+	Name:             "ruby",
+	VersionQualifier: "dev",
+	RequiredTools:    []string{},
+	DockerImage:      "alpine:3",
+}
+
+// rubyPlugin is the plugin for Ruby gems.
+type rubyPlugin struct {
+	plugin.Plugin
+}
+
+// Register the ruby plugin
+func init() {
+	pluginFactory := plugin.NewFactory()
+
+	// Create plugin with pluginFactory to get hooks and other dependencies
+	rubyPlugin := &rubyPlugin{
+		Plugin: pluginFactory.NewPlugin(pluginConfig),
+	}
+
+	// Register plugin directly in core
+	core.RegisterPlugin(rubyPlugin)
+}
+
+// VersionFileNames returns the version file configured under 'ruby.version-file', or the version.rb files below
+// lib, shallowest first, followed by the gem specifications, which often read the version from version.rb.
+func (p *rubyPlugin) VersionFileNames() []string {
+	if v, ok := core.PluginSettings(p)[versionFileSetting].(string); ok && len(v) > 0 {
+		return []string{filepath.ToSlash(filepath.Clean(v))}
+	}
+
+	fileNames := make([]string, 0)
+	_ = filepath.WalkDir(filepath.Join(core.ProjectPath, libDirectory), func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() && entry.Name() == versionSource {
+			if name, err := filepath.Rel(core.ProjectPath, path); err == nil {
+				fileNames = append(fileNames, filepath.ToSlash(name))
+			}
+		}
+		return nil
+	})
+
+	slices.SortStableFunc(fileNames, func(a, b string) int {
+		return strings.Count(a, "/") - strings.Count(b, "/")
+	})
+
+	if gemspecs, err := filepath.Glob(filepath.Join(core.ProjectPath, gemspecPattern)); err == nil {
+		for _, gemspec := range gemspecs {
+			fileNames = append(fileNames, filepath.Base(gemspec))
+		}
+	}
+
+	return fileNames
+}
+
+// ReadVersion reads the VERSION constant of version.rb or the version attribute of the gem specification
+func (p *rubyPlugin) ReadVersion(repository core.Repository) (core.Version, error) {
+	var logs = make([]any, 0)
+	versionFilePath := filepath.Join(repository.Local(), p.VersionFileName())
+
+	// log human-readable description of commands
+	defer func() { core.Log(logs...) }()
+
+	data, err := os.ReadFile(versionFilePath)
+	if err != nil {
+		logs = append(logs, fmt.Sprintf("Reading file: %s", versionFilePath), err)
+		return core.NoVersion, fmt.Errorf("ruby version evaluation failed with %v: %v", err, p.VersionFileName())
+	}
+
+	logs = append(logs, fmt.Sprintf("Reading file: %s", versionFilePath), string(data))
+
+	// Check for multiple version declarations
+	allMatches := p.versionRegex().FindAllSubmatch(data, -1)
+	if len(allMatches) > 1 {
+		return core.NoVersion, fmt.Errorf("multiple version declarations found in %v", p.VersionFileName())
+	} else if len(allMatches) == 0 {
+		return core.NoVersion, fmt.Errorf("no version declaration found in %v", p.VersionFileName())
+	}
+
+	return parseVersion(string(allMatches[0][3]))
+}
+
+// WriteVersion writes the VERSION constant of version.rb or the version attribute of the gem specification
+func (p *rubyPlugin) WriteVersion(repository core.Repository, version core.Version) error {
+	versionFilePath := filepath.Join(repository.Local(), p.VersionFileName())
+
+	var err error
+	operation := fmt.Sprintf("Writing to file: %s, content: %s", versionFilePath, formatVersion(version))
+
+	// log operation description
+	defer func() { core.Log(operation, err) }()
+
+	var data []byte
+	if data, err = os.ReadFile(versionFilePath); err != nil {
+		return fmt.Errorf("ruby version update failed with %v: %v", err, p.VersionFileName())
+	}
+
+	if !p.versionRegex().Match(data) {
+		err = fmt.Errorf("no version declaration found in %v", p.VersionFileName())
+		return err
+	}
+
+	// keep the quotation marks and a trailing .freeze of the declaration
+	newContent := p.versionRegex().ReplaceAllString(string(data), "${1}${2}"+formatVersion(version)+"${4}")
+
+	if err = os.WriteFile(versionFilePath, []byte(newContent), 0644); err != nil {
+		return fmt.Errorf("ruby version update failed with %v: %v", err, p.VersionFileName())
+	}
+
+	return nil
+}
+
+// versionRegex returns the expression of the version declaration of the current version file.
+func (p *rubyPlugin) versionRegex() *regexp.Regexp {
+	if strings.HasSuffix(p.VersionFileName(), ".gemspec") {
+		return gemspecRegex
+	}
+	return constantRegex
+}
+
+// formatVersion returns a version in the prerelease notation of RubyGems, e.g. 1.2.0.dev, because a hyphen is not
+// allowed in gem versions.
+func formatVersion(version core.Version) string {
+	if len(version.Qualifier) == 0 {
+		return version.String()
+	}
+	return fmt.Sprintf("%v.%v.%v.%v", version.Major, version.Minor, version.Incremental, version.Qualifier)
+}
+
+// parseVersion parses a version in the prerelease notation of RubyGems, or a semantic version.
+func parseVersion(version string) (core.Version, error) {
+	if matches := prereleaseRegex.FindStringSubmatch(version); matches != nil {
+		return core.ParseVersion(matches[1] + "-" + matches[2])
+	}
+	return core.ParseVersion(version)
+}
@@ -0,0 +1,226 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package ruby
+
+import (
+	_ "embed"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+	"github.com/mercedes-benz/gitflow-cli/e2e/workflow"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/e2e/version.rb.tpl
+var versionTemplate string
+
+//go:embed testdata/e2e/example.gemspec.tpl
+var gemspecTemplate string
+
+// rubyNotation converts the versions of the e2e assertions to the prerelease notation of RubyGems.
+func rubyNotation(version string) string {
+	v, err := core.ParseVersion(version)
+	if err != nil {
+		return version
+	}
+	return formatVersion(v)
+}
+
+var testConfigs = []plugin.TestConfig{
+	{
+		Name:             "ruby_version_rb",
+		PluginName:       "ruby",
+		DockerImage:      pluginConfig.DockerImage,
+		VersionQualifier: "dev",
+		VersionFileName:  "lib/example/version.rb",
+		Template:         versionTemplate,
+		VersionNotation:  rubyNotation,
+	},
+	{
+		Name:             "ruby_gemspec",
+		PluginName:       "ruby",
+		DockerImage:      pluginConfig.DockerImage,
+		VersionQualifier: "dev",
+		VersionFileName:  "example.gemspec",
+		Template:         gemspecTemplate,
+		VersionNotation:  rubyNotation,
+	},
+}
+
+func TestE2E_ReleaseStart(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStart(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseStartMajor(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStartMajor(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseStartMinor(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStartMinor(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseFinish(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseFinish(t, tc)
+		})
+	}
+}
+
+func TestE2E_HotfixStart(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunHotfixStart(t, tc)
+		})
+	}
+}
+
+func TestE2E_HotfixFinish(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunHotfixFinish(t, tc)
+		})
+	}
+}
+
+// setupTest writes a version file into a temp dir and selects it as the version file of the plugin. The settings
+// of previous e2e tests are reset, so that no 'ruby.version-file' setting overrides the selected file.
+func setupTest(t *testing.T, fileName, content string) (string, core.Repository, *rubyPlugin) {
+	t.Helper()
+	viper.Reset()
+	tempDir := t.TempDir()
+
+	testFilePath := filepath.Join(tempDir, fileName)
+	require.NoError(t, os.MkdirAll(filepath.Dir(testFilePath), 0755))
+	require.NoError(t, os.WriteFile(testFilePath, []byte(content), 0644))
+
+	p := &rubyPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+	p.SetVersionFileName(fileName)
+
+	return testFilePath, core.NewRepository(tempDir, ""), p
+}
+
+func TestVersionReadWrite(t *testing.T) {
+	testCases := []struct {
+		name           string
+		fileName       string
+		initialContent string
+		expectedResult string
+	}{
+		{"Constant", "lib/app/version.rb", "module App\n  VERSION = \"1.2.3\"\nend\n", "module App\n  VERSION = \"1.2.3.dev\"\nend\n"},
+		{"Frozen", "lib/app/version.rb", "module App\n  VERSION = '1.2.3'.freeze\nend\n", "module App\n  VERSION = '1.2.3.dev'.freeze\nend\n"},
+		{"OtherConstantsIgnored", "lib/app/version.rb", "module App\n  MIN_RUBY_VERSION = \"3.1.0\"\n  VERSION = \"1.2.3\"\nend\n", "module App\n  MIN_RUBY_VERSION = \"3.1.0\"\n  VERSION = \"1.2.3.dev\"\nend\n"},
+		{"Gemspec", "app.gemspec", "Gem::Specification.new do |s|\n  s.name = \"app\"\n  s.version = \"1.2.3\"\nend\n", "Gem::Specification.new do |s|\n  s.name = \"app\"\n  s.version = \"1.2.3.dev\"\nend\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(test *testing.T) {
+			testFilePath, repository, p := setupTest(test, testCase.fileName, testCase.initialContent)
+
+			version, err := p.ReadVersion(repository)
+			require.NoError(test, err)
+			assert.Equal(test, "1.2.3", version.String())
+
+			version.Qualifier = "dev"
+			require.NoError(test, p.WriteVersion(repository, version))
+
+			result, err := os.ReadFile(testFilePath)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expectedResult, string(result))
+
+			version, err = p.ReadVersion(repository)
+			require.NoError(test, err)
+			assert.Equal(test, "1.2.3-dev", version.String())
+		})
+	}
+}
+
+func TestReadPrerelease(t *testing.T) {
+	testCases := []struct {
+		name            string
+		initialContent  string
+		expectedVersion string
+	}{
+		{"Hyphenated", "VERSION = \"1.2.3-dev\"\n", "1.2.3-dev"},
+		{"Numbered", "VERSION = \"1.2.3.rc.2\"\n", "1.2.3-rc.2"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(test *testing.T) {
+			_, repository, p := setupTest(test, "lib/app/version.rb", testCase.initialContent)
+
+			version, err := p.ReadVersion(repository)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expectedVersion, version.String())
+		})
+	}
+}
+
+func TestVersionNoMatch(t *testing.T) {
+	testCases := []struct {
+		name           string
+		fileName       string
+		initialContent string
+	}{
+		{"NoConstant", "lib/app/version.rb", "module App\n  NAME = \"app\"\nend\n"},
+		{"MultipleConstants", "lib/app/version.rb", "VERSION = \"1.2.3\"\nVERSION = \"3.4.5\"\n"},
+		{"GemspecReferencesConstant", "app.gemspec", "Gem::Specification.new do |s|\n  s.version = App::VERSION\nend\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(test *testing.T) {
+			_, repository, p := setupTest(test, testCase.fileName, testCase.initialContent)
+
+			_, err := p.ReadVersion(repository)
+			require.Error(test, err)
+		})
+	}
+}
+
+func TestVersionFileDetection(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	tempDir := t.TempDir()
+
+	projectPath := core.ProjectPath
+	core.ProjectPath = tempDir
+	t.Cleanup(func() { core.ProjectPath = projectPath })
+
+	p := &rubyPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+	assert.False(t, core.CheckVersionFile(p))
+
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "app.gemspec"), []byte("s.version = \"1.0.0\"\n"), 0644))
+	assert.True(t, core.CheckVersionFile(p))
+	assert.Equal(t, "app.gemspec", p.VersionFileName())
+
+	for _, name := range []string{"lib/app/generators/version.rb", "lib/app/version.rb"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tempDir, filepath.Dir(name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, name), []byte("VERSION = \"1.0.0\"\n"), 0644))
+	}
+	assert.True(t, core.CheckVersionFile(p))
+	assert.Equal(t, "lib/app/version.rb", p.VersionFileName())
+
+	viper.Set("ruby.version-file", "./lib/app/generators/version.rb")
+	assert.True(t, core.CheckVersionFile(p))
+	assert.Equal(t, "lib/app/generators/version.rb", p.VersionFileName())
+}
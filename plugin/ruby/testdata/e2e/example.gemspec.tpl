@@ -0,0 +1,9 @@
+# frozen_string_literal: true
+
+Gem::Specification.new do |spec|
+  spec.name = "example"
+  spec.version = "{{.Version}}"
+  spec.authors = ["Example"]
+  spec.summary = "Example gem"
+  spec.files = Dir["lib/**/*.rb"]
+end
@@ -0,0 +1,5 @@
+# frozen_string_literal: true
+
+module Example
+  VERSION = "{{.Version}}".freeze
+end
@@ -14,11 +14,18 @@ import (
 	"strings"
 )
 
+// Uppercase version file, which is detected alongside version.txt.
+const upperVersionFileName = "VERSION"
+
+// Version file template for new projects created with the bootstrap command.
+const versionFileTemplate = "{{.Version}}"
+
 // Fixed configuration for the standard plugin
 var pluginConfig = plugin.Config{
 	Name:             "standard",
 	VersionFileName:  "version.txt",
 	VersionQualifier: "dev",
+	Template:         versionFileTemplate,
 	RequiredTools:    []string{},
 	DockerImage:      "alpine:3",
 }
@@ -47,6 +54,15 @@ func init() {
 	core.RegisterFallbackPlugin(standardPlugin)
 }
 
+// VersionFileNames returns the version file configured under 'version-file.name', e.g. 'config/VERSION', or
+// version.txt and VERSION, in this order.
+func (p *standardPlugin) VersionFileNames() []string {
+	if name := core.ConfiguredVersionFileName(); len(name) > 0 {
+		return []string{name}
+	}
+	return []string{pluginConfig.VersionFileName, upperVersionFileName}
+}
+
 // ReadVersion reads the current version from the project
 func (p *standardPlugin) ReadVersion(repository core.Repository) (core.Version, error) {
 	var logs = make([]any, 0)
@@ -109,7 +125,20 @@ func (p *standardPlugin) beforeReleaseStart(repository core.Repository) error {
 		return nil
 	}
 
-	initVersion := core.NewVersion("1", "0", "0", p.Config.VersionQualifier)
+	// create the version file with the configured initial version unless this is disabled
+	if err := core.CheckVersionFileCreation(p.Config.VersionFileName); err != nil {
+		return err
+	}
+
+	initVersion, err := core.InitialVersion()
+	if err != nil {
+		return err
+	}
+
+	initVersion = initVersion.AddQualifier(core.Qualifier(p, core.Development))
+	if err := os.MkdirAll(filepath.Dir(versionFilePath), 0755); err != nil {
+		return repository.Rollback(err)
+	}
 	if err := os.WriteFile(versionFilePath, []byte(initVersion.String()), 0644); err != nil {
 		return repository.Rollback(err)
 	}
@@ -136,7 +165,19 @@ func (p *standardPlugin) beforeHotfixStart(repository core.Repository) error {
 		return nil
 	}
 
-	initVersion := core.NewVersion("1", "0", "0")
+	// create the version file with the configured initial version unless this is disabled
+	if err := core.CheckVersionFileCreation(p.Config.VersionFileName); err != nil {
+		return err
+	}
+
+	initVersion, err := core.InitialVersion()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(versionFilePath), 0755); err != nil {
+		return repository.Rollback(err)
+	}
 	if err := os.WriteFile(versionFilePath, []byte(initVersion.String()), 0644); err != nil {
 		return repository.Rollback(err)
 	}
@@ -164,9 +205,9 @@ func (p *standardPlugin) afterMergeIntoDevelopment(repository core.Repository) e
 	if filesEqual {
 		if current, err := p.ReadVersion(repository); err != nil {
 			return repository.Rollback(err)
-		} else if next, err := current.Next(core.Minor); err != nil {
+		} else if next, err := core.Scheme().Next(current, core.Minor); err != nil {
 			return repository.Rollback(err)
-		} else if err := p.WriteVersion(repository, next.AddQualifier(p.VersionQualifier())); err != nil {
+		} else if err := p.WriteVersion(repository, next.AddQualifier(core.Qualifier(p, core.Development))); err != nil {
 			return repository.Rollback(err)
 		}
 
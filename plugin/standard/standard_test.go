@@ -28,10 +28,86 @@ func TestReleaseStart(t *testing.T) {
 	workflow.RunReleaseStart(t, testConfig)
 }
 
+func TestReleaseStartMajor(t *testing.T) {
+	workflow.RunReleaseStartMajor(t, testConfig)
+}
+
+func TestReleaseStartMinor(t *testing.T) {
+	workflow.RunReleaseStartMinor(t, testConfig)
+}
+
 func TestReleaseStartFallback(t *testing.T) {
 	workflow.RunReleaseStartFallback(t)
 }
 
+func TestReleaseStartExplicitVersion(t *testing.T) {
+	workflow.RunReleaseStartExplicitVersion(t)
+}
+
+func TestReleaseStartVersionFlag(t *testing.T) {
+	workflow.RunReleaseStartVersionFlag(t)
+}
+
+func TestReleaseStartInvalidVersion(t *testing.T) {
+	workflow.RunReleaseStartInvalidVersion(t)
+}
+
+func TestReleaseStartReleasedVersion(t *testing.T) {
+	workflow.RunReleaseStartReleasedVersion(t)
+}
+
+func TestReleaseStartMajorAndMinor(t *testing.T) {
+	workflow.RunReleaseStartMajorAndMinor(t)
+}
+
+func TestReleaseStartBumpAutoPatch(t *testing.T) {
+	workflow.RunReleaseStartBumpAutoPatch(t)
+}
+
+func TestReleaseStartBumpAutoMinor(t *testing.T) {
+	workflow.RunReleaseStartBumpAutoMinor(t)
+}
+
+func TestReleaseStartBumpAutoMajor(t *testing.T) {
+	workflow.RunReleaseStartBumpAutoMajor(t)
+}
+
+func TestReleaseStartBumpAutoBreakingFooter(t *testing.T) {
+	workflow.RunReleaseStartBumpAutoBreakingFooter(t)
+}
+
+func TestReleaseStartBumpAutoNoChanges(t *testing.T) {
+	workflow.RunReleaseStartBumpAutoNoChanges(t)
+}
+
+func TestReleaseStartBumpExplicit(t *testing.T) {
+	workflow.RunReleaseStartBumpExplicit(t)
+}
+
+func TestReleaseStartBumpWithoutRelease(t *testing.T) {
+	workflow.RunReleaseStartBumpWithoutRelease(t)
+}
+
+func TestReleaseStartBumpInvalid(t *testing.T) {
+	workflow.RunReleaseStartBumpInvalid(t)
+}
+
+func TestReleaseStartFallbackSettings(t *testing.T) {
+	workflow.RunReleaseStartFallbackSettings(t)
+}
+
+func TestReleaseStartFallbackNestedPath(t *testing.T) {
+	workflow.RunReleaseStartFallbackNestedPath(t)
+}
+
+func TestReleaseStartUpperVersionFile(t *testing.T) {
+	workflow.RunReleaseStartUpperVersionFile(t)
+}
+
+func TestReleaseStartFallbackCreationDisabled(t *testing.T) {
+	workflow.RunReleaseStartFallbackCreationDisabled(t)
+}
+
 func TestReleaseFinish(t *testing.T) {
 	workflow.RunReleaseFinish(t, testConfig)
 }
@@ -40,6 +116,158 @@ func TestReleaseFinishFallback(t *testing.T) {
 	workflow.RunReleaseFinishFallback(t)
 }
 
+func TestReleaseFinishWithSBOM(t *testing.T) {
+	workflow.RunReleaseFinishWithSBOM(t)
+}
+
+func TestReleaseFinishWithUnknownSBOMGenerator(t *testing.T) {
+	workflow.RunReleaseFinishWithUnknownSBOMGenerator(t)
+}
+
+func TestReleaseFinishWithProvenance(t *testing.T) {
+	workflow.RunReleaseFinishWithProvenance(t)
+}
+
+func TestReleaseFinishWithProvenanceInWorktree(t *testing.T) {
+	workflow.RunReleaseFinishWithProvenanceInWorktree(t)
+}
+
+func TestReleaseFinishFastForwardDevelop(t *testing.T) {
+	workflow.RunReleaseFinishFastForwardDevelop(t)
+}
+
+func TestReleaseFinishFastForwardDevelopDiverged(t *testing.T) {
+	workflow.RunReleaseFinishFastForwardDevelopDiverged(t)
+}
+
+func TestBugfixFinishWithoutPruneHint(t *testing.T) {
+	workflow.RunBugfixFinishWithoutPruneHint(t)
+}
+
+func TestReleaseFinishWithHeadTag(t *testing.T) {
+	workflow.RunReleaseFinishWithHeadTag(t)
+}
+
+func TestReleaseFinishWithPreTagHook(t *testing.T) {
+	workflow.RunReleaseFinishWithPreTagHook(t)
+}
+
+func TestReleaseFinishPreTagHookVeto(t *testing.T) {
+	workflow.RunReleaseFinishPreTagHookVeto(t)
+}
+
+func TestReleaseFinishInvalidCommands(t *testing.T) {
+	workflow.RunReleaseFinishInvalidCommands(t)
+}
+
+func TestReleaseFinishWithTagPrefix(t *testing.T) {
+	workflow.RunReleaseFinishWithTagPrefix(t)
+}
+
+func TestReleaseFinishDevelopmentBumpPullRequest(t *testing.T) {
+	workflow.RunReleaseFinishDevelopmentBumpPullRequest(t)
+}
+
+func TestReleaseFinishDevelopmentBumpGitHub(t *testing.T) {
+	workflow.RunReleaseFinishDevelopmentBumpGitHub(t)
+}
+
+func TestReleaseFinishViaPullRequest(t *testing.T) {
+	workflow.RunReleaseFinishViaPullRequest(t)
+}
+
+func TestReleaseFinishViaPullRequestClosed(t *testing.T) {
+	workflow.RunReleaseFinishViaPullRequestClosed(t)
+}
+
+func TestReleaseFinishViaPullRequestWithoutToken(t *testing.T) {
+	workflow.RunReleaseFinishViaPullRequestWithoutToken(t)
+}
+
+func TestReleaseFinishPublish(t *testing.T) {
+	workflow.RunReleaseFinishPublish(t)
+}
+
+func TestReleaseFinishPublishDraft(t *testing.T) {
+	workflow.RunReleaseFinishPublishDraft(t)
+}
+
+func TestReleaseFinishPublishWithoutToken(t *testing.T) {
+	workflow.RunReleaseFinishPublishWithoutToken(t)
+}
+
+func TestReleaseFinishViaMergeRequest(t *testing.T) {
+	workflow.RunReleaseFinishViaMergeRequest(t)
+}
+
+func TestHotfixFinishViaMergeRequest(t *testing.T) {
+	workflow.RunHotfixFinishViaMergeRequest(t)
+}
+
+func TestReleaseFinishPublishGitLab(t *testing.T) {
+	workflow.RunReleaseFinishPublishGitLab(t)
+}
+
+func TestReleaseFinishPublishGitLabDraft(t *testing.T) {
+	workflow.RunReleaseFinishPublishGitLabDraft(t)
+}
+
+func TestReleaseFinishViaBitbucketPullRequest(t *testing.T) {
+	workflow.RunReleaseFinishViaBitbucketPullRequest(t)
+}
+
+func TestReleaseFinishBitbucketRestricted(t *testing.T) {
+	workflow.RunReleaseFinishBitbucketRestricted(t)
+}
+
+func TestReleaseFinishPublishBitbucket(t *testing.T) {
+	workflow.RunReleaseFinishPublishBitbucket(t)
+}
+
+func TestTelemetryQueue(t *testing.T) {
+	workflow.RunTelemetryQueue(t)
+}
+
+func TestTelemetryEndpoint(t *testing.T) {
+	workflow.RunTelemetryEndpoint(t)
+}
+
+func TestTelemetryDoNotTrack(t *testing.T) {
+	workflow.RunTelemetryDoNotTrack(t)
+}
+
+func TestTelemetryProjectConfigIgnored(t *testing.T) {
+	workflow.RunTelemetryProjectConfigIgnored(t)
+}
+
+func TestReleaseFinishWithCommitIdentity(t *testing.T) {
+	workflow.RunReleaseFinishWithCommitIdentity(t)
+}
+
+func TestReleaseFinishWithInvalidCommitAuthor(t *testing.T) {
+	workflow.RunReleaseFinishWithInvalidCommitAuthor(t)
+}
+
+func TestReleaseFinishWithSignedCommits(t *testing.T) {
+	workflow.RunReleaseFinishWithSignedCommits(t)
+}
+
+func TestReleaseFinishWithAnnotatedTag(t *testing.T) {
+	workflow.RunReleaseFinishWithAnnotatedTag(t)
+}
+
+func TestReleaseFinishWithSignedTag(t *testing.T) {
+	workflow.RunReleaseFinishWithSignedTag(t)
+}
+
+func TestStaleReleaseAge(t *testing.T) {
+	workflow.RunStaleReleaseAge(t)
+}
+
+func TestStaleReleaseBehind(t *testing.T) {
+	workflow.RunStaleReleaseBehind(t)
+}
+
 func TestHotfixStart(t *testing.T) {
 	workflow.RunHotfixStart(t, testConfig)
 }
@@ -90,6 +318,22 @@ func TestHotfixFinishNoPushFlag(t *testing.T) {
 	workflow.RunHotfixFinishNoPushFlag(t)
 }
 
+func TestReleaseStartDryRun(t *testing.T) {
+	workflow.RunReleaseStartDryRun(t)
+}
+
+func TestReleaseFinishDryRun(t *testing.T) {
+	workflow.RunReleaseFinishDryRun(t)
+}
+
+func TestHotfixStartDryRunConfig(t *testing.T) {
+	workflow.RunHotfixStartDryRunConfig(t)
+}
+
+func TestBootstrapDryRun(t *testing.T) {
+	workflow.RunBootstrapDryRun(t)
+}
+
 func TestRollbackPreservesExistingBranches(t *testing.T) {
 	workflow.RunRollbackPreservesExistingBranches(t)
 }
@@ -118,6 +362,14 @@ func TestReleaseStartWithDevBranch(t *testing.T) {
 	workflow.RunReleaseStartWithDevBranch(t)
 }
 
+func TestReleaseDetectsNvieLayout(t *testing.T) {
+	workflow.RunReleaseDetectsNvieLayout(t)
+}
+
+func TestReleaseStartDetectsNvieConfig(t *testing.T) {
+	workflow.RunReleaseStartDetectsNvieConfig(t)
+}
+
 func TestReleaseStartYesAutoResolvesBranch(t *testing.T) {
 	workflow.RunReleaseStartYesAutoResolvesBranch(t)
 }
@@ -130,6 +382,14 @@ func TestReleaseStartDirtyRepo(t *testing.T) {
 	workflow.RunReleaseStartDirtyRepo(t)
 }
 
+func TestReleaseStartCleanIgnore(t *testing.T) {
+	workflow.RunReleaseStartCleanIgnore(t)
+}
+
+func TestReleaseStartCleanIgnoreTracked(t *testing.T) {
+	workflow.RunReleaseStartCleanIgnoreTracked(t)
+}
+
 func TestReleaseStartDuplicateRelease(t *testing.T) {
 	workflow.RunReleaseStartDuplicateRelease(t)
 }
@@ -137,3 +397,617 @@ func TestReleaseStartDuplicateRelease(t *testing.T) {
 func TestHotfixStartDuplicateHotfix(t *testing.T) {
 	workflow.RunHotfixStartDuplicateHotfix(t)
 }
+
+func TestHotfixFinishVersionConflictResolutionDisabled(t *testing.T) {
+	workflow.RunHotfixFinishVersionConflictResolutionDisabled(t)
+}
+
+func TestHotfixFinishConflictOutsideVersionFile(t *testing.T) {
+	workflow.RunHotfixFinishConflictOutsideVersionFile(t)
+}
+
+func TestHotfixContinueAfterConflict(t *testing.T) {
+	workflow.RunHotfixContinueAfterConflict(t)
+}
+
+func TestReleaseFinishLinkedWorktree(t *testing.T) {
+	workflow.RunReleaseFinishLinkedWorktree(t)
+}
+
+func TestFinishWhileInterrupted(t *testing.T) {
+	workflow.RunFinishWhileInterrupted(t)
+}
+
+func TestReleaseContinueWithoutState(t *testing.T) {
+	workflow.RunReleaseContinueWithoutState(t)
+}
+
+func TestRecoverReleaseFromHistory(t *testing.T) {
+	workflow.RunRecoverReleaseFromHistory(t)
+}
+
+func TestRecoverBranchNotDeleted(t *testing.T) {
+	workflow.RunRecoverBranchNotDeleted(t)
+}
+
+func TestRecoverFromState(t *testing.T) {
+	workflow.RunRecoverFromState(t)
+}
+
+func TestRecoverNothing(t *testing.T) {
+	workflow.RunRecoverNothing(t)
+}
+
+func TestRedactEnvironmentSecret(t *testing.T) {
+	workflow.RunRedactEnvironmentSecret(t)
+}
+
+func TestRedactURLCredentials(t *testing.T) {
+	workflow.RunRedactURLCredentials(t)
+}
+
+func TestVersionSyncReleaseStart(t *testing.T) {
+	workflow.RunVersionSyncReleaseStart(t)
+}
+
+func TestVersionSyncReleaseFinish(t *testing.T) {
+	workflow.RunVersionSyncReleaseFinish(t)
+}
+
+func TestVersionSyncMissingVersion(t *testing.T) {
+	workflow.RunVersionSyncMissingVersion(t)
+}
+
+func TestVersionSyncInvalidPath(t *testing.T) {
+	workflow.RunVersionSyncInvalidPath(t)
+}
+
+func TestReleaseActionOutputs(t *testing.T) {
+	workflow.RunReleaseActionOutputs(t)
+}
+
+func TestHotfixActionOutputs(t *testing.T) {
+	workflow.RunHotfixActionOutputs(t)
+}
+
+func TestReport(t *testing.T) {
+	workflow.RunReport(t)
+}
+
+func TestReportWithoutReleases(t *testing.T) {
+	workflow.RunReportWithoutReleases(t)
+}
+
+func TestReleaseQualifier(t *testing.T) {
+	workflow.RunReleaseQualifier(t)
+}
+
+func TestHotfixQualifier(t *testing.T) {
+	workflow.RunHotfixQualifier(t)
+}
+
+func TestNumberedReleaseQualifier(t *testing.T) {
+	workflow.RunNumberedReleaseQualifier(t)
+}
+
+func TestCheckQualifierViolation(t *testing.T) {
+	workflow.RunCheckQualifierViolation(t)
+}
+
+func TestMetrics(t *testing.T) {
+	workflow.RunMetrics(t)
+}
+
+func TestMetricsCSV(t *testing.T) {
+	workflow.RunMetricsCSV(t)
+}
+
+func TestMetricsPush(t *testing.T) {
+	workflow.RunMetricsPush(t)
+}
+
+func TestMetricsPushWithoutGateway(t *testing.T) {
+	workflow.RunMetricsPushWithoutGateway(t)
+}
+
+func TestReleaseJSONOutput(t *testing.T) {
+	workflow.RunReleaseJSONOutput(t)
+}
+
+func TestReleaseJSONOutputLinks(t *testing.T) {
+	workflow.RunReleaseJSONOutputLinks(t)
+}
+
+func TestFailureJSONOutput(t *testing.T) {
+	workflow.RunFailureJSONOutput(t)
+}
+
+func TestCheckJSONOutput(t *testing.T) {
+	workflow.RunCheckJSONOutput(t)
+}
+
+func TestSchema(t *testing.T) {
+	workflow.RunSchema(t)
+}
+
+func TestReleaseStartGitLabDetachedHead(t *testing.T) {
+	workflow.RunReleaseStartGitLabDetachedHead(t)
+}
+
+func TestReleaseFinishGitLabMergeRequest(t *testing.T) {
+	workflow.RunReleaseFinishGitLabMergeRequest(t)
+}
+
+func TestUnsupportedCI(t *testing.T) {
+	workflow.RunUnsupportedCI(t)
+}
+
+func TestReleaseStartDetachedHead(t *testing.T) {
+	workflow.RunReleaseStartDetachedHead(t)
+}
+
+func TestReleaseStartDuringRebase(t *testing.T) {
+	workflow.RunReleaseStartDuringRebase(t)
+}
+
+func TestReleaseFinishDuringMerge(t *testing.T) {
+	workflow.RunReleaseFinishDuringMerge(t)
+}
+
+func TestHotfixStartDuringBisect(t *testing.T) {
+	workflow.RunHotfixStartDuringBisect(t)
+}
+
+func TestHotfixStartFromReleaseBranch(t *testing.T) {
+	workflow.RunHotfixStartFromReleaseBranch(t)
+}
+
+func TestHotfixStartFromReleaseBranchWithUnpushedCommits(t *testing.T) {
+	workflow.RunHotfixStartFromReleaseBranchWithUnpushedCommits(t)
+}
+
+func TestReleaseFinishIgnoresUnversionedBranches(t *testing.T) {
+	workflow.RunReleaseFinishIgnoresUnversionedBranches(t)
+}
+
+func TestHotfixFinishWithDescribedBranch(t *testing.T) {
+	workflow.RunHotfixFinishWithDescribedBranch(t)
+}
+
+func TestReleaseStartPullsDevelop(t *testing.T) {
+	workflow.RunReleaseStartPullsDevelop(t)
+}
+
+func TestReleaseStartPullDisabled(t *testing.T) {
+	workflow.RunReleaseStartPullDisabled(t)
+}
+
+func TestReleaseStartDivergedNotMerged(t *testing.T) {
+	workflow.RunReleaseStartDivergedNotMerged(t)
+}
+
+func TestReleaseStartFetchGitflowBranches(t *testing.T) {
+	workflow.RunReleaseStartFetchGitflowBranches(t)
+}
+
+func TestReleaseStartPruneDisabled(t *testing.T) {
+	workflow.RunReleaseStartPruneDisabled(t)
+}
+
+func TestReleaseStartDivergedFastForwardOnly(t *testing.T) {
+	workflow.RunReleaseStartDivergedFastForwardOnly(t)
+}
+
+// --- Fast-forward tests ---
+
+func TestFinishFastForwardsStaleDevelop(t *testing.T) {
+	workflow.RunFinishFastForwardsStaleDevelop(t)
+}
+
+func TestFinishKeepsDivergedDevelop(t *testing.T) {
+	workflow.RunFinishKeepsDivergedDevelop(t)
+}
+
+// --- Commit identity tests ---
+
+func TestReleaseStartWithConditionalIdentity(t *testing.T) {
+	workflow.RunReleaseStartWithConditionalIdentity(t)
+}
+
+func TestReleaseStartWithoutIdentity(t *testing.T) {
+	workflow.RunReleaseStartWithoutIdentity(t)
+}
+
+func TestHotfixFinishWithConditionalTagSigning(t *testing.T) {
+	workflow.RunHotfixFinishWithConditionalTagSigning(t)
+}
+
+// --- Release notes tests ---
+
+func TestReleaseNotes(t *testing.T) {
+	workflow.RunReleaseNotes(t)
+}
+
+func TestReleaseNotesForVersion(t *testing.T) {
+	workflow.RunReleaseNotesForVersion(t)
+}
+
+func TestReleaseNotesUnreleasedVersion(t *testing.T) {
+	workflow.RunReleaseNotesUnreleasedVersion(t)
+}
+
+func TestReleaseNotesContributors(t *testing.T) {
+	workflow.RunReleaseNotesContributors(t)
+}
+
+func TestReleaseNotesGitHubHandles(t *testing.T) {
+	workflow.RunReleaseNotesGitHubHandles(t)
+}
+
+func TestReleaseNotesCachedHandles(t *testing.T) {
+	workflow.RunReleaseNotesCachedHandles(t)
+}
+
+func TestReleaseNotesRateLimited(t *testing.T) {
+	workflow.RunReleaseNotesRateLimited(t)
+}
+
+func TestReleaseNotesRateLimitTimeout(t *testing.T) {
+	workflow.RunReleaseNotesRateLimitTimeout(t)
+}
+
+func TestReleaseNotesCompareLink(t *testing.T) {
+	workflow.RunReleaseNotesCompareLink(t)
+}
+
+// --- Promotion tests ---
+
+func TestPromote(t *testing.T) {
+	workflow.RunPromote(t)
+}
+
+func TestPromoteSkippingEnvironment(t *testing.T) {
+	workflow.RunPromoteSkippingEnvironment(t)
+}
+
+func TestPromoteUnreleasedVersion(t *testing.T) {
+	workflow.RunPromoteUnreleasedVersion(t)
+}
+
+func TestPromoteUnknownEnvironment(t *testing.T) {
+	workflow.RunPromoteUnknownEnvironment(t)
+}
+
+// --- Verify tests ---
+
+func TestVerifyTags(t *testing.T) {
+	workflow.RunVerifyTags(t)
+}
+
+func TestVerifyTagsUnreachable(t *testing.T) {
+	workflow.RunVerifyTagsUnreachable(t)
+}
+
+func TestVerifyTagsVersionDrift(t *testing.T) {
+	workflow.RunVerifyTagsVersionDrift(t)
+}
+
+// --- Configuration edge case tests ---
+
+func TestReleaseAndHotfixWithTagPrefixAndQualifiers(t *testing.T) {
+	workflow.RunReleaseAndHotfixWithTagPrefixAndQualifiers(t)
+}
+
+func TestReleaseStartWithoutOriginRemote(t *testing.T) {
+	workflow.RunReleaseStartWithoutOriginRemote(t)
+}
+
+func TestReleaseFinishWithAdditionalRemote(t *testing.T) {
+	workflow.RunReleaseFinishWithAdditionalRemote(t)
+}
+
+func TestHotfixFinishWithoutDevelop(t *testing.T) {
+	workflow.RunHotfixFinishWithoutDevelop(t)
+}
+
+func TestReleaseFinishProtectedDevelop(t *testing.T) {
+	workflow.RunReleaseFinishProtectedDevelop(t)
+}
+
+func TestReleaseFinishProtectedDevelopPullRequest(t *testing.T) {
+	workflow.RunReleaseFinishProtectedDevelopPullRequest(t)
+}
+
+// --- Remote failure tests ---
+
+func TestReleaseFinishResumeAfterRejectedPush(t *testing.T) {
+	workflow.RunReleaseFinishResumeAfterRejectedPush(t)
+}
+
+func TestReleaseFinishRetryRejectedTags(t *testing.T) {
+	workflow.RunReleaseFinishRetryRejectedTags(t)
+}
+
+func TestReleaseFinishBranchDeletedOnRemote(t *testing.T) {
+	workflow.RunReleaseFinishBranchDeletedOnRemote(t)
+}
+
+func TestReleaseStartReadOnlyRemote(t *testing.T) {
+	workflow.RunReleaseStartReadOnlyRemote(t)
+}
+
+// --- Plan tests ---
+
+func TestReleaseStartPlan(t *testing.T) {
+	workflow.RunReleaseStartPlan(t)
+}
+
+func TestReleaseStartPlanMismatch(t *testing.T) {
+	workflow.RunReleaseStartPlanMismatch(t)
+}
+
+func TestPlanOtherCommand(t *testing.T) {
+	workflow.RunPlanOtherCommand(t)
+}
+
+// --- Check tests ---
+
+func TestCheckReleaseBranch(t *testing.T) {
+	workflow.RunCheckReleaseBranch(t)
+}
+
+func TestCheckReleaseBranchViolations(t *testing.T) {
+	workflow.RunCheckReleaseBranchViolations(t)
+}
+
+func TestCheckRemoteTags(t *testing.T) {
+	workflow.RunCheckRemoteTags(t)
+}
+
+func TestCheckInvalidBranchName(t *testing.T) {
+	workflow.RunCheckInvalidBranchName(t)
+}
+
+func TestCheckDevelopmentBranch(t *testing.T) {
+	workflow.RunCheckDevelopmentBranch(t)
+}
+
+func TestCheckDetachedHead(t *testing.T) {
+	workflow.RunCheckDetachedHead(t)
+}
+
+// --- Bugfix tests ---
+
+func TestBugfixStart(t *testing.T) {
+	workflow.RunBugfixStart(t)
+}
+
+func TestBugfixStartFetchesOnSync(t *testing.T) {
+	workflow.RunBugfixStartFetchesOnSync(t)
+}
+
+func TestBugfixStartExistingBranch(t *testing.T) {
+	workflow.RunBugfixStartExistingBranch(t)
+}
+
+func TestBugfixFinish(t *testing.T) {
+	workflow.RunBugfixFinish(t)
+}
+
+func TestBugfixFinishNamed(t *testing.T) {
+	workflow.RunBugfixFinishNamed(t)
+}
+
+func TestBugfixFinishUnknownName(t *testing.T) {
+	workflow.RunBugfixFinishUnknownName(t)
+}
+
+// --- Support tests ---
+
+func TestSupportStart(t *testing.T) {
+	workflow.RunSupportStart(t)
+}
+
+func TestSupportStartUnreleasedVersion(t *testing.T) {
+	workflow.RunSupportStartUnreleasedVersion(t)
+}
+
+func TestHotfixStartOnSupport(t *testing.T) {
+	workflow.RunHotfixStartOnSupport(t)
+}
+
+func TestHotfixStartOnMissingSupport(t *testing.T) {
+	workflow.RunHotfixStartOnMissingSupport(t)
+}
+
+func TestHotfixFinishOnSupport(t *testing.T) {
+	workflow.RunHotfixFinishOnSupport(t)
+}
+
+// --- Init tests ---
+
+func TestInit(t *testing.T) {
+	workflow.RunInit(t)
+}
+
+func TestInitExistingDevelop(t *testing.T) {
+	workflow.RunInitExistingDevelop(t)
+}
+
+// --- Bootstrap tests ---
+
+func TestBootstrap(t *testing.T) {
+	workflow.RunBootstrap(t)
+}
+
+func TestBootstrapInitialVersion(t *testing.T) {
+	workflow.RunBootstrapInitialVersion(t)
+}
+
+func TestBootstrapExistingProject(t *testing.T) {
+	workflow.RunBootstrapExistingProject(t)
+}
+
+func TestBootstrapUnknownTemplate(t *testing.T) {
+	workflow.RunBootstrapUnknownTemplate(t)
+}
+
+func TestReleaseStartOptionBranchSetting(t *testing.T) {
+	workflow.RunReleaseStartOptionBranchSetting(t)
+}
+
+func TestReleaseStartInvalidBranchSetting(t *testing.T) {
+	workflow.RunReleaseStartInvalidBranchSetting(t)
+}
+
+func TestReleaseStartInvalidQualifierSetting(t *testing.T) {
+	workflow.RunReleaseStartInvalidQualifierSetting(t)
+}
+
+func TestPromoteInvalidEnvironmentSetting(t *testing.T) {
+	workflow.RunPromoteInvalidEnvironmentSetting(t)
+}
+
+func TestVersionFileOutsideProject(t *testing.T) {
+	workflow.RunVersionFileOutsideProject(t)
+}
+
+func TestReleaseFinishWithChangelog(t *testing.T) {
+	workflow.RunReleaseFinishWithChangelog(t)
+}
+
+func TestReleaseFinishWithExistingChangelog(t *testing.T) {
+	workflow.RunReleaseFinishWithExistingChangelog(t)
+}
+
+func TestReleaseFinishWithMessageTemplates(t *testing.T) {
+	workflow.RunReleaseFinishWithMessageTemplates(t)
+}
+
+func TestInvalidMessageTemplates(t *testing.T) {
+	workflow.RunInvalidMessageTemplates(t)
+}
+
+func TestReleaseFinishMonorepoProject(t *testing.T) {
+	workflow.RunReleaseFinishMonorepoProject(t)
+}
+
+func TestReleaseFinishWithoutChangelog(t *testing.T) {
+	workflow.RunReleaseFinishWithoutChangelog(t)
+}
+
+func TestReleaseFinishChangelogDryRun(t *testing.T) {
+	workflow.RunReleaseFinishChangelogDryRun(t)
+}
+
+func TestChangelog(t *testing.T) {
+	workflow.RunChangelog(t)
+}
+
+func TestChangelogWithoutReleases(t *testing.T) {
+	workflow.RunChangelogWithoutReleases(t)
+}
+
+func TestReleaseFinishSkipStep(t *testing.T) {
+	workflow.RunReleaseFinishSkipStep(t)
+}
+
+func TestReleaseFinishInsertStep(t *testing.T) {
+	workflow.RunReleaseFinishInsertStep(t)
+}
+
+func TestReleaseFinishInsertStepFailure(t *testing.T) {
+	workflow.RunReleaseFinishInsertStepFailure(t)
+}
+
+func TestReleaseFinishInvalidDefinition(t *testing.T) {
+	workflow.RunReleaseFinishInvalidDefinition(t)
+}
+
+func TestReleaseFinishMoveStep(t *testing.T) {
+	workflow.RunReleaseFinishMoveStep(t)
+}
+
+func TestReleaseStartInsertStep(t *testing.T) {
+	workflow.RunReleaseStartInsertStep(t)
+}
+
+func TestOneFlowRelease(t *testing.T) {
+	workflow.RunOneFlowRelease(t)
+}
+
+func TestOneFlowHotfix(t *testing.T) {
+	workflow.RunOneFlowHotfix(t)
+}
+
+func TestOneFlowHotfixWithoutRelease(t *testing.T) {
+	workflow.RunOneFlowHotfixWithoutRelease(t)
+}
+
+func TestGitLabFlowPromote(t *testing.T) {
+	workflow.RunGitLabFlowPromote(t)
+}
+
+func TestGitLabFlowPromoteDiverged(t *testing.T) {
+	workflow.RunGitLabFlowPromoteDiverged(t)
+}
+
+func TestPresetInvalid(t *testing.T) {
+	workflow.RunPresetInvalid(t)
+}
+
+func TestReleaseCandidate(t *testing.T) {
+	workflow.RunReleaseCandidate(t)
+}
+
+func TestReleaseCandidateWrite(t *testing.T) {
+	workflow.RunReleaseCandidateWrite(t)
+}
+
+func TestReleaseCandidateWithoutBranch(t *testing.T) {
+	workflow.RunReleaseCandidateWithoutBranch(t)
+}
+
+func TestReleaseStartBuildMetadata(t *testing.T) {
+	workflow.RunReleaseStartBuildMetadata(t)
+}
+
+func TestReleaseStartFourPartVersion(t *testing.T) {
+	workflow.RunReleaseStartFourPartVersion(t)
+}
+
+func TestReleaseCalendarVersion(t *testing.T) {
+	workflow.RunReleaseCalendarVersion(t)
+}
+
+func TestReleaseCalendarVersionOfMonth(t *testing.T) {
+	workflow.RunReleaseCalendarVersionOfMonth(t)
+}
+
+func TestReleaseStartInvalidVersionScheme(t *testing.T) {
+	workflow.RunReleaseStartInvalidVersionScheme(t)
+}
+
+func TestReleaseStartLooseVersion(t *testing.T) {
+	workflow.RunReleaseStartLooseVersion(t)
+}
+
+func TestReleaseFinishGitOps(t *testing.T) {
+	workflow.RunReleaseFinishGitOps(t)
+}
+
+func TestReleaseFinishGitOpsPullRequest(t *testing.T) {
+	workflow.RunReleaseFinishGitOpsPullRequest(t)
+}
+
+func TestReleaseFinishGitOpsInvalidPattern(t *testing.T) {
+	workflow.RunReleaseFinishGitOpsInvalidPattern(t)
+}
+
+func TestReleaseStartDetectedCI(t *testing.T) {
+	workflow.RunReleaseStartDetectedCI(t)
+}
+
+func TestReleaseStartCIDefaultsDisabled(t *testing.T) {
+	workflow.RunReleaseStartCIDefaultsDisabled(t)
+}
@@ -0,0 +1,223 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package terraform
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+)
+
+// terraform-specific constants
+const (
+	mainTf             = "main.tf"
+	versionFileSetting = "version-file"
+	localSetting       = "local"
+	defaultLocal       = "module_version"
+	moduleTagPrefix    = "v"
+)
+
+// Terraform file holding the version local, created if the module has no version file yet.
+const versionLocalsTemplate = `locals {
+  %v = "%v"
+}
+`
+
+// Fixed configuration for the terraform plugin
+var pluginConfig = plugin.Config{
+	Name:             "terraform",
+	VersionFileName:  "VERSION",
+	VersionQualifier: "dev",
+	RequiredTools:    []string{},
+	DockerImage:      "alpine:3",
+}
+
+// terraformPlugin is the plugin for Terraform modules, whose releases are tagged with a 'v' prefix, e.g. 'v1.2.0',
+// as required by the module registries.
+type terraformPlugin struct {
+	plugin.Plugin
+}
+
+// Register the terraform plugin
+func init() {
+	pluginFactory := plugin.NewFactory()
+
+	// Create plugin with pluginFactory to get hooks and other dependencies
+	terraformPlugin := &terraformPlugin{
+		Plugin: pluginFactory.NewPlugin(pluginConfig),
+	}
+
+	// Register hooks
+	terraformPlugin.RegisterHook(core.ReleaseStartHooks.BeforeReleaseStartHook, terraformPlugin.beforeReleaseStart)
+	terraformPlugin.RegisterHook(core.HotfixStartHooks.BeforeHotfixStartHook, terraformPlugin.beforeHotfixStart)
+
+	// Register plugin directly in core
+	core.RegisterPlugin(terraformPlugin)
+}
+
+// RequiredFile identifies Terraform modules by their main.tf file, the version file is created if it is missing.
+func (p *terraformPlugin) RequiredFile() string {
+	return mainTf
+}
+
+// TagPrefix returns the 'v' prefix of module versions, which the Terraform registries expect in tags.
+func (p *terraformPlugin) TagPrefix() string {
+	return moduleTagPrefix
+}
+
+// VersionFileName returns the version file configured under 'terraform.version-file', or VERSION.
+func (p *terraformPlugin) VersionFileName() string {
+	if v, ok := core.PluginSettings(p)[versionFileSetting].(string); ok && len(v) > 0 {
+		return filepath.ToSlash(filepath.Clean(v))
+	}
+	return p.Config.VersionFileName
+}
+
+// ReadVersion reads the version local of a Terraform file, or the content of a plain version file
+func (p *terraformPlugin) ReadVersion(repository core.Repository) (core.Version, error) {
+	var logs = make([]any, 0)
+	versionFilePath := filepath.Join(repository.Local(), p.VersionFileName())
+
+	// log human-readable description of commands
+	defer func() { core.Log(logs...) }()
+
+	data, err := os.ReadFile(versionFilePath)
+	if err != nil {
+		logs = append(logs, fmt.Sprintf("Reading file: %s", versionFilePath), err)
+		return core.NoVersion, fmt.Errorf("terraform version evaluation failed with %v: %v", err, p.VersionFileName())
+	}
+
+	logs = append(logs, fmt.Sprintf("Reading file: %s", versionFilePath), string(data))
+
+	if !p.isTerraform() {
+		return core.ParseVersion(strings.TrimSpace(string(data)))
+	}
+
+	// Check for multiple version locals
+	allMatches := p.localRegex().FindAllSubmatch(data, -1)
+	if len(allMatches) > 1 {
+		return core.NoVersion, fmt.Errorf("multiple %v locals found in %v", p.local(), p.VersionFileName())
+	} else if len(allMatches) == 0 {
+		return core.NoVersion, fmt.Errorf("no %v local found in %v", p.local(), p.VersionFileName())
+	}
+
+	return core.ParseVersion(string(allMatches[0][2]))
+}
+
+// WriteVersion writes the version local of a Terraform file, or the content of a plain version file
+func (p *terraformPlugin) WriteVersion(repository core.Repository, version core.Version) error {
+	versionFilePath := filepath.Join(repository.Local(), p.VersionFileName())
+
+	var err error
+	operation := fmt.Sprintf("Writing to file: %s, content: %s", versionFilePath, version.String())
+
+	// log operation description
+	defer func() { core.Log(operation, err) }()
+
+	content := version.String()
+	if p.isTerraform() {
+		if content, err = p.terraformContent(versionFilePath, version); err != nil {
+			return err
+		}
+	}
+
+	if err = os.MkdirAll(filepath.Dir(versionFilePath), 0755); err != nil {
+		return fmt.Errorf("terraform version update failed with %v: %v", err, p.VersionFileName())
+	}
+
+	if err = os.WriteFile(versionFilePath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("terraform version update failed with %v: %v", err, p.VersionFileName())
+	}
+
+	return nil
+}
+
+// terraformContent replaces the version local of a Terraform file, or renders a new file with a locals block.
+func (p *terraformPlugin) terraformContent(versionFilePath string, version core.Version) (string, error) {
+	data, err := os.ReadFile(versionFilePath)
+	if os.IsNotExist(err) {
+		return fmt.Sprintf(versionLocalsTemplate, p.local(), version), nil
+	} else if err != nil {
+		return "", fmt.Errorf("terraform version update failed with %v: %v", err, p.VersionFileName())
+	}
+
+	expression := p.localRegex()
+	if !expression.Match(data) {
+		return "", fmt.Errorf("no %v local found in %v", p.local(), p.VersionFileName())
+	}
+
+	return expression.ReplaceAllString(string(data), `${1}"`+version.String()+`"`), nil
+}
+
+// local returns the name of the version local configured under 'terraform.local', or module_version.
+func (p *terraformPlugin) local() string {
+	if v, ok := core.PluginSettings(p)[localSetting].(string); ok && len(v) > 0 {
+		return v
+	}
+	return defaultLocal
+}
+
+// localRegex returns the expression of the version local, e.g. module_version = "1.2.0" inside a locals block.
+func (p *terraformPlugin) localRegex() *regexp.Regexp {
+	return regexp.MustCompile(`(?m)^([ \t]*` + regexp.QuoteMeta(p.local()) + `[ \t]*=[ \t]*)"([^"\n]*)"`)
+}
+
+// isTerraform reports whether the version file is a Terraform file, otherwise it only holds the version.
+func (p *terraformPlugin) isTerraform() bool {
+	return strings.HasSuffix(p.VersionFileName(), ".tf")
+}
+
+func (p *terraformPlugin) beforeReleaseStart(repository core.Repository) error {
+	if err := repository.CheckoutBranch(core.Development.String()); err != nil {
+		return repository.Rollback(err)
+	}
+
+	return p.createVersionFile(repository, core.Qualifier(p, core.Development))
+}
+
+func (p *terraformPlugin) beforeHotfixStart(repository core.Repository) error {
+	if err := repository.CheckoutBranch(core.Production.String()); err != nil {
+		return repository.Rollback(err)
+	}
+
+	return p.createVersionFile(repository, "")
+}
+
+// createVersionFile creates a missing version file with the configured initial version and commits it.
+func (p *terraformPlugin) createVersionFile(repository core.Repository, qualifier string) error {
+	if _, err := os.Stat(filepath.Join(repository.Local(), p.VersionFileName())); err == nil {
+		return nil
+	}
+
+	// create the version file with the configured initial version unless this is disabled
+	if err := core.CheckVersionFileCreation(p.VersionFileName()); err != nil {
+		return err
+	}
+
+	initVersion, err := core.InitialVersion()
+	if err != nil {
+		return err
+	}
+
+	if err := p.WriteVersion(repository, initVersion.AddQualifier(qualifier)); err != nil {
+		return repository.Rollback(err)
+	}
+
+	if err := repository.AddFile(p.VersionFileName()); err != nil {
+		return repository.Rollback(err)
+	}
+
+	if err := repository.CommitChanges("Create versions file"); err != nil {
+		return repository.Rollback(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+/*
+SPDX-FileCopyrightText: 2024 Mercedes-Benz Tech Innovation GmbH
+SPDX-License-Identifier: MIT
+*/
+
+package terraform
+
+import (
+	_ "embed"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mercedes-benz/gitflow-cli/core"
+	"github.com/mercedes-benz/gitflow-cli/core/plugin"
+	"github.com/mercedes-benz/gitflow-cli/e2e/workflow"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/e2e/VERSION.tpl
+var plainTemplate string
+
+//go:embed testdata/e2e/versions.tf.tpl
+var localsTemplate string
+
+const mainTfContent = "variable \"name\" {\n  type = string\n}\n"
+
+var testConfigs = []plugin.TestConfig{
+	{
+		Name:             "terraform_version_file",
+		PluginName:       "terraform",
+		DockerImage:      pluginConfig.DockerImage,
+		VersionQualifier: "dev",
+		VersionFileName:  "VERSION",
+		Template:         plainTemplate,
+		ProjectFiles:     map[string]string{mainTf: mainTfContent},
+		TagPrefix:        moduleTagPrefix,
+	},
+	{
+		Name:             "terraform_locals",
+		PluginName:       "terraform",
+		DockerImage:      pluginConfig.DockerImage,
+		VersionQualifier: "dev",
+		VersionFileName:  "versions.tf",
+		Template:         localsTemplate,
+		ProjectFiles: map[string]string{
+			mainTf:                     mainTfContent,
+			core.ProjectConfigFileName: "terraform:\n  version-file: versions.tf\n",
+		},
+		TagPrefix: moduleTagPrefix,
+	},
+}
+
+func TestE2E_ReleaseStart(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseStart(t, tc)
+		})
+	}
+}
+
+func TestE2E_ReleaseFinish(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunReleaseFinish(t, tc)
+		})
+	}
+}
+
+func TestE2E_HotfixStart(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunHotfixStart(t, tc)
+		})
+	}
+}
+
+func TestE2E_HotfixFinish(t *testing.T) {
+	for _, tc := range testConfigs {
+		t.Run(tc.Name, func(t *testing.T) {
+			workflow.RunHotfixFinish(t, tc)
+		})
+	}
+}
+
+// setupTest writes a version file into a temp dir and selects it as the version file of the plugin. The settings
+// of previous e2e tests are reset, so that no 'terraform.version-file' setting overrides the selected file.
+func setupTest(t *testing.T, fileName, content string) (string, core.Repository, *terraformPlugin) {
+	t.Helper()
+	viper.Reset()
+	tempDir := t.TempDir()
+
+	testFilePath := filepath.Join(tempDir, fileName)
+	require.NoError(t, os.WriteFile(testFilePath, []byte(content), 0644))
+
+	p := &terraformPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+	p.Config.VersionFileName = fileName
+
+	return testFilePath, core.NewRepository(tempDir, ""), p
+}
+
+func TestVersionReadWrite(t *testing.T) {
+	testCases := []struct {
+		name           string
+		fileName       string
+		initialContent string
+		expectedResult string
+	}{
+		{"Locals", "versions.tf", "locals {\n  module_version = \"1.2.3\"\n}\n", "locals {\n  module_version = \"1.2.3-dev\"\n}\n"},
+		{"AlignedLocals", "versions.tf", "locals {\n  name           = \"network\"\n  module_version = \"1.2.3\"\n}\n", "locals {\n  name           = \"network\"\n  module_version = \"1.2.3-dev\"\n}\n"},
+		{"VersionFile", "VERSION", "1.2.3\n", "1.2.3-dev"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(test *testing.T) {
+			testFilePath, repository, p := setupTest(test, testCase.fileName, testCase.initialContent)
+
+			version, err := p.ReadVersion(repository)
+			require.NoError(test, err)
+			assert.Equal(test, "1.2.3", version.String())
+
+			version.Qualifier = "dev"
+			require.NoError(test, p.WriteVersion(repository, version))
+
+			result, err := os.ReadFile(testFilePath)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expectedResult, string(result))
+		})
+	}
+}
+
+func TestVersionNoMatch(t *testing.T) {
+	testCases := []struct {
+		name           string
+		initialContent string
+	}{
+		{"NoVersionLocal", "locals {\n  name = \"network\"\n}\n"},
+		{"MultipleVersionLocals", "locals {\n  module_version = \"1.2.3\"\n}\n\nlocals {\n  module_version = \"3.4.5\"\n}\n"},
+		{"OtherLocal", "locals {\n  min_module_version = \"1.2.3\"\n}\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(test *testing.T) {
+			_, repository, p := setupTest(test, "versions.tf", testCase.initialContent)
+
+			_, err := p.ReadVersion(repository)
+			require.Error(test, err)
+		})
+	}
+}
+
+func TestLocalSetting(t *testing.T) {
+	testFilePath, repository, p := setupTest(t, "versions.tf", "locals {\n  version = \"1.2.3\"\n}\n")
+	t.Cleanup(viper.Reset)
+	viper.Set("terraform.local", "version")
+
+	require.NoError(t, p.WriteVersion(repository, core.NewVersion("1", "3", "0", "")))
+
+	result, err := os.ReadFile(testFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, "locals {\n  version = \"1.3.0\"\n}\n", string(result))
+}
+
+func TestWriteVersionCreatesLocals(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set("terraform.version-file", "versions.tf")
+	tempDir := t.TempDir()
+	repository := core.NewRepository(tempDir, "")
+	p := &terraformPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+
+	require.NoError(t, p.WriteVersion(repository, core.NewVersion("1", "0", "0", "dev")))
+
+	result, err := os.ReadFile(filepath.Join(tempDir, "versions.tf"))
+	require.NoError(t, err)
+	assert.Equal(t, "locals {\n  module_version = \"1.0.0-dev\"\n}\n", string(result))
+}
+
+func TestDetectionByMainTf(t *testing.T) {
+	tempDir := t.TempDir()
+
+	projectPath := core.ProjectPath
+	core.ProjectPath = tempDir
+	t.Cleanup(func() { core.ProjectPath = projectPath })
+
+	p := &terraformPlugin{Plugin: plugin.NewFactory().NewPlugin(pluginConfig)}
+	assert.False(t, core.CheckVersionFile(p))
+
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, mainTf), []byte(mainTfContent), 0644))
+	assert.True(t, core.CheckVersionFile(p))
+}
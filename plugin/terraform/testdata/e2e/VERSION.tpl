@@ -0,0 +1 @@
+{{.Version}}
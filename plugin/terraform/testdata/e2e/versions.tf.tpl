@@ -0,0 +1,7 @@
+terraform {
+  required_version = ">= 1.5.0"
+}
+
+locals {
+  module_version = "{{.Version}}"
+}